@@ -0,0 +1,227 @@
+package claim
+
+import (
+	"encoding/hex"
+	"mime"
+	"path"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+	schema "github.com/lbryio/lbryschema.go/claim"
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Builder assembles a claim one field at a time, e.g.
+//
+//	claim.NewStream().Title("title").SdHash(sdHash).Fee("USD", "0.99", nil).Build()
+//
+// The first error stops the builder and is returned by Build. Build normalizes the claim and validates it.
+type Builder struct {
+	claim *types.Claim
+	err   error
+
+	channelKey     *btcec.PrivateKey
+	channelClaimID string
+}
+
+// NewStream starts building a stream claim
+func NewStream() *Builder {
+	return &Builder{claim: &types.Claim{Type: &types.Claim_Stream{Stream: &types.Stream{Source: &types.Source{}}}}}
+}
+
+// NewChannel starts building a channel claim with the channel's DER encoded public key
+func NewChannel(publicKey []byte) *Builder {
+	return &Builder{claim: &types.Claim{Type: &types.Claim_Channel{Channel: &types.Channel{PublicKey: publicKey}}}}
+}
+
+func (b *Builder) stream(field string) *types.Stream {
+	if b.err == nil && b.claim.GetStream() == nil {
+		b.err = errors.Err("%s can only be set on a stream", field)
+	}
+	return b.claim.GetStream()
+}
+
+func (b *Builder) Title(title string) *Builder {
+	b.claim.Title = title
+	return b
+}
+
+func (b *Builder) Description(description string) *Builder {
+	b.claim.Description = description
+	return b
+}
+
+func (b *Builder) Thumbnail(url string) *Builder {
+	b.claim.Thumbnail = &types.Source{Url: url}
+	return b
+}
+
+// Tags adds tags. They are normalized when the claim is built.
+func (b *Builder) Tags(tags ...string) *Builder {
+	b.claim.Tags = append(b.claim.Tags, tags...)
+	return b
+}
+
+// Languages adds languages from tags such as "en" or "pt-BR"
+func (b *Builder) Languages(tags ...string) *Builder {
+	for _, tag := range tags {
+		l, err := ParseLanguage(tag)
+		if err != nil {
+			b.setErr(err)
+			return b
+		}
+		b.claim.Languages = append(b.claim.Languages, l)
+	}
+	return b
+}
+
+// Locations adds locations in the form ParseLocation accepts
+func (b *Builder) Locations(locations ...string) *Builder {
+	for _, s := range locations {
+		l, err := ParseLocation(s)
+		if err != nil {
+			b.setErr(err)
+			return b
+		}
+		b.claim.Locations = append(b.claim.Locations, l)
+	}
+	return b
+}
+
+func (b *Builder) Author(author string) *Builder {
+	if s := b.stream("author"); s != nil {
+		s.Author = author
+	}
+	return b
+}
+
+func (b *Builder) License(license, url string) *Builder {
+	if s := b.stream("license"); s != nil {
+		s.License = license
+		s.LicenseUrl = url
+	}
+	return b
+}
+
+func (b *Builder) ReleaseTime(t time.Time) *Builder {
+	if s := b.stream("release time"); s != nil {
+		s.ReleaseTime = t.Unix()
+	}
+	return b
+}
+
+// File sets the name, size and media type of the file. If mediaType is empty, it is guessed from the name.
+func (b *Builder) File(name string, size uint64, mediaType string) *Builder {
+	if s := b.stream("file"); s != nil {
+		if mediaType == "" {
+			mediaType = mime.TypeByExtension(path.Ext(name))
+		}
+		if mediaType == "" {
+			mediaType = "application/octet-stream"
+		}
+		s.Source.Name = name
+		s.Source.Size = size
+		s.Source.MediaType = mediaType
+	}
+	return b
+}
+
+// FileHash sets the hex sha384 hash of the file
+func (b *Builder) FileHash(hash string) *Builder {
+	if s := b.stream("file hash"); s != nil {
+		s.Source.Hash = b.decodeHex("file hash", hash)
+	}
+	return b
+}
+
+// SdHash sets the hex hash of the stream's sd blob
+func (b *Builder) SdHash(hash string) *Builder {
+	if s := b.stream("sd hash"); s != nil {
+		s.Source.SdHash = b.decodeHex("sd hash", hash)
+	}
+	return b
+}
+
+// Fee sets the price of the stream, e.g. ("LBC", "1.5") or ("USD", "0.99"). address may be nil to pay the
+// claim's address.
+func (b *Builder) Fee(currency, amount string, address []byte) *Builder {
+	if s := b.stream("fee"); s != nil {
+		fee, err := NewFee(currency, amount, address)
+		b.setErr(err)
+		s.Fee = fee
+	}
+	return b
+}
+
+// SignWith makes BuildSigned sign the claim with a channel's private key
+func (b *Builder) SignWith(channelKey *btcec.PrivateKey, channelClaimID string) *Builder {
+	b.channelKey = channelKey
+	b.channelClaimID = channelClaimID
+	return b
+}
+
+// Build normalizes and validates the claim. The first error found is returned. Warnings are ignored.
+func (b *Builder) Build() (*types.Claim, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	b.claim.Tags = NormalizeTags(b.claim.Tags)
+	if s := b.claim.GetStream(); s != nil {
+		setStreamType(s)
+	}
+
+	if v := NewReport(Validate(b.claim)).Errors(); len(v) > 0 {
+		return nil, errors.Err(v[0])
+	}
+	return b.claim, nil
+}
+
+// BuildSigned builds the claim and returns its value for a claim output. If SignWith was called, the claim
+// is signed with the channel key. firstInput must be the first input of the transaction that will contain
+// the claim.
+func (b *Builder) BuildSigned(firstInput wire.OutPoint) ([]byte, error) {
+	c, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	helper := &schema.ClaimHelper{Claim: c, Version: schema.NoSig}
+	if b.channelKey == nil {
+		value, err := helper.CompileValue()
+		return value, errors.Err(err)
+	}
+	return lbrycrd.SignClaimWithChannel(helper, b.channelKey, b.channelClaimID, firstInput)
+}
+
+// setStreamType sets the type specific metadata message from the media type, if it is not set
+func setStreamType(s *types.Stream) {
+	if s.GetType() != nil {
+		return
+	}
+	switch streamType(s.GetSource().GetMediaType()) {
+	case "video":
+		s.Type = &types.Stream_Video{Video: &types.Video{}}
+	case "audio":
+		s.Type = &types.Stream_Audio{Audio: &types.Audio{}}
+	case "image":
+		s.Type = &types.Stream_Image{Image: &types.Image{}}
+	}
+}
+
+func (b *Builder) decodeHex(field, s string) []byte {
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		b.setErr(errors.Err("%s is not hex", field))
+	}
+	return decoded
+}
+
+func (b *Builder) setErr(err error) {
+	if b.err == nil && err != nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,104 @@
+package claim
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+	schema "github.com/lbryio/lbryschema.go/claim"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var testSdHash = strings.Repeat("ab", 48)
+
+func TestBuilder(t *testing.T) {
+	c, err := NewStream().
+		Title("title").
+		Tags("Science", "science", "Space").
+		Languages("en-US").
+		File("video.mp4", 1024, "").
+		SdHash(testSdHash).
+		Fee("USD", "0.99", nil).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.GetTitle() != "title" || len(c.GetTags()) != 2 || LanguageTag(c.GetLanguages()[0]) != "en-US" {
+		t.Errorf("unexpected claim %v", c)
+	}
+	if c.GetStream().GetSource().GetMediaType() != "video/mp4" || c.GetStream().GetVideo() == nil {
+		t.Errorf("expected a video stream, got %v", c.GetStream())
+	}
+	if hex.EncodeToString(c.GetStream().GetSource().GetSdHash()) != testSdHash || c.GetStream().GetFee().GetAmount() != 99 {
+		t.Errorf("unexpected source or fee in %v", c.GetStream())
+	}
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	tests := map[string]*Builder{
+		"missing sd hash": NewStream().Title("title"),
+		"bad hex":         NewStream().SdHash("xyz"),
+		"bad language":    NewStream().SdHash(testSdHash).Languages("xx"),
+		"bad fee":         NewStream().SdHash(testSdHash).Fee("EUR", "1", nil),
+		"stream field":    NewChannel([]byte{1}).Author("author"),
+		"missing key":     NewChannel(nil).Title("channel"),
+	}
+	for name, b := range tests {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBuilder_BuildSigned(t *testing.T) {
+	channel, key, err := lbrycrd.NewChannel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	channelClaimID := "e67323a67a42307410f9679bf7fb344a428eb75c"
+	txid := "becb96a4a2e66bd24f083772fe9da904654ea9b5f07cc5bfbee233355911ddb1"
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := NewStream().Title("title").SdHash(testSdHash).SignWith(key, channelClaimID).BuildSigned(*wire.NewOutPoint(hash, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := schema.DecodeClaimBytes(value, lbrycrd.LbrycrdMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outpointHash, err := schema.GetOutpointHash(txid, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := decoded.ValidateClaimSignature(channel, outpointHash, channelClaimID, lbrycrd.LbrycrdMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Error("signature is not valid")
+	}
+
+	unsigned, err := NewStream().SdHash(testSdHash).BuildSigned(wire.OutPoint{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unsigned[0] != byte(schema.NoSig) {
+		t.Errorf("expected an unsigned claim, got version %d", unsigned[0])
+	}
+
+	other, _ := btcec.NewPrivateKey(btcec.S256())
+	_, err = NewStream().SdHash(testSdHash).SignWith(other, "short").BuildSigned(wire.OutPoint{})
+	if err == nil {
+		t.Error("expected an error for an invalid channel claim ID")
+	}
+}
@@ -2,7 +2,15 @@ package claim
 
 import (
 	"bytes"
+	"encoding/binary"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	schema "github.com/lbryio/lbryschema.go/claim"
 	types "github.com/lbryio/types/v2/go"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -22,3 +30,214 @@ func ToJSON(value []byte) (string, error) {
 
 	return b.String(), err
 }
+
+// DecodeMode controls how claim values that are not well formed are decoded
+type DecodeMode int
+
+const (
+	// DecodeDefault decodes claims the way lbryschema does
+	DecodeDefault DecodeMode = iota
+	// DecodeStrict rejects claims with unknown fields, bytes that do not form a protobuf field or strings that
+	// are not valid UTF-8. Validators should use it.
+	DecodeStrict
+	// DecodeLenient keeps whatever fields of a claim can be parsed and replaces invalid UTF-8. Explorers should
+	// use it.
+	DecodeLenient
+)
+
+// length of the header of a signed claim value: the version byte, the channel claim hash and the signature
+const signedHeaderLength = 1 + 20 + 64
+
+// DecodeClaimBytes decodes a claim value in any format lbryschema accepts. The mode only affects current
+// protobuf claims. Legacy protobuf and json claims are always decoded the default way.
+func DecodeClaimBytes(value []byte, blockchainName string, mode DecodeMode) (*schema.ClaimHelper, error) {
+	if mode == DecodeDefault || len(value) == 0 || (value[0] != byte(schema.NoSig) && value[0] != byte(schema.WithSig)) {
+		return schema.DecodeClaimBytes(value, blockchainName)
+	}
+
+	if mode == DecodeLenient {
+		helper, err := schema.DecodeClaimBytes(value, blockchainName)
+		if err == nil {
+			return helper, nil
+		}
+		helper, salvageErr := splitClaimValue(value)
+		if salvageErr != nil {
+			return nil, salvageErr
+		}
+		helper.Claim = salvageClaim(helper.Payload)
+		if len(helper.Payload) > 0 && proto.Size(helper.Claim) == 0 {
+			return nil, errors.Err(err)
+		}
+		return helper, nil
+	}
+
+	helper, err := splitClaimValue(value)
+	if err != nil {
+		return nil, err
+	}
+	helper.Claim = &types.Claim{}
+	err = proto.Unmarshal(helper.Payload, helper.Claim)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	var unknown bool
+	walkMessages(reflect.ValueOf(helper.Claim), func(m reflect.Value) {
+		if f := m.FieldByName("XXX_unrecognized"); f.IsValid() && f.Len() > 0 {
+			unknown = true
+		}
+	})
+	if unknown {
+		return nil, errors.Err("claim has unknown fields")
+	}
+	err = helper.ValidateCertificate()
+	if err != nil {
+		return nil, err
+	}
+	return helper, nil
+}
+
+// splitClaimValue splits a current claim value into its signature header and protobuf payload
+func splitClaimValue(value []byte) (*schema.ClaimHelper, error) {
+	if value[0] == byte(schema.NoSig) {
+		return &schema.ClaimHelper{Version: schema.NoSig, Payload: value[1:]}, nil
+	}
+	if len(value) < signedHeaderLength {
+		return nil, errors.Err("signed claim value is too short")
+	}
+	return &schema.ClaimHelper{
+		Version:   schema.WithSig,
+		ClaimID:   value[1:21],
+		Signature: value[21:signedHeaderLength],
+		Payload:   value[signedHeaderLength:],
+	}, nil
+}
+
+// salvageClaim decodes each top level field of a claim protobuf on its own, dropping fields that can't be
+// decoded and everything after the first field that can't be split off
+func salvageClaim(payload []byte) *types.Claim {
+	c := &types.Claim{}
+	for len(payload) > 0 {
+		n := fieldLength(payload)
+		if n == 0 {
+			break
+		}
+		field := &types.Claim{}
+		err := proto.Unmarshal(payload[:n], field)
+		if err == nil || strings.Contains(err.Error(), "invalid UTF-8") { // invalid UTF-8 doesn't stop decoding
+			proto.Merge(c, field)
+		}
+		payload = payload[n:]
+	}
+
+	walkMessages(reflect.ValueOf(c), func(m reflect.Value) {
+		for i := 0; i < m.NumField(); i++ {
+			f := m.Field(i)
+			switch {
+			case f.Kind() == reflect.String && f.CanSet():
+				f.SetString(strings.ToValidUTF8(f.String(), "\uFFFD"))
+			case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.String && f.CanSet():
+				for j := 0; j < f.Len(); j++ {
+					f.Index(j).SetString(strings.ToValidUTF8(f.Index(j).String(), "\uFFFD"))
+				}
+			}
+		}
+	})
+	return c
+}
+
+// fieldLength returns the length of the protobuf field at the start of b, or 0 if b does not start with a
+// complete field
+func fieldLength(b []byte) int {
+	key, n := binary.Uvarint(b)
+	if n <= 0 || key>>3 == 0 {
+		return 0
+	}
+	switch key & 7 {
+	case proto.WireVarint:
+		_, m := binary.Uvarint(b[n:])
+		if m <= 0 {
+			return 0
+		}
+		return n + m
+	case proto.WireFixed64:
+		n += 8
+	case proto.WireFixed32:
+		n += 4
+	case proto.WireBytes:
+		l, m := binary.Uvarint(b[n:])
+		if m <= 0 || l > uint64(len(b)) {
+			return 0
+		}
+		n += m + int(l)
+	default:
+		return 0 // groups were never used in claims
+	}
+	if n > len(b) {
+		return 0
+	}
+	return n
+}
+
+// walkMessages calls fn with every protobuf message struct in v, which must be a pointer to a message
+func walkMessages(v reflect.Value, fn func(reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walkMessages(v.Elem(), fn)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Ptr || v.Type().Elem().Kind() == reflect.Interface {
+			for i := 0; i < v.Len(); i++ {
+				walkMessages(v.Index(i), fn)
+			}
+		}
+	case reflect.Struct:
+		if _, ok := v.Addr().Interface().(proto.Message); ok {
+			fn(v)
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" { // exported
+				walkMessages(v.Field(i), fn)
+			}
+		}
+	}
+}
+
+// DecodeResult is the outcome of decoding one claim value in a batch
+type DecodeResult struct {
+	Claim *schema.ClaimHelper
+	Err   error
+}
+
+// DecodeClaimBytesBatch decodes many claim values concurrently, like DecodeClaimBytes. The results are in the same order as the values, and a value that fails to decode does not affect the others.
+// workers is the number of goroutines to use. If it is 0 or less, GOMAXPROCS is used.
+func DecodeClaimBytesBatch(values [][]byte, blockchainName string, mode DecodeMode, workers int) []DecodeResult {
+	results := make([]DecodeResult, len(values))
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(values) {
+		workers = len(values)
+	}
+
+	// workers take the next value by incrementing a shared index, so a few slow values don't hold up a worker's
+	// whole share of the batch
+	var next int64 = -1
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(values) {
+					return
+				}
+				results[i].Claim, results[i].Err = DecodeClaimBytes(values[i], blockchainName, mode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,89 @@
+package claim
+
+import (
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+	schema "github.com/lbryio/lbryschema.go/claim"
+	types "github.com/lbryio/types/v2/go"
+)
+
+func TestDecodeClaimBytesBatch(t *testing.T) {
+	var values [][]byte
+	for i := 0; i < 100; i++ {
+		if i%10 == 0 {
+			values = append(values, []byte{byte(schema.WithSig), 1, 2, 3})
+			continue
+		}
+		helper := &schema.ClaimHelper{
+			Claim:   &types.Claim{Title: string(rune('a' + i%26)), Type: &types.Claim_Stream{Stream: &types.Stream{}}},
+			Version: schema.NoSig,
+		}
+		value, err := helper.CompileValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, value)
+	}
+
+	for _, workers := range []int{0, 1, 7, 200} {
+		results := DecodeClaimBytesBatch(values, lbrycrd.LbrycrdMain, DecodeDefault, workers)
+		if len(results) != len(values) {
+			t.Fatalf("expected %d results, got %d", len(values), len(results))
+		}
+		for i, r := range results {
+			if i%10 == 0 {
+				if r.Err == nil {
+					t.Errorf("%d workers, value %d: expected an error", workers, i)
+				}
+				continue
+			}
+			if r.Err != nil {
+				t.Errorf("%d workers, value %d: %v", workers, i, r.Err)
+			} else if r.Claim.GetTitle() != string(rune('a'+i%26)) {
+				t.Errorf("%d workers, value %d: results are out of order", workers, i)
+			}
+		}
+	}
+
+	if results := DecodeClaimBytesBatch(nil, lbrycrd.LbrycrdMain, DecodeDefault, 0); len(results) != 0 {
+		t.Errorf("expected no results, got %v", results)
+	}
+}
+
+func TestDecodeClaimBytes_Modes(t *testing.T) {
+	valid := []byte{byte(schema.NoSig), 0x0a, 0x00, 0x42, 0x02, 'h', 'i'} // an empty stream titled "hi"
+
+	tests := []struct {
+		name    string
+		value   []byte
+		title   string // expected title in each mode, or "!" for an error
+		strict  string
+		lenient string
+	}{
+		{"valid", valid, "hi", "hi", "hi"},
+		{"unknown field", append(append([]byte{}, valid...), 0x98, 0x06, 0x01), "hi", "!", "hi"},
+		{"invalid utf-8", []byte{byte(schema.NoSig), 0x0a, 0x00, 0x42, 0x02, 'h', 0xff}, "!", "!", "h�"},
+		{"truncated field", append(append([]byte{}, valid...), 0x4a, 0x05, 'a'), "!", "!", "hi"},
+		{"bad field", []byte{byte(schema.NoSig), 0x0a, 0x02, 0x0a, 0x05, 0x42, 0x02, 'h', 'i'}, "!", "!", "hi"},
+		{"nothing parseable", []byte{byte(schema.NoSig), 0x0a, 0x05}, "!", "!", "!"},
+		{"short signed value", []byte{byte(schema.WithSig), 0x0a, 0x00}, "!", "!", "!"},
+	}
+
+	for _, test := range tests {
+		for mode, want := range map[DecodeMode]string{DecodeDefault: test.title, DecodeStrict: test.strict, DecodeLenient: test.lenient} {
+			helper, err := DecodeClaimBytes(test.value, lbrycrd.LbrycrdMain, mode)
+			if want == "!" {
+				if err == nil {
+					t.Errorf("%s, mode %d: expected an error", test.name, mode)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s, mode %d: %v", test.name, mode, err)
+			} else if helper.GetTitle() != want {
+				t.Errorf("%s, mode %d: expected title %q, got %q", test.name, mode, want, helper.GetTitle())
+			}
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package claim
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// Change is a single field that differs between two claims. Field is the path of the field using protobuf
+// names, e.g. "stream.fee.amount". Old is nil for added fields and New is nil for removed fields. Values are
+// in their protobuf JSON form, so repeated fields are compared and replaced as a whole.
+type Change struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// Diff returns the fields that differ between two claims, sorted by field
+func Diff(old, new *types.Claim) ([]Change, error) {
+	oldFields, err := flatten(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := flatten(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for field, o := range oldFields {
+		n, ok := newFields[field]
+		if !ok {
+			changes = append(changes, Change{Field: field, Old: o})
+		} else if !reflect.DeepEqual(o, n) {
+			changes = append(changes, Change{Field: field, Old: o, New: n})
+		}
+	}
+	for field, n := range newFields {
+		if _, ok := oldFields[field]; !ok {
+			changes = append(changes, Change{Field: field, New: n})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// ApplyPatch returns a copy of the claim with the changes applied. Fields that are not in the changes are
+// kept as they are, so ApplyPatch(old, Diff(old, new)) gives back new.
+func ApplyPatch(c *types.Claim, changes []Change) (*types.Claim, error) {
+	m, err := toMap(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// removals go first, so that a message emptied by a removal can still be set by a later change
+	for _, change := range changes {
+		if change.New == nil {
+			remove(m, strings.Split(change.Field, "."))
+		}
+	}
+	for _, change := range changes {
+		if change.New == nil {
+			continue
+		}
+		path := strings.Split(change.Field, ".")
+		parent := m
+		for _, key := range path[:len(path)-1] {
+			next, ok := parent[key].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				parent[key] = next
+			}
+			parent = next
+		}
+		parent[path[len(path)-1]] = change.New
+	}
+
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	patched := &types.Claim{}
+	err = jsonpb.Unmarshal(bytes.NewReader(encoded), patched)
+	if err != nil {
+		return nil, errors.Prefix("invalid patch", err)
+	}
+	return patched, nil
+}
+
+// Merge applies an update to a claim the way lbry-sdk's claim_update does: fields set in the update replace
+// the claim's fields, fields not set are kept, and tags, languages and locations are added to the existing
+// ones. The claim type cannot be changed by an update.
+func Merge(c, update *types.Claim) (*types.Claim, error) {
+	if update.GetType() != nil && c.GetType() != nil && ValueType(update) != ValueType(c) {
+		return nil, errors.Err("cannot update a %s claim to a %s", ValueType(c), ValueType(update))
+	}
+
+	merged := proto.Clone(c).(*types.Claim)
+	proto.Merge(merged, update)
+
+	merged.Tags = uniqueTags(merged.Tags)
+	merged.Languages = uniqueLanguages(merged.Languages)
+	merged.Locations = uniqueLocations(merged.Locations)
+	return merged, nil
+}
+
+func uniqueTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	unique := tags[:0]
+	for _, t := range tags {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+func uniqueLanguages(languages []*types.Language) []*types.Language {
+	unique := languages[:0]
+	for _, l := range languages {
+		dup := false
+		for _, u := range unique {
+			if proto.Equal(l, u) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			unique = append(unique, l)
+		}
+	}
+	return unique
+}
+
+func uniqueLocations(locations []*types.Location) []*types.Location {
+	unique := locations[:0]
+	for _, l := range locations {
+		dup := false
+		for _, u := range unique {
+			if proto.Equal(l, u) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			unique = append(unique, l)
+		}
+	}
+	return unique
+}
+
+// toMap returns the protobuf JSON form of the claim as a map
+func toMap(c *types.Claim) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if c == nil {
+		return m, nil
+	}
+	var buf bytes.Buffer
+	err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, c)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	err = json.Unmarshal(buf.Bytes(), &m)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return m, nil
+}
+
+// flatten maps the path of every field in the claim to its value. Messages are descended into, everything
+// else, including repeated fields and empty messages, is a value.
+func flatten(c *types.Claim) (map[string]interface{}, error) {
+	m, err := toMap(c)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	flattenInto(fields, "", m)
+	return fields, nil
+}
+
+func flattenInto(fields map[string]interface{}, prefix string, m map[string]interface{}) {
+	for k, v := range m {
+		if sub, ok := v.(map[string]interface{}); ok && len(sub) > 0 {
+			flattenInto(fields, prefix+k+".", sub)
+		} else {
+			fields[prefix+k] = v
+		}
+	}
+}
+
+// remove deletes the field at the path, and any messages that are left empty by deleting it
+func remove(m map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(m, path[0])
+		return
+	}
+	sub, ok := m[path[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	remove(sub, path[1:])
+	if len(sub) == 0 {
+		delete(m, path[0])
+	}
+}
@@ -0,0 +1,106 @@
+package claim
+
+import (
+	"bytes"
+	"testing"
+
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestDiff(t *testing.T) {
+	old := &types.Claim{
+		Title: "old title",
+		Tags:  []string{"a", "b"},
+		Type: &types.Claim_Stream{Stream: &types.Stream{
+			Author: "author",
+			Source: &types.Source{SdHash: bytes.Repeat([]byte{1}, 48)},
+			Fee:    &types.Fee{Currency: types.Fee_LBC, Amount: 100},
+		}},
+	}
+	new := &types.Claim{
+		Title:       "new title",
+		Description: "description",
+		Tags:        []string{"a"},
+		Type: &types.Claim_Stream{Stream: &types.Stream{
+			Source: &types.Source{SdHash: bytes.Repeat([]byte{1}, 48)},
+			Fee:    &types.Fee{Currency: types.Fee_USD, Amount: 100},
+		}},
+	}
+
+	changes, err := Diff(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"description", "stream.author", "stream.fee.currency", "tags", "title"}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected changes to %v, got %v", expected, changes)
+	}
+	for i, c := range changes {
+		if c.Field != expected[i] {
+			t.Errorf("expected change %d to be %s, got %s", i, expected[i], c.Field)
+		}
+	}
+	if changes[1].New != nil || changes[0].Old != nil {
+		t.Errorf("expected stream.author removed and description added, got %v", changes)
+	}
+
+	patched, err := ApplyPatch(old, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(patched, new) {
+		t.Errorf("expected %v, got %v", new, patched)
+	}
+
+	changes, err = Diff(new, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	c := &types.Claim{
+		Title: "title",
+		Tags:  []string{"a", "b"},
+		Type: &types.Claim_Stream{Stream: &types.Stream{
+			Author: "author",
+			Source: &types.Source{SdHash: bytes.Repeat([]byte{1}, 48)},
+		}},
+	}
+	update := &types.Claim{
+		Description: "description",
+		Tags:        []string{"b", "c"},
+		Type:        &types.Claim_Stream{Stream: &types.Stream{License: "MIT"}},
+	}
+
+	merged, err := Merge(c, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &types.Claim{
+		Title:       "title",
+		Description: "description",
+		Tags:        []string{"a", "b", "c"},
+		Type: &types.Claim_Stream{Stream: &types.Stream{
+			Author:  "author",
+			License: "MIT",
+			Source:  &types.Source{SdHash: bytes.Repeat([]byte{1}, 48)},
+		}},
+	}
+	if !proto.Equal(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+	if c.GetDescription() != "" {
+		t.Error("merge should not modify the original claim")
+	}
+
+	_, err = Merge(c, &types.Claim{Type: &types.Claim_Channel{Channel: &types.Channel{}}})
+	if err == nil {
+		t.Error("expected an error when changing the claim type")
+	}
+}
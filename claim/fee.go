@@ -0,0 +1,129 @@
+package claim
+
+import (
+	"math"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/shopspring/decimal"
+)
+
+// DeweysPerLBC is the number of deweys, the smallest unit of LBC, in one LBC
+const DeweysPerLBC = 100000000
+
+// ParseFeeCurrency parses a currency code such as "LBC" or "usd"
+func ParseFeeCurrency(s string) (types.Fee_Currency, error) {
+	currency, ok := types.Fee_Currency_value[strings.ToUpper(s)]
+	if !ok || currency == int32(types.Fee_UNKNOWN_CURRENCY) {
+		return types.Fee_UNKNOWN_CURRENCY, errors.Err("unknown fee currency %q", s)
+	}
+	return types.Fee_Currency(currency), nil
+}
+
+// feeExponent is the number of decimal places in the base unit of the currency
+func feeExponent(currency types.Fee_Currency) int32 {
+	if currency == types.Fee_USD {
+		return 2 // cents
+	}
+	return 8 // deweys and satoshis
+}
+
+// ToBaseUnits converts an amount of a currency to the base units a Fee stores: deweys for LBC, satoshis for
+// BTC and cents for USD. Amounts with more decimal places than the base unit allows are rejected.
+func ToBaseUnits(amount decimal.Decimal, currency types.Fee_Currency) (uint64, error) {
+	if amount.Sign() <= 0 {
+		return 0, errors.Err("amount must be positive")
+	}
+	units := amount.Shift(feeExponent(currency))
+	if !units.Equal(units.Truncate(0)) {
+		return 0, errors.Err("%s has more than %d decimal places", amount, feeExponent(currency))
+	}
+	if units.GreaterThan(decimal.New(math.MaxInt64, 0)) {
+		return 0, errors.Err("amount %s is too large", amount)
+	}
+	return uint64(units.IntPart()), nil
+}
+
+// FromBaseUnits converts base units of a currency to an amount of the currency
+func FromBaseUnits(units uint64, currency types.Fee_Currency) decimal.Decimal {
+	return decimal.New(int64(units), -feeExponent(currency))
+}
+
+// LBCToDeweys converts an amount of LBC to deweys
+func LBCToDeweys(lbc decimal.Decimal) (uint64, error) {
+	return ToBaseUnits(lbc, types.Fee_LBC)
+}
+
+// DeweysToLBC converts deweys to an amount of LBC
+func DeweysToLBC(deweys uint64) decimal.Decimal {
+	return FromBaseUnits(deweys, types.Fee_LBC)
+}
+
+// NewFee creates a fee from a currency code and a decimal amount, such as "USD" and "0.99". address is the
+// raw address the fee is paid to, and may be empty to pay the claim's address.
+func NewFee(currency, amount string, address []byte) (*types.Fee, error) {
+	c, err := ParseFeeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, errors.Err("invalid fee amount %q", amount)
+	}
+	units, err := ToBaseUnits(d, c)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := &types.Fee{Currency: c, Amount: units, Address: address}
+	if v := ValidateFee(fee); len(v) > 0 {
+		return nil, errors.Err(v[0])
+	}
+	return fee, nil
+}
+
+// ExchangeRates is a source of exchange rates, such as a price API, used to work out what a fee costs in LBC
+type ExchangeRates interface {
+	// LBCPerUnit returns how many LBC one unit (not base unit) of the currency is worth
+	LBCPerUnit(currency types.Fee_Currency) (decimal.Decimal, error)
+}
+
+// FixedRates is an ExchangeRates with rates that do not change. LBC is always worth 1 LBC.
+type FixedRates map[types.Fee_Currency]decimal.Decimal
+
+func (r FixedRates) LBCPerUnit(currency types.Fee_Currency) (decimal.Decimal, error) {
+	if currency == types.Fee_LBC {
+		return decimal.New(1, 0), nil
+	}
+	rate, ok := r[currency]
+	if !ok {
+		return decimal.Decimal{}, errors.Err("no exchange rate for %s", currency)
+	}
+	return rate, nil
+}
+
+// FeeInDeweys returns what a fee costs in deweys, converting it with the exchange rates if it is not in LBC.
+// The result is rounded up to the next dewey, so the fee is never underpaid.
+func FeeInDeweys(fee *types.Fee, rates ExchangeRates) (uint64, error) {
+	if fee.GetCurrency() == types.Fee_LBC {
+		return fee.GetAmount(), nil
+	}
+	if rates == nil {
+		return 0, errors.Err("exchange rates are needed to convert a %s fee", fee.GetCurrency())
+	}
+	rate, err := rates.LBCPerUnit(fee.GetCurrency())
+	if err != nil {
+		return 0, err
+	}
+	if rate.Sign() <= 0 {
+		return 0, errors.Err("exchange rate for %s must be positive", fee.GetCurrency())
+	}
+
+	deweys := FromBaseUnits(fee.GetAmount(), fee.GetCurrency()).Mul(rate).Shift(feeExponent(types.Fee_LBC)).Ceil()
+	if deweys.GreaterThan(decimal.New(math.MaxInt64, 0)) {
+		return 0, errors.Err("fee is too large")
+	}
+	return uint64(deweys.IntPart()), nil
+}
@@ -0,0 +1,72 @@
+package claim
+
+import (
+	"testing"
+
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestNewFee(t *testing.T) {
+	fee, err := NewFee("usd", "0.99", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee.Currency != types.Fee_USD || fee.Amount != 99 {
+		t.Errorf("unexpected fee %v", fee)
+	}
+
+	fee, err = NewFee("LBC", "1.5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee.Amount != 150000000 || !DeweysToLBC(fee.Amount).Equal(decimal.RequireFromString("1.5")) {
+		t.Errorf("unexpected fee %v", fee)
+	}
+
+	for _, test := range [][2]string{{"EUR", "1"}, {"USD", "0.001"}, {"LBC", "0"}, {"LBC", "-1"}, {"LBC", "lots"}, {"LBC", "100000000000"}} {
+		if _, err := NewFee(test[0], test[1], nil); err == nil {
+			t.Errorf("%v: expected an error", test)
+		}
+	}
+	if _, err := NewFee("LBC", "1", []byte{1, 2, 3}); err == nil {
+		t.Error("expected an error for a short address")
+	}
+}
+
+func TestFeeInDeweys(t *testing.T) {
+	rates := FixedRates{types.Fee_USD: decimal.RequireFromString("33.333333333")}
+
+	deweys, err := FeeInDeweys(&types.Fee{Currency: types.Fee_USD, Amount: 150}, rates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1.50 USD * 33.333333333 = 49.9999999995 LBC, rounded up
+	if deweys != 5000000000 {
+		t.Errorf("expected 5000000000 deweys, got %d", deweys)
+	}
+
+	deweys, err = FeeInDeweys(&types.Fee{Currency: types.Fee_LBC, Amount: 123}, nil)
+	if err != nil || deweys != 123 {
+		t.Errorf("expected 123 deweys, got %d (%v)", deweys, err)
+	}
+
+	if _, err := FeeInDeweys(&types.Fee{Currency: types.Fee_BTC, Amount: 1}, rates); err == nil {
+		t.Error("expected an error for a currency without a rate")
+	}
+	if _, err := FeeInDeweys(&types.Fee{Currency: types.Fee_USD, Amount: 1}, nil); err == nil {
+		t.Error("expected an error without exchange rates")
+	}
+}
+
+func TestValidateThumbnailURL(t *testing.T) {
+	if v := ValidateThumbnailURL("https://thumbnails.lbry.com/abc.png"); len(v) != 0 {
+		t.Errorf("expected no violations, got %v", v)
+	}
+	for _, url := range []string{"ftp://example.com/a.png", "/a.png", "javascript:alert(1)"} {
+		if v := ValidateThumbnailURL(url); len(v) != 1 || v[0].Code != InvalidThumbnailURL {
+			t.Errorf("%s: expected an invalid thumbnail url violation, got %v", url, v)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package claim
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/shopspring/decimal"
+)
+
+// value types, as lbry-sdk names them
+const (
+	ValueTypeStream     = "stream"
+	ValueTypeChannel    = "channel"
+	ValueTypeCollection = "collection"
+	ValueTypeRepost     = "repost"
+)
+
+// Value wraps a claim protobuf so that it encodes to and from JSON the way lbry-sdk renders a claim's
+// value: the fields of the claim type are merged into the top level, hashes and keys are hex, fee
+// addresses are base58, fee amounts are decimal strings, and languages are language tags.
+type Value struct {
+	*types.Claim
+}
+
+// ValueType returns the lbry-sdk name for the type of claim, or an empty string if the type is not set
+func ValueType(c *types.Claim) string {
+	switch c.GetType().(type) {
+	case *types.Claim_Stream:
+		return ValueTypeStream
+	case *types.Claim_Channel:
+		return ValueTypeChannel
+	case *types.Claim_Collection:
+		return ValueTypeCollection
+	case *types.Claim_Repost:
+		return ValueTypeRepost
+	default:
+		return ""
+	}
+}
+
+// Output is a claim as it appears in lbry-sdk's claim_search and resolve results
+type Output struct {
+	Address                 string  `json:"address"`
+	Amount                  string  `json:"amount"`
+	CanonicalURL            string  `json:"canonical_url,omitempty"`
+	ClaimID                 string  `json:"claim_id"`
+	ClaimOp                 string  `json:"claim_op,omitempty"`
+	Confirmations           int     `json:"confirmations"`
+	Height                  int     `json:"height"`
+	IsChannelSignatureValid *bool   `json:"is_channel_signature_valid,omitempty"`
+	Name                    string  `json:"name"`
+	NormalizedName          string  `json:"normalized_name"`
+	Nout                    uint32  `json:"nout"`
+	PermanentURL            string  `json:"permanent_url"`
+	ShortURL                string  `json:"short_url,omitempty"`
+	SigningChannel          *Output `json:"signing_channel,omitempty"`
+	Timestamp               int64   `json:"timestamp"`
+	Txid                    string  `json:"txid"`
+	Type                    string  `json:"type"`
+	Value                   Value   `json:"value"`
+	ValueType               string  `json:"value_type"`
+}
+
+// MarshalJSON fills in the value type from the claim, so it always matches the value
+func (o Output) MarshalJSON() ([]byte, error) {
+	type outputAlias Output
+	alias := outputAlias(o)
+	if alias.Value.Claim != nil {
+		alias.ValueType = ValueType(alias.Value.Claim)
+	}
+	if alias.Type == "" {
+		alias.Type = "claim"
+	}
+	return json.Marshal(alias)
+}
+
+// UnmarshalJSON decodes the value using the value type, since the value itself does not say what type it is
+func (o *Output) UnmarshalJSON(b []byte) error {
+	type outputAlias Output
+	var alias struct {
+		outputAlias
+		Value json.RawMessage `json:"value"`
+	}
+	err := json.Unmarshal(b, &alias)
+	if err != nil {
+		return errors.Err(err)
+	}
+
+	*o = Output(alias.outputAlias)
+	if len(alias.Value) == 0 || string(alias.Value) == "null" {
+		return nil
+	}
+	o.Value.Claim, err = valueFromJSON(alias.Value, o.ValueType)
+	return err
+}
+
+// MarshalJSON encodes the claim the same way lbry-sdk does
+func (v Value) MarshalJSON() ([]byte, error) {
+	if v.Claim == nil {
+		return []byte("null"), nil
+	}
+
+	m, err := toMap(v.Claim)
+	if err != nil {
+		return nil, err
+	}
+
+	valueType := ValueType(v.Claim)
+	if inner, ok := m[valueType].(map[string]interface{}); ok {
+		delete(m, valueType)
+		for k, val := range inner {
+			m[k] = val
+		}
+	}
+
+	if len(v.GetLanguages()) > 0 {
+		tags := make([]string, len(v.GetLanguages()))
+		for i, l := range v.GetLanguages() {
+			tags[i] = LanguageTag(l)
+		}
+		m["languages"] = tags
+	}
+	if len(v.GetLocations()) > 0 {
+		locations := make([]map[string]interface{}, len(v.GetLocations()))
+		for i, l := range v.GetLocations() {
+			locations[i] = locationToMap(l)
+		}
+		m["locations"] = locations
+	}
+
+	switch valueType {
+	case ValueTypeStream:
+		streamToMap(m, v.GetStream())
+	case ValueTypeChannel:
+		if len(v.GetChannel().GetPublicKey()) > 0 {
+			m["public_key"] = hex.EncodeToString(v.GetChannel().GetPublicKey())
+		}
+		if featured, ok := m["featured"].(map[string]interface{}); ok {
+			delete(featured, "claim_references")
+			featured["claim_ids"] = claimIDs(v.GetChannel().GetFeatured().GetClaimReferences())
+		}
+	case ValueTypeCollection:
+		delete(m, "claim_references")
+		m["claims"] = claimIDs(v.GetCollection().GetClaimReferences())
+	case ValueTypeRepost:
+		delete(m, "claim_hash")
+		m["claim_id"] = lbrycrd.ClaimIDFromHash(v.GetRepost().GetClaimHash())
+	}
+
+	return json.Marshal(m)
+}
+
+func streamToMap(m map[string]interface{}, s *types.Stream) {
+	if source, ok := m["source"].(map[string]interface{}); ok {
+		if len(s.GetSource().GetHash()) > 0 {
+			source["hash"] = hex.EncodeToString(s.GetSource().GetHash())
+		}
+		if len(s.GetSource().GetSdHash()) > 0 {
+			source["sd_hash"] = hex.EncodeToString(s.GetSource().GetSdHash())
+		}
+		if mediaType := s.GetSource().GetMediaType(); mediaType != "" {
+			m["stream_type"] = streamType(mediaType)
+		}
+	}
+	if fee, ok := m["fee"].(map[string]interface{}); ok {
+		if len(s.GetFee().GetAddress()) > 0 {
+			fee["address"] = base58.Encode(s.GetFee().GetAddress())
+		}
+		if s.GetFee().GetAmount() > 0 {
+			fee["amount"] = decimal.New(int64(s.GetFee().GetAmount()), -feeExponent(s.GetFee().GetCurrency())).String()
+		}
+	}
+}
+
+// UnmarshalJSON cannot tell what type of claim the JSON is for, so it only works for claims whose type
+// can be inferred from their fields. Use Output, which has the value type, when possible.
+func (v *Value) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		v.Claim = nil
+		return nil
+	}
+	var m map[string]interface{}
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return errors.Err(err)
+	}
+
+	valueType := ValueTypeStream
+	switch {
+	case m["public_key"] != nil:
+		valueType = ValueTypeChannel
+	case m["claims"] != nil:
+		valueType = ValueTypeCollection
+	case m["claim_id"] != nil:
+		valueType = ValueTypeRepost
+	}
+
+	v.Claim, err = valueFromJSON(b, valueType)
+	return err
+}
+
+// valueFromJSON reverses Value.MarshalJSON for a claim of the given type
+func valueFromJSON(b []byte, valueType string) (*types.Claim, error) {
+	var m map[string]interface{}
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+
+	// fields that belong to the claim itself. everything else belongs to the claim type
+	top := map[string]interface{}{}
+	for _, k := range []string{"title", "description", "thumbnail", "tags", "languages", "locations"} {
+		if val, ok := m[k]; ok {
+			top[k] = val
+			delete(m, k)
+		}
+	}
+
+	if tags, ok := top["languages"].([]interface{}); ok {
+		languages := make([]interface{}, len(tags))
+		for i, t := range tags {
+			tag, _ := t.(string)
+			languages[i], err = languageFromTag(tag)
+			if err != nil {
+				return nil, err
+			}
+		}
+		top["languages"] = languages
+	}
+	if locations, ok := top["locations"].([]interface{}); ok {
+		for _, l := range locations {
+			if loc, ok := l.(map[string]interface{}); ok {
+				err = locationFromMap(loc)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	switch valueType {
+	case ValueTypeStream:
+		delete(m, "stream_type")
+		err = streamFromMap(m)
+	case ValueTypeChannel:
+		err = hexToBase64(m, "public_key")
+		if featured, ok := m["featured"].(map[string]interface{}); ok && err == nil {
+			err = claimRefsFromIDs(featured, "claim_ids")
+		}
+	case ValueTypeCollection:
+		err = claimRefsFromIDs(m, "claims")
+	case ValueTypeRepost:
+		id, _ := m["claim_id"].(string)
+		delete(m, "claim_id")
+		var hash []byte
+		hash, err = lbrycrd.ClaimHashFromID(id)
+		if err == nil {
+			m["claim_hash"] = base64.StdEncoding.EncodeToString(hash)
+		}
+	default:
+		return nil, errors.Err("unknown claim value type %q", valueType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	top[valueType] = m
+
+	encoded, err := json.Marshal(top)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	c := &types.Claim{}
+	err = jsonpb.Unmarshal(bytes.NewReader(encoded), c)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return c, nil
+}
+
+func streamFromMap(m map[string]interface{}) error {
+	if source, ok := m["source"].(map[string]interface{}); ok {
+		for _, k := range []string{"hash", "sd_hash"} {
+			err := hexToBase64(source, k)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	fee, ok := m["fee"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if addr, ok := fee["address"].(string); ok {
+		decoded := base58.Decode(addr)
+		if len(decoded) == 0 {
+			return errors.Err("invalid fee address %s", addr)
+		}
+		fee["address"] = base64.StdEncoding.EncodeToString(decoded)
+	}
+	if amount, ok := fee["amount"].(string); ok {
+		currency, _ := fee["currency"].(string)
+		d, err := decimal.NewFromString(amount)
+		if err != nil {
+			return errors.Err(err)
+		}
+		exp := feeExponent(types.Fee_Currency(types.Fee_Currency_value[currency]))
+		fee["amount"] = d.Shift(exp).Truncate(0).String()
+	}
+	return nil
+}
+
+func hexToBase64(m map[string]interface{}, key string) error {
+	s, ok := m[key].(string)
+	if !ok {
+		return nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return errors.Prefix(key, err)
+	}
+	m[key] = base64.StdEncoding.EncodeToString(b)
+	return nil
+}
+
+func claimIDs(refs []*types.ClaimReference) []string {
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		ids[i] = lbrycrd.ClaimIDFromHash(ref.GetClaimHash())
+	}
+	return ids
+}
+
+func claimRefsFromIDs(m map[string]interface{}, key string) error {
+	ids, _ := m[key].([]interface{})
+	delete(m, key)
+	refs := make([]interface{}, len(ids))
+	for i, id := range ids {
+		s, _ := id.(string)
+		hash, err := lbrycrd.ClaimHashFromID(s)
+		if err != nil {
+			return err
+		}
+		refs[i] = map[string]interface{}{"claim_hash": base64.StdEncoding.EncodeToString(hash)}
+	}
+	m["claim_references"] = refs
+	return nil
+}
+
+// languageFromTag returns the protobuf JSON form of a language tag
+func languageFromTag(tag string) (map[string]interface{}, error) {
+	l, err := ParseLanguage(tag)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{"language": l.GetLanguage().String()}
+	if l.GetScript() != types.Language_UNKNOWN_SCRIPT {
+		m["script"] = l.GetScript().String()
+	}
+	if l.GetRegion() != types.Location_UNKNOWN_COUNTRY {
+		m["region"] = l.GetRegion().String()
+	}
+	return m, nil
+}
+
+func locationToMap(l *types.Location) map[string]interface{} {
+	m := map[string]interface{}{}
+	if l.GetCountry() != types.Location_UNKNOWN_COUNTRY {
+		m["country"] = types.Location_Country_name[int32(l.GetCountry())]
+	}
+	for k, v := range map[string]string{"state": l.GetState(), "city": l.GetCity(), "code": l.GetCode()} {
+		if v != "" {
+			m[k] = v
+		}
+	}
+	if l.GetLatitude() != 0 {
+		m["latitude"] = FormatCoordinate(l.GetLatitude())
+	}
+	if l.GetLongitude() != 0 {
+		m["longitude"] = FormatCoordinate(l.GetLongitude())
+	}
+	return m
+}
+
+func locationFromMap(m map[string]interface{}) error {
+	for k, limit := range map[string]int64{"latitude": 90, "longitude": 180} {
+		s, ok := m[k].(string)
+		if !ok {
+			continue
+		}
+		c, err := ParseCoordinate(s, limit)
+		if err != nil {
+			return errors.Prefix(k, err)
+		}
+		m[k] = c
+	}
+	return nil
+}
+
+// streamType guesses the lbry-sdk stream type from a media type
+func streamType(mediaType string) string {
+	switch {
+	case strings.HasPrefix(mediaType, "video/"):
+		return "video"
+	case strings.HasPrefix(mediaType, "audio/"):
+		return "audio"
+	case strings.HasPrefix(mediaType, "image/"):
+		return "image"
+	case strings.HasPrefix(mediaType, "text/"), mediaType == "application/pdf", mediaType == "application/epub+zip":
+		return "document"
+	case strings.HasPrefix(mediaType, "model/"):
+		return "model"
+	default:
+		return "binary"
+	}
+}
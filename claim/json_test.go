@@ -0,0 +1,115 @@
+package claim
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestValue_MarshalJSON(t *testing.T) {
+	address := base58.Decode("bHGtXrVXn2gmk7X6sbdrx6ujeDc5AdNtmL")
+	claim := &types.Claim{
+		Title:     "title",
+		Languages: []*types.Language{{Language: types.Language_en, Region: types.Location_US}},
+		Locations: []*types.Location{{Country: types.Location_US, City: "Manchester", Latitude: 429956000}},
+		Type: &types.Claim_Stream{Stream: &types.Stream{
+			Source: &types.Source{SdHash: bytes.Repeat([]byte{0xab}, 48), MediaType: "video/mp4"},
+			Fee:    &types.Fee{Currency: types.Fee_LBC, Amount: 150000000, Address: address},
+		}},
+	}
+
+	b, err := json.Marshal(Value{claim})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if m["stream_type"] != "video" {
+		t.Errorf("expected stream_type video, got %v", m["stream_type"])
+	}
+	if langs, _ := m["languages"].([]interface{}); len(langs) != 1 || langs[0] != "en-US" {
+		t.Errorf("expected languages [en-US], got %v", m["languages"])
+	}
+	fee, _ := m["fee"].(map[string]interface{})
+	if fee["amount"] != "1.5" || fee["address"] != "bHGtXrVXn2gmk7X6sbdrx6ujeDc5AdNtmL" || fee["currency"] != "LBC" {
+		t.Errorf("unexpected fee %v", fee)
+	}
+	loc, _ := m["locations"].([]interface{})[0].(map[string]interface{})
+	if loc["latitude"] != "42.9956" || loc["country"] != "US" {
+		t.Errorf("unexpected location %v", loc)
+	}
+	if _, ok := m["stream"]; ok {
+		t.Error("stream fields should be at the top level")
+	}
+
+	var decoded Value
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(decoded.Claim, claim) {
+		t.Errorf("round trip mismatch:\nexpected %v\ngot      %v", claim, decoded.Claim)
+	}
+}
+
+func TestOutput_JSON(t *testing.T) {
+	valid := true
+	channel := &types.Claim{
+		Title: "channel",
+		Type:  &types.Claim_Channel{Channel: &types.Channel{PublicKey: []byte{1, 2, 3}}},
+	}
+	repost := &types.Claim{
+		Type: &types.Claim_Repost{Repost: &types.ClaimReference{
+			ClaimHash: []byte{20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+		}},
+	}
+	out := Output{
+		Address:                 "bHGtXrVXn2gmk7X6sbdrx6ujeDc5AdNtmL",
+		Amount:                  "0.01",
+		ClaimID:                 "0102030405060708090a0b0c0d0e0f1011121314",
+		Name:                    "repost",
+		Value:                   Value{repost},
+		IsChannelSignatureValid: &valid,
+		SigningChannel:          &Output{Name: "@channel", Value: Value{channel}},
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["value_type"] != ValueTypeRepost || m["type"] != "claim" {
+		t.Errorf("unexpected type %v, value type %v", m["type"], m["value_type"])
+	}
+	if v, _ := m["value"].(map[string]interface{}); v["claim_id"] != "0102030405060708090a0b0c0d0e0f1011121314" {
+		t.Errorf("expected reposted claim_id, got %v", m["value"])
+	}
+	sc, _ := m["signing_channel"].(map[string]interface{})
+	if v, _ := sc["value"].(map[string]interface{}); sc["value_type"] != ValueTypeChannel || v["public_key"] != "010203" {
+		t.Errorf("unexpected signing channel %v", sc)
+	}
+
+	var decoded Output
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(decoded.Value.Claim, repost) {
+		t.Errorf("expected %v, got %v", repost, decoded.Value.Claim)
+	}
+	if decoded.SigningChannel == nil || !proto.Equal(decoded.SigningChannel.Value.Claim, channel) {
+		t.Errorf("expected signing channel %v, got %v", channel, decoded.SigningChannel)
+	}
+	if decoded.IsChannelSignatureValid == nil || !*decoded.IsChannelSignatureValid {
+		t.Error("expected a valid channel signature")
+	}
+}
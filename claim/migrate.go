@@ -0,0 +1,263 @@
+package claim
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/lbryio/lbry.go/v2/extras/base58"
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	legacy "github.com/lbryio/types/v1/go"
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/shopspring/decimal"
+)
+
+// Historical claim formats
+const (
+	FormatJSONV1         = "json 0.0.1"
+	FormatJSONV2         = "json 0.0.2"
+	FormatJSONV3         = "json 0.0.3"
+	FormatLegacyProtobuf = "legacy protobuf"
+)
+
+// MigrationNote describes how one field of a historical claim was handled
+type MigrationNote struct {
+	Field string // field name in the historical claim
+	Note  string
+}
+
+// MigrationReport lists the fields of a historical claim that did not carry over unchanged
+type MigrationReport struct {
+	Format     string
+	Translated []MigrationNote // fields whose value was converted, e.g. nsfw to the mature tag
+	Dropped    []MigrationNote // fields with no place in the current claim
+}
+
+func (r *MigrationReport) translated(field, note string) {
+	r.Translated = append(r.Translated, MigrationNote{Field: field, Note: note})
+}
+
+func (r *MigrationReport) dropped(field, note string) {
+	r.Dropped = append(r.Dropped, MigrationNote{Field: field, Note: note})
+}
+
+// jsonClaim has the fields of all three versions of json claim metadata
+type jsonClaim struct {
+	Version      string                     `json:"ver"`
+	Title        string                     `json:"title"`
+	Description  string                     `json:"description"`
+	Author       string                     `json:"author"`
+	Language     string                     `json:"language"`
+	License      string                     `json:"license"`
+	LicenseURL   string                     `json:"license_url"`
+	NSFW         bool                       `json:"nsfw"`
+	Thumbnail    string                     `json:"thumbnail"`
+	ContentType  string                     `json:"content_type"`
+	ContentType1 string                     `json:"content-type"` // versions 0.0.1 and 0.0.2
+	Sources      map[string]string          `json:"sources"`
+	Fee          map[string]json.RawMessage `json:"fee"`
+}
+
+var jsonClaimFields = map[string]bool{
+	"ver": true, "title": true, "description": true, "author": true, "language": true, "license": true,
+	"license_url": true, "nsfw": true, "thumbnail": true, "content_type": true, "content-type": true,
+	"sources": true, "fee": true,
+}
+
+// MigrateJSONClaim converts json claim metadata (versions 0.0.1 to 0.0.3) to a current stream claim
+func MigrateJSONClaim(value []byte) (*types.Claim, *MigrationReport, error) {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(value, &raw)
+	if err != nil {
+		return nil, nil, errors.Prefix("claim is not json", err)
+	}
+	var old jsonClaim
+	err = json.Unmarshal(value, &old)
+	if err != nil {
+		return nil, nil, errors.Prefix("invalid json claim", err)
+	}
+
+	report := &MigrationReport{}
+	switch old.Version {
+	case "", "0.0.1":
+		report.Format = FormatJSONV1
+	case "0.0.2":
+		report.Format = FormatJSONV2
+	case "0.0.3":
+		report.Format = FormatJSONV3
+	default:
+		return nil, nil, errors.Err("unknown json claim version %q", old.Version)
+	}
+
+	stream := &types.Stream{
+		Author:     old.Author,
+		License:    old.License,
+		LicenseUrl: old.LicenseURL,
+		Source:     &types.Source{MediaType: old.ContentType},
+	}
+	c := &types.Claim{Title: old.Title, Description: old.Description, Type: &types.Claim_Stream{Stream: stream}}
+
+	if stream.Source.MediaType == "" {
+		stream.Source.MediaType = old.ContentType1
+	}
+	if old.Thumbnail != "" {
+		c.Thumbnail = &types.Source{Url: old.Thumbnail}
+	}
+	migrateLanguage(c, report, "language", old.Language)
+	if old.NSFW {
+		c.Tags = []string{"mature"}
+		report.translated("nsfw", "added the mature tag")
+	}
+
+	sources := make([]string, 0, len(old.Sources))
+	for k := range old.Sources {
+		sources = append(sources, k)
+	}
+	sort.Strings(sources)
+	for _, k := range sources {
+		if k != "lbry_sd_hash" {
+			if old.Sources[k] != "" {
+				report.dropped("sources."+k, "only lbry sd hash sources are supported")
+			}
+			continue
+		}
+		stream.Source.SdHash, err = hex.DecodeString(old.Sources[k])
+		if err != nil {
+			return nil, nil, errors.Err("invalid sd hash %q", old.Sources[k])
+		}
+	}
+
+	for currency, raw := range old.Fee {
+		var info struct {
+			Amount  json.Number `json:"amount"`
+			Address string      `json:"address"`
+		}
+		err = json.Unmarshal(raw, &info)
+		if err != nil {
+			return nil, nil, errors.Prefix("invalid fee", err)
+		}
+		stream.Fee, err = migrateFee(report, currency, string(info.Amount), info.Address)
+		if err != nil {
+			return nil, nil, err
+		}
+		break // claims only ever had one fee
+	}
+
+	fields := make([]string, 0, len(raw))
+	for k := range raw {
+		if !jsonClaimFields[k] {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	for _, k := range fields {
+		report.dropped(k, "no equivalent field")
+	}
+
+	return c, report, nil
+}
+
+// MigrateLegacyClaim converts a legacy (v1 protobuf) claim to a current stream or channel claim. The
+// publisher signature is not part of the claim and is reported as dropped.
+func MigrateLegacyClaim(old *legacy.Claim) (*types.Claim, *MigrationReport, error) {
+	report := &MigrationReport{Format: FormatLegacyProtobuf}
+	if old.GetPublisherSignature() != nil {
+		report.dropped("publisherSignature", "signatures are stored outside the claim protobuf")
+	}
+
+	switch old.GetClaimType() {
+	case legacy.Claim_certificateType:
+		if len(old.GetCertificate().GetPublicKey()) == 0 {
+			return nil, nil, errors.Err("legacy certificate has no public key")
+		}
+		c := &types.Claim{Type: &types.Claim_Channel{Channel: &types.Channel{PublicKey: old.GetCertificate().GetPublicKey()}}}
+		if old.GetCertificate().GetKeyType() != legacy.KeyType_SECP256k1 {
+			report.translated("certificate.keyType", "key type "+old.GetCertificate().GetKeyType().String()+" is assumed to be secp256k1")
+		}
+		return c, report, nil
+
+	case legacy.Claim_streamType:
+		md := old.GetStream().GetMetadata()
+		stream := &types.Stream{
+			Author:     md.GetAuthor(),
+			License:    md.GetLicense(),
+			LicenseUrl: md.GetLicenseUrl(),
+			Source: &types.Source{
+				SdHash:    old.GetStream().GetSource().GetSource(),
+				MediaType: old.GetStream().GetSource().GetContentType(),
+			},
+		}
+		c := &types.Claim{Title: md.GetTitle(), Description: md.GetDescription(), Type: &types.Claim_Stream{Stream: stream}}
+
+		if md.GetThumbnail() != "" {
+			c.Thumbnail = &types.Source{Url: md.GetThumbnail()}
+		}
+		if md.Language != nil {
+			migrateLanguage(c, report, "metadata.language", md.GetLanguage().String())
+		}
+		if md.GetNsfw() {
+			c.Tags = []string{"mature"}
+			report.translated("metadata.nsfw", "added the mature tag")
+		}
+		if md.GetPreview() != "" {
+			report.dropped("metadata.preview", "no equivalent field")
+		}
+		if fee := md.GetFee(); fee != nil {
+			amount := strconv.FormatFloat(float64(fee.GetAmount()), 'f', -1, 32)
+			var err error
+			stream.Fee, err = migrateFee(report, fee.GetCurrency().String(), amount, "")
+			if err != nil {
+				return nil, nil, err
+			}
+			stream.Fee.Address = fee.GetAddress()
+		}
+		return c, report, nil
+
+	default:
+		return nil, nil, errors.Err("unknown legacy claim type %s", old.GetClaimType())
+	}
+}
+
+func migrateLanguage(c *types.Claim, report *MigrationReport, field, language string) {
+	if language == "" {
+		return
+	}
+	l, err := ParseLanguage(language)
+	if err != nil {
+		report.dropped(field, "unknown language "+language)
+		return
+	}
+	c.Languages = []*types.Language{l}
+}
+
+// migrateFee converts a fee with a decimal amount. Old fees were floats, so amounts are rounded to the base
+// unit of the currency.
+func migrateFee(report *MigrationReport, currency, amount, address string) (*types.Fee, error) {
+	c, err := ParseFeeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, errors.Err("invalid fee amount %q", amount)
+	}
+	rounded := d.Round(feeExponent(c))
+	if !rounded.Equal(d) {
+		report.translated("fee.amount", "rounded "+d.String()+" to "+rounded.String())
+	}
+	units, err := ToBaseUnits(rounded, c)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := &types.Fee{Currency: c, Amount: units}
+	if address != "" {
+		fee.Address, err = base58.Decode(address)
+		if err != nil {
+			return nil, errors.Prefix("invalid fee address", err)
+		}
+	}
+	return fee, nil
+}
@@ -0,0 +1,94 @@
+package claim
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	legacy "github.com/lbryio/types/v1/go"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestMigrateJSONClaim(t *testing.T) {
+	sdHash := strings.Repeat("d5", 48)
+	value := `{"ver": "0.0.2", "title": "Title", "description": "Description", "author": "Author",
+		"language": "en", "license": "Public Domain", "license_url": "https://example.com/license",
+		"nsfw": true, "content-type": "video/mp4", "thumbnail": "https://example.com/thumb.jpg",
+		"sources": {"lbry_sd_hash": "` + sdHash + `", "btih": "abc"},
+		"fee": {"USD": {"amount": 0.999, "address": "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"}},
+		"contact": 1}`
+
+	c, report, err := MigrateJSONClaim([]byte(value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Format != FormatJSONV2 {
+		t.Errorf("expected format %s, got %s", FormatJSONV2, report.Format)
+	}
+	if c.GetTitle() != "Title" || c.GetStream().GetAuthor() != "Author" || c.GetStream().GetLicenseUrl() != "https://example.com/license" {
+		t.Errorf("unexpected claim %v", c)
+	}
+	if hex.EncodeToString(c.GetStream().GetSource().GetSdHash()) != sdHash || c.GetStream().GetSource().GetMediaType() != "video/mp4" {
+		t.Errorf("unexpected source %v", c.GetStream().GetSource())
+	}
+	if len(c.GetTags()) != 1 || c.GetTags()[0] != "mature" || LanguageTag(c.GetLanguages()[0]) != "en" {
+		t.Errorf("unexpected tags %v or languages %v", c.GetTags(), c.GetLanguages())
+	}
+	if c.GetStream().GetFee().GetAmount() != 100 || base58.Encode(c.GetStream().GetFee().GetAddress()) != "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha" {
+		t.Errorf("unexpected fee %v", c.GetStream().GetFee())
+	}
+
+	var translated, dropped []string
+	for _, n := range report.Translated {
+		translated = append(translated, n.Field)
+	}
+	for _, n := range report.Dropped {
+		dropped = append(dropped, n.Field)
+	}
+	if strings.Join(translated, ",") != "nsfw,fee.amount" {
+		t.Errorf("unexpected translated fields %v", translated)
+	}
+	if strings.Join(dropped, ",") != "sources.btih,contact" {
+		t.Errorf("unexpected dropped fields %v", dropped)
+	}
+
+	for _, bad := range []string{`not json`, `{"ver": "0.0.9"}`, `{"sources": {"lbry_sd_hash": "xyz"}}`, `{"fee": {"EUR": {"amount": 1}}}`} {
+		if _, _, err := MigrateJSONClaim([]byte(bad)); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+func TestMigrateLegacyClaim(t *testing.T) {
+	// a signed legacy stream claim with an LBC fee
+	value, _ := hex.DecodeString("080110011ad7010801128f01080410011a0c47616d65206f66206c696665221047616d65206f66206c696665206769662a0b4a6f686e20436f6e776179322e437265617469766520436f6d6d6f6e73204174747269627574696f6e20342e3020496e7465726e6174696f6e616c38004224080110011a195569c917f18bf5d2d67f1346aa467b218ba90cdbf2795676da250000803f4a0052005a001a41080110011a30b6adf6e2a62950407ea9fb045a96127b67d39088678d2f738c359894c88d95698075ee6203533d3c204330713aa7acaf2209696d6167652f6769662a5c080110031a40c73fe1be4f1743c2996102eec6ce0509e03744ab940c97d19ddb3b25596206367ab1a3d2583b16c04d2717eeb983ae8f84fee2a46621ffa5c4726b30174c6ff82214251305ca93d4dbedb50dceb282ebcb7b07b7ac65")
+	old := &legacy.Claim{}
+	if err := proto.Unmarshal(value, old); err != nil {
+		t.Fatal(err)
+	}
+
+	c, report, err := MigrateLegacyClaim(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.GetTitle() != "Game of life" || c.GetStream().GetAuthor() != "John Conway" || c.GetStream().GetSource().GetMediaType() != "image/gif" {
+		t.Errorf("unexpected claim %v", c)
+	}
+	if c.GetStream().GetFee().GetAmount() != 100000000 || c.GetStream().GetFee().GetCurrency().String() != "LBC" || len(c.GetStream().GetFee().GetAddress()) != 25 {
+		t.Errorf("unexpected fee %v", c.GetStream().GetFee())
+	}
+	if len(report.Dropped) != 1 || report.Dropped[0].Field != "publisherSignature" {
+		t.Errorf("expected the signature to be dropped, got %v", report.Dropped)
+	}
+
+	channelType := legacy.Claim_certificateType
+	channel, _, err := MigrateLegacyClaim(&legacy.Claim{ClaimType: &channelType, Certificate: &legacy.Certificate{PublicKey: []byte{1, 2, 3}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(channel.GetChannel().GetPublicKey()) != 3 {
+		t.Errorf("unexpected channel %v", channel)
+	}
+}
@@ -0,0 +1,158 @@
+package claim
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/shopspring/decimal"
+)
+
+// gpsPrecision is the scale of the integer latitude and longitude in a Location
+const gpsPrecision = 7
+
+// NormalizeTag lowercases a tag and collapses its whitespace, as lbry-sdk does. Tags longer than
+// MaxTagLength are cut short.
+func NormalizeTag(tag string) string {
+	tag = strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+	if utf8.RuneCountInString(tag) > MaxTagLength {
+		tag = strings.TrimSpace(string([]rune(tag)[:MaxTagLength]))
+	}
+	return tag
+}
+
+// NormalizeTags normalizes each tag and drops empty and duplicate tags, keeping the order of the rest
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// ParseLanguage parses an RFC 5646 language tag made of a language and an optional script and region, such
+// as en, en-US or zh-Hant-TW. Each part must be in the claim protobuf's enums. Case does not matter.
+func ParseLanguage(tag string) (*types.Language, error) {
+	parts := strings.Split(strings.Replace(tag, "_", "-", -1), "-")
+
+	language, ok := types.Language_Language_value[strings.ToLower(parts[0])]
+	if !ok || language == int32(types.Language_UNKNOWN_LANGUAGE) {
+		return nil, errors.Err("unknown language %q", tag)
+	}
+	l := &types.Language{Language: types.Language_Language(language)}
+
+	for _, p := range parts[1:] {
+		script, isScript := types.Language_Script_value[strings.Title(strings.ToLower(p))]
+		region, isRegion := types.Location_Country_value[strings.ToUpper(p)]
+		switch {
+		case len(p) == 4 && isScript && l.Script == types.Language_UNKNOWN_SCRIPT && l.Region == types.Location_UNKNOWN_COUNTRY:
+			l.Script = types.Language_Script(script)
+		case isRegion && l.Region == types.Location_UNKNOWN_COUNTRY:
+			l.Region = types.Location_Country(region)
+		default:
+			return nil, errors.Err("invalid language tag %q", tag)
+		}
+	}
+	return l, nil
+}
+
+// LanguageTag formats a language as an RFC 5646 tag, e.g. en, en-US or zh-Hant-TW
+func LanguageTag(l *types.Language) string {
+	parts := []string{types.Language_Language_name[int32(l.GetLanguage())]}
+	if l.GetScript() != types.Language_UNKNOWN_SCRIPT {
+		parts = append(parts, types.Language_Script_name[int32(l.GetScript())])
+	}
+	if l.GetRegion() != types.Location_UNKNOWN_COUNTRY {
+		parts = append(parts, types.Location_Country_name[int32(l.GetRegion())])
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseLocation parses a location in lbry-sdk's colon separated form,
+// country:state:city:code:latitude:longitude. Trailing parts may be left off and any part may be empty,
+// e.g. "US:NH:Manchester" or "::::42.99:-71.46".
+func ParseLocation(s string) (*types.Location, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 6 {
+		return nil, errors.Err("location %q has too many parts", s)
+	}
+	for len(parts) < 6 {
+		parts = append(parts, "")
+	}
+
+	l := &types.Location{State: parts[1], City: parts[2], Code: parts[3]}
+	if parts[0] != "" {
+		country, ok := types.Location_Country_value[strings.ToUpper(parts[0])]
+		if !ok || country == int32(types.Location_UNKNOWN_COUNTRY) {
+			return nil, errors.Err("unknown country %q", parts[0])
+		}
+		l.Country = types.Location_Country(country)
+	}
+
+	var err error
+	if parts[4] != "" {
+		l.Latitude, err = ParseCoordinate(parts[4], 90)
+		if err != nil {
+			return nil, errors.Prefix("latitude", err)
+		}
+	}
+	if parts[5] != "" {
+		l.Longitude, err = ParseCoordinate(parts[5], 180)
+		if err != nil {
+			return nil, errors.Prefix("longitude", err)
+		}
+	}
+	return l, nil
+}
+
+// LocationString formats a location in the form ParseLocation accepts, leaving off empty trailing parts
+func LocationString(l *types.Location) string {
+	parts := make([]string, 6)
+	if l.GetCountry() != types.Location_UNKNOWN_COUNTRY {
+		parts[0] = types.Location_Country_name[int32(l.GetCountry())]
+	}
+	parts[1], parts[2], parts[3] = l.GetState(), l.GetCity(), l.GetCode()
+	if l.GetLatitude() != 0 {
+		parts[4] = FormatCoordinate(l.GetLatitude())
+	}
+	if l.GetLongitude() != 0 {
+		parts[5] = FormatCoordinate(l.GetLongitude())
+	}
+
+	end := len(parts)
+	for end > 0 && parts[end-1] == "" {
+		end--
+	}
+	return strings.Join(parts[:end], ":")
+}
+
+// ParseCoordinate converts a decimal latitude or longitude to the fixed point form stored in a Location.
+// The coordinate must be within ±limit degrees.
+func ParseCoordinate(s string, limit int64) (int32, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return 0, errors.Err("invalid coordinate %q", s)
+	}
+	if d.Abs().GreaterThan(decimal.New(limit, 0)) {
+		return 0, errors.Err("coordinate %s is not between -%d and %d", s, limit, limit)
+	}
+	fixed := d.Shift(gpsPrecision).Round(0).IntPart()
+	if fixed > math.MaxInt32 || fixed < math.MinInt32 {
+		return 0, errors.Err("coordinate %s is out of range", s)
+	}
+	return int32(fixed), nil
+}
+
+// FormatCoordinate converts a fixed point latitude or longitude from a Location to a decimal string
+func FormatCoordinate(c int32) string {
+	return decimal.New(int64(c), -gpsPrecision).String()
+}
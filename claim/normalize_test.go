@@ -0,0 +1,82 @@
+package claim
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags := []string{"Science", "  science ", "Mature", "", "  ", "space   exploration", strings.Repeat("x", MaxTagLength+10)}
+	expected := []string{"science", "mature", "space exploration", strings.Repeat("x", MaxTagLength)}
+	if got := NormalizeTags(tags); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	tests := map[string]*types.Language{
+		"en":         {Language: types.Language_en},
+		"EN-us":      {Language: types.Language_en, Region: types.Location_US},
+		"zh-Hant-TW": {Language: types.Language_zh, Script: types.Language_Hant, Region: types.Location_TW},
+		"sr_latn":    {Language: types.Language_sr, Script: types.Language_Latn},
+	}
+	for tag, expected := range tests {
+		l, err := ParseLanguage(tag)
+		if err != nil {
+			t.Errorf("%s: %v", tag, err)
+			continue
+		}
+		if !proto.Equal(l, expected) {
+			t.Errorf("%s: expected %v, got %v", tag, expected, l)
+		}
+	}
+	if tag := LanguageTag(tests["zh-Hant-TW"]); tag != "zh-Hant-TW" {
+		t.Errorf("expected zh-Hant-TW, got %s", tag)
+	}
+
+	for _, tag := range []string{"", "xx", "en-XX", "en-US-Latn", "en-US-CA", "UNKNOWN_LANGUAGE"} {
+		if _, err := ParseLanguage(tag); err == nil {
+			t.Errorf("%q: expected an error", tag)
+		}
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	l, err := ParseLocation("us:NH:Manchester:03101:42.990605:-71.460989")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &types.Location{
+		Country:   types.Location_US,
+		State:     "NH",
+		City:      "Manchester",
+		Code:      "03101",
+		Latitude:  429906050,
+		Longitude: -714609890,
+	}
+	if !proto.Equal(l, expected) {
+		t.Errorf("expected %v, got %v", expected, l)
+	}
+	if s := LocationString(l); s != "US:NH:Manchester:03101:42.990605:-71.460989" {
+		t.Errorf("unexpected location string %s", s)
+	}
+
+	l, err = ParseLocation("CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Country != types.Location_CA || LocationString(l) != "CA" {
+		t.Errorf("unexpected location %v", l)
+	}
+
+	for _, s := range []string{"XX", "US::::91:0", "US:::::181", "US::::north", "1:2:3:4:5:6:7"} {
+		if _, err := ParseLocation(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
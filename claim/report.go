@@ -0,0 +1,114 @@
+package claim
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+	"github.com/lbryio/lbry.go/v2/lbryurl"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// MaxNameLength is the longest claim name lbrycrd accepts, in bytes
+const MaxNameLength = 255
+
+// Report is the result of a validation, for services that show it to users or tools that gate on it. It
+// serializes to JSON as is.
+type Report struct {
+	Valid      bool        `json:"valid"` // true if there are no violations of SeverityError
+	Violations []Violation `json:"violations"`
+}
+
+// NewReport makes a report from the violations returned by Validate and the other validation functions
+func NewReport(violations []Violation) *Report {
+	r := &Report{Valid: true, Violations: violations}
+	if r.Violations == nil {
+		r.Violations = []Violation{}
+	}
+	for _, v := range violations {
+		if v.Severity != SeverityWarning {
+			r.Valid = false
+		}
+	}
+	return r
+}
+
+// Errors returns the violations that make the report invalid
+func (r *Report) Errors() []Violation {
+	return r.filter(func(v Violation) bool { return v.Severity != SeverityWarning })
+}
+
+// Warnings returns the violations that don't make the report invalid
+func (r *Report) Warnings() []Violation {
+	return r.filter(func(v Violation) bool { return v.Severity == SeverityWarning })
+}
+
+func (r *Report) filter(keep func(Violation) bool) []Violation {
+	var filtered []Violation
+	for _, v := range r.Violations {
+		if keep(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// ValidateAddress checks that a base58 or bech32 address is valid for a network, such as lbrycrd.LbrycrdMain.
+// field is the path reported for violations.
+func ValidateAddress(field, address, blockchainName string) []Violation {
+	var v violations
+	params, err := lbrycrd.GetChainParams(blockchainName)
+	if err != nil {
+		v.add(field, WrongNetwork, "unknown network "+blockchainName)
+		return v
+	}
+
+	err = lbrycrd.ValidateAddress(address, params)
+	if wrongNetwork, ok := errors.Unwrap(err).(*lbrycrd.WrongNetworkError); ok {
+		v.add(field, WrongNetwork, wrongNetwork.Error())
+	} else if err != nil {
+		v.add(field, InvalidAddress, err.Error())
+	}
+	return v
+}
+
+// ValidateStake checks a claim output before it is published: its name, its amount and the claim value. The
+// fee address of a stream must be for the network.
+func ValidateStake(name string, amount btcutil.Amount, value []byte, blockchainName string) []Violation {
+	var v violations
+
+	switch {
+	case name == "":
+		v.add("name", InvalidName, "name is empty")
+	case len(name) > MaxNameLength:
+		v.add("name", InvalidName, "is "+strconv.Itoa(len(name))+" bytes, the maximum is "+strconv.Itoa(MaxNameLength))
+	case strings.ContainsAny(strings.TrimPrefix(name, "@"), lbryurl.InvalidNameChars) || strings.IndexFunc(name, unicode.IsSpace) >= 0:
+		v.add("name", InvalidName, "name can't contain whitespace or any of "+lbryurl.InvalidNameChars)
+	}
+
+	if amount <= 0 {
+		v.add("amount", InvalidAmount, "amount must be positive")
+	}
+
+	helper, err := DecodeClaimBytes(value, blockchainName, DecodeStrict)
+	if err != nil {
+		v.add("value", InvalidValue, err.Error())
+		return v
+	}
+	if helper.Claim == nil {
+		v.add("value", InvalidValue, "claim could not be migrated to the current format")
+		return v
+	}
+	for _, violation := range Validate(helper.Claim) {
+		violation.Field = "value." + violation.Field
+		v = append(v, violation)
+	}
+	if address := helper.GetStream().GetFee().GetAddress(); len(address) == addressLength {
+		v = append(v, ValidateAddress("value.stream.fee.address", base58.Encode(address), blockchainName)...)
+	}
+	return v
+}
@@ -0,0 +1,115 @@
+package claim
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+	schema "github.com/lbryio/lbryschema.go/claim"
+	types "github.com/lbryio/types/v2/go"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func TestReport(t *testing.T) {
+	c := &types.Claim{
+		Tags: []string{"Music", "rock"},
+		Type: &types.Claim_Stream{Stream: &types.Stream{Source: &types.Source{SdHash: bytes.Repeat([]byte{1}, 48)}}},
+	}
+	r := NewReport(Validate(c))
+	if !r.Valid || len(r.Errors()) != 0 {
+		t.Errorf("expected a valid report, got %+v", r)
+	}
+	if w := r.Warnings(); len(w) != 1 || w[0].Field != "tags[0]" || w[0].Code != UnnormalizedTag {
+		t.Errorf("expected a warning for tags[0], got %+v", w)
+	}
+
+	c.GetStream().Source.SdHash = nil
+	r = NewReport(Validate(c))
+	if r.Valid || len(r.Errors()) != 1 {
+		t.Errorf("expected one error, got %+v", r)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"valid":false,"violations":[` +
+		`{"field":"tags[0]","code":"unnormalized_tag","severity":"warning","message":"should be \"music\""},` +
+		`{"field":"stream.source.sd_hash","code":"missing_source_hash","severity":"error","message":"stream source must have an sd hash"}]}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+
+	if b, _ := json.Marshal(NewReport(nil)); string(b) != `{"valid":true,"violations":[]}` {
+		t.Errorf("unexpected empty report %s", b)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	if v := ValidateAddress("address", "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", lbrycrd.LbrycrdMain); len(v) != 0 {
+		t.Errorf("expected no violations, got %v", v)
+	}
+	if v := ValidateAddress("address", "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHhb", lbrycrd.LbrycrdMain); len(v) != 1 || v[0].Code != InvalidAddress {
+		t.Errorf("expected an invalid address, got %v", v)
+	}
+	if v := ValidateAddress("address", "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", lbrycrd.LbrycrdTestnet); len(v) != 1 || v[0].Code != WrongNetwork {
+		t.Errorf("expected a wrong network, got %v", v)
+	}
+}
+
+func TestValidateStake(t *testing.T) {
+	address := base58.Decode("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha")
+	value, err := NewStream().Title("title").SdHash(testSdHash).Fee("LBC", "1", address).BuildSigned(wire.OutPoint{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := ValidateStake("name", 1, value, lbrycrd.LbrycrdMain); len(v) != 0 {
+		t.Errorf("expected no violations, got %v", v)
+	}
+	// names are checked against the characters lbry urls forbid, after a channel's leading '@'
+	if v := ValidateStake("@name", 1, value, lbrycrd.LbrycrdMain); len(v) != 0 {
+		t.Errorf("expected no violations for a channel name, got %v", v)
+	}
+	for _, name := range []string{"na@me", "@@name", "name#1", "a/b"} {
+		if v := ValidateStake(name, 1, value, lbrycrd.LbrycrdMain); len(v) != 1 || v[0].Code != InvalidName {
+			t.Errorf("%s: expected an invalid name, got %v", name, v)
+		}
+	}
+
+	v := ValidateStake("bad name", 0, value, lbrycrd.LbrycrdTestnet)
+	fields := map[string]ViolationCode{}
+	for _, violation := range v {
+		fields[violation.Field] = violation.Code
+	}
+	expected := map[string]ViolationCode{
+		"name":                     InvalidName,
+		"amount":                   InvalidAmount,
+		"value.stream.fee.address": WrongNetwork,
+	}
+	if len(fields) != len(expected) {
+		t.Errorf("expected %d violations, got %v", len(expected), v)
+	}
+	for field, code := range expected {
+		if fields[field] != code {
+			t.Errorf("expected %s for %s, got %v", code, field, v)
+		}
+	}
+
+	unsigned := &schema.ClaimHelper{Claim: &types.Claim{Type: &types.Claim_Stream{Stream: &types.Stream{}}}, Version: schema.NoSig}
+	value, err = unsigned.CompileValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v = ValidateStake("name", 1, append(value, 0x98, 0x06, 0x01), lbrycrd.LbrycrdMain)
+	if len(v) != 1 || v[0].Code != InvalidValue {
+		t.Errorf("expected an invalid value, got %v", v)
+	}
+	v = ValidateStake("name", 1, value, lbrycrd.LbrycrdMain)
+	if len(v) != 1 || v[0].Field != "value.stream.source" {
+		t.Errorf("expected a missing source, got %v", v)
+	}
+}
@@ -0,0 +1,233 @@
+package claim
+
+import (
+	"crypto/sha512"
+	"math"
+	"net/url"
+	"strconv"
+	"unicode/utf8"
+
+	types "github.com/lbryio/types/v2/go"
+)
+
+// ViolationCode identifies a kind of problem with claim metadata
+type ViolationCode string
+
+const (
+	MissingType         ViolationCode = "missing_type"
+	MissingSource       ViolationCode = "missing_source"
+	MissingSourceHash   ViolationCode = "missing_source_hash"
+	InvalidSourceHash   ViolationCode = "invalid_source_hash"
+	InvalidFeeCurrency  ViolationCode = "invalid_fee_currency"
+	InvalidFeeAmount    ViolationCode = "invalid_fee_amount"
+	InvalidFeeAddress   ViolationCode = "invalid_fee_address"
+	InvalidLanguage     ViolationCode = "invalid_language"
+	InvalidRegion       ViolationCode = "invalid_region"
+	InvalidLocation     ViolationCode = "invalid_location"
+	FieldTooLong        ViolationCode = "field_too_long"
+	InvalidURL          ViolationCode = "invalid_url"
+	InvalidThumbnailURL ViolationCode = "invalid_thumbnail_url"
+	MissingPublicKey    ViolationCode = "missing_public_key"
+	InvalidClaimHash    ViolationCode = "invalid_claim_hash"
+	UnnormalizedTag     ViolationCode = "unnormalized_tag"
+	InvalidAddress      ViolationCode = "invalid_address"
+	WrongNetwork        ViolationCode = "wrong_network"
+	InvalidName         ViolationCode = "invalid_name"
+	InvalidAmount       ViolationCode = "invalid_amount"
+	InvalidValue        ViolationCode = "invalid_value"
+)
+
+// Severity says whether a violation makes a claim invalid
+type Severity string
+
+const (
+	// SeverityError means the claim should not be published
+	SeverityError Severity = "error"
+	// SeverityWarning means the claim can be published, but lbry-sdk would have done it differently
+	SeverityWarning Severity = "warning"
+)
+
+// Limits on the length of text fields, in characters
+const (
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 5000
+	MaxTagLength         = 100
+	MaxAuthorLength      = 200
+	MaxLicenseLength     = 500
+)
+
+const (
+	addressLength   = 25 // version byte, 20 byte hash, 4 byte checksum
+	claimHashLength = 20
+)
+
+// Violation is a single problem found in a claim's metadata
+type Violation struct {
+	Field    string        `json:"field"` // path of the offending field, e.g. "stream.fee.currency"
+	Code     ViolationCode `json:"code"`
+	Severity Severity      `json:"severity"`
+	Message  string        `json:"message"`
+}
+
+func (v Violation) Error() string {
+	return v.Field + ": " + v.Message
+}
+
+// Validate checks a claim's metadata and returns every problem it finds. A claim with no violations of
+// SeverityError is safe to publish, as far as its metadata goes.
+func Validate(c *types.Claim) []Violation {
+	var v violations
+
+	if c == nil || c.GetType() == nil {
+		v.add("type", MissingType, "claim must be a stream, channel, collection or repost")
+		return v
+	}
+
+	v.checkLength("title", c.GetTitle(), MaxTitleLength)
+	v.checkLength("description", c.GetDescription(), MaxDescriptionLength)
+	for i, tag := range c.GetTags() {
+		field := "tags[" + strconv.Itoa(i) + "]"
+		v.checkLength(field, tag, MaxTagLength)
+		if normalized := NormalizeTag(tag); normalized != tag && utf8.RuneCountInString(tag) <= MaxTagLength {
+			v.warn(field, UnnormalizedTag, "should be "+strconv.Quote(normalized))
+		}
+	}
+	v.checkSourceURL("thumbnail", c.GetThumbnail(), InvalidThumbnailURL)
+
+	for i, l := range c.GetLanguages() {
+		field := "languages[" + strconv.Itoa(i) + "]"
+		if _, ok := types.Language_Language_name[int32(l.GetLanguage())]; !ok || l.GetLanguage() == types.Language_UNKNOWN_LANGUAGE {
+			v.add(field+".language", InvalidLanguage, "unknown language code "+strconv.Itoa(int(l.GetLanguage())))
+		}
+		if _, ok := types.Language_Script_name[int32(l.GetScript())]; !ok {
+			v.add(field+".script", InvalidLanguage, "unknown script code "+strconv.Itoa(int(l.GetScript())))
+		}
+		if _, ok := types.Location_Country_name[int32(l.GetRegion())]; !ok {
+			v.add(field+".region", InvalidRegion, "unknown region code "+strconv.Itoa(int(l.GetRegion())))
+		}
+	}
+
+	for i, l := range c.GetLocations() {
+		if _, ok := types.Location_Country_name[int32(l.GetCountry())]; !ok {
+			v.add("locations["+strconv.Itoa(i)+"].country", InvalidLocation, "unknown country code "+strconv.Itoa(int(l.GetCountry())))
+		}
+	}
+
+	switch {
+	case c.GetStream() != nil:
+		v.checkStream(c.GetStream())
+	case c.GetChannel() != nil:
+		v.checkChannel(c.GetChannel())
+	case c.GetRepost() != nil:
+		if len(c.GetRepost().GetClaimHash()) != claimHashLength {
+			v.add("repost.claim_hash", InvalidClaimHash, "reposted claim hash must be 20 bytes")
+		}
+	case c.GetCollection() != nil:
+		for i, ref := range c.GetCollection().GetClaimReferences() {
+			if len(ref.GetClaimHash()) != claimHashLength {
+				v.add("collection.claim_references["+strconv.Itoa(i)+"]", InvalidClaimHash, "claim hash must be 20 bytes")
+			}
+		}
+	}
+
+	return v
+}
+
+// ValidateFee checks a fee on its own, e.g. before it is added to a stream
+func ValidateFee(fee *types.Fee) []Violation {
+	var v violations
+	if fee == nil {
+		v.add("fee", InvalidFeeAmount, "fee is missing")
+		return v
+	}
+	v.checkFee("fee", fee)
+	return v
+}
+
+// ValidateThumbnailURL checks a thumbnail or cover image url on its own
+func ValidateThumbnailURL(url string) []Violation {
+	var v violations
+	v.checkURL("thumbnail.url", url, InvalidThumbnailURL)
+	return v
+}
+
+type violations []Violation
+
+func (v *violations) add(field string, code ViolationCode, message string) {
+	*v = append(*v, Violation{Field: field, Code: code, Severity: SeverityError, Message: message})
+}
+
+func (v *violations) warn(field string, code ViolationCode, message string) {
+	*v = append(*v, Violation{Field: field, Code: code, Severity: SeverityWarning, Message: message})
+}
+
+func (v *violations) checkLength(field, value string, max int) {
+	if n := utf8.RuneCountInString(value); n > max {
+		v.add(field, FieldTooLong, "is "+strconv.Itoa(n)+" characters, the maximum is "+strconv.Itoa(max))
+	}
+}
+
+func (v *violations) checkURL(field, value string, code ViolationCode) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(field, code, "must be an absolute http or https url")
+	}
+}
+
+func (v *violations) checkSourceURL(field string, s *types.Source, code ViolationCode) {
+	if s == nil {
+		return
+	}
+	v.checkURL(field+".url", s.GetUrl(), code)
+}
+
+func (v *violations) checkStream(s *types.Stream) {
+	v.checkLength("stream.author", s.GetAuthor(), MaxAuthorLength)
+	v.checkLength("stream.license", s.GetLicense(), MaxLicenseLength)
+	v.checkURL("stream.license_url", s.GetLicenseUrl(), InvalidURL)
+
+	source := s.GetSource()
+	if source == nil {
+		v.add("stream.source", MissingSource, "stream must have a source")
+	} else {
+		switch len(source.GetSdHash()) {
+		case 0:
+			v.add("stream.source.sd_hash", MissingSourceHash, "stream source must have an sd hash")
+		case sha512.Size384:
+		default:
+			v.add("stream.source.sd_hash", InvalidSourceHash, "sd hash must be 48 bytes")
+		}
+		if n := len(source.GetHash()); n != 0 && n != sha512.Size384 {
+			v.add("stream.source.hash", InvalidSourceHash, "file hash must be 48 bytes")
+		}
+	}
+
+	if s.GetFee() != nil {
+		v.checkFee("stream.fee", s.GetFee())
+	}
+}
+
+func (v *violations) checkFee(field string, fee *types.Fee) {
+	if _, ok := types.Fee_Currency_name[int32(fee.GetCurrency())]; !ok || fee.GetCurrency() == types.Fee_UNKNOWN_CURRENCY {
+		v.add(field+".currency", InvalidFeeCurrency, "fee currency must be LBC, BTC or USD")
+	}
+	if fee.GetAmount() == 0 {
+		v.add(field+".amount", InvalidFeeAmount, "fee amount must be positive")
+	} else if fee.GetAmount() > math.MaxInt64 {
+		v.add(field+".amount", InvalidFeeAmount, "fee amount is too large")
+	}
+	if n := len(fee.GetAddress()); n != 0 && n != addressLength {
+		v.add(field+".address", InvalidFeeAddress, "fee address must be 25 bytes")
+	}
+}
+
+func (v *violations) checkChannel(c *types.Channel) {
+	if len(c.GetPublicKey()) == 0 {
+		v.add("channel.public_key", MissingPublicKey, "channel must have a public key")
+	}
+	v.checkURL("channel.website_url", c.GetWebsiteUrl(), InvalidURL)
+	v.checkSourceURL("channel.cover", c.GetCover(), InvalidThumbnailURL)
+}
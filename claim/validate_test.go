@@ -0,0 +1,58 @@
+package claim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	types "github.com/lbryio/types/v2/go"
+)
+
+func TestValidate(t *testing.T) {
+	valid := &types.Claim{
+		Title:     "title",
+		Thumbnail: &types.Source{Url: "https://example.com/thumb.png"},
+		Languages: []*types.Language{{Language: types.Language_en}},
+		Type: &types.Claim_Stream{Stream: &types.Stream{
+			Source: &types.Source{SdHash: bytes.Repeat([]byte{1}, 48)},
+			Fee:    &types.Fee{Currency: types.Fee_LBC, Amount: 100},
+		}},
+	}
+	if v := Validate(valid); len(v) != 0 {
+		t.Errorf("expected no violations, got %v", v)
+	}
+
+	invalid := &types.Claim{
+		Title:     strings.Repeat("x", MaxTitleLength+1),
+		Thumbnail: &types.Source{Url: "not a url"},
+		Languages: []*types.Language{
+			{Language: types.Language_UNKNOWN_LANGUAGE},
+			{Language: types.Language_en, Region: types.Location_Country(9999)},
+		},
+		Type: &types.Claim_Stream{Stream: &types.Stream{
+			Source: &types.Source{},
+			Fee:    &types.Fee{Currency: types.Fee_Currency(99), Amount: 100},
+		}},
+	}
+	expected := map[ViolationCode]string{
+		FieldTooLong:        "title",
+		InvalidThumbnailURL: "thumbnail.url",
+		InvalidLanguage:     "languages[0].language",
+		InvalidRegion:       "languages[1].region",
+		MissingSourceHash:   "stream.source.sd_hash",
+		InvalidFeeCurrency:  "stream.fee.currency",
+	}
+	violations := Validate(invalid)
+	if len(violations) != len(expected) {
+		t.Errorf("expected %d violations, got %v", len(expected), violations)
+	}
+	for _, v := range violations {
+		if expected[v.Code] != v.Field {
+			t.Errorf("unexpected violation %s (%s)", v.Error(), v.Code)
+		}
+	}
+
+	if v := Validate(&types.Claim{}); len(v) != 1 || v[0].Code != MissingType {
+		t.Errorf("expected a missing type violation, got %v", v)
+	}
+}
@@ -22,10 +22,12 @@ var Log = func(*http.Request, *Response, error) {}
 
 // http://choly.ca/post/go-json-marshalling/
 type ResponseInfo struct {
-	Success bool        `json:"success"`
-	Error   *string     `json:"error"`
-	Data    interface{} `json:"data"`
-	Trace   []string    `json:"_trace,omitempty"`
+	Success bool           `json:"success"`
+	Error   *string        `json:"error"`
+	Code    string         `json:"code,omitempty"`
+	Data    interface{}    `json:"data"`
+	Trace   []string       `json:"_trace,omitempty"`
+	Frames  []errors.Frame `json:"_frames,omitempty"`
 }
 
 // BuildJSONResponse allows implementers to control the json response form from the api
@@ -36,7 +38,12 @@ var BuildJSONResponse = func(response ResponseInfo) ([]byte, error) {
 // TraceEnabled Attaches a trace field to the JSON response when enabled.
 var TraceEnabled = false
 
-// StatusError represents an error with an associated HTTP status code.
+// StructuredTraceEnabled makes the trace attached by TraceEnabled a list of frames, in the _frames field, rather
+// than lines of text in the _trace field.
+var StructuredTraceEnabled = false
+
+// StatusError represents an error with an associated HTTP status code. To return the same status for an error
+// everywhere, use RegisterStatus instead.
 type StatusError struct {
 	Status int
 	Err    error
@@ -44,6 +51,9 @@ type StatusError struct {
 
 func (se StatusError) Error() string { return se.Err.Error() }
 
+// Unwrap returns the error that has the status
+func (se StatusError) Unwrap() error { return se.Err }
+
 // Response is returned by API handlers
 type Response struct {
 	Status      int
@@ -62,7 +72,7 @@ func (h Handler) callHandlerSafely(r *http.Request) (rsp Response) {
 			if !ok {
 				err = errors.Err("%v", r)
 			}
-			rsp = Response{Error: errors.Wrap(err, 2)}
+			rsp = Response{Error: errors.WrapError(err, 2)}
 		}
 	}()
 
@@ -87,12 +97,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	if rsp.Status == 0 {
 		if rsp.Error != nil {
-			ogErr := errors.Unwrap(rsp.Error)
-			if statusError, ok := ogErr.(StatusError); ok {
-				rsp.Status = statusError.Status
-			} else {
-				rsp.Status = http.StatusInternalServerError
-			}
+			rsp.Status = StatusFor(rsp.Error)
 		} else if rsp.RedirectURL != "" {
 			rsp.Status = http.StatusFound
 		} else {
@@ -125,15 +130,20 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var trace []string
-	if TraceEnabled && errors.HasTrace(rsp.Error) {
+	var frames []errors.Frame
+	if TraceEnabled && StructuredTraceEnabled {
+		frames = errors.TraceFrames(rsp.Error)
+	} else if TraceEnabled && errors.HasTrace(rsp.Error) {
 		trace = getTraceFromError(rsp.Error)
 	}
 
 	jsonResponse, err := BuildJSONResponse(ResponseInfo{
 		Success: success,
 		Error:   errorString,
+		Code:    errors.GetCode(rsp.Error),
 		Data:    rsp.Data,
 		Trace:   trace,
+		Frames:  frames,
 	})
 	if err != nil {
 		Log(r, &rsp, errors.Prefix("Error encoding JSON response: ", err))
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+func serve(t *testing.T, h Handler) (int, ResponseInfo) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	var info ResponseInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	return w.Code, info
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{errors.Code("no such claim", errors.CodeNotFound), http.StatusNotFound, errors.CodeNotFound},
+		{errors.Prefix("loading", errors.Code("bad id", errors.CodeInvalidArgument)), http.StatusBadRequest, errors.CodeInvalidArgument},
+		{errors.Code("custom", "CUSTOM"), http.StatusInternalServerError, "CUSTOM"},
+		{errors.Err("no code"), http.StatusInternalServerError, ""},
+		{StatusError{Status: http.StatusTeapot, Err: errors.Code("teapot", errors.CodeNotFound)}, http.StatusTeapot, errors.CodeNotFound},
+	}
+	for _, test := range tests {
+		err := test.err
+		status, info := serve(t, func(r *http.Request) Response { return Response{Error: err} })
+		if status != test.status {
+			t.Errorf("%q: expected status %d, got %d", err, test.status, status)
+		}
+		if info.Code != test.code {
+			t.Errorf("%q: expected code %q, got %q", err, test.code, info.Code)
+		}
+		if info.Success || info.Error == nil || *info.Error != err.Error() {
+			t.Errorf("%q: bad response %+v", err, info)
+		}
+	}
+
+	status, info := serve(t, func(r *http.Request) Response {
+		return Response{Status: http.StatusAccepted, Error: errors.Code("handled", errors.CodeNotFound)}
+	})
+	if status != http.StatusAccepted || info.Code != errors.CodeNotFound {
+		t.Errorf("the handler's status should win, got %d %q", status, info.Code)
+	}
+}
+
+func TestStructuredTrace(t *testing.T) {
+	TraceEnabled, StructuredTraceEnabled = true, true
+	defer func() { TraceEnabled, StructuredTraceEnabled = false, false }()
+
+	_, info := serve(t, func(r *http.Request) Response { return Response{Error: errors.Err("traced")} })
+	if len(info.Frames) == 0 || info.Trace != nil {
+		t.Fatalf("expected frames and no text trace, got %+v", info)
+	}
+	if info.Frames[0].Line == 0 || info.Frames[0].Function == "" {
+		t.Errorf("bad frame %+v", info.Frames[0])
+	}
+
+	StructuredTraceEnabled = false
+	_, info = serve(t, func(r *http.Request) Response { return Response{Error: errors.Err("traced")} })
+	if len(info.Trace) == 0 || info.Frames != nil {
+		t.Errorf("expected a text trace and no frames, got %+v", info)
+	}
+}
+
+type quotaError struct{ user int }
+
+func (e *quotaError) Error() string { return fmt.Sprintf("user %d is over quota", e.user) }
+
+// listError can't be compared with ==
+type listError struct{ ids []string }
+
+func (e listError) Error() string { return fmt.Sprintf("bad ids %v", e.ids) }
+
+func TestStatusFor(t *testing.T) {
+	errGone := errors.Base("gone")
+	RegisterStatus(errGone, http.StatusNotFound)
+	RegisterStatus(errGone, http.StatusGone)
+	RegisterStatusType(&quotaError{}, http.StatusTooManyRequests)
+	RegisterCodeStatus("PAYMENT_REQUIRED", http.StatusPaymentRequired)
+	RegisterStatus(listError{}, http.StatusBadRequest)
+	RegisterStatus(listError{}, http.StatusBadRequest)
+
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{errGone, http.StatusGone},
+		{errors.Prefix("loading", errGone), http.StatusGone},
+		{fmt.Errorf("std: %w", errors.Err(&quotaError{1})), http.StatusTooManyRequests},
+		{errors.Join(errors.Base("other"), &quotaError{2}), http.StatusTooManyRequests},
+		{errors.Code("pay up", "PAYMENT_REQUIRED"), http.StatusPaymentRequired},
+		{errors.Code(errGone, errors.CodeNotFound), http.StatusGone},
+		{fmt.Errorf("std: %w", StatusError{Status: http.StatusTeapot, Err: errGone}), http.StatusTeapot},
+		{errors.Base("other"), http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		if status := StatusFor(test.err); status != test.status {
+			t.Errorf("%q: expected status %d, got %d", test.err, test.status, status)
+		}
+	}
+
+	status, _ := serve(t, func(r *http.Request) Response { return Response{Error: errors.Err(errGone)} })
+	if status != http.StatusGone {
+		t.Errorf("expected the handler to return the registered status, got %d", status)
+	}
+}
+
+func TestRegisterStatusType_Nil(t *testing.T) {
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic for a nil example")
+			}
+		}()
+		RegisterStatusType(nil, http.StatusTeapot)
+	}()
+	if status := StatusFor(errors.Base("other")); status != http.StatusInternalServerError {
+		t.Errorf("expected statuses to keep working, got %d", status)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// codeStatuses maps the codes attached by errors.Code to the HTTP status returned for them. It is guarded by the
+// statuses lock.
+var codeStatuses = map[string]int{
+	errors.CodeInvalidArgument:  http.StatusBadRequest,
+	errors.CodeUnauthenticated:  http.StatusUnauthorized,
+	errors.CodePermissionDenied: http.StatusForbidden,
+	errors.CodeNotFound:         http.StatusNotFound,
+	errors.CodeAlreadyExists:    http.StatusConflict,
+	errors.CodeRateLimited:      http.StatusTooManyRequests,
+	errors.CodeInternal:         http.StatusInternalServerError,
+	errors.CodeUnavailable:      http.StatusServiceUnavailable,
+	errors.CodeTimeout:          http.StatusGatewayTimeout,
+}
+
+type errorStatus struct {
+	err    error
+	status int
+}
+
+type typeStatus struct {
+	typ    reflect.Type
+	status int
+}
+
+var statuses struct {
+	sync.RWMutex
+	errors []errorStatus
+	types  []typeStatus
+}
+
+// RegisterStatus sets the HTTP status returned for err, and for errors that wrap it, so a package can declare
+// the status for its errors once rather than wrapping them in a StatusError everywhere they are returned:
+//
+//	func init() {
+//		api.RegisterStatus(ErrNotFound, http.StatusNotFound)
+//	}
+func RegisterStatus(err error, status int) {
+	statuses.Lock()
+	defer statuses.Unlock()
+	// errors that can't be compared, like structs with a slice, would panic, so they are always added
+	if typ := reflect.TypeOf(err); typ != nil && typ.Comparable() {
+		for i := range statuses.errors {
+			if statuses.errors[i].err == err {
+				statuses.errors[i].status = status
+				return
+			}
+		}
+	}
+	statuses.errors = append(statuses.errors, errorStatus{err, status})
+}
+
+// RegisterStatusType sets the HTTP status returned for errors of the same type as example, and for errors that
+// wrap one, like errors.As finds them. It panics if example is nil, which has no type.
+func RegisterStatusType(example error, status int) {
+	typ := reflect.TypeOf(example)
+	if typ == nil {
+		panic("api: RegisterStatusType needs a non-nil example error")
+	}
+	statuses.Lock()
+	defer statuses.Unlock()
+	for i := range statuses.types {
+		if statuses.types[i].typ == typ {
+			statuses.types[i].status = status
+			return
+		}
+	}
+	statuses.types = append(statuses.types, typeStatus{typ, status})
+}
+
+// RegisterCodeStatus sets the HTTP status returned for errors with the code attached by errors.Code. The codes
+// in the errors package have statuses already, e.g. 404 for errors.CodeNotFound, which this can replace.
+func RegisterCodeStatus(code string, status int) {
+	statuses.Lock()
+	defer statuses.Unlock()
+	codeStatuses[code] = status
+}
+
+// StatusFor returns the HTTP status for an error, which is the first of:
+//
+//   - the status of a StatusError the error is or wraps
+//   - the status registered for the error, or an error it wraps, with RegisterStatus
+//   - the status registered for its type, or the type of an error it wraps, with RegisterStatusType
+//   - the status for its code, registered with RegisterCodeStatus or built in
+//   - 500
+func StatusFor(err error) int {
+	var statusError StatusError
+	if errors.As(err, &statusError) {
+		return statusError.Status
+	}
+
+	statuses.RLock()
+	defer statuses.RUnlock()
+	for _, s := range statuses.errors {
+		if errors.Is(err, s.err) {
+			return s.status
+		}
+	}
+	for _, s := range statuses.types {
+		if errors.As(err, reflect.New(s.typ).Interface()) {
+			return s.status
+		}
+	}
+	if status, ok := codeStatuses[errors.GetCode(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
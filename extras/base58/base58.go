@@ -0,0 +1,76 @@
+// Package base58 implements the base58 encoding used for addresses and keys, and base58check, which adds
+// version bytes and a checksum. It wraps btcutil's base58 package, adding errors for invalid characters and
+// version prefixes longer than a byte.
+package base58
+
+import (
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ChecksumLength is the number of checksum bytes at the end of base58check data
+const ChecksumLength = 4
+
+var (
+	ErrInvalidCharacter = errors.Base("invalid base58 character")
+	// ErrChecksum and ErrInvalidFormat are btcutil's errors, so errors.Is matches them from either package
+	ErrChecksum      = base58.ErrChecksum
+	ErrInvalidFormat = base58.ErrInvalidFormat
+)
+
+// Encode encodes bytes as base58. Each leading zero byte becomes a leading '1'.
+func Encode(b []byte) string {
+	return base58.Encode(b)
+}
+
+// Decode decodes a base58 string
+func Decode(s string) ([]byte, error) {
+	if err := checkCharacters(s); err != nil {
+		return nil, err
+	}
+	return base58.Decode(s), nil
+}
+
+// checkCharacters checks that s is all base58. btcutil decodes other strings to nothing rather than failing.
+func checkCharacters(s string) error {
+	for _, r := range s {
+		if !strings.ContainsRune(alphabet, r) {
+			return errors.Err(ErrInvalidCharacter)
+		}
+	}
+	return nil
+}
+
+// EncodeCheck encodes the version bytes and payload followed by a checksum. The version may be any length
+// but empty, e.g. one byte for addresses and WIF keys, or four for extended keys.
+func EncodeCheck(version, payload []byte) string {
+	// the checksum covers the version and payload alike, so all but the first version byte can go in the payload
+	b := make([]byte, 0, len(version)-1+len(payload))
+	b = append(b, version[1:]...)
+	b = append(b, payload...)
+	return base58.CheckEncode(b, version[0])
+}
+
+// DecodeCheck decodes base58check data with versionLength version bytes, and verifies its checksum
+func DecodeCheck(s string, versionLength int) (version, payload []byte, err error) {
+	if err := checkCharacters(s); err != nil {
+		return nil, nil, err
+	}
+	if versionLength < 1 {
+		return nil, nil, errors.Err(ErrInvalidFormat)
+	}
+	rest, first, err := base58.CheckDecode(s)
+	if err != nil {
+		return nil, nil, errors.Err(err)
+	}
+	if len(rest) < versionLength-1 {
+		return nil, nil, errors.Err(ErrInvalidFormat)
+	}
+	version = append([]byte{first}, rest[:versionLength-1]...)
+	return version, rest[versionLength-1:], nil
+}
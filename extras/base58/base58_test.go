@@ -0,0 +1,82 @@
+package base58
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		hex     string
+		encoded string
+	}{
+		{"", ""},
+		{"00", "1"},
+		{"0000", "11"},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"00000000000000000000", "1111111111"},
+	}
+	for _, test := range tests {
+		b, _ := hex.DecodeString(test.hex)
+		if got := Encode(b); got != test.encoded {
+			t.Errorf("encode %s: expected %s, got %s", test.hex, test.encoded, got)
+		}
+		decoded, err := Decode(test.encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded, b) {
+			t.Errorf("decode %s: expected %s, got %x", test.encoded, test.hex, decoded)
+		}
+	}
+
+	for _, s := range []string{"0", "O", "I", "l", "3mJr0"} {
+		_, err := Decode(s)
+		if !errors.Is(err, ErrInvalidCharacter) {
+			t.Errorf("%s: expected ErrInvalidCharacter, got %v", s, err)
+		}
+	}
+}
+
+func TestEncodeCheck(t *testing.T) {
+	// uncompressed WIF private key
+	key, _ := hex.DecodeString("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+	wif := "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"
+	if got := EncodeCheck([]byte{0x80}, key); got != wif {
+		t.Errorf("expected %s, got %s", wif, got)
+	}
+	version, payload, err := DecodeCheck(wif, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(version, []byte{0x80}) || !bytes.Equal(payload, key) {
+		t.Errorf("unexpected version %x and payload %x", version, payload)
+	}
+
+	// extended keys have four version bytes
+	xprvVersion := []byte{0x04, 0x88, 0xad, 0xe4}
+	encoded := EncodeCheck(xprvVersion, bytes.Repeat([]byte{1}, 74))
+	version, payload, err = DecodeCheck(encoded, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(version, xprvVersion) || !bytes.Equal(payload, bytes.Repeat([]byte{1}, 74)) {
+		t.Errorf("unexpected version %x and payload %x", version, payload)
+	}
+
+	_, _, err = DecodeCheck("5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTK", 1)
+	if !errors.Is(err, ErrChecksum) {
+		t.Errorf("expected ErrChecksum, got %v", err)
+	}
+	_, _, err = DecodeCheck("2g", 1)
+	if !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("expected ErrInvalidFormat, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package errors
+
+// Codes for classifying errors the same way across services. extras/api maps them to HTTP statuses. Any other
+// string can be used as a code too.
+const (
+	CodeInvalidArgument  = "INVALID_ARGUMENT"
+	CodeUnauthenticated  = "UNAUTHENTICATED"
+	CodePermissionDenied = "PERMISSION_DENIED"
+	CodeNotFound         = "NOT_FOUND"
+	CodeAlreadyExists    = "ALREADY_EXISTS"
+	CodeRateLimited      = "RATE_LIMITED"
+	CodeInternal         = "INTERNAL"
+	CodeUnavailable      = "UNAVAILABLE"
+	CodeTimeout          = "TIMEOUT"
+)
+
+// Code attaches a code to the error, like CodeNotFound, that says what kind of error it is. The code stays with
+// the error when it is wrapped again, and GetCode returns it. Like Err, it attaches a stack trace if the error
+// doesn't have one.
+func Code(err interface{}, code string) error {
+	if err == nil {
+		return nil
+	}
+	traced, captured := wrap(withCause(err), 1)
+	e := *traced
+	e.code = code
+	if captured {
+		report(&e, SeverityError, nil)
+	}
+	return &e
+}
+
+// GetCode returns the code attached to the error, or "" if it doesn't have one. If the error was wrapped with
+// more than one code, the outermost code wins.
+func GetCode(err error) string {
+	for ; err != nil; err = unwrapOnce(err) {
+		if e, ok := err.(*Error); ok && e.code != "" {
+			return e.code
+		}
+	}
+	return ""
+}
+
+// HasCode checks if the error has the code attached
+func HasCode(err error, code string) bool {
+	return code != "" && GetCode(err) == code
+}
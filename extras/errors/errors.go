@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"bytes"
+	stderrors "errors"
 	"fmt"
 
 	"github.com/go-errors/errors"
@@ -11,6 +13,43 @@ type causer interface {
 	Cause() error
 }
 
+// Error is an error with a stack trace attached. It wraps a go-errors Error, and adds the Unwrap method that the
+// standard library's errors.Is, errors.As and errors.Unwrap need to see the error underneath.
+type Error struct {
+	err    *errors.Error
+	code   string
+	fields map[string]interface{}
+}
+
+// Error returns the message of the wrapped error, with its prefix if it has one
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error that was wrapped
+func (e *Error) Unwrap() error {
+	return e.err.Err
+}
+
+// Stack returns the stack trace, formatted like a panic's
+func (e *Error) Stack() []byte {
+	buf := bytes.Buffer{}
+	for _, frame := range e.StackFrames() {
+		buf.WriteString(frame.String())
+	}
+	return buf.Bytes()
+}
+
+// StackFrames returns the frames of the stack trace, without the ones SkipFrames leaves out
+func (e *Error) StackFrames() []errors.StackFrame {
+	return skipFrames(e.err.StackFrames())
+}
+
+// ErrorStack returns the type of the wrapped error, its message, and the stack trace
+func (e *Error) ErrorStack() string {
+	return e.err.TypeName() + " " + e.Error() + "\n" + string(e.Stack())
+}
+
 // Err intelligently creates/handles errors, while preserving the stack trace.
 // It works with errors from github.com/pkg/errors too.
 func Err(err interface{}, fmtParams ...interface{}) error {
@@ -24,20 +63,54 @@ func Err(err interface{}, fmtParams ...interface{}) error {
 		err = fmt.Errorf(errString, fmtParams...)
 	}
 
-	return errors.Wrap(err, 1)
+	e, captured := wrap(err, 1)
+	if captured {
+		report(e, SeverityError, nil)
+	}
+	return e
 }
 
-// Wrap calls errors.Wrap, in case you want to skip a different amount
+// Wrap calls errors.Wrap, in case you want to skip a different amount. It returns the go-errors Error
+// underneath, which drops any code or fields and has no Unwrap method, so the standard library's errors.Is can't
+// see into it. Use WrapError to keep them.
 func Wrap(err interface{}, skip int) *errors.Error {
 	if err == nil {
 		return nil
 	}
+	return WrapError(err, skip+1).err
+}
+
+// WrapError attaches a stack trace to err, in case you want to skip a different amount than Err does
+func WrapError(err interface{}, skip int) *Error {
+	if err == nil {
+		return nil
+	}
 
+	e, captured := wrap(withCause(err), skip+1)
+	if captured {
+		report(e, SeverityError, nil)
+	}
+	return e
+}
+
+// withCause formats an error from pkg/errors with its stack trace, which go-errors can't see
+func withCause(err interface{}) interface{} {
 	if _, ok := err.(causer); ok {
-		err = fmt.Errorf("%+v", err)
+		return fmt.Errorf("%+v", err)
 	}
+	return err
+}
 
-	return errors.Wrap(err, skip+1)
+// wrap attaches a stack trace that starts skip frames above its caller, unless err already has one. captured
+// says whether the trace is new, and so whether the error should be reported once it is ready.
+func wrap(err interface{}, skip int) (e *Error, captured bool) {
+	switch e := err.(type) {
+	case *Error:
+		return e, false
+	case *errors.Error:
+		return &Error{err: e}, false
+	}
+	return &Error{err: errors.Wrap(err, skip+1)}, true
 }
 
 // Unwrap returns the original error that was wrapped
@@ -49,6 +122,10 @@ func Unwrap(err error) error {
 	deeper := true
 	for deeper {
 		deeper = false
+		if e, ok := err.(*Error); ok {
+			err = e.err.Err
+			deeper = true
+		}
 		if e, ok := err.(*errors.Error); ok {
 			err = e.Err
 			deeper = true
@@ -62,16 +139,44 @@ func Unwrap(err error) error {
 	return err
 }
 
-// Is compares two wrapped errors to determine if the underlying errors are the same
-// It also interops with errors from pkg/errors
+// unwrapOnce returns the error that err wraps, or nil. Unlike the standard library's errors.Unwrap, it sees into
+// go-errors and pkg/errors errors, which don't have an Unwrap method.
+func unwrapOnce(err error) error {
+	switch e := err.(type) {
+	case *errors.Error:
+		return e.Err
+	case causer:
+		return e.Cause()
+	}
+	return stderrors.Unwrap(err)
+}
+
+// Is compares two wrapped errors to determine if the underlying errors are the same.
+// Like the standard library's errors.Is, it matches any error in e's chain, including ones wrapped with
+// fmt.Errorf's %w. It also interops with errors from pkg/errors.
 func Is(e error, original error) bool {
-	if c, ok := e.(causer); ok {
-		e = c.Cause()
+	original = Unwrap(original)
+	if e == nil || original == nil {
+		return e == original
 	}
-	if c, ok := original.(causer); ok {
-		original = c.Cause()
+	for ; e != nil; e = unwrapOnce(e) {
+		if stderrors.Is(e, original) {
+			return true
+		}
 	}
-	return errors.Is(e, original)
+	return false
+}
+
+// As finds the first error in err's chain that matches target, and if it finds one, sets target to it and
+// returns true. It works like the standard library's errors.As, but also sees into go-errors and pkg/errors
+// errors.
+func As(err error, target interface{}) bool {
+	for ; err != nil; err = unwrapOnce(err) {
+		if stderrors.As(err, target) {
+			return true
+		}
+	}
+	return false
 }
 
 // Prefix prefixes the message of the error with the given string
@@ -79,7 +184,13 @@ func Prefix(prefix string, err interface{}) error {
 	if err == nil {
 		return nil
 	}
-	return errors.WrapPrefix(Err(err), prefix, 0)
+	traced, captured := wrap(withCause(err), 1)
+	e := *traced
+	e.err = errors.WrapPrefix(e.err, prefix, 0)
+	if captured {
+		report(&e, SeverityError, nil)
+	}
+	return &e
 }
 
 // Trace returns the stack trace
@@ -87,7 +198,7 @@ func Trace(err error) string {
 	if err == nil {
 		return ""
 	}
-	return string(Err(err).(*errors.Error).Stack())
+	return string(Err(err).(*Error).Stack())
 }
 
 // FullTrace returns the error type, message, and stack trace
@@ -95,7 +206,7 @@ func FullTrace(err error) string {
 	if err == nil {
 		return ""
 	}
-	return Err(err).(*errors.Error).ErrorStack()
+	return Err(err).(*Error).ErrorStack()
 }
 
 // Base returns a simple error with no stack trace attached
@@ -105,6 +216,9 @@ func Base(format string, a ...interface{}) error {
 
 // HasTrace checks if error has a trace attached
 func HasTrace(err error) bool {
-	_, ok := err.(*errors.Error)
-	return ok
+	switch err.(type) {
+	case *Error, *errors.Error:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,292 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	goerrors "github.com/go-errors/errors"
+)
+
+var errSentinel = Base("sentinel")
+
+type codeError struct {
+	code int
+}
+
+func (e codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func TestStdInterop(t *testing.T) {
+	wrapped := []error{
+		Err(errSentinel),
+		WrapError(errSentinel, 0),
+		Prefix("prefix", errSentinel),
+		Err(Err(errSentinel)),
+		fmt.Errorf("std: %w", Err(errSentinel)),
+		Err(fmt.Errorf("std: %w", errSentinel)),
+	}
+	for _, err := range wrapped {
+		if !stderrors.Is(err, errSentinel) {
+			t.Errorf("expected std errors.Is to match %q", err)
+		}
+		if !Is(err, errSentinel) {
+			t.Errorf("expected Is to match %q", err)
+		}
+		if !Is(err, Err(errSentinel)) {
+			t.Errorf("expected Is to match %q with a traced sentinel", err)
+		}
+	}
+	if Is(Err("other"), errSentinel) || stderrors.Is(Err("other"), errSentinel) {
+		t.Error("different errors should not match")
+	}
+	if Is(Err(errSentinel), nil) || !Is(nil, nil) {
+		t.Error("only nil should match nil")
+	}
+
+	err := fmt.Errorf("std: %w", Err(codeError{404}))
+	var target codeError
+	if !stderrors.As(err, &target) || target.code != 404 {
+		t.Errorf("expected std errors.As to find the code error, got %v", target)
+	}
+	target = codeError{}
+	if !As(Err(err), &target) || target.code != 404 {
+		t.Errorf("expected As to find the code error, got %v", target)
+	}
+	if stderrors.Unwrap(Err(errSentinel)) != errSentinel {
+		t.Error("expected std errors.Unwrap to return the sentinel")
+	}
+	if Unwrap(Prefix("prefix", Err(errSentinel))) != errSentinel {
+		t.Error("expected Unwrap to return the sentinel")
+	}
+}
+
+func TestTrace(t *testing.T) {
+	err := Err("test %d", 1)
+	if err.Error() != "test 1" {
+		t.Errorf("bad message %q", err.Error())
+	}
+	if !HasTrace(err) || HasTrace(errSentinel) {
+		t.Error("only wrapped errors should have a trace")
+	}
+	if Err(err) != err {
+		t.Error("wrapping again should keep the trace")
+	}
+	for _, e := range []*Error{Err(err).(*Error), WrapError(errSentinel, 0), Prefix("p", errSentinel).(*Error)} {
+		if frames := e.StackFrames(); len(frames) == 0 || frames[0].Name != "TestTrace" {
+			t.Errorf("expected the trace to start in the caller, got %+v", frames)
+		}
+	}
+	if !strings.Contains(FullTrace(Prefix("prefix", errSentinel)), "prefix: sentinel") {
+		t.Errorf("bad full trace %q", FullTrace(Prefix("prefix", errSentinel)))
+	}
+}
+
+func TestWrap(t *testing.T) {
+	var err *goerrors.Error = Wrap(Code(errSentinel, CodeNotFound), 0)
+	if !Is(err, errSentinel) || err.Error() != errSentinel.Error() {
+		t.Errorf("expected Wrap to keep the error, got %q", err)
+	}
+	if frames := err.StackFrames(); len(frames) == 0 || frames[0].Name != "TestWrap" {
+		t.Errorf("expected the trace to start in the caller, got %+v", frames)
+	}
+	if Wrap(nil, 0) != nil {
+		t.Error("expected nil for a nil error")
+	}
+}
+
+func TestCode(t *testing.T) {
+	err := Code(errSentinel, CodeNotFound)
+	if GetCode(err) != CodeNotFound || !HasCode(err, CodeNotFound) {
+		t.Errorf("expected %s, got %q", CodeNotFound, GetCode(err))
+	}
+	if !Is(err, errSentinel) || err.Error() != errSentinel.Error() {
+		t.Error("a code should not change the error")
+	}
+	if frames := err.(*Error).StackFrames(); frames[0].Name != "TestCode" {
+		t.Errorf("expected the trace to start in the caller, got %+v", frames)
+	}
+
+	wrapped := []error{
+		Err(err),
+		Prefix("prefix", err),
+		fmt.Errorf("std: %w", err),
+		Err(fmt.Errorf("std: %w", err)),
+	}
+	for _, e := range wrapped {
+		if GetCode(e) != CodeNotFound {
+			t.Errorf("expected %q to keep its code, got %q", e, GetCode(e))
+		}
+	}
+
+	traced := Err(errSentinel)
+	if Code(traced, CodeInternal); GetCode(traced) != "" {
+		t.Error("a code should not change the error it was attached to")
+	}
+	if GetCode(Code(err, CodeInternal)) != CodeInternal {
+		t.Error("the outer code should win")
+	}
+	if GetCode(errSentinel) != "" || GetCode(nil) != "" || HasCode(errSentinel, "") {
+		t.Error("errors without a code should not have one")
+	}
+}
+
+func TestMulti(t *testing.T) {
+	if Join() != nil || Join(nil, nil) != nil {
+		t.Error("no errors should join to nil")
+	}
+	var empty Multi
+	if empty.ErrorOrNil() != nil {
+		t.Error("an empty group should be nil")
+	}
+
+	first := Err("first\nline")
+	err := Join(first, nil, fmt.Errorf("std: %w", Code(codeError{404}, CodeNotFound)))
+	m := err.(*Multi)
+	if m.Len() != 2 || m.Errors()[0] != first {
+		t.Errorf("expected both errors as they were, got %v", m.Errors())
+	}
+	expected := "2 errors:\n\t* first\n\t  line\n\t* std: code 404"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+	if Join(first).Error() != first.Error() {
+		t.Errorf("a single error should keep its message, got %q", Join(first).Error())
+	}
+
+	withSentinel := Err(Join(first, Prefix("prefix", errSentinel)))
+	if !Is(withSentinel, errSentinel) || !stderrors.Is(withSentinel, errSentinel) {
+		t.Error("expected Is to match a member")
+	}
+	if Is(err, errSentinel) || stderrors.Is(err, errSentinel) {
+		t.Error("expected Is to not match when no member does")
+	}
+	var target codeError
+	if !stderrors.As(err, &target) || target.code != 404 {
+		t.Errorf("expected As to find a member, got %v", target)
+	}
+	if !HasTrace(m.Errors()[0]) {
+		t.Error("members should keep their traces")
+	}
+
+	var errs Multi
+	done := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		go func(i int) {
+			errs.Append(Err("error %d", i))
+			done <- struct{}{}
+		}(i)
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+	errs.Append(Join(errSentinel, errSentinel))
+	if errs.Len() != 12 {
+		t.Errorf("expected 12 errors, got %d", errs.Len())
+	}
+}
+
+func TestTraceFrames(t *testing.T) {
+	if TraceFrames(errSentinel) != nil || TraceFrames(nil) != nil {
+		t.Error("errors without a trace should not have frames")
+	}
+
+	err := Err(errSentinel)
+	for _, e := range []error{err, fmt.Errorf("std: %w", err), Prefix("prefix", err)} {
+		frames := TraceFrames(e)
+		if len(frames) == 0 {
+			t.Fatalf("expected frames for %q", e)
+		}
+		f := frames[0]
+		if !strings.HasSuffix(f.Function, "/extras/errors.TestTraceFrames") {
+			t.Errorf("bad function %q", f.Function)
+		}
+		if !strings.HasSuffix(f.File, "errors_test.go") || f.Line == 0 {
+			t.Errorf("bad location %s:%d", f.File, f.Line)
+		}
+	}
+
+	data, err := json.Marshal(Frame{Function: "main.main", File: "main.go", Line: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"function":"main.main","file":"main.go","line":12}` {
+		t.Errorf("bad json %s", data)
+	}
+}
+
+func TestSkipFrames(t *testing.T) {
+	hasFrame := func(err error, prefix string) bool {
+		for _, f := range TraceFrames(err) {
+			if strings.HasPrefix(f.Function, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	err := Err("all frames")
+	if !hasFrame(err, "testing.") {
+		t.Error("expected the testing package's frames by default")
+	}
+
+	SkipFrames = []string{"testing.", "runtime."}
+	defer func() { SkipFrames = nil }()
+	for _, prefix := range []string{"testing.", "runtime."} {
+		if hasFrame(err, prefix) {
+			t.Errorf("expected %s frames to be left out", prefix)
+		}
+		if strings.Contains(string(err.(*Error).Stack()), prefix[:len(prefix)-1]+"/") {
+			t.Errorf("expected %s frames to be left out of the text trace", prefix)
+		}
+	}
+	if frames := TraceFrames(err); len(frames) != 1 || !strings.HasSuffix(frames[0].Function, ".TestSkipFrames") {
+		t.Errorf("expected only the test's frame, got %+v", frames)
+	}
+	// the test is in this package too
+	SkipFrames = DefaultSkipFrames
+	if frames := TraceFrames(err); len(frames) != 0 {
+		t.Errorf("expected no frames, got %+v", frames)
+	}
+
+	SkipFrames = nil
+	SetMaxStackDepth(1)
+	defer SetMaxStackDepth(50)
+	if frames := TraceFrames(Err("shallow")); len(frames) != 1 {
+		t.Errorf("expected one frame, got %d", len(frames))
+	}
+}
+
+func TestFields(t *testing.T) {
+	if Fields(errSentinel) != nil || Fields(nil) != nil || WithField(nil, "k", "v") != nil {
+		t.Error("errors without fields should not have any")
+	}
+
+	err := WithField(errSentinel, "claim_id", "abc")
+	if !Is(err, errSentinel) || err.Error() != errSentinel.Error() {
+		t.Error("a field should not change the error")
+	}
+	if frames := TraceFrames(err); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, ".TestFields") {
+		t.Errorf("expected the trace to start in the caller, got %+v", frames)
+	}
+
+	err = WithFields(Prefix("loading", Code(err, CodeNotFound)), map[string]interface{}{"request_id": 12, "claim_id": "def"})
+	err = fmt.Errorf("std: %w", Err(err))
+	expected := map[string]interface{}{"claim_id": "def", "request_id": 12}
+	if fields := Fields(err); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected %v, got %v", expected, fields)
+	}
+	if GetCode(err) != CodeNotFound || !strings.HasSuffix(err.Error(), "loading: sentinel") {
+		t.Errorf("fields should keep the code and prefix, got %q %q", GetCode(err), err)
+	}
+
+	traced := Err(errSentinel)
+	WithField(traced, "user_id", 1)
+	if Fields(traced) != nil {
+		t.Error("a field should not change the error it was attached to")
+	}
+}
@@ -0,0 +1,54 @@
+package errors
+
+// WithField attaches a key and value to the error, like a request id or claim id, for handlers and structured
+// loggers to read with Fields. The field stays with the error when it is wrapped again. Like Err, it attaches a
+// stack trace if the error doesn't have one.
+func WithField(err interface{}, key string, value interface{}) error {
+	return withFields(err, map[string]interface{}{key: value})
+}
+
+// WithFields attaches several fields at once, like WithField
+func WithFields(err interface{}, fields map[string]interface{}) error {
+	return withFields(err, fields)
+}
+
+// withFields is WithFields for its caller's caller
+func withFields(err interface{}, fields map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+	traced, captured := wrap(withCause(err), 2)
+	e := *traced
+	e.fields = make(map[string]interface{}, len(traced.fields)+len(fields))
+	for k, v := range traced.fields {
+		e.fields[k] = v
+	}
+	for k, v := range fields {
+		e.fields[k] = v
+	}
+	if captured {
+		report(&e, SeverityError, e.fields)
+	}
+	return &e
+}
+
+// Fields returns the fields attached to the error and the errors it wraps, or nil if there are none. If a key
+// was attached more than once, the outermost value wins. The result can be passed to logrus.WithFields.
+func Fields(err error) map[string]interface{} {
+	var fields map[string]interface{}
+	for ; err != nil; err = unwrapOnce(err) {
+		e, ok := err.(*Error)
+		if !ok {
+			continue
+		}
+		for k, v := range e.fields {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			if _, ok := fields[k]; !ok {
+				fields[k] = v
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+)
+
+// Frame is one call in a stack trace. Unlike the text from Trace, frames can be logged or returned as JSON
+// without parsing, and getting them doesn't read source files.
+type Frame struct {
+	Function string `json:"function"` // the package path and function name, like github.com/lbryio/lbry.go/v2/stream.New
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String formats the frame like a line of a panic's stack trace
+func (f Frame) String() string {
+	return fmt.Sprintf("%s\n\t%s:%d", f.Function, f.File, f.Line)
+}
+
+// TraceFrames returns the frames of the stack trace attached to the error, or to the first error it wraps that
+// has one. It returns nil if there is no trace.
+func TraceFrames(err error) []Frame {
+	var stack []errors.StackFrame
+	for ; err != nil && stack == nil; err = unwrapOnce(err) {
+		switch e := err.(type) {
+		case *Error:
+			stack = e.StackFrames()
+		case *errors.Error:
+			stack = skipFrames(e.StackFrames())
+		}
+	}
+	if stack == nil {
+		return nil
+	}
+	frames := make([]Frame, len(stack))
+	for i, f := range stack {
+		frames[i] = Frame{Function: f.Package + "." + f.Name, File: f.File, Line: f.LineNumber}
+	}
+	return frames
+}
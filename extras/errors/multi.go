@@ -0,0 +1,119 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Multi is a group of errors, like the ones from announcing to several DHT nodes at once or validating a batch.
+// The errors keep their own stack traces. Is and As match any error in the group.
+//
+// The zero value is an empty group that is ready to use, and Append is safe to call from several goroutines:
+//
+//	var errs errors.Multi
+//	for _, node := range nodes {
+//		wg.Add(1)
+//		go func(node Node) {
+//			defer wg.Done()
+//			errs.Append(announce(node))
+//		}(node)
+//	}
+//	wg.Wait()
+//	return errs.ErrorOrNil()
+type Multi struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Join groups the errors that aren't nil into a Multi. It returns nil if they all are.
+func Join(errs ...error) error {
+	m := &Multi{}
+	for _, err := range errs {
+		m.Append(err)
+	}
+	return m.ErrorOrNil()
+}
+
+// Append adds err to the group, unless it is nil. The errors in a Multi that is appended are added one by one.
+func (m *Multi) Append(err error) {
+	if err == nil {
+		return
+	}
+	var errs []error
+	if other, ok := err.(*Multi); ok {
+		errs = other.Errors()
+	} else {
+		errs = []error{err}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs = append(m.errs, errs...)
+}
+
+// Errors returns the errors in the group
+func (m *Multi) Errors() []error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]error(nil), m.errs...)
+}
+
+// Len returns the number of errors in the group
+func (m *Multi) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.errs)
+}
+
+// ErrorOrNil returns a copy of the group, or nil if it is empty. Return it instead of the group, which would be
+// a non-nil error even if it is empty.
+func (m *Multi) ErrorOrNil() error {
+	errs := m.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &Multi{errs: errs}
+}
+
+// Error lists the messages of the errors in the group, one per line
+func (m *Multi) Error() string {
+	errs := m.Errors()
+	switch len(errs) {
+	case 0:
+		return "no errors"
+	case 1:
+		return errs[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d errors:", len(errs))
+	for _, err := range errs {
+		b.WriteString("\n\t* ")
+		b.WriteString(strings.Replace(err.Error(), "\n", "\n\t  ", -1))
+	}
+	return b.String()
+}
+
+// Is checks if any error in the group is target, for the standard library's errors.Is
+func (m *Multi) Is(target error) bool {
+	for _, err := range m.Errors() {
+		if Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in the group that matches target, for the standard library's errors.As
+func (m *Multi) As(target interface{}) bool {
+	for _, err := range m.Errors() {
+		if As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the errors in the group, for the standard library's errors.Is and errors.As in Go 1.20 and later
+func (m *Multi) Unwrap() []error {
+	return m.Errors()
+}
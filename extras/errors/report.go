@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Severity says how serious a reported error is
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// Report is an error passed to a Reporter
+type Report struct {
+	Err      error
+	Frames   []Frame
+	Severity Severity
+	Context  map[string]interface{} // extra information about the error, like its Fields
+}
+
+// Reporter ships errors somewhere, like Sentry. It is called synchronously, so it should hand slow work off to
+// another goroutine. It must not call Err, Wrap, Prefix or Code, which would report again.
+type Reporter func(Report)
+
+var reporter struct {
+	sync.RWMutex
+	report Reporter
+	min    Severity
+}
+
+// SetReporter sets the reporter that Err, Wrap, Prefix and Code call when they attach a stack trace to an error
+// that didn't have one, so errors can be shipped without changing every call site. Those errors are reported
+// with SeverityError, so they are only reported if min is SeverityError or lower. A nil reporter turns
+// reporting off.
+func SetReporter(r Reporter, min Severity) {
+	reporter.Lock()
+	defer reporter.Unlock()
+	reporter.report, reporter.min = r, min
+}
+
+// report calls the reporter, if one is set and the severity is high enough
+func report(err error, severity Severity, context map[string]interface{}) {
+	reporter.RLock()
+	r, min := reporter.report, reporter.min
+	reporter.RUnlock()
+	if r == nil || severity < min {
+		return
+	}
+	r(Report{Err: err, Frames: TraceFrames(err), Severity: severity, Context: context})
+}
+
+// LogHook is a logrus hook that reports the errors logged with logrus's WithError, for reporting from a logger
+// rather than from every Err call. The entry's level sets the severity, and its other fields and the error's
+// Fields are the context.
+type LogHook struct {
+	Reporter Reporter
+	Min      Severity
+}
+
+// Levels implements logrus.Hook.
+func (h LogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h LogHook) Fire(entry *logrus.Entry) error {
+	err, ok := entry.Data[logrus.ErrorKey].(error)
+	severity := logSeverity(entry.Level)
+	if !ok || h.Reporter == nil || severity < h.Min {
+		return nil
+	}
+	context := Fields(err)
+	if context == nil {
+		context = make(map[string]interface{}, len(entry.Data))
+	}
+	for k, v := range entry.Data {
+		if k != logrus.ErrorKey {
+			context[k] = v
+		}
+	}
+	h.Reporter(Report{Err: err, Frames: TraceFrames(err), Severity: severity, Context: context})
+	return nil
+}
+
+func logSeverity(level logrus.Level) Severity {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return SeverityFatal
+	case logrus.ErrorLevel:
+		return SeverityError
+	case logrus.WarnLevel:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestReporter(t *testing.T) {
+	var reports []Report
+	SetReporter(func(r Report) { reports = append(reports, r) }, SeverityError)
+	defer SetReporter(nil, SeverityInfo)
+
+	err := Err("first")
+	_ = Err(err)
+	_ = Wrap(err, 0)
+	_ = Prefix("prefix", Base("second"))
+	_ = Code(Base("third"), CodeNotFound)
+	if len(reports) != 3 {
+		t.Fatalf("expected each new trace to be reported once, got %d reports", len(reports))
+	}
+	if reports[0].Err != err || reports[0].Severity != SeverityError {
+		t.Errorf("bad report %+v", reports[0])
+	}
+	if len(reports[0].Frames) == 0 || !strings.HasSuffix(reports[0].Frames[0].Function, ".TestReporter") {
+		t.Errorf("expected frames from the caller, got %+v", reports[0].Frames)
+	}
+	if reports[1].Err.Error() != "prefix: second" || GetCode(reports[2].Err) != CodeNotFound {
+		t.Error("errors should be reported once they are ready")
+	}
+
+	reports = nil
+	SetReporter(func(r Report) { reports = append(reports, r) }, SeverityFatal)
+	_ = Err("not serious enough")
+	SetReporter(nil, SeverityInfo)
+	_ = Err("no reporter")
+	if len(reports) != 0 {
+		t.Errorf("expected no reports, got %+v", reports)
+	}
+}
+
+func TestLogHook(t *testing.T) {
+	var reports []Report
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	logger.AddHook(LogHook{Reporter: func(r Report) { reports = append(reports, r) }, Min: SeverityWarning})
+
+	err := WithField("logged", "user_id", 1)
+	logger.WithError(err).WithField("claim_id", "abc").Warn("warning")
+	logger.WithError(err).Info("not serious enough")
+	logger.WithField("claim_id", "abc").Error("no error")
+	if len(reports) != 1 {
+		t.Fatalf("expected one report, got %d", len(reports))
+	}
+	r := reports[0]
+	if r.Err != err || r.Severity != SeverityWarning || len(r.Frames) == 0 {
+		t.Errorf("bad report %+v", r)
+	}
+	if len(r.Context) != 2 || r.Context["claim_id"] != "abc" || r.Context["user_id"] != 1 {
+		t.Errorf("expected the entry's and error's fields as context, got %v", r.Context)
+	}
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// SkipFrames are the prefixes of the functions to leave out of stack traces, like "net/http.", so traces show
+// the calls that matter rather than the framework around them. Nothing is left out by default, and
+// DefaultSkipFrames has the usual prefixes. Set it at startup, before errors are wrapped.
+var SkipFrames []string
+
+// DefaultSkipFrames leaves out the Go runtime, net/http's server, the testing package and this package
+var DefaultSkipFrames = []string{"runtime.", "net/http.", "testing.", reflect.TypeOf(Error{}).PkgPath() + "."}
+
+// SetMaxStackDepth sets the most frames captured for a stack trace. Fewer frames are cheaper to capture and to
+// format. The default is 50. It sets go-errors' MaxStackDepth, so it also applies to errors wrapped with
+// go-errors directly. Set it at startup, before errors are wrapped.
+func SetMaxStackDepth(depth int) {
+	errors.MaxStackDepth = depth
+}
+
+// skipFrames leaves out the frames that SkipFrames matches
+func skipFrames(frames []errors.StackFrame) []errors.StackFrame {
+	if len(SkipFrames) == 0 {
+		return frames
+	}
+	kept := make([]errors.StackFrame, 0, len(frames))
+	for _, f := range frames {
+		if !skipFrame(f.Package + "." + f.Name) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+func skipFrame(function string) bool {
+	for _, prefix := range SkipFrames {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package jsonrpc
+
+import (
+	"sync/atomic"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/ybbus/jsonrpc"
+)
+
+// Batch collects calls that are sent to the daemon together in one request, e.g.
+//
+//	b := d.NewBatch()
+//	status, balance := new(StatusResponse), new(AccountBalanceResponse)
+//	b.Add(status, "status", nil)
+//	balanceCall := b.Add(balance, "account_balance", nil)
+//	if err := b.Send(); err == nil && balanceCall.Err == nil {
+//		...
+//	}
+//
+// Send fails only if the request as a whole fails. Each call reports its own error in its Err field. Batches
+// are not retried, and time out after the longest timeout of their calls.
+type Batch struct {
+	client *Client
+	calls  []*BatchCall
+}
+
+// BatchCall is a call in a batch. Its response and Err are set when the batch is sent.
+type BatchCall struct {
+	Command  string
+	Params   map[string]interface{}
+	Response interface{}
+	Err      error
+}
+
+func (d *Client) NewBatch() *Batch {
+	return &Batch{client: d}
+}
+
+// Add adds a call to the batch. Its result is decoded into response, which should be a pointer to the type
+// the matching Client method returns.
+func (b *Batch) Add(response interface{}, command string, params map[string]interface{}) *BatchCall {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	call := &BatchCall{Command: command, Params: params, Response: response}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Len returns the number of calls in the batch
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Send sends every call in the batch in one request and decodes the responses
+func (b *Batch) Send() error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+	d := b.client
+
+	if d.inFlight != nil {
+		select {
+		case d.inFlight <- struct{}{}:
+			defer func() { <-d.inFlight }()
+		case <-d.Context().Done():
+			return errors.Err(d.Context().Err())
+		}
+	}
+
+	// the batch gets the longest timeout of its calls
+	timeout := d.MethodTimeout(b.calls[0].Command)
+	requests := make(jsonrpc.RPCRequests, len(b.calls))
+	for i, call := range b.calls {
+		if t := d.MethodTimeout(call.Command); timeout != 0 && (t == 0 || t > timeout) {
+			timeout = t
+		}
+		request := jsonrpc.NewRequest(call.Command, call.Params)
+		request.ID = int(atomic.AddUint64(d.nextID, 1))
+		log.Debugf("jsonrpc: #%d %s %s (batch)", request.ID, call.Command, debugParams(call.Params))
+		requests[i] = request
+	}
+
+	responses, err := d.connFor(timeout).CallBatchRaw(requests)
+	if err != nil {
+		if d.ctx != nil && d.ctx.Err() != nil {
+			return errors.Err(d.ctx.Err())
+		}
+		return errors.WrapError(err, 0)
+	}
+
+	byID := responses.AsMap()
+	for i, call := range b.calls {
+		r, ok := byID[requests[i].ID]
+		switch {
+		case !ok:
+			call.Err = errors.Err("daemon did not answer %s", call.Command)
+		case r.Error != nil:
+			call.Err = newDaemonError(call.Command, r.Error)
+		case call.Response != nil:
+			call.Err = Decode(r.Result, call.Response)
+		}
+	}
+	return nil
+}
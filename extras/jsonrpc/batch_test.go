@@ -0,0 +1,84 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatch_Send(t *testing.T) {
+	var requests int
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var batch []testRequest
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Error(err)
+			return
+		}
+		var responses []map[string]interface{}
+		// answer in reverse order, to check that responses are matched by id
+		for i := len(batch) - 1; i >= 0; i-- {
+			req := batch[i]
+			response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+			switch req.Method {
+			case "wallet_status":
+				response["result"] = map[string]interface{}{"is_encrypted": true, "is_locked": false, "is_syncing": false}
+			case "address_is_mine":
+				response["result"] = req.Params["address"] == "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"
+			case "ignored":
+				continue
+			default:
+				response["error"] = map[string]interface{}{"code": -32601, "message": "Invalid method requested: " + req.Method}
+			}
+			responses = append(responses, response)
+		}
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer daemon.Close()
+
+	b := NewClient(daemon.URL).NewBatch()
+	status := new(WalletStatusResponse)
+	statusCall := b.Add(status, "wallet_status", nil)
+	var mine bool
+	mineCall := b.Add(&mine, "address_is_mine", map[string]interface{}{"address": "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"})
+	badCall := b.Add(nil, "not_a_method", nil)
+	ignoredCall := b.Add(nil, "ignored", nil)
+	if b.Len() != 4 {
+		t.Errorf("expected 4 calls, got %d", b.Len())
+	}
+
+	if err := b.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+	if statusCall.Err != nil || !status.IsEncrypted {
+		t.Errorf("unexpected status %+v, err %v", status, statusCall.Err)
+	}
+	if mineCall.Err != nil || !mine {
+		t.Errorf("unexpected address_is_mine result %v, err %v", mine, mineCall.Err)
+	}
+	if badCall.Err == nil {
+		t.Error("expected an error for an invalid method")
+	}
+	if ignoredCall.Err == nil {
+		t.Error("expected an error for an unanswered call")
+	}
+}
+
+func TestBatch_SendError(t *testing.T) {
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer daemon.Close()
+
+	b := NewClient(daemon.URL).NewBatch()
+	b.Add(nil, "status", nil)
+	if err := b.Send(); err == nil {
+		t.Error("expected an error")
+	}
+}
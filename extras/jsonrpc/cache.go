@@ -0,0 +1,157 @@
+package jsonrpc
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// DefaultCacheMaxEntries is how many results a cache keeps if CacheOptions doesn't say
+const DefaultCacheMaxEntries = 10000
+
+// CacheOptions configures the cache for Resolve and ClaimSearch
+type CacheOptions struct {
+	TTL        time.Duration // how long results are kept. 0 means they aren't kept, but identical calls in flight are still sent once
+	MaxEntries int           // most results kept, dropping the least recently used. 0 means DefaultCacheMaxEntries
+}
+
+// EnableCache caches the results of Resolve and ClaimSearch, and sends identical calls made at the same time
+// to the daemon once. Copies made by WithContext afterwards share the cache. Errors are not cached.
+// Shared calls are sent with the context of d, not of a caller, so a caller whose context is canceled stops
+// waiting without canceling the call for the others.
+func (d *Client) EnableCache(options CacheOptions) {
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = DefaultCacheMaxEntries
+	}
+	d.cache = &resultCache{
+		ttl:        options.TTL,
+		maxEntries: options.MaxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+		inFlight:   map[string]*flight{},
+		ctx:        d.ctx,
+	}
+}
+
+// DisableCache stops caching results
+func (d *Client) DisableCache() {
+	d.cache = nil
+}
+
+// ClearCache drops every cached result, e.g. after publishing, so that new claims are seen
+func (d *Client) ClearCache() {
+	if d.cache != nil {
+		d.cache.clear()
+	}
+}
+
+// cachedCall is call, with the result taken from the cache if it is enabled
+func (d *Client) cachedCall(response interface{}, command string, params map[string]interface{}) error {
+	if d.cache == nil {
+		return d.call(response, command, params)
+	}
+	key, err := json.Marshal(map[string]interface{}{"method": command, "params": params})
+	if err != nil {
+		return errors.Err(err)
+	}
+	result, err := d.cache.get(d.Context(), string(key), func() (interface{}, error) {
+		return d.WithContext(d.cache.ctx).callNoDecode(command, params)
+	})
+	if err != nil {
+		return err
+	}
+	return Decode(result, response)
+}
+
+type resultCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element // elements hold *cacheEntry
+	order    *list.List               // most recently used first
+	inFlight map[string]*flight
+
+	ctx context.Context // fetches run with this context instead of a caller's. may be nil
+}
+
+type cacheEntry struct {
+	key     string
+	result  interface{}
+	expires time.Time
+}
+
+// flight is a call that callers with the same key wait for instead of making their own
+type flight struct {
+	done   chan struct{}
+	result interface{}
+	err    error
+}
+
+// get returns the cached result for the key, or the result of fetch. Only one fetch runs at a time for a key,
+// in its own goroutine, so every caller, including the one that started it, gives up when its own context is
+// done without stopping the fetch for the others.
+func (c *resultCache) get(ctx context.Context, key string, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*cacheEntry)
+		if time.Now().Before(entry.expires) {
+			c.order.MoveToFront(e)
+			c.mu.Unlock()
+			return entry.result, nil
+		}
+		c.remove(e)
+	}
+	f, ok := c.inFlight[key]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		c.inFlight[key] = f
+		go c.fetch(key, f, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, errors.Err(ctx.Err())
+	}
+}
+
+// fetch runs a flight's fetch and caches its result
+func (c *resultCache) fetch(key string, f *flight, fetch func() (interface{}, error)) {
+	f.result, f.err = fetch()
+
+	c.mu.Lock()
+	delete(c.inFlight, key)
+	if f.err == nil && c.ttl > 0 {
+		c.add(key, f.result)
+	}
+	c.mu.Unlock()
+	close(f.done)
+}
+
+// add must be called with the lock held
+func (c *resultCache) add(key string, result interface{}) {
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, result: result, expires: time.Now().Add(c.ttl)})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove must be called with the lock held
+func (c *resultCache) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*cacheEntry).key)
+}
+
+func (c *resultCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*list.Element{}
+	c.order.Init()
+}
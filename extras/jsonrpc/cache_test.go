@@ -0,0 +1,169 @@
+package jsonrpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/extras/jsonrpc/mock"
+)
+
+func newResolveDaemon() *mock.Server {
+	daemon := mock.NewServer()
+	daemon.Handle("resolve", func(req mock.Request) (interface{}, error) {
+		url := req.Params["urls"].(string)
+		if url == "lbry://missing" {
+			return nil, errors.Base("Could not find claim at %q.", url)
+		}
+		return map[string]interface{}{url: map[string]interface{}{"name": url}}, nil
+	})
+	return daemon
+}
+
+func TestClient_Cache(t *testing.T) {
+	daemon := newResolveDaemon()
+	defer daemon.Close()
+
+	d := NewClient(daemon.URL)
+	d.EnableCache(CacheOptions{TTL: 100 * time.Millisecond})
+	resolve := func(url string) {
+		t.Helper()
+		got, err := d.Resolve(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (*got)[url].Name != url {
+			t.Errorf("unexpected resolve %+v", got)
+		}
+	}
+
+	resolve("lbry://a")
+	resolve("lbry://a")
+	if _, err := d.WithContext(context.Background()).Resolve("lbry://a"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(daemon.Calls("resolve")); n != 1 {
+		t.Errorf("expected 1 resolve, got %d", n)
+	}
+
+	resolve("lbry://b")
+	if n := len(daemon.Calls("resolve")); n != 2 {
+		t.Errorf("expected a different url to be resolved, got %d resolves", n)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	resolve("lbry://a")
+	if n := len(daemon.Calls("resolve")); n != 3 {
+		t.Errorf("expected an expired result to be resolved again, got %d resolves", n)
+	}
+
+	d.ClearCache()
+	resolve("lbry://a")
+	if n := len(daemon.Calls("resolve")); n != 4 {
+		t.Errorf("expected a cleared result to be resolved again, got %d resolves", n)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := d.Resolve("lbry://missing"); err == nil {
+			t.Error("expected an error")
+		}
+	}
+	if n := len(daemon.Calls("resolve")); n != 6 {
+		t.Errorf("expected errors not to be cached, got %d resolves", n)
+	}
+}
+
+func TestClient_CacheMaxEntries(t *testing.T) {
+	daemon := newResolveDaemon()
+	defer daemon.Close()
+
+	d := NewClient(daemon.URL)
+	d.EnableCache(CacheOptions{TTL: time.Minute, MaxEntries: 1})
+	for _, url := range []string{"lbry://a", "lbry://b", "lbry://a"} {
+		if _, err := d.Resolve(url); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(daemon.Calls("resolve")); n != 3 {
+		t.Errorf("expected the first result to be dropped, got %d resolves", n)
+	}
+}
+
+func TestClient_CacheSingleFlight(t *testing.T) {
+	daemon := mock.NewServer()
+	defer daemon.Close()
+	release := make(chan struct{})
+	daemon.Handle("claim_search", func(req mock.Request) (interface{}, error) {
+		<-release
+		return map[string]interface{}{"items": []interface{}{}, "page": 1, "page_size": 20}, nil
+	})
+
+	d := NewClient(daemon.URL)
+	d.EnableCache(CacheOptions{TTL: time.Minute})
+	channelID := "3f"
+	options := ClaimSearchOptions{ChannelIDs: []string{channelID}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := d.ClaimSearch(options, 1, 20); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := len(daemon.Calls("claim_search")); n != 1 {
+		t.Errorf("expected identical searches to be sent once, got %d", n)
+	}
+}
+
+func TestClient_CacheCanceledCaller(t *testing.T) {
+	daemon := mock.NewServer()
+	defer daemon.Close()
+	release := make(chan struct{})
+	daemon.Handle("resolve", func(req mock.Request) (interface{}, error) {
+		<-release
+		url := req.Params["urls"].(string)
+		return map[string]interface{}{url: map[string]interface{}{"name": url}}, nil
+	})
+
+	d := NewClient(daemon.URL)
+	d.EnableCache(CacheOptions{TTL: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := d.WithContext(ctx).Resolve("lbry://a")
+		first <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		got, err := d.Resolve("lbry://a")
+		if err == nil && (*got)["lbry://a"].Name != "lbry://a" {
+			err = errors.Err("unexpected resolve %+v", got)
+		}
+		second <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled caller to get context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("expected the second caller to get the result, got %v", err)
+	}
+	if n := len(daemon.Calls("resolve")); n != 1 {
+		t.Errorf("expected 1 resolve, got %d", n)
+	}
+}
@@ -0,0 +1,348 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/extras/jsonrpc/mock"
+)
+
+// testRequest is a request received by a test daemon
+type testRequest struct {
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+	ID     int                    `json:"id"`
+}
+
+// newTestDaemon starts a fake daemon that answers every request with handle's result, or with its error if
+// the error is not empty
+func newTestDaemon(t *testing.T, handle func(req testRequest) (interface{}, string)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req testRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request: %v", err)
+			return
+		}
+		result, errMessage := handle(req)
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if errMessage != "" {
+			response["error"] = map[string]interface{}{"code": -32500, "message": errMessage}
+		} else {
+			response["result"] = result
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Error(err)
+		}
+	}))
+}
+
+func TestClient_WithContext(t *testing.T) {
+	release := make(chan struct{})
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		if req.Method == "status" {
+			<-release
+		}
+		return map[string]interface{}{"lbrynet_version": "0.0.0"}, ""
+	})
+	defer daemon.Close()
+	defer close(release)
+
+	d := NewClient(daemon.URL)
+	if _, err := d.WithContext(context.Background()).Version(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := d.WithContext(ctx).Status()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("call was not canceled")
+	}
+	if d.ctx != nil {
+		t.Error("WithContext changed the original client")
+	}
+}
+
+func TestNewClientAndWaitContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) { return nil, "daemon is starting" })
+	defer daemon.Close()
+
+	_, err := NewClientAndWaitContext(ctx, daemon.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	var attempts int
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		attempts++
+		if req.Method == "version" && attempts < 3 {
+			return nil, `Not all components required to use "version" are running. Missing components: wallet`
+		}
+		if req.Method == "status" {
+			return nil, "invalid arguments"
+		}
+		return map[string]interface{}{"lbrynet_version": "0.0.0"}, ""
+	})
+	defer daemon.Close()
+
+	d := NewClient(daemon.URL)
+	if _, err := d.Version(); err == nil {
+		t.Error("expected an error without a retry policy")
+	}
+
+	attempts = 0
+	d.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	if _, err := d.Version(); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+
+	attempts = 0
+	if _, err := d.Status(); err == nil {
+		t.Error("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected an error that is not retryable to be tried once, got %d attempts", attempts)
+	}
+}
+
+func TestClient_RetryHTTPError(t *testing.T) {
+	var attempts int
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer daemon.Close()
+
+	d := NewClient(daemon.URL)
+	d.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	if _, err := d.Version(); err == nil {
+		t.Error("expected an error")
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if backoff := p.backoff(attempt + 1); backoff != expected {
+			t.Errorf("attempt %d: expected %s, got %s", attempt+1, expected, backoff)
+		}
+	}
+}
+
+func TestClient_Concurrent(t *testing.T) {
+	const calls = 50
+	var mu sync.Mutex
+	var running, maxRunning int
+	ids := map[int]bool{}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		mu.Lock()
+		ids[req.ID] = true
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return map[string]interface{}{"is_encrypted": false, "is_locked": false, "is_syncing": false}, ""
+	})
+	defer daemon.Close()
+
+	d := NewClient(daemon.URL)
+	d.SetMaxInFlight(4)
+	withCtx := d.WithContext(context.Background())
+
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		c := d
+		if i%2 == 0 {
+			c = withCtx
+		}
+		go func() {
+			defer wg.Done()
+			if _, err := c.WalletStatus(nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(ids) != calls {
+		t.Errorf("expected %d distinct request ids, got %d", calls, len(ids))
+	}
+	if maxRunning > 4 {
+		t.Errorf("expected at most 4 calls in flight, got %d", maxRunning)
+	}
+}
+
+func TestClient_MaxInFlightContext(t *testing.T) {
+	release := make(chan struct{})
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		<-release
+		return true, ""
+	})
+	defer daemon.Close()
+	defer close(release)
+
+	d := NewClient(daemon.URL)
+	d.SetMaxInFlight(1)
+	go d.AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil)
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := d.WithContext(ctx).AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded while waiting for a slot, got %v", err)
+	}
+}
+
+func TestClient_Auth(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+		var req testRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": true})
+	}))
+	defer daemon.Close()
+
+	d := NewClient(daemon.URL)
+	d.SetBasicAuth("lbry", "hunter2")
+	d.SetHeader("x-lbry-auth-token", "abc")
+	if _, err := d.AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil); err != nil {
+		t.Fatal(err)
+	}
+	h := <-headers
+	if h.Get("Authorization") != "Basic bGJyeTpodW50ZXIy" || h.Get("X-Lbry-Auth-Token") != "abc" {
+		t.Errorf("unexpected headers %v", h)
+	}
+
+	withCtx := d.WithContext(context.Background())
+	withCtx.SetBearerToken("token")
+	if _, err := withCtx.AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil); err != nil {
+		t.Fatal(err)
+	}
+	if h := <-headers; h.Get("Authorization") != "Bearer token" {
+		t.Errorf("unexpected authorization %s", h.Get("Authorization"))
+	}
+
+	if _, err := d.AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil); err != nil {
+		t.Fatal(err)
+	}
+	if h := <-headers; h.Get("Authorization") != "Basic bGJyeTpodW50ZXIy" {
+		t.Errorf("changing the copy's headers changed the original client's: %s", h.Get("Authorization"))
+	}
+}
+
+func TestNewClientWithHTTPClient(t *testing.T) {
+	daemon := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req testRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": true})
+	}))
+	defer daemon.Close()
+
+	if _, err := NewClient(daemon.URL).AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil); err == nil {
+		t.Error("expected the default client to reject the test certificate")
+	}
+
+	d := NewClientWithHTTPClient(daemon.URL, daemon.Client())
+	if _, err := d.AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.WithContext(context.Background()).AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_MethodTimeout(t *testing.T) {
+	daemon := mock.NewServer()
+	defer daemon.Close()
+	slow := func(mock.Request) (interface{}, error) {
+		time.Sleep(200 * time.Millisecond)
+		return map[string]interface{}{}, nil
+	}
+	daemon.Handle("account_balance", slow)
+	daemon.Handle("version", slow)
+
+	d := NewClient(daemon.URL)
+	d.SetRPCTimeout(50 * time.Millisecond)
+	if _, err := d.AccountBalance(nil); err == nil {
+		t.Error("expected account_balance to time out with the client's timeout")
+	}
+	if _, err := d.Version(); err == nil {
+		t.Error("expected the client's timeout to replace the default for version")
+	}
+	d.SetRPCTimeout(0)
+	if d.MethodTimeout("version") != DefaultMethodTimeouts["version"] || d.MethodTimeout("account_balance") != 0 {
+		t.Error("expected the defaults back without a client timeout")
+	}
+	if c := NewClientWithHTTPClient(daemon.URL, &http.Client{Timeout: time.Second}); c.MethodTimeout("get") != time.Second {
+		t.Error("expected the http client's timeout to replace the defaults")
+	}
+	d.SetRPCTimeout(50 * time.Millisecond)
+
+	d.SetMethodTimeout("account_balance", time.Second)
+	d.SetMethodTimeout("version", 50*time.Millisecond)
+	if _, err := d.AccountBalance(nil); err != nil {
+		t.Errorf("expected account_balance to use its own timeout, got %v", err)
+	}
+	if _, err := d.Version(); err == nil {
+		t.Error("expected version to time out with its own timeout")
+	}
+	if NewClient(daemon.URL).MethodTimeout("version") != DefaultMethodTimeouts["version"] {
+		t.Error("setting a method timeout should not change other clients")
+	}
+
+	// method timeouts can be set while calls are being made
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			d.SetMethodTimeout("status", time.Duration(i)*time.Second)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		d.WithContext(context.Background()).MethodTimeout("status")
+	}
+	<-done
+
+	b := d.NewBatch()
+	versionCall := b.Add(new(VersionResponse), "version", nil)
+	b.Add(new(AccountBalanceResponse), "account_balance", nil)
+	if err := b.Send(); err != nil {
+		t.Errorf("expected the batch to use its longest timeout, got %v", err)
+	} else if versionCall.Err != nil {
+		t.Error(versionCall.Err)
+	}
+}
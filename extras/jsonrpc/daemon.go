@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/structs"
@@ -20,35 +23,154 @@ import (
 
 const DefaultPort = 5279
 
+// maxIdleConns is how many idle connections to the daemon a client keeps open for reuse
+const maxIdleConns = 32
+
+// Client calls the daemon's api. It is safe for concurrent use by multiple goroutines, which share a pool of
+// connections to the daemon. Configure it with the Set methods before sharing it.
 type Client struct {
-	conn    jsonrpc.RPCClient
-	address string
+	conn       jsonrpc.RPCClient
+	address    string
+	timeout    time.Duration
+	ctx        context.Context
+	retry      RetryPolicy
+	httpClient *http.Client
+	nextID     *uint64
+	inFlight   chan struct{}
+	headers    map[string]string
+	timeouts   *methodTimeouts
+	cache      *resultCache
 }
 
 func NewClient(address string) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConns
+	return NewClientWithHTTPClient(address, &http.Client{Transport: transport})
+}
+
+// NewClientWithHTTPClient creates a client that sends its calls with httpClient, e.g. to set up TLS or a
+// proxy. If httpClient has a timeout, it is used in place of DefaultMethodTimeouts until SetRPCTimeout is
+// called. The event websocket uses the dialer and TLS config of the client's transport if it is an
+// *http.Transport, but does not go through its proxy.
+func NewClientWithHTTPClient(address string, httpClient *http.Client) *Client {
 	d := Client{}
 
 	if address == "" {
 		address = "http://localhost:" + strconv.Itoa(DefaultPort)
 	}
 
-	d.conn = jsonrpc.NewClient(address)
 	d.address = address
+	d.httpClient = httpClient
+	d.timeout = httpClient.Timeout
+	d.timeouts = newMethodTimeouts()
+	d.nextID = new(uint64)
+	d.conn = d.newConn()
 
 	return &d
 }
 
+// SetHeader sets a header that is sent with every call, e.g. for a proxy in front of the daemon
+func (d *Client) SetHeader(key, value string) {
+	headers := make(map[string]string, len(d.headers)+1)
+	for k, v := range d.headers {
+		headers[k] = v
+	}
+	headers[http.CanonicalHeaderKey(key)] = value
+	d.headers = headers
+	d.conn = d.newConn()
+}
+
+// SetBasicAuth authenticates every call with a username and password
+func (d *Client) SetBasicAuth(username, password string) {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	d.SetHeader("Authorization", "Basic "+credentials)
+}
+
+// SetBearerToken authenticates every call with a token, as sdk proxies like api.lbry.tv expect
+func (d *Client) SetBearerToken(token string) {
+	d.SetHeader("Authorization", "Bearer "+token)
+}
+
+// SetMaxInFlight limits how many calls the client sends to the daemon at once. Copies made by WithContext
+// afterwards share the limit. Calls over the limit wait for a slot. 0 means no limit.
+func (d *Client) SetMaxInFlight(max int) {
+	if max <= 0 {
+		d.inFlight = nil
+		return
+	}
+	d.inFlight = make(chan struct{}, max)
+}
+
 func NewClientAndWait(address string) *Client {
+	d, _ := NewClientAndWaitContext(context.Background(), address)
+	return d
+}
+
+// NewClientAndWaitContext is like NewClientAndWait, but gives up when the context is done. The client it
+// returns does not keep the context.
+func NewClientAndWaitContext(ctx context.Context, address string) (*Client, error) {
 	d := NewClient(address)
 	for {
-		_, err := d.AccountBalance(nil)
+		_, err := d.WithContext(ctx).AccountBalance(nil)
 		if err == nil {
-			return d
+			return d, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Err(ctx.Err())
+		case <-time.After(5 * time.Second):
 		}
-		time.Sleep(5 * time.Second)
 	}
 }
 
+// WithContext returns a copy of the client whose calls are canceled when the context is done, e.g.
+//
+//	d.WithContext(ctx).Resolve(url)
+//
+// A canceled call returns the context's error, which errors.Is can check for.
+func (d *Client) WithContext(ctx context.Context) *Client {
+	c := *d
+	c.ctx = ctx
+	c.conn = c.newConn()
+	return &c
+}
+
+// Context returns the client's context, or context.Background() if it has none
+func (d *Client) Context() context.Context {
+	if d.ctx == nil {
+		return context.Background()
+	}
+	return d.ctx
+}
+
+func (d *Client) newConn() jsonrpc.RPCClient {
+	return d.newConnWithTimeout(d.timeout)
+}
+
+func (d *Client) newConnWithTimeout(timeout time.Duration) jsonrpc.RPCClient {
+	httpClient := *d.httpClient
+	httpClient.Timeout = timeout
+	if d.ctx != nil {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		httpClient.Transport = &contextTransport{ctx: d.ctx, base: base}
+	}
+	return jsonrpc.NewClientWithOpts(d.address, &jsonrpc.RPCClientOpts{HTTPClient: &httpClient, CustomHeaders: d.headers})
+}
+
+// contextTransport sends every request with a context, since the jsonrpc client can't be given one
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
 func Decode(data interface{}, targetStruct interface{}) error {
 	config := &mapstructure.DecoderConfig{
 		Metadata: nil,
@@ -60,12 +182,12 @@ func Decode(data interface{}, targetStruct interface{}) error {
 
 	decoder, err := mapstructure.NewDecoder(config)
 	if err != nil {
-		return errors.Wrap(err, 0)
+		return errors.WrapError(err, 0)
 	}
 
 	err = decoder.Decode(data)
 	if err != nil {
-		return errors.Wrap(err, 0)
+		return errors.WrapError(err, 0)
 	}
 	return nil
 }
@@ -84,12 +206,19 @@ func decodeNumber(data interface{}) (decimal.Decimal, error) {
 
 	dec, err := decimal.NewFromString(number)
 	if err != nil {
-		return decimal.Decimal{}, errors.Wrap(err, 0)
+		return decimal.Decimal{}, errors.WrapError(err, 0)
 	}
 
 	return dec, nil
 }
 
+// toParams maps a struct of call arguments to daemon params, using its json tags
+func toParams(args interface{}) map[string]interface{} {
+	s := structs.New(args)
+	s.TagName = "json"
+	return s.Map()
+}
+
 func debugParams(params map[string]interface{}) string {
 	var s []string
 	for k, v := range params {
@@ -107,17 +236,47 @@ func debugParams(params map[string]interface{}) string {
 }
 
 func (d *Client) callNoDecode(command string, params map[string]interface{}) (interface{}, error) {
-	log.Debugln("jsonrpc: " + command + " " + debugParams(params))
-	r, err := d.conn.Call(command, params)
-	if err != nil {
-		return nil, errors.Wrap(err, 0)
+	for attempt := 1; ; attempt++ {
+		r, err := d.send(command, params)
+		if err == nil && r.Error == nil {
+			return r.Result, nil
+		}
+		if d.ctx != nil && d.ctx.Err() != nil {
+			return nil, errors.Err(d.ctx.Err())
+		}
+
+		if attempt >= d.retry.MaxAttempts || !isRetryable(r, err) {
+			if err != nil {
+				return nil, errors.WrapError(err, 0)
+			}
+			return nil, newDaemonError(command, r.Error)
+		}
+
+		backoff := d.retry.backoff(attempt)
+		log.Debugf("jsonrpc: retrying %s in %s after attempt %d failed", command, backoff, attempt)
+		select {
+		case <-d.Context().Done():
+			return nil, errors.Err(d.Context().Err())
+		case <-time.After(backoff):
+		}
 	}
+}
 
-	if r.Error != nil {
-		return nil, errors.Err("Error in daemon: " + r.Error.Message)
+// send makes one attempt at a call, once there is a free slot for it
+func (d *Client) send(command string, params map[string]interface{}) (*jsonrpc.RPCResponse, error) {
+	if d.inFlight != nil {
+		select {
+		case d.inFlight <- struct{}{}:
+			defer func() { <-d.inFlight }()
+		case <-d.Context().Done():
+			return nil, d.Context().Err()
+		}
 	}
 
-	return r.Result, nil
+	request := jsonrpc.NewRequest(command, params)
+	request.ID = int(atomic.AddUint64(d.nextID, 1))
+	log.Debugf("jsonrpc: #%d %s %s", request.ID, command, debugParams(params))
+	return d.connFor(d.MethodTimeout(command)).CallRaw(request)
 }
 
 func (d *Client) call(response interface{}, command string, params map[string]interface{}) error {
@@ -128,10 +287,11 @@ func (d *Client) call(response interface{}, command string, params map[string]in
 	return Decode(result, response)
 }
 
+// SetRPCTimeout sets the timeout for calls to methods that don't have their own, see SetMethodTimeout. It
+// replaces DefaultMethodTimeouts, which a timeout of 0 brings back.
 func (d *Client) SetRPCTimeout(timeout time.Duration) {
-	d.conn = jsonrpc.NewClientWithOpts(d.address, &jsonrpc.RPCClientOpts{
-		HTTPClient: &http.Client{Timeout: timeout},
-	})
+	d.timeout = timeout
+	d.conn = d.newConn()
 }
 
 //============================================
@@ -174,8 +334,7 @@ func (d *Client) AccountSet(accountID string, settings AccountSettings) (*Accoun
 		AccountID:       accountID,
 		AccountSettings: settings,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "account_set", structs.Map(args))
+	return response, d.call(response, "account_set", toParams(args))
 }
 
 func (d *Client) AccountBalance(account *string) (*AccountBalanceResponse, error) {
@@ -198,6 +357,29 @@ func (d *Client) AccountFund(fromAccount string, toAccount string, amount string
 	})
 }
 
+// AccountSend sends amount from the account to each of the addresses
+func (d *Client) AccountSend(accountID string, amount string, addresses []string) (*AccountSendResponse, error) {
+	if len(addresses) == 0 {
+		return nil, errors.Err("no addresses to send to")
+	}
+	response := new(AccountSendResponse)
+	return response, d.call(response, "account_send", map[string]interface{}{
+		"account_id": accountID,
+		"amount":     amount,
+		"addresses":  addresses,
+		"blocking":   true,
+	})
+}
+
+// AccountMaxAddressGap returns the largest runs of unused addresses in the account, which the account's
+// address gaps must cover for all of its addresses to be found when it is restored from its seed
+func (d *Client) AccountMaxAddressGap(accountID string) (*AccountMaxAddressGapResponse, error) {
+	response := new(AccountMaxAddressGapResponse)
+	return response, d.call(response, "account_max_address_gap", map[string]interface{}{
+		"account_id": accountID,
+	})
+}
+
 func (d *Client) AccountCreate(accountName string, singleKey bool) (*Account, error) {
 	response := new(Account)
 	return response, d.call(response, "account_create", map[string]interface{}{
@@ -237,9 +419,12 @@ func (d *Client) ChannelList(account *string, page uint64, pageSize uint64, wid
 type streamType string
 
 var (
-	StreamTypeVideo = streamType("video")
-	StreamTypeAudio = streamType("audio")
-	StreamTypeImage = streamType("image")
+	StreamTypeVideo    = streamType("video")
+	StreamTypeAudio    = streamType("audio")
+	StreamTypeImage    = streamType("image")
+	StreamTypeDocument = streamType("document")
+	StreamTypeBinary   = streamType("binary")
+	StreamTypeModel    = streamType("model")
 )
 
 type Location struct {
@@ -288,8 +473,7 @@ func (d *Client) ChannelCreate(name string, bid float64, options ChannelCreateOp
 		ChannelCreateOptions: options,
 		Blocking:             true,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "channel_create", structs.Map(args))
+	return response, d.call(response, "channel_create", toParams(args))
 }
 
 type ChannelUpdateOptions struct {
@@ -315,8 +499,7 @@ func (d *Client) ChannelUpdate(claimID string, options ChannelUpdateOptions) (*T
 		ChannelUpdateOptions: &options,
 		Blocking:             true,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "channel_update", structs.Map(args))
+	return response, d.call(response, "channel_update", toParams(args))
 }
 
 type StreamCreateOptions struct {
@@ -356,8 +539,7 @@ func (d *Client) StreamCreate(name, filePath string, bid float64, options Stream
 		Blocking:            true,
 		StreamCreateOptions: &options,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "stream_create", structs.Map(args))
+	return response, d.call(response, "stream_create", toParams(args))
 }
 
 func (d *Client) StreamAbandon(txID string, nOut uint64, accountID *string, blocking bool) (*ClaimAbandonResponse, error) {
@@ -400,8 +582,7 @@ func (d *Client) StreamUpdate(claimID string, options StreamUpdateOptions) (*Tra
 		StreamUpdateOptions: &options,
 		Blocking:            true,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "stream_update", structs.Map(args))
+	return response, d.call(response, "stream_update", toParams(args))
 }
 
 func (d *Client) ChannelAbandon(txID string, nOut uint64, accountID *string, blocking bool) (*TransactionSummary, error) {
@@ -433,8 +614,33 @@ func (d *Client) AddressList(account *string, address *string, page uint64, page
 		Page:      page,
 		PageSize:  pageSize,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "address_list", structs.Map(args))
+	return response, d.call(response, "address_list", toParams(args))
+}
+
+// AddressListAll pages through address_list and returns every address in the account, or in the wallet if
+// account is nil
+func (d *Client) AddressListAll(account *string) ([]AddressListItem, error) {
+	const pageSize = 100
+	var addresses []AddressListItem
+	for page := uint64(1); ; page++ {
+		response, err := d.AddressList(account, nil, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, response.Items...)
+		if page >= response.TotalPages || len(response.Items) == 0 {
+			return addresses, nil
+		}
+	}
+}
+
+func (d *Client) AddressIsMine(address string, account *string) (bool, error) {
+	var response bool
+	err := d.call(&response, "address_is_mine", map[string]interface{}{
+		"address":    address,
+		"account_id": account,
+	})
+	return response, err
 }
 
 func (d *Client) StreamList(account *string, page uint64, pageSize uint64) (*StreamListResponse, error) {
@@ -491,6 +697,13 @@ func (d *Client) UTXOList(account *string, page uint64, pageSize uint64) (*UTXOL
 	})
 }
 
+func (d *Client) TransactionShow(txid string) (*TransactionSummary, error) {
+	response := new(TransactionSummary)
+	return response, d.call(response, "transaction_show", map[string]interface{}{
+		"txid": txid,
+	})
+}
+
 func (d *Client) UTXORelease(account *string) (*UTXOReleaseResponse, error) {
 	response := new(UTXOReleaseResponse)
 	return response, d.call(response, "utxo_release", map[string]interface{}{
@@ -507,11 +720,68 @@ func (d *Client) Get(uri string) (*GetResponse, error) {
 }
 
 func (d *Client) FileList(page uint64, pageSize uint64) (*FileListResponse, error) {
+	return d.FileListWithFilter(FileListFilter{}, page, pageSize)
+}
+
+// Comparison is how file_list compares the numeric filters to the files
+type Comparison string
+
+const (
+	ComparisonEqual          = Comparison("eq")
+	ComparisonNotEqual       = Comparison("ne")
+	ComparisonGreater        = Comparison("g")
+	ComparisonGreaterOrEqual = Comparison("ge")
+	ComparisonLess           = Comparison("l")
+	ComparisonLessOrEqual    = Comparison("le")
+)
+
+// FileListFilter selects the files returned by file_list. Unset fields don't filter.
+type FileListFilter struct {
+	SDHash         *string    `json:"sd_hash,omitempty"`
+	FileName       *string    `json:"file_name,omitempty"`
+	StreamHash     *string    `json:"stream_hash,omitempty"`
+	ClaimID        *string    `json:"claim_id,omitempty"`
+	ClaimName      *string    `json:"claim_name,omitempty"`
+	Outpoint       *string    `json:"outpoint,omitempty"`
+	TxID           *string    `json:"txid,omitempty"`
+	Nout           *uint64    `json:"nout,omitempty"`
+	ChannelClaimID *string    `json:"channel_claim_id,omitempty"`
+	ChannelName    *string    `json:"channel_name,omitempty"`
+	DownloadPath   *string    `json:"download_path,omitempty"`
+	Status         *string    `json:"status,omitempty"`
+	Completed      *bool      `json:"completed,omitempty"`
+	AddedOn        *int64     `json:"added_on,omitempty"`
+	BlobsInStream  *uint64    `json:"blobs_in_stream,omitempty"`
+	BlobsRemaining *uint64    `json:"blobs_remaining,omitempty"`
+	Comparison     Comparison `json:"comparison,omitempty"`
+	Sort           *string    `json:"sort,omitempty"`
+	Reverse        bool       `json:"reverse,omitempty"`
+	WalletID       *string    `json:"wallet_id,omitempty"`
+}
+
+func (d *Client) FileListWithFilter(filter FileListFilter, page uint64, pageSize uint64) (*FileListResponse, error) {
 	response := new(FileListResponse)
-	return response, d.call(response, "file_list", map[string]interface{}{
-		"include_protobuf": true,
-		"page":             page,
-		"page_size":        pageSize,
+	params := toParams(filter)
+	params["include_protobuf"] = true
+	params["page"] = page
+	params["page_size"] = pageSize
+	return response, d.call(response, "file_list", params)
+}
+
+func (d *Client) StreamCostEstimate(uri string) (decimal.Decimal, error) {
+	response, err := d.callNoDecode("stream_cost_estimate", map[string]interface{}{
+		"uri": uri,
+	})
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return decodeNumber(response)
+}
+
+func (d *Client) PeerList(blobHash string) (*PeerListResponse, error) {
+	response := new(PeerListResponse)
+	return response, d.call(response, "peer_list", map[string]interface{}{
+		"blob_hash": blobHash,
 	})
 }
 
@@ -522,33 +792,144 @@ func (d *Client) Version() (*VersionResponse, error) {
 
 func (d *Client) Resolve(urls string) (*ResolveResponse, error) {
 	response := new(ResolveResponse)
-	return response, d.call(response, "resolve", map[string]interface{}{
+	return response, d.cachedCall(response, "resolve", map[string]interface{}{
 		"urls":             urls,
 		"include_protobuf": true,
 	})
 }
 
-func (d *Client) ClaimSearch(claimName, claimID, txid *string, nout *uint, page uint64, pageSize uint64) (*ClaimSearchResponse, error) {
-	response := new(ClaimSearchResponse)
-	args := struct {
-		ClaimID         *string `json:"claim_id,omitempty"`
-		TXID            *string `json:"txid,omitempty"`
-		Nout            *uint   `json:"nout,omitempty"`
-		Name            *string `json:"name,omitempty"`
-		IncludeProtobuf bool    `json:"include_protobuf"`
-		Page            uint64  `json:"page"`
-		PageSize        uint64  `json:"page_size"`
-	}{
-		ClaimID:         claimID,
-		TXID:            txid,
-		Nout:            nout,
-		Name:            claimName,
-		IncludeProtobuf: true,
-		Page:            page,
-		PageSize:        pageSize,
+// Constraint limits a numeric claim_search field, e.g. AtLeast(1000) or LessThan("2.5"). A plain number,
+// as EqualTo gives, matches only that value.
+type Constraint string
+
+func EqualTo(v interface{}) Constraint     { return Constraint(fmt.Sprint(v)) }
+func GreaterThan(v interface{}) Constraint { return Constraint(">" + fmt.Sprint(v)) }
+func AtLeast(v interface{}) Constraint     { return Constraint(">=" + fmt.Sprint(v)) }
+func LessThan(v interface{}) Constraint    { return Constraint("<" + fmt.Sprint(v)) }
+func AtMost(v interface{}) Constraint      { return Constraint("<=" + fmt.Sprint(v)) }
+
+func (c Constraint) validate() error {
+	value := strings.TrimLeft(string(c), "<>=")
+	switch string(c)[:len(c)-len(value)] {
+	case "", ">", ">=", "<", "<=":
+	default:
+		return errors.Err("constraint %q has an unknown comparison", string(c))
+	}
+	if _, err := decimal.NewFromString(value); err != nil {
+		return errors.Err("constraint %q does not compare to a number", string(c))
+	}
+	return nil
+}
+
+const (
+	ClaimTypeStream     = "stream"
+	ClaimTypeChannel    = "channel"
+	ClaimTypeRepost     = "repost"
+	ClaimTypeCollection = "collection"
+)
+
+// ClaimSearchOptions selects the claims returned by claim_search. Unset fields don't filter. OrderBy takes
+// field names such as "release_time" or "trending_score", sorted descending unless prefixed with "^".
+type ClaimSearchOptions struct {
+	Name     *string  `json:"name,omitempty"`
+	Text     *string  `json:"text,omitempty"`
+	ClaimID  *string  `json:"claim_id,omitempty"`
+	ClaimIDs []string `json:"claim_ids,omitempty"`
+	TxID     *string  `json:"txid,omitempty"`
+	Nout     *uint    `json:"nout,omitempty"`
+	SDHash   *string  `json:"sd_hash,omitempty"`
+
+	Channel                 *string  `json:"channel,omitempty"`
+	ChannelIDs              []string `json:"channel_ids,omitempty"`
+	NotChannelIDs           []string `json:"not_channel_ids,omitempty"`
+	HasChannelSignature     bool     `json:"has_channel_signature,omitempty"`
+	ValidChannelSignature   bool     `json:"valid_channel_signature,omitempty"`
+	InvalidChannelSignature bool     `json:"invalid_channel_signature,omitempty"`
+	LimitClaimsPerChannel   *uint64  `json:"limit_claims_per_channel,omitempty"`
+
+	ClaimType       *string      `json:"claim_type,omitempty"`
+	StreamTypes     []streamType `json:"stream_types,omitempty"`
+	MediaTypes      []string     `json:"media_types,omitempty"`
+	RepostedClaimID *string      `json:"reposted_claim_id,omitempty"`
+	IsControlling   bool         `json:"is_controlling,omitempty"`
+	HasSource       bool         `json:"has_source,omitempty"`
+	HasNoSource     bool         `json:"has_no_source,omitempty"`
+
+	AnyTags      []string `json:"any_tags,omitempty"`
+	AllTags      []string `json:"all_tags,omitempty"`
+	NotTags      []string `json:"not_tags,omitempty"`
+	AnyLanguages []string `json:"any_languages,omitempty"`
+	AllLanguages []string `json:"all_languages,omitempty"`
+	NotLanguages []string `json:"not_languages,omitempty"`
+
+	FeeCurrency       *string    `json:"fee_currency,omitempty"`
+	FeeAmount         Constraint `json:"fee_amount,omitempty"`
+	Height            Constraint `json:"height,omitempty"`
+	Timestamp         Constraint `json:"timestamp,omitempty"`
+	CreationHeight    Constraint `json:"creation_height,omitempty"`
+	CreationTimestamp Constraint `json:"creation_timestamp,omitempty"`
+	ReleaseTime       Constraint `json:"release_time,omitempty"`
+	Duration          Constraint `json:"duration,omitempty"`
+	Amount            Constraint `json:"amount,omitempty"`
+	SupportAmount     Constraint `json:"support_amount,omitempty"`
+	EffectiveAmount   Constraint `json:"effective_amount,omitempty"`
+	Reposted          Constraint `json:"reposted,omitempty"`
+
+	OrderBy          []string `json:"order_by,omitempty"`
+	RemoveDuplicates bool     `json:"remove_duplicates,omitempty"`
+	NoTotals         bool     `json:"no_totals,omitempty"`
+	WalletID         *string  `json:"wallet_id,omitempty"`
+}
+
+func (o ClaimSearchOptions) validate() error {
+	if o.ClaimID != nil && len(o.ClaimIDs) > 0 {
+		return errors.Err("ClaimID and ClaimIDs can't be used together")
+	}
+	if o.ValidChannelSignature && o.InvalidChannelSignature {
+		return errors.Err("ValidChannelSignature and InvalidChannelSignature can't be used together")
+	}
+	if o.HasSource && o.HasNoSource {
+		return errors.Err("HasSource and HasNoSource can't be used together")
+	}
+	if o.ClaimType != nil {
+		switch *o.ClaimType {
+		case ClaimTypeStream, ClaimTypeChannel, ClaimTypeRepost, ClaimTypeCollection:
+		default:
+			return errors.Err("unknown claim type %s", *o.ClaimType)
+		}
+	}
+	for _, t := range o.StreamTypes {
+		switch t {
+		case StreamTypeVideo, StreamTypeAudio, StreamTypeImage, StreamTypeDocument, StreamTypeBinary, StreamTypeModel:
+		default:
+			return errors.Err("unknown stream type %s", string(t))
+		}
+	}
+	for _, c := range []Constraint{o.FeeAmount, o.Height, o.Timestamp, o.CreationHeight, o.CreationTimestamp,
+		o.ReleaseTime, o.Duration, o.Amount, o.SupportAmount, o.EffectiveAmount, o.Reposted} {
+		if c == "" {
+			continue
+		}
+		if err := c.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Client) ClaimSearch(options ClaimSearchOptions, page uint64, pageSize uint64) (*ClaimSearchResponse, error) {
+	if page == 0 {
+		return nil, errors.Err("pages start from 1")
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "claim_search", structs.Map(args))
+	response := new(ClaimSearchResponse)
+	params := toParams(options)
+	params["include_protobuf"] = true
+	params["page"] = page
+	params["page_size"] = pageSize
+	return response, d.cachedCall(response, "claim_search", params)
 }
 
 func (d *Client) ChannelExport(channelClaimID string, channelName, accountID *string) (*ChannelExportResponse, error) {
@@ -597,8 +978,7 @@ func (d *Client) SupportCreate(claimID string, amount string, tip *bool, account
 		Preview:           false,
 		Tip:               tip,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "support_create", structs.Map(args))
+	return response, d.call(response, "support_create", toParams(args))
 }
 
 func (d *Client) SupportAbandon(claimID *string, txid *string, nout *uint, keep *string, accountID *string) (*TransactionSummary, error) {
@@ -621,8 +1001,7 @@ func (d *Client) SupportAbandon(claimID *string, txid *string, nout *uint, keep
 		Blocking:  true,
 		Preview:   false,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "support_abandon", structs.Map(args))
+	return response, d.call(response, "support_abandon", toParams(args))
 }
 
 func (d *Client) AccountAdd(accountName string, seed *string, privateKey *string, publicKey *string, singleKey *bool, walletID *string) (*Account, error) {
@@ -643,8 +1022,7 @@ func (d *Client) AccountAdd(accountName string, seed *string, privateKey *string
 		SingleKey:   singleKey,
 		WalletID:    walletID,
 	}
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "account_add", structs.Map(args))
+	return response, d.call(response, "account_add", toParams(args))
 }
 
 type WalletCreateOpts struct {
@@ -660,8 +1038,7 @@ func (d *Client) WalletCreate(id string, opts *WalletCreateOpts) (*Wallet, error
 		opts = &WalletCreateOpts{}
 	}
 	opts.ID = id
-	structs.DefaultTagName = "json"
-	return response, d.call(response, "wallet_create", structs.Map(opts))
+	return response, d.call(response, "wallet_create", toParams(opts))
 }
 
 func (d *Client) WalletAdd(id string) (*Wallet, error) {
@@ -686,3 +1063,549 @@ func (d *Client) WalletRemove(id string) (*Wallet, error) {
 	response := new(Wallet)
 	return response, d.call(response, "wallet_remove", map[string]interface{}{"wallet_id": id})
 }
+
+func (d *Client) WalletBalance(walletID *string) (*WalletBalanceResponse, error) {
+	response := new(WalletBalanceResponse)
+	return response, d.call(response, "wallet_balance", map[string]interface{}{
+		"wallet_id": walletID,
+	})
+}
+
+func (d *Client) WalletStatus(walletID *string) (*WalletStatusResponse, error) {
+	response := new(WalletStatusResponse)
+	return response, d.call(response, "wallet_status", map[string]interface{}{
+		"wallet_id": walletID,
+	})
+}
+
+// WalletEncrypt encrypts the wallet with the password, which is then needed to unlock it
+func (d *Client) WalletEncrypt(password string, walletID *string) error {
+	return d.walletCall("wallet_encrypt", map[string]interface{}{
+		"new_password": password,
+		"wallet_id":    walletID,
+	})
+}
+
+func (d *Client) WalletDecrypt(walletID *string) error {
+	return d.walletCall("wallet_decrypt", map[string]interface{}{
+		"wallet_id": walletID,
+	})
+}
+
+func (d *Client) WalletLock(walletID *string) error {
+	return d.walletCall("wallet_lock", map[string]interface{}{
+		"wallet_id": walletID,
+	})
+}
+
+func (d *Client) WalletUnlock(password string, walletID *string) error {
+	return d.walletCall("wallet_unlock", map[string]interface{}{
+		"password":  password,
+		"wallet_id": walletID,
+	})
+}
+
+// walletCall calls a wallet method that reports whether it succeeded, and turns a false result into an error
+func (d *Client) walletCall(command string, params map[string]interface{}) error {
+	var response bool
+	if err := d.call(&response, command, params); err != nil {
+		return err
+	}
+	if !response {
+		return errors.Err("%s failed", command)
+	}
+	return nil
+}
+
+func (d *Client) WalletSend(amount string, addresses []string, accountID *string, walletID *string) (*TransactionSummary, error) {
+	response := new(TransactionSummary)
+	args := struct {
+		Amount    string   `json:"amount"`
+		Addresses []string `json:"addresses"`
+		AccountID *string  `json:"account_id,omitempty"`
+		WalletID  *string  `json:"wallet_id,omitempty"`
+		Blocking  bool     `json:"blocking"`
+	}{
+		Amount:    amount,
+		Addresses: addresses,
+		AccountID: accountID,
+		WalletID:  walletID,
+		Blocking:  true,
+	}
+	return response, d.call(response, "wallet_send", toParams(args))
+}
+
+type CommentListOptions struct {
+	ParentID                *string `json:"parent_id,omitempty"`
+	IncludeReplies          *bool   `json:"include_replies,omitempty"`
+	IsChannelSignatureValid *bool   `json:"is_channel_signature_valid,omitempty"`
+	Hidden                  *bool   `json:"hidden,omitempty"`
+}
+
+func (d *Client) CommentList(claimID string, page uint64, pageSize uint64, options CommentListOptions) (*CommentListResponse, error) {
+	if page == 0 {
+		return nil, errors.Err("pages start from 1")
+	}
+	response := new(CommentListResponse)
+	// options are mapped on their own because structs can't flatten a struct with every field omitted
+	params := toParams(options)
+	params["claim_id"] = claimID
+	params["page"] = page
+	params["page_size"] = pageSize
+	return response, d.call(response, "comment_list", params)
+}
+
+type CommentCreateOptions struct {
+	ParentID         *string `json:"parent_id,omitempty"`
+	ChannelID        *string `json:"channel_id,omitempty"`
+	ChannelName      *string `json:"channel_name,omitempty"`
+	ChannelAccountID *string `json:"channel_account_id,omitempty"`
+	AccountID        *string `json:"account_id,omitempty"`
+	WalletID         *string `json:"wallet_id,omitempty"`
+}
+
+// CommentCreate posts a comment on a claim. Comments are signed by the channel given in the options, and are
+// anonymous without one.
+func (d *Client) CommentCreate(claimID, comment string, options CommentCreateOptions) (*Comment, error) {
+	response := new(Comment)
+	params := toParams(options)
+	params["claim_id"] = claimID
+	params["comment"] = comment
+	return response, d.call(response, "comment_create", params)
+}
+
+func (d *Client) CommentUpdate(commentID, comment string, walletID *string) (*Comment, error) {
+	response := new(Comment)
+	return response, d.call(response, "comment_update", map[string]interface{}{
+		"comment_id": commentID,
+		"comment":    comment,
+		"wallet_id":  walletID,
+	})
+}
+
+func (d *Client) CommentAbandon(commentID string, walletID *string) (*CommentAbandonResponse, error) {
+	response := new(CommentAbandonResponse)
+	return response, d.call(response, "comment_abandon", map[string]interface{}{
+		"comment_id": commentID,
+		"wallet_id":  walletID,
+	})
+}
+
+func (d *Client) CommentHide(commentIDs []string, walletID *string) (*CommentHideResponse, error) {
+	response := new(CommentHideResponse)
+	return response, d.call(response, "comment_hide", map[string]interface{}{
+		"comment_ids": commentIDs,
+		"wallet_id":   walletID,
+	})
+}
+
+type CommentReactOptions struct {
+	ChannelID   *string `json:"channel_id,omitempty"`
+	ChannelName *string `json:"channel_name,omitempty"`
+	Remove      bool    `json:"remove,omitempty"`
+	ClearTypes  *string `json:"clear_types,omitempty"`
+	WalletID    *string `json:"wallet_id,omitempty"`
+}
+
+// CommentReact adds a reaction of the given type (e.g. "like") to each comment, or removes it if
+// options.Remove is set
+func (d *Client) CommentReact(commentIDs []string, reactType string, options CommentReactOptions) (*CommentReactions, error) {
+	response := new(CommentReactions)
+	params := toParams(options)
+	params["comment_ids"] = commentIDs
+	params["react_type"] = reactType
+	return response, d.call(response, "comment_react", params)
+}
+
+func (d *Client) CommentReactList(commentIDs []string, channelID, channelName *string, walletID *string) (*CommentReactListResponse, error) {
+	response := new(CommentReactListResponse)
+	return response, d.call(response, "comment_react_list", map[string]interface{}{
+		"comment_ids":  commentIDs,
+		"channel_id":   channelID,
+		"channel_name": channelName,
+		"wallet_id":    walletID,
+	})
+}
+
+type TxoType string
+
+const (
+	TxoTypeStream     = TxoType("stream")
+	TxoTypeChannel    = TxoType("channel")
+	TxoTypeRepost     = TxoType("repost")
+	TxoTypeCollection = TxoType("collection")
+	TxoTypeSupport    = TxoType("support")
+	TxoTypePurchase   = TxoType("purchase")
+	TxoTypeOther      = TxoType("other")
+)
+
+// TxoFilter selects the outputs used by txo_list, txo_spend and txo_sum. Unset fields don't filter.
+type TxoFilter struct {
+	Type                     []TxoType `json:"type,omitempty"`
+	TxID                     *string   `json:"txid,omitempty"`
+	ClaimID                  *string   `json:"claim_id,omitempty"`
+	ChannelID                *string   `json:"channel_id,omitempty"`
+	Name                     *string   `json:"name,omitempty"`
+	IsSpent                  bool      `json:"is_spent,omitempty"`
+	IsNotSpent               bool      `json:"is_not_spent,omitempty"`
+	IsMyInputOrOutput        bool      `json:"is_my_input_or_output,omitempty"`
+	IsMyOutput               bool      `json:"is_my_output,omitempty"`
+	IsNotMyOutput            bool      `json:"is_not_my_output,omitempty"`
+	IsMyInput                bool      `json:"is_my_input,omitempty"`
+	IsNotMyInput             bool      `json:"is_not_my_input,omitempty"`
+	ExcludeInternalTransfers bool      `json:"exclude_internal_transfers,omitempty"`
+	IncludeReceivedTips      bool      `json:"include_received_tips,omitempty"`
+	AccountID                *string   `json:"account_id,omitempty"`
+	WalletID                 *string   `json:"wallet_id,omitempty"`
+}
+
+func (f TxoFilter) params() map[string]interface{} {
+	return toParams(f)
+}
+
+func (d *Client) TxoList(filter TxoFilter, page uint64, pageSize uint64) (*TxoListResponse, error) {
+	if page == 0 {
+		return nil, errors.Err("pages start from 1")
+	}
+	response := new(TxoListResponse)
+	params := filter.params()
+	params["page"] = page
+	params["page_size"] = pageSize
+	params["include_is_received"] = true
+	return response, d.call(response, "txo_list", params)
+}
+
+// TxoSpend spends every output matched by the filter back into the wallet and returns the transactions
+// it created
+func (d *Client) TxoSpend(filter TxoFilter, batchSize *uint64) ([]TransactionSummary, error) {
+	var response []TransactionSummary
+	params := filter.params()
+	if batchSize != nil {
+		params["batch_size"] = *batchSize
+	}
+	params["blocking"] = true
+	err := d.call(&response, "txo_spend", params)
+	return response, err
+}
+
+func (d *Client) TxoSum(filter TxoFilter) (decimal.Decimal, error) {
+	response, err := d.callNoDecode("txo_sum", filter.params())
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return decodeNumber(response)
+}
+
+type PurchaseCreateOptions struct {
+	WalletID               *string  `json:"wallet_id,omitempty"`
+	FundingAccountIDs      []string `json:"funding_account_ids,omitempty"`
+	AllowDuplicatePurchase bool     `json:"allow_duplicate_purchase,omitempty"`
+	OverrideMaxKeyFee      bool     `json:"override_max_key_fee,omitempty"`
+	Preview                bool     `json:"preview,omitempty"`
+}
+
+// PurchaseCreate pays the fee of a claim, given by either its claim ID or its URL
+func (d *Client) PurchaseCreate(claimID, url *string, options PurchaseCreateOptions) (*TransactionSummary, error) {
+	if (claimID == nil) == (url == nil) {
+		return nil, errors.Err("exactly one of claimID or url must be supplied")
+	}
+	response := new(TransactionSummary)
+	params := toParams(options)
+	if claimID != nil {
+		params["claim_id"] = *claimID
+	} else {
+		params["url"] = *url
+	}
+	params["blocking"] = true
+	return response, d.call(response, "purchase_create", params)
+}
+
+func (d *Client) PurchaseList(claimID *string, resolve bool, accountID *string, page uint64, pageSize uint64) (*PurchaseListResponse, error) {
+	if page == 0 {
+		return nil, errors.Err("pages start from 1")
+	}
+	response := new(PurchaseListResponse)
+	args := struct {
+		ClaimID   *string `json:"claim_id,omitempty"`
+		Resolve   bool    `json:"resolve"`
+		AccountID *string `json:"account_id,omitempty"`
+		Page      uint64  `json:"page"`
+		PageSize  uint64  `json:"page_size"`
+	}{
+		ClaimID:   claimID,
+		Resolve:   resolve,
+		AccountID: accountID,
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	return response, d.call(response, "purchase_list", toParams(args))
+}
+
+type CollectionCreateOptions struct {
+	ClaimCreateOptions `json:",omitempty,flatten"`
+	ChannelID          *string `json:"channel_id,omitempty"`
+	ChannelName        *string `json:"channel_name,omitempty"`
+	ChannelAccountID   *string `json:"channel_account_id,omitempty"`
+	AllowDuplicateName *bool   `json:"allow_duplicate_name,omitempty"`
+}
+
+func (d *Client) CollectionCreate(name string, bid float64, claimIDs []string, options CollectionCreateOptions) (*TransactionSummary, error) {
+	response := new(TransactionSummary)
+	args := struct {
+		Name                    string   `json:"name"`
+		Bid                     string   `json:"bid"`
+		Claims                  []string `json:"claims"`
+		IncludeProtoBuf         bool     `json:"include_protobuf"`
+		CollectionCreateOptions `json:",omitempty,flatten"`
+		Blocking                bool `json:"blocking"`
+	}{
+		Name:                    name,
+		Bid:                     fmt.Sprintf("%.6f", bid),
+		Claims:                  claimIDs,
+		IncludeProtoBuf:         true,
+		CollectionCreateOptions: options,
+		Blocking:                true,
+	}
+	return response, d.call(response, "collection_create", toParams(args))
+}
+
+type CollectionUpdateOptions struct {
+	CollectionCreateOptions `json:",omitempty,flatten"`
+	Bid                     *string  `json:"bid,omitempty"`
+	Claims                  []string `json:"claims,omitempty"`
+	ClearClaims             *bool    `json:"clear_claims,omitempty"`
+	ClearTags               *bool    `json:"clear_tags,omitempty"`
+	ClearLanguages          *bool    `json:"clear_languages,omitempty"`
+	ClearLocations          *bool    `json:"clear_locations,omitempty"`
+	Replace                 *bool    `json:"replace,omitempty"`
+}
+
+// CollectionUpdate updates a collection. Claims are appended to the collection unless ClearClaims or Replace
+// is set.
+func (d *Client) CollectionUpdate(claimID string, options CollectionUpdateOptions) (*TransactionSummary, error) {
+	response := new(TransactionSummary)
+	args := struct {
+		ClaimID                 string `json:"claim_id"`
+		IncludeProtoBuf         bool   `json:"include_protobuf"`
+		CollectionUpdateOptions `json:",omitempty,flatten"`
+		Blocking                bool `json:"blocking"`
+	}{
+		ClaimID:                 claimID,
+		IncludeProtoBuf:         true,
+		CollectionUpdateOptions: options,
+		Blocking:                true,
+	}
+	return response, d.call(response, "collection_update", toParams(args))
+}
+
+// CollectionList lists the collections in the wallet, resolving up to resolveClaims claims of each
+func (d *Client) CollectionList(accountID *string, resolveClaims uint64, page uint64, pageSize uint64) (*CollectionListResponse, error) {
+	if page == 0 {
+		return nil, errors.Err("pages start from 1")
+	}
+	response := new(CollectionListResponse)
+	return response, d.call(response, "collection_list", map[string]interface{}{
+		"account_id":       accountID,
+		"resolve_claims":   resolveClaims,
+		"include_protobuf": true,
+		"page":             page,
+		"page_size":        pageSize,
+	})
+}
+
+// CollectionResolve resolves the claims in a collection, given by either its claim ID or its URL
+func (d *Client) CollectionResolve(claimID, url *string, page uint64, pageSize uint64) (*CollectionResolveResponse, error) {
+	if (claimID == nil) == (url == nil) {
+		return nil, errors.Err("exactly one of claimID or url must be supplied")
+	}
+	if page == 0 {
+		return nil, errors.Err("pages start from 1")
+	}
+	response := new(CollectionResolveResponse)
+	args := struct {
+		ClaimID         *string `json:"claim_id,omitempty"`
+		URL             *string `json:"url,omitempty"`
+		IncludeProtoBuf bool    `json:"include_protobuf"`
+		Page            uint64  `json:"page"`
+		PageSize        uint64  `json:"page_size"`
+	}{
+		ClaimID:         claimID,
+		URL:             url,
+		IncludeProtoBuf: true,
+		Page:            page,
+		PageSize:        pageSize,
+	}
+	return response, d.call(response, "collection_resolve", toParams(args))
+}
+
+func (d *Client) SettingsGet() (*Settings, error) {
+	return d.settingsCall("settings_get", map[string]interface{}{})
+}
+
+// SettingsSet changes a setting, e.g. SettingsSet("save_files", false), and returns the updated settings.
+// Values are sent as they are given, so they must be of the type the daemon expects for the key.
+func (d *Client) SettingsSet(key string, value interface{}) (*Settings, error) {
+	return d.settingsCall("settings_set", map[string]interface{}{
+		"key":   key,
+		"value": value,
+	})
+}
+
+// SettingsClear resets a setting to its default and returns the updated settings
+func (d *Client) SettingsClear(key string) (*Settings, error) {
+	return d.settingsCall("settings_clear", map[string]interface{}{
+		"key": key,
+	})
+}
+
+func (d *Client) settingsCall(command string, params map[string]interface{}) (*Settings, error) {
+	result, err := d.callNoDecode(command, params)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, errors.Err("%s: expected settings, got %T", command, result)
+	}
+	settings := &Settings{Raw: raw}
+	return settings, Decode(raw, settings)
+}
+
+// BlobListFilter selects the blobs returned by blob_list. At most one of URI, StreamHash and SDHash should be
+// set. Without any, every blob is listed.
+type BlobListFilter struct {
+	URI        *string `json:"uri,omitempty"`
+	StreamHash *string `json:"stream_hash,omitempty"`
+	SDHash     *string `json:"sd_hash,omitempty"`
+	Needed     bool    `json:"needed,omitempty"`
+	Finished   bool    `json:"finished,omitempty"`
+}
+
+func (d *Client) BlobList(filter BlobListFilter, page uint64, pageSize uint64) (*BlobListResponse, error) {
+	if page == 0 {
+		return nil, errors.Err("pages start from 1")
+	}
+	response := new(BlobListResponse)
+	params := toParams(filter)
+	params["page"] = page
+	params["page_size"] = pageSize
+	return response, d.call(response, "blob_list", params)
+}
+
+// BlobGet downloads a blob, waiting up to timeout seconds for it
+func (d *Client) BlobGet(blobHash string, timeout *uint64) error {
+	_, err := d.callNoDecode("blob_get", map[string]interface{}{
+		"blob_hash": blobHash,
+		"timeout":   timeout,
+	})
+	return err
+}
+
+// BlobRead downloads an sd blob if needed and returns its contents
+func (d *Client) BlobRead(sdHash string, timeout *uint64) (*BlobGetResponse, error) {
+	response := new(BlobGetResponse)
+	return response, d.call(response, "blob_get", map[string]interface{}{
+		"blob_hash": sdHash,
+		"timeout":   timeout,
+		"read":      true,
+	})
+}
+
+func (d *Client) BlobDelete(blobHash string) error {
+	_, err := d.callNoDecode("blob_delete", map[string]interface{}{
+		"blob_hash": blobHash,
+	})
+	return err
+}
+
+// BlobAnnounce announces a blob, the blobs of a stream, or the blobs of the stream with an sd hash to the dht.
+// Exactly one of the hashes must be set.
+func (d *Client) BlobAnnounce(blobHash, streamHash, sdHash *string) (bool, error) {
+	set := 0
+	for _, h := range []*string{blobHash, streamHash, sdHash} {
+		if h != nil {
+			set++
+		}
+	}
+	if set != 1 {
+		return false, errors.Err("exactly one of blobHash, streamHash or sdHash must be supplied")
+	}
+	var response BlobAnnounceResponse
+	err := d.call(&response, "blob_announce", toParams(struct {
+		BlobHash   *string `json:"blob_hash,omitempty"`
+		StreamHash *string `json:"stream_hash,omitempty"`
+		SDHash     *string `json:"sd_hash,omitempty"`
+	}{blobHash, streamHash, sdHash}))
+	return bool(response), err
+}
+
+// BlobReflect sends blobs to a reflector, or to the daemon's default reflector if reflectorServer is nil, and
+// returns the hashes of the blobs that were sent
+func (d *Client) BlobReflect(blobHashes []string, reflectorServer *string) (BlobReflectResponse, error) {
+	var response BlobReflectResponse
+	err := d.call(&response, "blob_reflect", map[string]interface{}{
+		"blob_hashes":      blobHashes,
+		"reflector_server": reflectorServer,
+	})
+	return response, err
+}
+
+// SyncHash returns the hash of the wallet's contents, which changes whenever the wallet does
+func (d *Client) SyncHash(walletID *string) (string, error) {
+	var response string
+	err := d.call(&response, "sync_hash", map[string]interface{}{
+		"wallet_id": walletID,
+	})
+	return response, err
+}
+
+// SyncApply merges the encrypted wallet data, as returned by an earlier SyncApply on this or another device,
+// into the wallet and returns the result encrypted with the password. If data is nil the wallet is returned
+// without changes, which is how a new wallet is first uploaded to a sync server.
+func (d *Client) SyncApply(password string, data *string, walletID *string) (*SyncApplyResponse, error) {
+	if data != nil {
+		if _, err := base64.StdEncoding.DecodeString(*data); err != nil {
+			return nil, errors.Prefix("wallet data is not base64 encoded", err)
+		}
+	}
+	response := new(SyncApplyResponse)
+	return response, d.call(response, "sync_apply", map[string]interface{}{
+		"password":  password,
+		"data":      data,
+		"wallet_id": walletID,
+		"blocking":  true,
+	})
+}
+
+// PreferenceGet returns the preference with the given key, or all of them if key is nil
+func (d *Client) PreferenceGet(key *string, walletID *string) (Preferences, error) {
+	response := Preferences{}
+	err := d.call(&response, "preference_get", map[string]interface{}{
+		"key":       key,
+		"wallet_id": walletID,
+	})
+	return response, err
+}
+
+// PreferenceSet stores a preference in the wallet, so that it syncs with the wallet to the user's other
+// devices, and returns it as the daemon stored it. Strings are stored as they are. Other values are encoded
+// to JSON; the daemon decodes objects and arrays again, but keeps numbers and booleans as their JSON text.
+func (d *Client) PreferenceSet(key string, value interface{}, walletID *string) (Preferences, error) {
+	var encoded string
+	if s, ok := value.(string); ok {
+		encoded = s
+	} else {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Prefix("encoding preference "+key, err)
+		}
+		encoded = string(b)
+	}
+	response := Preferences{}
+	err := d.call(&response, "preference_set", map[string]interface{}{
+		"key":       key,
+		"value":     encoded,
+		"wallet_id": walletID,
+	})
+	return response, err
+}
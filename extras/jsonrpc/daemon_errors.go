@@ -0,0 +1,151 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// Categories of daemon errors. Calls the daemon answers with an error return a *DaemonError, which matches its
+// category with the standard library's errors.Is, e.g.
+//
+//	_, err := d.WalletSend(amount, addresses, nil, nil)
+//	if errors.Is(err, jsonrpc.ErrInsufficientFunds) {
+//		...
+//	}
+var (
+	ErrInsufficientFunds = errors.Base("insufficient funds")
+	ErrClaimNotFound     = errors.Base("claim not found")
+	ErrKeyFeeAboveMax    = errors.Base("key fee above max allowed")
+	ErrDaemonTimeout     = errors.Base("daemon timed out")
+	ErrDaemonStarting    = errors.Base("daemon is starting")
+	ErrWalletNotFound    = errors.Base("wallet not found")
+	ErrInvalidPassword   = errors.Base("invalid password")
+	ErrMethodNotFound    = errors.Base("method not found")
+	ErrInvalidParams     = errors.Base("invalid params")
+)
+
+// error codes from the json-rpc spec
+const (
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// daemonErrorCategory says which daemon errors are in a category, by the name of the exception that caused
+// them or, for daemons that don't send names, by part of the message
+type daemonErrorCategory struct {
+	err      error
+	names    []string
+	messages []string
+}
+
+var daemonErrorCategories = []daemonErrorCategory{
+	{
+		err:      ErrInsufficientFunds,
+		names:    []string{"InsufficientFundsError"},
+		messages: []string{"not enough funds", "insufficient funds"},
+	},
+	{
+		err:      ErrClaimNotFound,
+		names:    []string{"ResolveError"},
+		messages: []string{"could not find claim", "couldn't find claim"},
+	},
+	{
+		err:      ErrKeyFeeAboveMax,
+		names:    []string{"KeyFeeAboveMaxAllowedError"},
+		messages: []string{"key fee above max allowed"},
+	},
+	{
+		err:   ErrDaemonTimeout,
+		names: []string{"ResolveTimeoutError", "DownloadSDTimeoutError", "DownloadDataTimeoutError"},
+	},
+	{
+		err:      ErrDaemonStarting,
+		names:    []string{"ComponentsNotStartedError", "ComponentStartConditionNotMetError"},
+		messages: []string{"not all components required", "daemon is starting", "still starting"},
+	},
+	{
+		err:      ErrWalletNotFound,
+		names:    []string{"WalletNotFoundError", "WalletNotLoadedError"},
+		messages: []string{"couldn't find wallet"},
+	},
+	{
+		err:      ErrInvalidPassword,
+		names:    []string{"InvalidPasswordError"},
+		messages: []string{"invalid password"},
+	},
+}
+
+// DaemonError is an error the daemon answered a call with
+type DaemonError struct {
+	Command   string
+	Code      int
+	Message   string
+	Name      string   // the name of the exception in the daemon, if it sent one
+	Traceback []string // the daemon's traceback, if it sent one
+}
+
+func (e *DaemonError) Error() string {
+	return "Error in daemon: " + e.Message
+}
+
+// Is reports whether the error is in the target category
+func (e *DaemonError) Is(target error) bool {
+	switch target {
+	case ErrMethodNotFound:
+		return e.Code == codeMethodNotFound
+	case ErrInvalidParams:
+		return e.Code == codeInvalidParams
+	}
+	message := strings.ToLower(e.Message)
+	for _, c := range daemonErrorCategories {
+		if c.err != target {
+			continue
+		}
+		for _, n := range c.names {
+			if e.Name == n {
+				return true
+			}
+		}
+		for _, m := range c.messages {
+			if strings.Contains(message, m) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// newDaemonError reads the error the daemon sent. The daemon puts the exception name and traceback in the
+// error's data, or only the traceback in older versions.
+func newDaemonError(command string, rpcErr *jsonrpc.RPCError) *DaemonError {
+	e := &DaemonError{Command: command, Code: rpcErr.Code, Message: rpcErr.Message}
+	switch data := rpcErr.Data.(type) {
+	case map[string]interface{}:
+		if name, ok := data["name"].(string); ok {
+			e.Name = name
+		}
+		e.Traceback = tracebackLines(data["traceback"])
+	default:
+		e.Traceback = tracebackLines(data)
+	}
+	return e
+}
+
+func tracebackLines(traceback interface{}) []string {
+	switch t := traceback.(type) {
+	case string:
+		return strings.Split(strings.TrimRight(t, "\n"), "\n")
+	case []interface{}:
+		lines := make([]string, 0, len(t))
+		for _, l := range t {
+			lines = append(lines, strings.TrimRight(fmt.Sprint(l), "\n"))
+		}
+		return lines
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package jsonrpc
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/jsonrpc/mock"
+)
+
+func TestDaemonError(t *testing.T) {
+	daemon := mock.NewServer()
+	defer daemon.Close()
+	daemon.Handle("wallet_send", func(mock.Request) (interface{}, error) {
+		return nil, &mock.Error{
+			Code:    mock.ErrorCode,
+			Message: "Not enough funds to cover this transaction.",
+			Data: map[string]interface{}{
+				"name": "InsufficientFundsError",
+				"traceback": []string{
+					"Traceback (most recent call last):\n",
+					"lbry.error.InsufficientFundsError: Not enough funds to cover this transaction.\n",
+				},
+			},
+		}
+	})
+	daemon.Handle("resolve", func(mock.Request) (interface{}, error) {
+		// older daemons send only the traceback, as one string
+		return nil, &mock.Error{
+			Code:    mock.ErrorCode,
+			Message: "Could not find claim at \"lbry://missing\".",
+			Data:    "Traceback (most recent call last):\nResolveError\n",
+		}
+	})
+	d := NewClient(daemon.URL)
+
+	_, err := d.WalletSend("1.0", []string{"bHmNm1zGxZ1Y5N8WdLXAaUkRdUDSGnqhqe"}, nil, nil)
+	var daemonErr *DaemonError
+	if !stderrors.As(err, &daemonErr) {
+		t.Fatalf("expected a *DaemonError, got %T", err)
+	}
+	if daemonErr.Command != "wallet_send" || daemonErr.Name != "InsufficientFundsError" || len(daemonErr.Traceback) != 2 {
+		t.Errorf("unexpected error %+v", daemonErr)
+	}
+	if !stderrors.Is(err, ErrInsufficientFunds) {
+		t.Error("expected ErrInsufficientFunds")
+	}
+	if stderrors.Is(err, ErrClaimNotFound) {
+		t.Error("did not expect ErrClaimNotFound")
+	}
+
+	_, err = d.Resolve("lbry://missing")
+	if !stderrors.Is(err, ErrClaimNotFound) {
+		t.Errorf("expected ErrClaimNotFound, got %v", err)
+	}
+	if stderrors.As(err, &daemonErr) && len(daemonErr.Traceback) != 2 {
+		t.Errorf("unexpected traceback %q", daemonErr.Traceback)
+	}
+
+	_, err = d.Status()
+	if !stderrors.Is(err, ErrMethodNotFound) && err.Error() != "Error in daemon: mock: no response for status" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestDaemonError_Is(t *testing.T) {
+	tests := []struct {
+		err      *DaemonError
+		category error
+	}{
+		{&DaemonError{Name: "KeyFeeAboveMaxAllowedError"}, ErrKeyFeeAboveMax},
+		{&DaemonError{Name: "DownloadSDTimeoutError"}, ErrDaemonTimeout},
+		{&DaemonError{Message: "Not all components required are running yet"}, ErrDaemonStarting},
+		{&DaemonError{Name: "WalletNotFoundError"}, ErrWalletNotFound},
+		{&DaemonError{Name: "InvalidPasswordError"}, ErrInvalidPassword},
+		{&DaemonError{Code: codeMethodNotFound, Message: "Invalid method requested: foo."}, ErrMethodNotFound},
+		{&DaemonError{Code: codeInvalidParams}, ErrInvalidParams},
+	}
+	for _, test := range tests {
+		if !stderrors.Is(test.err, test.category) {
+			t.Errorf("expected %+v to be %v", test.err, test.category)
+		}
+		if stderrors.Is(test.err, ErrInsufficientFunds) {
+			t.Errorf("did not expect %+v to be %v", test.err, ErrInsufficientFunds)
+		}
+	}
+}
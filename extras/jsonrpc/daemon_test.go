@@ -370,7 +370,7 @@ func TestClient_SupportTest(t *testing.T) {
 
 func TestClient_ClaimSearch(t *testing.T) {
 	d := NewClient("")
-	got, err := d.ClaimSearch(nil, util.PtrToString(channelID), nil, nil, 1, 20)
+	got, err := d.ClaimSearch(ClaimSearchOptions{ClaimID: util.PtrToString(channelID)}, 1, 20)
 	if err != nil {
 		t.Error(err)
 		return
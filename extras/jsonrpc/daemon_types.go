@@ -79,17 +79,20 @@ func getEnumVal(enum map[string]int32, data interface{}) (int32, error) {
 }
 
 func fixDecodeProto(src, dest reflect.Type, data interface{}) (interface{}, error) {
-	switch dest {
-	case reflect.TypeOf(uint64(0)):
+	switch dest.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if n, ok := data.(json.Number); ok {
 			val, err := n.Int64()
 			if err != nil {
-				return nil, errors.Wrap(err, 0)
+				return nil, errors.WrapError(err, 0)
 			} else if val < 0 {
 				return nil, errors.Err("must be unsigned int")
 			}
-			return uint64(val), nil
+			return reflect.ValueOf(uint64(val)).Convert(dest).Interface(), nil
 		}
+	}
+
+	switch dest {
 	case reflect.TypeOf([]byte{}):
 		if s, ok := data.(string); ok {
 			return []byte(s), nil
@@ -99,13 +102,13 @@ func fixDecodeProto(src, dest reflect.Type, data interface{}) (interface{}, erro
 		if n, ok := data.(json.Number); ok {
 			val, err := n.Float64()
 			if err != nil {
-				return nil, errors.Wrap(err, 0)
+				return nil, errors.WrapError(err, 0)
 			}
 			return decimal.NewFromFloat(val), nil
 		} else if s, ok := data.(string); ok {
 			d, err := decimal.NewFromString(s)
 			if err != nil {
-				return nil, errors.Wrap(err, 0)
+				return nil, errors.WrapError(err, 0)
 			}
 			return d, nil
 		}
@@ -129,12 +132,19 @@ func fixDecodeProto(src, dest reflect.Type, data interface{}) (interface{}, erro
 	return data, nil
 }
 
-type WalletBalanceResponse decimal.Decimal
+type WalletBalanceResponse AccountBalanceResponse
+
+type WalletStatusResponse struct {
+	IsEncrypted bool `json:"is_encrypted"`
+	IsLocked    bool `json:"is_locked"`
+	IsSyncing   bool `json:"is_syncing"`
+}
 
 type PeerListResponsePeer struct {
-	IP     string `json:"host"`
-	Port   uint   `json:"port"`
-	NodeId string `json:"node_id"`
+	IP      string `json:"address"`
+	Port    uint   `json:"tcp_port"`
+	UDPPort uint   `json:"udp_port"`
+	NodeId  string `json:"node_id"`
 }
 type PeerListResponse []PeerListResponsePeer
 
@@ -180,6 +190,7 @@ type FileListResponse struct {
 	Items      []File `json:"items"`
 	Page       uint64 `json:"page"`
 	PageSize   uint64 `json:"page_size"`
+	TotalItems uint64 `json:"total_items"`
 	TotalPages uint64 `json:"total_pages"`
 }
 
@@ -233,11 +244,17 @@ type AccountListResponse struct {
 	TotalPages uint64    `json:"total_pages"`
 }
 
+type ReservedSubtotals struct {
+	Claims   decimal.Decimal `json:"claims"`
+	Supports decimal.Decimal `json:"supports"`
+	Tips     decimal.Decimal `json:"tips"`
+}
+
 type AccountBalanceResponse struct {
-	Available         decimal.Decimal  `json:"available"`
-	Reserved          decimal.Decimal  `json:"reserved"`
-	ReservedSubtotals *decimal.Decimal `json:"reserved_subtotals"`
-	Total             decimal.Decimal  `json:"total"`
+	Available         decimal.Decimal    `json:"available"`
+	Reserved          decimal.Decimal    `json:"reserved"`
+	ReservedSubtotals *ReservedSubtotals `json:"reserved_subtotals"`
+	Total             decimal.Decimal    `json:"total"`
 }
 
 type Transaction struct {
@@ -268,19 +285,28 @@ type TransactionSummary struct {
 }
 
 type AccountFundResponse TransactionSummary
+type AccountSendResponse TransactionSummary
+
+type AccountMaxAddressGapResponse struct {
+	MaxChangeGap    uint64 `json:"max_change_gap"`
+	MaxReceivingGap uint64 `json:"max_receiving_gap"`
+}
 
 type Address string
 type AddressUnusedResponse Address
+type AddressListItem struct {
+	Account   string  `json:"account"`
+	Address   Address `json:"address"`
+	Pubkey    string  `json:"pubkey"`
+	UsedTimes uint64  `json:"used_times"`
+}
+
 type AddressListResponse struct {
-	Items []struct {
-		Account   string  `json:"account"`
-		Address   Address `json:"address"`
-		Pubkey    string  `json:"pubkey"`
-		UsedTimes uint64  `json:"used_times"`
-	} `json:"items"`
-	Page       uint64 `json:"page"`
-	PageSize   uint64 `json:"page_size"`
-	TotalPages uint64 `json:"total_pages"`
+	Items      []AddressListItem `json:"items"`
+	Page       uint64            `json:"page"`
+	PageSize   uint64            `json:"page_size"`
+	TotalItems uint64            `json:"total_items"`
+	TotalPages uint64            `json:"total_pages"`
 }
 
 type ChannelExportResponse string
@@ -423,10 +449,10 @@ type ClaimListResponse struct {
 type ClaimSearchResponse ClaimListResponse
 
 type SupportListResponse struct {
-	Items      []Claim
-	Page       uint64 `json:"page"`
-	PageSize   uint64 `json:"page_size"`
-	TotalPages uint64 `json:"total_pages"`
+	Items      []Claim `json:"items"`
+	Page       uint64  `json:"page"`
+	PageSize   uint64  `json:"page_size"`
+	TotalPages uint64  `json:"total_pages"`
 }
 type StatusResponse struct {
 	BlobManager struct {
@@ -469,7 +495,7 @@ type StatusResponse struct {
 		Gateway         string   `json:"gateway"`
 		PeerRedirectSet bool     `json:"peer_redirect_set"`
 		Redirects       struct{} `json:"redirects"`
-	}
+	} `json:"upnp"`
 	Wallet struct {
 		BestBlochash string `json:"best_blockhash"`
 		Blocks       int    `json:"blocks"`
@@ -479,18 +505,20 @@ type StatusResponse struct {
 	} `json:"wallet"`
 }
 
+type UTXO struct {
+	Address       string `json:"address"`
+	Amount        string `json:"amount"`
+	Confirmations int    `json:"confirmations"`
+	Height        int    `json:"height"`
+	IsChange      bool   `json:"is_change"`
+	IsMine        bool   `json:"is_mine"`
+	Nout          int    `json:"nout"`
+	Txid          string `json:"txid"`
+	Type          string `json:"type"`
+}
+
 type UTXOListResponse struct {
-	Items []struct {
-		Address       string `json:"address"`
-		Amount        string `json:"amount"`
-		Confirmations int    `json:"confirmations"`
-		Height        int    `json:"height"`
-		IsChange      bool   `json:"is_change"`
-		IsMine        bool   `json:"is_mine"`
-		Nout          int    `json:"nout"`
-		Txid          string `json:"txid"`
-		Type          string `json:"type"`
-	} `json:"items"`
+	Items      []UTXO `json:"items"`
 	Page       uint64 `json:"page"`
 	PageSize   uint64 `json:"page_size"`
 	TotalPages uint64 `json:"total_pages"`
@@ -519,22 +547,24 @@ type supportBlob struct {
 	IsTip        bool   `json:"is_tip"`
 }
 
+type TransactionListItem struct {
+	AbandonInfo   []transactionListBlob `json:"abandon_info"`
+	ClaimInfo     []transactionListBlob `json:"claim_info"`
+	Confirmations int64                 `json:"confirmations"`
+	Date          string                `json:"date"`
+	Fee           string                `json:"fee"`
+	SupportInfo   []supportBlob         `json:"support_info"`
+	Timestamp     int64                 `json:"timestamp"`
+	Txid          string                `json:"txid"`
+	UpdateInfo    []transactionListBlob `json:"update_info"`
+	Value         string                `json:"value"`
+}
+
 type TransactionListResponse struct {
-	Items []struct {
-		AbandonInfo   []transactionListBlob `json:"abandon_info"`
-		ClaimInfo     []transactionListBlob `json:"claim_info"`
-		Confirmations int64                 `json:"confirmations"`
-		Date          string                `json:"date"`
-		Fee           string                `json:"fee"`
-		SupportInfo   []supportBlob         `json:"support_info"`
-		Timestamp     int64                 `json:"timestamp"`
-		Txid          string                `json:"txid"`
-		UpdateInfo    []transactionListBlob `json:"update_info"`
-		Value         string                `json:"value"`
-	} `json:"items"`
-	Page       uint64 `json:"page"`
-	PageSize   uint64 `json:"page_size"`
-	TotalPages uint64 `json:"total_pages"`
+	Items      []TransactionListItem `json:"items"`
+	Page       uint64                `json:"page"`
+	PageSize   uint64                `json:"page_size"`
+	TotalPages uint64                `json:"total_pages"`
 }
 
 type VersionResponse struct {
@@ -580,3 +610,195 @@ type WalletList struct {
 	PageSize   uint64   `json:"page_size"`
 	TotalPages uint64   `json:"total_pages"`
 }
+
+type Comment struct {
+	Comment                 string  `json:"comment"`
+	CommentID               string  `json:"comment_id"`
+	ClaimID                 string  `json:"claim_id"`
+	ParentID                string  `json:"parent_id,omitempty"`
+	Timestamp               int64   `json:"timestamp"`
+	ChannelID               string  `json:"channel_id,omitempty"`
+	ChannelName             string  `json:"channel_name,omitempty"`
+	ChannelURL              string  `json:"channel_url,omitempty"`
+	Signature               string  `json:"signature,omitempty"`
+	SigningTs               string  `json:"signing_ts,omitempty"`
+	IsChannelSignatureValid bool    `json:"is_channel_signature_valid"`
+	IsHidden                bool    `json:"is_hidden"`
+	IsPinned                bool    `json:"is_pinned"`
+	Replies                 int     `json:"replies"`
+	SupportAmount           float64 `json:"support_amount"`
+}
+
+type CommentListResponse struct {
+	Items             []Comment `json:"items"`
+	HasHiddenComments bool      `json:"has_hidden_comments"`
+	Page              uint64    `json:"page"`
+	PageSize          uint64    `json:"page_size"`
+	TotalItems        uint64    `json:"total_items"`
+	TotalPages        uint64    `json:"total_pages"`
+}
+
+type CommentAbandonResponse map[string]struct {
+	Abandoned bool `json:"abandoned"`
+}
+
+type CommentHideResponse map[string]struct {
+	Hidden bool `json:"hidden"`
+}
+
+// CommentReactions maps comment IDs to the number of reactions of each type, e.g. "like" or "dislike"
+type CommentReactions map[string]map[string]int
+
+type CommentReactListResponse struct {
+	MyReactions     CommentReactions `json:"my_reactions"`
+	OthersReactions CommentReactions `json:"others_reactions"`
+}
+
+type Txo struct {
+	Address        string            `json:"address"`
+	Amount         string            `json:"amount"`
+	ClaimID        string            `json:"claim_id,omitempty"`
+	ClaimOp        string            `json:"claim_op,omitempty"`
+	Confirmations  int               `json:"confirmations"`
+	Height         int               `json:"height"`
+	IsChange       bool              `json:"is_change"`
+	IsInternal     bool              `json:"is_internal_transfer"`
+	IsMine         bool              `json:"is_my_output"`
+	IsMyInput      bool              `json:"is_my_input"`
+	IsReceived     bool              `json:"is_received"`
+	IsSpent        bool              `json:"is_spent"`
+	Name           string            `json:"name,omitempty"`
+	NormalizedName string            `json:"normalized_name,omitempty"`
+	Nout           uint64            `json:"nout"`
+	PermanentURL   string            `json:"permanent_url,omitempty"`
+	Timestamp      int64             `json:"timestamp"`
+	Txid           string            `json:"txid"`
+	Type           string            `json:"type"`
+	Value          *lbryschema.Claim `json:"protobuf,omitempty"`
+	ValueType      string            `json:"value_type,omitempty"`
+}
+
+type TxoListResponse struct {
+	Items      []Txo  `json:"items"`
+	Page       uint64 `json:"page"`
+	PageSize   uint64 `json:"page_size"`
+	TotalItems uint64 `json:"total_items"`
+	TotalPages uint64 `json:"total_pages"`
+}
+
+type Purchase struct {
+	Address       string `json:"address"`
+	Amount        string `json:"amount"`
+	ClaimID       string `json:"claim_id"`
+	Confirmations int    `json:"confirmations"`
+	Height        int    `json:"height"`
+	IsMine        bool   `json:"is_my_output"`
+	Nout          uint64 `json:"nout"`
+	Timestamp     int64  `json:"timestamp"`
+	Txid          string `json:"txid"`
+	Type          string `json:"type"`
+	// Claim is the purchased claim, only included when the list is resolved
+	Claim *Claim `json:"claim,omitempty"`
+}
+
+type PurchaseListResponse struct {
+	Items      []Purchase `json:"items"`
+	Page       uint64     `json:"page"`
+	PageSize   uint64     `json:"page_size"`
+	TotalItems uint64     `json:"total_items"`
+	TotalPages uint64     `json:"total_pages"`
+}
+
+type Collection struct {
+	Claim `json:",squash"`
+	// Claims holds the resolved claims in the collection, up to the number requested with resolve_claims
+	Claims []Claim `json:"claims,omitempty"`
+}
+
+type CollectionListResponse struct {
+	Items      []Collection `json:"items"`
+	Page       uint64       `json:"page"`
+	PageSize   uint64       `json:"page_size"`
+	TotalItems uint64       `json:"total_items"`
+	TotalPages uint64       `json:"total_pages"`
+}
+
+type CollectionResolveResponse struct {
+	Items      []Claim `json:"items"`
+	Page       uint64  `json:"page"`
+	PageSize   uint64  `json:"page_size"`
+	TotalItems uint64  `json:"total_items"`
+	TotalPages uint64  `json:"total_pages"`
+}
+
+type SettingsFee struct {
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+}
+
+// Settings holds the daemon configuration. The known keys are typed, and Raw holds every key as the daemon
+// returned it, for the ones that aren't.
+type Settings struct {
+	API                       string       `json:"api"`
+	StreamingServer           string       `json:"streaming_server"`
+	DataDir                   string       `json:"data_dir"`
+	DownloadDir               string       `json:"download_dir"`
+	WalletDir                 string       `json:"wallet_dir"`
+	Wallets                   []string     `json:"wallets"`
+	BlockchainName            string       `json:"blockchain_name"`
+	ComponentsToSkip          []string     `json:"components_to_skip"`
+	ShareUsageData            bool         `json:"share_usage_data"`
+	UseUPNP                   bool         `json:"use_upnp"`
+	NetworkInterface          string       `json:"network_interface"`
+	UDPPort                   int          `json:"udp_port"`
+	TCPPort                   int          `json:"tcp_port"`
+	SaveFiles                 bool         `json:"save_files"`
+	SaveBlobs                 bool         `json:"save_blobs"`
+	SaveResolvedClaims        bool         `json:"save_resolved_claims"`
+	StreamingGet              bool         `json:"streaming_get"`
+	ReflectStreams            bool         `json:"reflect_streams"`
+	MaxKeyFee                 *SettingsFee `json:"max_key_fee"`
+	DisableMaxKeyFee          bool         `json:"disable_max_key_fee"`
+	DownloadTimeout           float64      `json:"download_timeout"`
+	BlobDownloadTimeout       float64      `json:"blob_download_timeout"`
+	PeerConnectTimeout        float64      `json:"peer_connect_timeout"`
+	NodeRPCTimeout            float64      `json:"node_rpc_timeout"`
+	BlobLRUCacheSize          int          `json:"blob_lru_cache_size"`
+	MaxConnectionsPerDownload int          `json:"max_connections_per_download"`
+	CoinSelectionStrategy     string       `json:"coin_selection_strategy"`
+
+	Raw map[string]interface{} `json:"-"`
+}
+
+type BlobListResponse struct {
+	Items      []string `json:"items"`
+	Page       uint64   `json:"page"`
+	PageSize   uint64   `json:"page_size"`
+	TotalItems uint64   `json:"total_items"`
+	TotalPages uint64   `json:"total_pages"`
+}
+
+type BlobReflectResponse []string
+
+// SyncApplyResponse holds the wallet after a sync. Data is the encrypted wallet, base64 encoded, and Hash is
+// the hash of the wallet's contents that the sync server stores alongside it.
+type SyncApplyResponse struct {
+	Hash string `json:"hash"`
+	Data string `json:"data"`
+}
+
+// Preferences are the values apps store in the wallet, keyed by name. Values are whatever JSON the app stored,
+// with numbers as json.Number.
+type Preferences map[string]interface{}
+
+// Get decodes the preference with the given key into v, e.g.
+//
+//	var subscriptions []string
+//	found, err := prefs.Get("subscriptions", &subscriptions)
+func (p Preferences) Get(key string, v interface{}) (bool, error) {
+	value, ok := p[key]
+	if !ok {
+		return false, nil
+	}
+	return true, Decode(value, v)
+}
@@ -0,0 +1,930 @@
+package jsonrpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// newFixtureDaemon starts a fake daemon that answers every request with the recorded response in
+// testdata/<method>.json
+func newFixtureDaemon(t *testing.T) *httptest.Server {
+	return newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", req.Method+".json"))
+		if err != nil {
+			return nil, "no fixture for " + req.Method
+		}
+		return json.RawMessage(data), ""
+	})
+}
+
+func TestFixture_WalletBalance(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).WalletBalance(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Total.Equal(decimal.RequireFromString("11.9864455")) {
+		t.Errorf("total = %s", got.Total)
+	}
+	if got.ReservedSubtotals == nil {
+		t.Fatal("missing reserved subtotals")
+	}
+	if !got.ReservedSubtotals.Tips.Equal(decimal.RequireFromString("0.1")) {
+		t.Errorf("tips = %s", got.ReservedSubtotals.Tips)
+	}
+}
+
+func TestFixture_WalletStatus(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).WalletStatus(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.IsEncrypted || got.IsLocked || !got.IsSyncing {
+		t.Errorf("unexpected status %+v", got)
+	}
+}
+
+func TestFixture_AddressList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).AddressList(nil, nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 {
+		t.Fatalf("expected 1 address, got %d", len(got.Items))
+	}
+	if got.Items[0].Address != "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha" || got.Items[0].UsedTimes != 2 {
+		t.Errorf("unexpected address %+v", got.Items[0])
+	}
+}
+
+func TestFixture_UTXOList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).UTXOList(nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 {
+		t.Fatalf("expected 1 utxo, got %d", len(got.Items))
+	}
+	if u := got.Items[0]; u.Amount != "9.9864455" || u.Nout != 1 || !u.IsChange {
+		t.Errorf("unexpected utxo %+v", u)
+	}
+}
+
+func TestFixture_TransactionList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).TransactionList(nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(got.Items))
+	}
+	tx := got.Items[0]
+	if len(tx.ClaimInfo) != 1 || tx.ClaimInfo[0].ClaimName != "@channel" {
+		t.Errorf("unexpected claim info %+v", tx.ClaimInfo)
+	}
+	if len(tx.SupportInfo) != 1 || !tx.SupportInfo[0].IsTip {
+		t.Errorf("unexpected support info %+v", tx.SupportInfo)
+	}
+}
+
+func TestFixture_TransactionShow(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).TransactionShow("a0b4d0e4e6a1a4fc3e3b5c05e6d6e1f4c6b0b1b2a5e5cbb2c1c3e3f5bd8b0b3c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Inputs) != 1 || len(got.Outputs) != 1 || got.TotalFee != "0.0001355" {
+		t.Errorf("unexpected transaction %+v", got)
+	}
+}
+
+func TestFixture_SupportList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).SupportList(nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 || got.Items[0].Amount != "0.9" {
+		t.Errorf("unexpected supports %+v", got.Items)
+	}
+}
+
+func TestFixture_PeerList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).PeerList("abcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*got) != 1 {
+		t.Fatalf("expected 1 peer, got %d", len(*got))
+	}
+	if p := (*got)[0]; p.IP != "18.233.20.48" || p.Port != 3333 || p.UDPPort != 4444 {
+		t.Errorf("unexpected peer %+v", p)
+	}
+}
+
+func TestFixture_Status(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Upnp.ExternalIp != "203.0.113.7" || !got.Upnp.DhtRedirectSet {
+		t.Errorf("unexpected upnp status %+v", got.Upnp)
+	}
+	if got.Wallet.Blocks != 812345 {
+		t.Errorf("blocks = %d", got.Wallet.Blocks)
+	}
+}
+
+func TestClient_ScalarResponses(t *testing.T) {
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		switch req.Method {
+		case "address_is_mine":
+			return req.Params["address"] == "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", ""
+		case "stream_cost_estimate":
+			return json.Number("1.25"), ""
+		}
+		return nil, "unexpected method " + req.Method
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	mine, err := d.AddressIsMine("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mine {
+		t.Error("expected address to be mine")
+	}
+
+	cost, err := d.StreamCostEstimate("lbry://what")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cost.Equal(decimal.RequireFromString("1.25")) {
+		t.Errorf("cost = %s", cost)
+	}
+}
+
+func TestFixture_CommentList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).CommentList("9f5a0f3c8e6d1b7a2c4e8f0a1b3d5c7e9f1a3b5c", 1, 50, CommentListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 2 || got.TotalItems != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(got.Items))
+	}
+	signed, reply := got.Items[0], got.Items[1]
+	if !signed.IsChannelSignatureValid || signed.ChannelName != "@channel" || signed.SigningTs != "1579111614" {
+		t.Errorf("unexpected signed comment %+v", signed)
+	}
+	if signed.SupportAmount != 0.5 || !signed.IsPinned {
+		t.Errorf("unexpected signed comment %+v", signed)
+	}
+	if reply.ParentID != signed.CommentID || reply.ChannelID != "" {
+		t.Errorf("unexpected reply %+v", reply)
+	}
+}
+
+func TestClient_CommentCreate(t *testing.T) {
+	var params map[string]interface{}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		params = req.Params
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "comment_create.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return json.RawMessage(data), ""
+	})
+	defer daemon.Close()
+
+	channelName := "@channel"
+	got, err := NewClient(daemon.URL).CommentCreate("9f5a0f3c8e6d1b7a2c4e8f0a1b3d5c7e9f1a3b5c", "great video", CommentCreateOptions{
+		ChannelName: &channelName,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Comment != "great video" || got.Signature == "" {
+		t.Errorf("unexpected comment %+v", got)
+	}
+	if params["channel_name"] != channelName || params["comment"] != "great video" {
+		t.Errorf("unexpected params %v", params)
+	}
+	if _, ok := params["parent_id"]; ok {
+		t.Error("unset options should not be sent")
+	}
+}
+
+func TestFixture_CommentAbandon(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	id := "4dd0d1b2f7e6c5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0"
+	got, err := NewClient(daemon.URL).CommentAbandon(id, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(*got)[id].Abandoned {
+		t.Errorf("unexpected response %+v", got)
+	}
+}
+
+func TestFixture_CommentReactList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	id := "4dd0d1b2f7e6c5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0"
+	got, err := NewClient(daemon.URL).CommentReactList([]string{id}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MyReactions[id]["like"] != 1 || got.OthersReactions[id]["like"] != 14 {
+		t.Errorf("unexpected reactions %+v", got)
+	}
+}
+
+func TestFixture_TxoList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).TxoList(TxoFilter{Type: []TxoType{TxoTypeChannel, TxoTypeSupport}}, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 2 || got.TotalItems != 2 {
+		t.Fatalf("expected 2 txos, got %d", len(got.Items))
+	}
+	if c := got.Items[0]; c.ClaimOp != "create" || c.ValueType != "channel" || !c.IsMyInput {
+		t.Errorf("unexpected channel txo %+v", c)
+	}
+	if s := got.Items[1]; s.Type != "support" || !s.IsReceived || s.IsMyInput {
+		t.Errorf("unexpected support txo %+v", s)
+	}
+}
+
+func TestFixture_TxoSpend(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).TxoSpend(TxoFilter{Type: []TxoType{TxoTypeSupport}, IsNotMyInput: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].TotalFee != "0.0001355" {
+		t.Errorf("unexpected transactions %+v", got)
+	}
+}
+
+func TestClient_TxoFilter(t *testing.T) {
+	var params map[string]interface{}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		params = req.Params
+		return "2.5", ""
+	})
+	defer daemon.Close()
+
+	channelID := "d5f4b2d4bb1d2f8fb6d3b3dc8b6e9a8a0f2e8b7c"
+	sum, err := NewClient(daemon.URL).TxoSum(TxoFilter{
+		Type:       []TxoType{TxoTypeSupport},
+		ChannelID:  &channelID,
+		IsNotSpent: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sum.Equal(decimal.RequireFromString("2.5")) {
+		t.Errorf("sum = %s", sum)
+	}
+	types, _ := params["type"].([]interface{})
+	if len(types) != 1 || types[0] != "support" {
+		t.Errorf("type = %v", params["type"])
+	}
+	if params["channel_id"] != channelID || params["is_not_spent"] != true {
+		t.Errorf("unexpected params %v", params)
+	}
+	for _, unset := range []string{"is_spent", "account_id", "txid"} {
+		if _, ok := params[unset]; ok {
+			t.Errorf("%s should not be sent", unset)
+		}
+	}
+}
+
+func TestFixture_PurchaseList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).PurchaseList(nil, true, nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 {
+		t.Fatalf("expected 1 purchase, got %d", len(got.Items))
+	}
+	p := got.Items[0]
+	if p.Amount != "2.5" || p.Type != "purchase" {
+		t.Errorf("unexpected purchase %+v", p)
+	}
+	if p.Claim == nil || p.Claim.ClaimID != p.ClaimID || p.Claim.ValueType != "stream" {
+		t.Errorf("unexpected purchased claim %+v", p.Claim)
+	}
+}
+
+func TestClient_PurchaseCreate(t *testing.T) {
+	var params map[string]interface{}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		params = req.Params
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "purchase_create.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return json.RawMessage(data), ""
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	url := "lbry://video#9"
+	got, err := d.PurchaseCreate(nil, &url, PurchaseCreateOptions{OverrideMaxKeyFee: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Txid == "" {
+		t.Error("missing txid")
+	}
+	if params["url"] != url || params["override_max_key_fee"] != true {
+		t.Errorf("unexpected params %v", params)
+	}
+	if _, ok := params["claim_id"]; ok {
+		t.Error("claim_id should not be sent")
+	}
+
+	if _, err := d.PurchaseCreate(nil, nil, PurchaseCreateOptions{}); err == nil {
+		t.Error("expected an error without a claim ID or url")
+	}
+}
+
+func TestFixture_CollectionList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).CollectionList(nil, 10, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 {
+		t.Fatalf("expected 1 collection, got %d", len(got.Items))
+	}
+	c := got.Items[0]
+	if c.Name != "favorites" || c.ValueType != "collection" {
+		t.Errorf("unexpected collection %+v", c.Claim)
+	}
+	if len(c.Claims) != 1 || c.Claims[0].Name != "video" {
+		t.Errorf("unexpected claims in collection %+v", c.Claims)
+	}
+}
+
+func TestFixture_CollectionResolve(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+	claimID := "2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c"
+	got, err := d.CollectionResolve(&claimID, nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 || got.Items[0].ClaimID != "9f5a0f3c8e6d1b7a2c4e8f0a1b3d5c7e9f1a3b5c" {
+		t.Errorf("unexpected claims %+v", got.Items)
+	}
+	if _, err := d.CollectionResolve(&claimID, &claimID, 1, 20); err == nil {
+		t.Error("expected an error with both a claim ID and a url")
+	}
+}
+
+func TestClient_CollectionUpdate(t *testing.T) {
+	var params map[string]interface{}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		params = req.Params
+		return map[string]interface{}{"txid": "f0e1d2c3b4a5968778695a4b3c2d1e0f1a2b3c4d5e6f708192a3b4c5d6e7f809"}, ""
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	if _, err := d.CollectionUpdate("2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c", CollectionUpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := params["claims"]; ok {
+		t.Error("claims should not be sent")
+	}
+
+	title := "Favorites"
+	replace := true
+	options := CollectionUpdateOptions{Claims: []string{"9f5a0f3c8e6d1b7a2c4e8f0a1b3d5c7e9f1a3b5c"}, Replace: &replace}
+	options.Title = &title
+	if _, err := d.CollectionUpdate("2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c", options); err != nil {
+		t.Fatal(err)
+	}
+	if params["title"] != title || params["replace"] != true {
+		t.Errorf("unexpected params %v", params)
+	}
+	if claims, _ := params["claims"].([]interface{}); len(claims) != 1 {
+		t.Errorf("claims = %v", params["claims"])
+	}
+}
+
+func TestClient_WalletEncryption(t *testing.T) {
+	var locked, encrypted bool
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		switch req.Method {
+		case "wallet_encrypt":
+			encrypted, locked = true, true
+			return req.Params["new_password"] == "hunter2", ""
+		case "wallet_unlock":
+			if req.Params["password"] != "hunter2" {
+				return false, ""
+			}
+			locked = false
+			return true, ""
+		case "wallet_lock":
+			locked = true
+			return true, ""
+		case "wallet_decrypt":
+			encrypted = false
+			return true, ""
+		case "wallet_status":
+			return map[string]interface{}{"is_encrypted": encrypted, "is_locked": locked, "is_syncing": false}, ""
+		}
+		return nil, "unexpected method " + req.Method
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	if err := d.WalletEncrypt("hunter2", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.WalletUnlock("wrong", nil); err == nil {
+		t.Error("expected unlocking with the wrong password to fail")
+	}
+	if err := d.WalletUnlock("hunter2", nil); err != nil {
+		t.Fatal(err)
+	}
+	status, err := d.WalletStatus(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.IsEncrypted || status.IsLocked {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if err := d.WalletLock(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.WalletDecrypt(nil); err != nil {
+		t.Fatal(err)
+	}
+	status, err = d.WalletStatus(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.IsEncrypted || !status.IsLocked {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestFixture_AddressUnused(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).AddressUnused(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha" {
+		t.Errorf("address = %s", *got)
+	}
+}
+
+func TestClient_AddressListAll(t *testing.T) {
+	const total = 230
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		page, _ := req.Params["page"].(float64)
+		pageSize, _ := req.Params["page_size"].(float64)
+		var items []map[string]interface{}
+		for i := int((page - 1) * pageSize); i < total && i < int(page*pageSize); i++ {
+			items = append(items, map[string]interface{}{"address": "address" + strconv.Itoa(i), "used_times": 0})
+		}
+		return map[string]interface{}{
+			"items":       items,
+			"page":        page,
+			"page_size":   pageSize,
+			"total_items": total,
+			"total_pages": (total + int(pageSize) - 1) / int(pageSize),
+		}, ""
+	})
+	defer daemon.Close()
+
+	got, err := NewClient(daemon.URL).AddressListAll(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != total {
+		t.Fatalf("expected %d addresses, got %d", total, len(got))
+	}
+	if got[total-1].Address != "address229" {
+		t.Errorf("last address = %s", got[total-1].Address)
+	}
+}
+
+func TestFixture_FileList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).FileList(1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 1 || got.TotalItems != 1 {
+		t.Fatalf("expected 1 file, got %d", len(got.Items))
+	}
+	if f := got.Items[0]; !f.Completed || f.BlobsInStream != 4 || f.ChannelName != "@channel" {
+		t.Errorf("unexpected file %+v", f)
+	}
+}
+
+func TestClient_FileListWithFilter(t *testing.T) {
+	var params map[string]interface{}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		params = req.Params
+		return map[string]interface{}{"items": []interface{}{}, "page": 2, "page_size": 10}, ""
+	})
+	defer daemon.Close()
+
+	channelID := "d5f4b2d4bb1d2f8fb6d3b3dc8b6e9a8a0f2e8b7c"
+	blobs := uint64(10)
+	sort := "added_on"
+	_, err := NewClient(daemon.URL).FileListWithFilter(FileListFilter{
+		ChannelClaimID: &channelID,
+		BlobsInStream:  &blobs,
+		Comparison:     ComparisonGreaterOrEqual,
+		Sort:           &sort,
+		Reverse:        true,
+	}, 2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"channel_claim_id": channelID,
+		"blobs_in_stream":  float64(10),
+		"comparison":       "ge",
+		"sort":             sort,
+		"reverse":          true,
+		"include_protobuf": true,
+		"page":             float64(2),
+		"page_size":        float64(10),
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("expected params %v, got %v", expected, params)
+	}
+}
+
+func TestFixture_SettingsGet(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).SettingsGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SaveFiles || !got.SaveBlobs || got.TCPPort != 3333 || got.DownloadTimeout != 30 {
+		t.Errorf("unexpected settings %+v", got)
+	}
+	if got.MaxKeyFee == nil || got.MaxKeyFee.Currency != "USD" || got.MaxKeyFee.Amount != 50 {
+		t.Errorf("unexpected max key fee %+v", got.MaxKeyFee)
+	}
+	if len(got.Wallets) != 1 || got.Wallets[0] != "default_wallet" {
+		t.Errorf("unexpected wallets %v", got.Wallets)
+	}
+	if servers, ok := got.Raw["lbryum_servers"].([]interface{}); !ok || len(servers) != 2 {
+		t.Errorf("expected untyped settings in Raw, got %v", got.Raw["lbryum_servers"])
+	}
+}
+
+func TestClient_SettingsSet(t *testing.T) {
+	settings := map[string]interface{}{"save_files": true, "max_key_fee": nil}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		if req.Method != "settings_set" {
+			return nil, "unexpected method " + req.Method
+		}
+		settings[req.Params["key"].(string)] = req.Params["value"]
+		return settings, ""
+	})
+	defer daemon.Close()
+
+	got, err := NewClient(daemon.URL).SettingsSet("save_files", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SaveFiles || got.Raw["save_files"] != false {
+		t.Errorf("expected save_files to be false, got %+v", got)
+	}
+	if got.MaxKeyFee != nil {
+		t.Errorf("expected no max key fee, got %+v", got.MaxKeyFee)
+	}
+}
+
+func TestFixture_BlobList(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).BlobList(BlobListFilter{Finished: true}, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 2 || got.TotalItems != 2 {
+		t.Errorf("unexpected blobs %+v", got)
+	}
+}
+
+func TestFixture_BlobRead(t *testing.T) {
+	daemon := newFixtureDaemon(t)
+	defer daemon.Close()
+	got, err := NewClient(daemon.URL).BlobRead("0c9675ad7f40f29dcd41883ed9cf7e145bbb13976d9b83ab9354f4f61a87f0f7771a56724c2aa7a5ab43c68d7942e5cb", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Blobs) != 2 || got.Blobs[0].Length != 2097152 || got.Blobs[1].BlobHash != "" {
+		t.Errorf("unexpected sd blob %+v", got)
+	}
+}
+
+func TestClient_BlobMethods(t *testing.T) {
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		switch req.Method {
+		case "blob_get":
+			return "Downloaded blob " + req.Params["blob_hash"].(string), ""
+		case "blob_delete":
+			return "Deleted " + req.Params["blob_hash"].(string), ""
+		case "blob_announce":
+			return req.Params["sd_hash"] != nil, ""
+		case "blob_reflect":
+			return req.Params["blob_hashes"], ""
+		}
+		return nil, "unexpected method " + req.Method
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	hash := "0c9675ad7f40f29dcd41883ed9cf7e145bbb13976d9b83ab9354f4f61a87f0f7771a56724c2aa7a5ab43c68d7942e5cb"
+	if err := d.BlobGet(hash, nil); err != nil {
+		t.Error(err)
+	}
+	if err := d.BlobDelete(hash); err != nil {
+		t.Error(err)
+	}
+	announced, err := d.BlobAnnounce(nil, nil, &hash)
+	if err != nil {
+		t.Error(err)
+	} else if !announced {
+		t.Error("expected the blob to be announced")
+	}
+	if _, err := d.BlobAnnounce(&hash, nil, &hash); err == nil {
+		t.Error("expected an error with more than one hash")
+	}
+	reflected, err := d.BlobReflect([]string{hash}, nil)
+	if err != nil {
+		t.Error(err)
+	} else if len(reflected) != 1 || reflected[0] != hash {
+		t.Errorf("unexpected reflected blobs %v", reflected)
+	}
+}
+
+func TestClient_Sync(t *testing.T) {
+	encrypted := base64.StdEncoding.EncodeToString([]byte("encrypted wallet"))
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		switch req.Method {
+		case "sync_hash":
+			return "4f2d1c5ad2a1e2c0b7d36f1d8a7e0e1bf7b2a3c4d5e6f708192a3b4c5d6e7f80", ""
+		case "sync_apply":
+			if req.Params["password"] != "hunter2" {
+				return nil, "wrong password"
+			}
+			if req.Params["data"] != nil && req.Params["data"] != encrypted {
+				return nil, "unexpected data"
+			}
+			return map[string]interface{}{
+				"hash": "5a3e2d6be3b2f3d1c8e47a2e9b8f1f2ca8c3b4d5e6f708192a3b4c5d6e7f8091",
+				"data": encrypted,
+			}, ""
+		}
+		return nil, "unexpected method " + req.Method
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	hash, err := d.SyncHash(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hash) != 64 {
+		t.Errorf("unexpected hash %s", hash)
+	}
+
+	synced, err := d.SyncApply("hunter2", &encrypted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if synced.Data != encrypted || synced.Hash == hash {
+		t.Errorf("unexpected sync result %+v", synced)
+	}
+	if _, err := d.SyncApply("hunter2", nil, nil); err != nil {
+		t.Error(err)
+	}
+	if _, err := d.SyncApply("wrong", nil, nil); err == nil {
+		t.Error("expected an error for the wrong password")
+	}
+	notBase64 := "not base64!"
+	if _, err := d.SyncApply("hunter2", &notBase64, nil); err == nil {
+		t.Error("expected an error for data that is not base64")
+	}
+}
+
+func TestClient_Preferences(t *testing.T) {
+	stored := map[string]interface{}{}
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		switch req.Method {
+		case "preference_set":
+			key, value := req.Params["key"].(string), req.Params["value"].(string)
+			var decoded interface{} = value
+			if value != "" && (value[0] == '{' || value[0] == '[') {
+				if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+					return nil, err.Error()
+				}
+			}
+			stored[key] = decoded
+			return map[string]interface{}{key: decoded}, ""
+		case "preference_get":
+			if key, ok := req.Params["key"].(string); ok {
+				return map[string]interface{}{key: stored[key]}, ""
+			}
+			return stored, ""
+		}
+		return nil, "unexpected method " + req.Method
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	type shared struct {
+		Subscriptions []string `json:"subscriptions"`
+		Tags          []string `json:"tags"`
+	}
+	if _, err := d.PreferenceSet("shared", shared{Subscriptions: []string{"lbry://@lbry#3f"}, Tags: []string{"science"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.PreferenceSet("theme", "dark", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	prefs, err := d.PreferenceGet(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s shared
+	if found, err := prefs.Get("shared", &s); err != nil || !found {
+		t.Fatalf("expected the shared preference, got %v %v", found, err)
+	}
+	if len(s.Subscriptions) != 1 || s.Subscriptions[0] != "lbry://@lbry#3f" || s.Tags[0] != "science" {
+		t.Errorf("unexpected shared preference %+v", s)
+	}
+	var theme string
+	if _, err := prefs.Get("theme", &theme); err != nil || theme != "dark" {
+		t.Errorf("unexpected theme %q %v", theme, err)
+	}
+	if found, _ := prefs.Get("missing", &theme); found {
+		t.Error("expected a missing preference not to be found")
+	}
+
+	key := "theme"
+	prefs, err = d.PreferenceGet(&key, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prefs) != 1 || prefs["theme"] != "dark" {
+		t.Errorf("unexpected preferences %v", prefs)
+	}
+}
+
+func TestClient_ClaimSearchOptions(t *testing.T) {
+	params := make(chan map[string]interface{}, 1)
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		params <- req.Params
+		return map[string]interface{}{"items": []interface{}{}, "page": 1, "page_size": 20}, ""
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	claimType := ClaimTypeStream
+	_, err := d.ClaimSearch(ClaimSearchOptions{
+		ClaimType:   &claimType,
+		StreamTypes: []streamType{StreamTypeVideo},
+		AnyTags:     []string{"science", "history"},
+		NotTags:     []string{"mature"},
+		ChannelIDs:  []string{"3f"},
+		FeeAmount:   AtMost("2.5"),
+		Height:      GreaterThan(800000),
+		Reposted:    EqualTo(0),
+		OrderBy:     []string{"^release_time"},
+		HasSource:   true,
+	}, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := <-params
+	expected := map[string]interface{}{
+		"claim_type":       "stream",
+		"stream_types":     []interface{}{"video"},
+		"any_tags":         []interface{}{"science", "history"},
+		"not_tags":         []interface{}{"mature"},
+		"channel_ids":      []interface{}{"3f"},
+		"fee_amount":       "<=2.5",
+		"height":           ">800000",
+		"reposted":         "0",
+		"order_by":         []interface{}{"^release_time"},
+		"has_source":       true,
+		"include_protobuf": true,
+		"page":             float64(1),
+		"page_size":        float64(20),
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected params %v, got %v", expected, got)
+	}
+
+	invalid := []ClaimSearchOptions{
+		{HasSource: true, HasNoSource: true},
+		{ValidChannelSignature: true, InvalidChannelSignature: true},
+		{ClaimID: &claimType, ClaimIDs: []string{"3f"}},
+		{StreamTypes: []streamType{"hologram"}},
+		{FeeAmount: "=2.5"},
+		{FeeAmount: "=<2.5"},
+		{Height: ">tall"},
+	}
+	for _, options := range invalid {
+		if _, err := d.ClaimSearch(options, 1, 20); err == nil {
+			t.Errorf("expected %+v to be invalid", options)
+		}
+	}
+}
+
+func TestClient_AccountSend(t *testing.T) {
+	daemon := newTestDaemon(t, func(req testRequest) (interface{}, string) {
+		switch req.Method {
+		case "account_send":
+			outputs := []interface{}{}
+			for _, a := range req.Params["addresses"].([]interface{}) {
+				outputs = append(outputs, map[string]interface{}{"address": a, "amount": req.Params["amount"]})
+			}
+			return map[string]interface{}{
+				"height":       -2,
+				"txid":         "a3c4d6e1f2b3a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+				"outputs":      outputs,
+				"total_fee":    "0.000124",
+				"total_output": "2.0",
+			}, ""
+		case "account_max_address_gap":
+			return map[string]interface{}{"max_change_gap": 2, "max_receiving_gap": 27}, ""
+		}
+		return nil, "unexpected method " + req.Method
+	})
+	defer daemon.Close()
+	d := NewClient(daemon.URL)
+
+	sent, err := d.AccountSend("treasury", "1.0", []string{"bHmNm1zGxZ1Y5N8WdLXAaUkRdUDSGnqhqe", "bXr1oLLZmfKxzjDFsbxjNqBPB5MxxxN5cS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sent.Outputs) != 2 || sent.TotalFee != "0.000124" {
+		t.Errorf("unexpected transaction %+v", sent)
+	}
+	if _, err := d.AccountSend("treasury", "1.0", nil); err == nil {
+		t.Error("expected an error without addresses")
+	}
+
+	gap, err := d.AccountMaxAddressGap("treasury")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gap.MaxChangeGap != 2 || gap.MaxReceivingGap != 27 {
+		t.Errorf("unexpected address gap %+v", gap)
+	}
+}
@@ -0,0 +1,301 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/extras/stop"
+
+	"golang.org/x/net/websocket"
+)
+
+// EventType is an event the daemon publishes on its websocket, identified by the daemon module that sends it
+// and the event name
+type EventType struct {
+	Module string
+	Event  string
+}
+
+var (
+	// EventDownloadProgress carries a *File with the status of a download
+	EventDownloadProgress = EventType{Module: "file_manager", Event: "status"}
+	// EventBlobCompleted carries a *BlobCompletedEvent
+	EventBlobCompleted = EventType{Module: "blob_manager", Event: "blob_completed"}
+	// EventWalletSync carries a *WalletSyncEvent
+	EventWalletSync = EventType{Module: "wallet", Event: "sync"}
+)
+
+type BlobCompletedEvent struct {
+	BlobHash   string `json:"blob_hash"`
+	Length     int    `json:"length"`
+	StreamHash string `json:"stream_hash,omitempty"`
+}
+
+type WalletSyncEvent struct {
+	Height       int  `json:"height"`
+	BlocksBehind int  `json:"blocks_behind"`
+	IsSyncing    bool `json:"is_syncing"`
+}
+
+// Event is a notification from the daemon. Data holds the typed payload for the known event types, and a
+// map[string]interface{} for the others. If the payload could not be decoded, Err says why and Data holds it
+// undecoded, or holds the raw bytes if the message isn't an event at all.
+type Event struct {
+	Type EventType
+	Data interface{}
+	Err  error
+}
+
+type rawEvent struct {
+	Module  string      `json:"module"`
+	Event   string      `json:"event"`
+	Payload interface{} `json:"payload"`
+}
+
+func decodeEvent(raw rawEvent) (Event, error) {
+	e := Event{Type: EventType{Module: raw.Module, Event: raw.Event}, Data: raw.Payload}
+	var typed interface{}
+	switch e.Type {
+	case EventDownloadProgress:
+		typed = new(File)
+	case EventBlobCompleted:
+		typed = new(BlobCompletedEvent)
+	case EventWalletSync:
+		typed = new(WalletSyncEvent)
+	default:
+		return e, nil
+	}
+	if err := Decode(raw.Payload, typed); err != nil {
+		return e, errors.Prefix("decoding "+raw.Module+"/"+raw.Event+" event", err)
+	}
+	e.Data = typed
+	return e, nil
+}
+
+// Subscriber delivers the events the daemon publishes on its websocket
+type Subscriber struct {
+	conn   *websocket.Conn
+	events chan Event
+	grp    *stop.Group
+
+	mu  sync.Mutex
+	err error
+}
+
+// Subscribe connects to the daemon's websocket and subscribes to the given event types, or to every event
+// if none are given. The subscription ends when the context is done or Close is called.
+func (d *Client) Subscribe(ctx context.Context, types ...EventType) (*Subscriber, error) {
+	location, err := websocketURL(d.address)
+	if err != nil {
+		return nil, err
+	}
+	transport, _ := d.httpClient.Transport.(*http.Transport)
+	conn, err := dialWebsocket(ctx, location, d.headers, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := map[string][]string{}
+	for _, t := range types {
+		subscriptions[t.Module] = append(subscriptions[t.Module], t.Event)
+	}
+	if len(types) == 0 {
+		subscriptions["*"] = []string{"*"}
+	}
+	err = websocket.JSON.Send(conn, map[string]interface{}{"subscribe": subscriptions})
+	if err != nil {
+		conn.Close()
+		return nil, errors.Err(err)
+	}
+
+	s := &Subscriber{
+		conn:   conn,
+		events: make(chan Event),
+		grp:    stop.New(),
+	}
+
+	s.grp.Add(1)
+	go func() {
+		defer s.grp.Done()
+		s.read()
+	}()
+
+	s.grp.Add(1)
+	go func() {
+		defer s.grp.Done()
+		select {
+		case <-ctx.Done():
+			s.setErr(errors.Err(ctx.Err()))
+		case <-s.grp.Ch():
+		}
+		// closing the connection ends the read loop
+		s.conn.Close()
+	}()
+
+	return s, nil
+}
+
+// Events returns the channel that events are delivered on. It is closed when the subscription ends.
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns the reason the subscription ended, or nil if it is still running or was closed
+func (s *Subscriber) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close ends the subscription and waits for it to shut down
+func (s *Subscriber) Close() {
+	s.grp.StopAndWait()
+}
+
+func (s *Subscriber) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *Subscriber) read() {
+	defer close(s.events)
+	defer s.grp.Stop()
+
+	for {
+		var msg []byte
+		err := websocket.Message.Receive(s.conn, &msg)
+		if err != nil {
+			select {
+			case <-s.grp.Ch():
+			default:
+				s.setErr(errors.Err(err))
+			}
+			return
+		}
+
+		// numbers are kept as json.Number, which is what Decode expects from the rpc client
+		// a message that can't be decoded is delivered with its error. only losing the connection ends the
+		// subscription.
+		var e Event
+		var raw rawEvent
+		decoder := json.NewDecoder(bytes.NewReader(msg))
+		decoder.UseNumber()
+		if err := decoder.Decode(&raw); err != nil {
+			e = Event{Data: msg, Err: errors.Prefix("decoding event", err)}
+		} else if e, err = decodeEvent(raw); err != nil {
+			e.Err = err
+		}
+
+		select {
+		case s.events <- e:
+		case <-s.grp.Ch():
+			return
+		}
+	}
+}
+
+// websocketURL returns the websocket endpoint of the daemon at the given api address
+func websocketURL(address string) (*url.URL, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return nil, errors.Err("unsupported daemon address %s", address)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/ws"
+	return u, nil
+}
+
+// dialWebsocket connects to the websocket at location, using the transport's dialer and TLS config if it is
+// not nil
+func dialWebsocket(ctx context.Context, location *url.URL, headers map[string]string, transport *http.Transport) (*websocket.Conn, error) {
+	origin := &url.URL{Scheme: "http", Host: location.Host}
+	config, err := websocket.NewConfig(location.String(), origin.String())
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	for k, v := range headers {
+		config.Header.Set(k, v)
+	}
+
+	host := location.Host
+	if location.Port() == "" {
+		if location.Scheme == "wss" {
+			host = net.JoinHostPort(location.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(location.Hostname(), "80")
+		}
+	}
+
+	dial := (&net.Dialer{}).DialContext
+	var tlsConfig *tls.Config
+	if transport != nil {
+		if transport.DialContext != nil {
+			dial = transport.DialContext
+		}
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = location.Hostname()
+	}
+
+	tcpConn, err := dial(ctx, "tcp", host)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	conn := tcpConn
+
+	// the handshakes aren't context aware, so close the connection if the context is done before they finish
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			tcpConn.Close()
+		case <-done:
+		}
+	}()
+	fail := func(err error) (*websocket.Conn, error) {
+		tcpConn.Close()
+		if ctx.Err() != nil {
+			return nil, errors.Err(ctx.Err())
+		}
+		return nil, errors.Err(err)
+	}
+
+	if location.Scheme == "wss" {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return fail(err)
+		}
+		conn = tlsConn
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		return fail(err)
+	}
+	return ws, nil
+}
@@ -0,0 +1,206 @@
+package jsonrpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"golang.org/x/net/websocket"
+)
+
+// newEventDaemon starts a fake daemon websocket that sends the events to every subscriber, then calls hold
+// before closing the connection
+func newEventDaemon(t *testing.T, events []string, hold func()) (*httptest.Server, <-chan map[string][]string) {
+	subscriptions := make(chan map[string][]string, 1)
+	mux := http.NewServeMux()
+	mux.Handle("/ws", websocket.Handler(func(ws *websocket.Conn) {
+		var msg struct {
+			Subscribe map[string][]string `json:"subscribe"`
+		}
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			t.Error(err)
+			return
+		}
+		subscriptions <- msg.Subscribe
+		for _, e := range events {
+			if err := websocket.Message.Send(ws, e); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		hold()
+	}))
+	return httptest.NewServer(mux), subscriptions
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	release := make(chan struct{})
+	daemon, subscriptions := newEventDaemon(t, []string{
+		`{"module": "file_manager", "event": "status", "payload": {"claim_name": "video", "blobs_completed": 2, "blobs_in_stream": 4, "points_paid": 0.5}}`,
+		`{"module": "blob_manager", "event": "blob_completed", "payload": {"blob_hash": "abcd", "length": 2097152}}`,
+		`{"module": "wallet", "event": "sync", "payload": {"height": 812345, "blocks_behind": 3, "is_syncing": true}}`,
+		`{"module": "dht", "event": "peers", "payload": {"count": 87}}`,
+		`{"module": "wallet", "event": "sync", "payload": {"height": "tall"}}`,
+		`not json`,
+		`{"module": "blob_manager", "event": "blob_completed", "payload": {"blob_hash": "ef01", "length": 12}}`,
+	}, func() { <-release })
+	defer daemon.Close()
+	defer close(release)
+
+	s, err := NewClient(daemon.URL).Subscribe(context.Background(), EventDownloadProgress, EventBlobCompleted, EventWalletSync)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	subscribed := <-subscriptions
+	if len(subscribed["file_manager"]) != 1 || subscribed["file_manager"][0] != "status" || len(subscribed) != 3 {
+		t.Errorf("unexpected subscriptions %v", subscribed)
+	}
+
+	var events []Event
+	for len(events) < 7 {
+		select {
+		case e := <-s.Events():
+			events = append(events, e)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	f, ok := events[0].Data.(*File)
+	if !ok || f.ClaimName != "video" || f.BlobsCompleted != 2 || f.PointsPaid.String() != "0.5" {
+		t.Errorf("unexpected download progress %+v", events[0].Data)
+	}
+	if b, ok := events[1].Data.(*BlobCompletedEvent); !ok || b.BlobHash != "abcd" || b.Length != 2097152 {
+		t.Errorf("unexpected blob event %+v", events[1].Data)
+	}
+	if w, ok := events[2].Data.(*WalletSyncEvent); !ok || w.BlocksBehind != 3 || !w.IsSyncing {
+		t.Errorf("unexpected wallet event %+v", events[2].Data)
+	}
+	if events[3].Type != (EventType{Module: "dht", Event: "peers"}) {
+		t.Errorf("unexpected event type %+v", events[3].Type)
+	}
+	if m, ok := events[3].Data.(map[string]interface{}); !ok || m["count"] == nil {
+		t.Errorf("unexpected untyped event %+v", events[3].Data)
+	}
+	for i, e := range events[:4] {
+		if e.Err != nil {
+			t.Errorf("event %d: unexpected error %v", i, e.Err)
+		}
+	}
+
+	// events that can't be decoded are delivered with their error, and later events still arrive
+	if m, ok := events[4].Data.(map[string]interface{}); events[4].Type != EventWalletSync || !ok || m["height"] == nil || events[4].Err == nil {
+		t.Errorf("expected a wallet event that failed to decode, got %+v", events[4])
+	}
+	if b, ok := events[5].Data.([]byte); !ok || string(b) != "not json" || events[5].Err == nil {
+		t.Errorf("expected the raw message with an error, got %+v", events[5])
+	}
+	if b, ok := events[6].Data.(*BlobCompletedEvent); !ok || b.BlobHash != "ef01" || events[6].Err != nil {
+		t.Errorf("unexpected event after the bad ones %+v", events[6])
+	}
+
+	s.Close()
+	if _, open := <-s.Events(); open {
+		t.Error("events channel should be closed")
+	}
+	if s.Err() != nil {
+		t.Errorf("closing should not set an error, got %v", s.Err())
+	}
+}
+
+func TestClient_SubscribeContext(t *testing.T) {
+	release := make(chan struct{})
+	daemon, _ := newEventDaemon(t, nil, func() { <-release })
+	defer daemon.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := NewClient(daemon.URL).Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	cancel()
+	select {
+	case _, open := <-s.Events():
+		if open {
+			t.Error("expected no events")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription was not canceled")
+	}
+	if !errors.Is(s.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", s.Err())
+	}
+}
+
+func TestClient_SubscribeDisconnect(t *testing.T) {
+	daemon, _ := newEventDaemon(t, nil, func() {})
+	defer daemon.Close()
+
+	s, err := NewClient(daemon.URL).Subscribe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	select {
+	case <-s.Events():
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not end")
+	}
+	if s.Err() == nil {
+		t.Error("expected an error when the daemon disconnects")
+	}
+}
+
+func TestWebsocketURL(t *testing.T) {
+	tests := map[string]string{
+		"http://localhost:5279":       "ws://localhost:5279/ws",
+		"https://api.lbry.tv/api/v1/": "wss://api.lbry.tv/api/v1/ws",
+	}
+	for address, expected := range tests {
+		u, err := websocketURL(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.String() != expected {
+			t.Errorf("%s: expected %s, got %s", address, expected, u)
+		}
+	}
+	if _, err := websocketURL("localhost:5279"); err == nil {
+		t.Error("expected an error for an address without a scheme")
+	}
+}
+
+func TestClient_SubscribeTransport(t *testing.T) {
+	release := make(chan struct{})
+	daemon, _ := newEventDaemon(t, nil, func() { <-release })
+	defer daemon.Close()
+	defer close(release)
+
+	var dials int32
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			atomic.AddInt32(&dials, 1)
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+	s, err := NewClientWithHTTPClient(daemon.URL, &http.Client{Transport: transport}).Subscribe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if atomic.LoadInt32(&dials) != 1 {
+		t.Errorf("expected the websocket to be dialed with the client's transport")
+	}
+}
@@ -0,0 +1,266 @@
+// Package mock implements a fake lbrynet daemon for testing code that talks to the daemon through the jsonrpc
+// client, e.g.
+//
+//	daemon := mock.NewServer()
+//	defer daemon.Close()
+//	daemon.Respond("status", map[string]interface{}{"is_running": true})
+//
+//	c := jsonrpc.NewClient(daemon.URL)
+//	...
+//	daemon.AssertCalled(t, "claim_search", map[string]interface{}{"channel_ids": []string{"3f"}})
+//
+// Methods without a response answer with an error, and are reported by AssertNoUnexpected.
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// ErrorCode is the code the daemon sends with errors
+const ErrorCode = -32500
+
+// Request is a request the server received
+type Request struct {
+	Method string
+	Params map[string]interface{}
+}
+
+// Handler answers a request with a result, or with an error if err is not nil. Results are encoded to JSON.
+type Handler func(req Request) (result interface{}, err error)
+
+// Error is an error a Handler can return to control the error the daemon sends
+type Error struct {
+	Code    int
+	Message string
+	Data    interface{}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Server is a fake daemon. It is safe to set responses while requests are being served.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	handlers   map[string]Handler
+	requests   []Request
+	unexpected []Request
+}
+
+// NewServer starts a fake daemon. Its address is in the URL field.
+func NewServer() *Server {
+	s := &Server{handlers: map[string]Handler{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Handle sets the handler for a method, replacing any earlier response
+func (s *Server) Handle(method string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = h
+}
+
+// Respond answers every call to the method with the result
+func (s *Server) Respond(method string, result interface{}) {
+	s.Handle(method, func(Request) (interface{}, error) { return result, nil })
+}
+
+// RespondJSON answers every call to the method with the result encoded in data
+func (s *Server) RespondJSON(method string, data string) {
+	s.Respond(method, json.RawMessage(data))
+}
+
+// RespondFile answers every call to the method with the JSON result in the file, e.g. a response recorded
+// from a real daemon
+func (s *Server) RespondFile(method string, path string) error {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("%s does not contain valid json", path)
+	}
+	s.RespondJSON(method, string(data))
+	return nil
+}
+
+// RespondError answers every call to the method with an error
+func (s *Server) RespondError(method string, message string) {
+	s.Handle(method, func(Request) (interface{}, error) { return nil, &Error{Code: ErrorCode, Message: message} })
+}
+
+// Requests returns every request the server received, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Calls returns the requests for a method, in order
+func (s *Server) Calls(method string) []Request {
+	var calls []Request
+	for _, r := range s.Requests() {
+		if r.Method == method {
+			calls = append(calls, r)
+		}
+	}
+	return calls
+}
+
+// AssertCalled fails the test unless the method was called with at least the given params. Params are
+// compared by their JSON encoding, so e.g. []string and []interface{} with the same strings match.
+func (s *Server) AssertCalled(t testing.TB, method string, params map[string]interface{}) {
+	t.Helper()
+	calls := s.Calls(method)
+	if len(calls) == 0 {
+		t.Errorf("mock: %s was not called", method)
+		return
+	}
+	for _, c := range calls {
+		if containsParams(c.Params, params) {
+			return
+		}
+	}
+	t.Errorf("mock: %s was not called with %v, calls were %v", method, params, calls)
+}
+
+// AssertNotCalled fails the test if the method was called
+func (s *Server) AssertNotCalled(t testing.TB, method string) {
+	t.Helper()
+	if calls := s.Calls(method); len(calls) > 0 {
+		t.Errorf("mock: %s was called %d times", method, len(calls))
+	}
+}
+
+// AssertNoUnexpected fails the test if the server received requests for methods it had no response for
+func (s *Server) AssertNoUnexpected(t testing.TB) {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.unexpected {
+		t.Errorf("mock: unexpected call to %s with %v", r.Method, r.Params)
+	}
+}
+
+type rpcRequest struct {
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+	ID     interface{}            `json:"id"`
+}
+
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   *rpcError   `json:"error,omitempty"`
+	ID      interface{} `json:"id"`
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response interface{}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var batch []rpcRequest
+		if err := decode(body, &batch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		responses := make([]rpcResponse, len(batch))
+		for i, req := range batch {
+			responses[i] = s.answer(req)
+		}
+		response = responses
+	} else {
+		var req rpcRequest
+		if err := decode(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response = s.answer(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) answer(r rpcRequest) rpcResponse {
+	req := Request{Method: r.Method, Params: r.Params}
+	if req.Params == nil {
+		req.Params = map[string]interface{}{}
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	h, ok := s.handlers[req.Method]
+	if !ok {
+		s.unexpected = append(s.unexpected, req)
+	}
+	s.mu.Unlock()
+
+	response := rpcResponse{JSONRPC: "2.0", ID: r.ID}
+	if !ok {
+		response.Error = &rpcError{Code: ErrorCode, Message: "mock: no response for " + req.Method}
+		return response
+	}
+
+	result, err := h(req)
+	if err != nil {
+		e, ok := err.(*Error)
+		if !ok {
+			e = &Error{Code: ErrorCode, Message: err.Error()}
+		}
+		response.Error = &rpcError{Code: e.Code, Message: e.Message, Data: e.Data}
+		return response
+	}
+	if result == nil {
+		// a null result would be dropped by omitempty
+		result = json.RawMessage("null")
+	}
+	response.Result = result
+	return response
+}
+
+// decode decodes the request keeping numbers as json.Number, so they compare as the client sent them
+func decode(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func containsParams(got, want map[string]interface{}) bool {
+	for k, w := range want {
+		g, ok := got[k]
+		if !ok {
+			return false
+		}
+		gotJSON, err1 := json.Marshal(g)
+		wantJSON, err2 := json.Marshal(w)
+		if err1 != nil || err2 != nil || !bytes.Equal(gotJSON, wantJSON) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package mock_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/jsonrpc"
+	"github.com/lbryio/lbry.go/v2/extras/jsonrpc/mock"
+)
+
+func TestServer(t *testing.T) {
+	daemon := mock.NewServer()
+	defer daemon.Close()
+	daemon.Respond("version", map[string]interface{}{"lbrynet_version": "0.113.0"})
+	daemon.RespondJSON("address_is_mine", "true")
+	daemon.RespondError("wallet_send", "Not enough funds to cover this transaction.")
+	if err := daemon.RespondFile("blob_list", "../testdata/blob_list.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	d := jsonrpc.NewClient(daemon.URL)
+	version, err := d.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.LbrynetVersion != "0.113.0" {
+		t.Errorf("unexpected version %+v", version)
+	}
+	if mine, err := d.AddressIsMine("bHmNm1zGxZ1Y5N8WdLXAaUkRdUDSGnqhqe", nil); err != nil || !mine {
+		t.Errorf("expected the address to be mine, got %v %v", mine, err)
+	}
+	if _, err := d.WalletSend("1.0", []string{"bHmNm1zGxZ1Y5N8WdLXAaUkRdUDSGnqhqe"}, nil, nil); err == nil || !strings.Contains(err.Error(), "Not enough funds") {
+		t.Errorf("expected the daemon's error, got %v", err)
+	}
+	blobs, err := d.BlobList(jsonrpc.BlobListFilter{}, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs.Items) != 2 {
+		t.Errorf("unexpected blobs %+v", blobs)
+	}
+
+	daemon.AssertCalled(t, "wallet_send", map[string]interface{}{
+		"amount":    "1.0",
+		"addresses": []string{"bHmNm1zGxZ1Y5N8WdLXAaUkRdUDSGnqhqe"},
+	})
+	daemon.AssertCalled(t, "blob_list", map[string]interface{}{"page": 1, "page_size": 20})
+	daemon.AssertNotCalled(t, "status")
+	daemon.AssertNoUnexpected(t)
+	if n := len(daemon.Requests()); n != 4 {
+		t.Errorf("expected 4 requests, got %d", n)
+	}
+}
+
+func TestServer_Handle(t *testing.T) {
+	daemon := mock.NewServer()
+	defer daemon.Close()
+	daemon.Handle("resolve", func(req mock.Request) (interface{}, error) {
+		url := req.Params["urls"].(string)
+		if url == "lbry://missing" {
+			return nil, errors.New("could not find claim")
+		}
+		return map[string]interface{}{url: map[string]interface{}{"name": strings.TrimPrefix(url, "lbry://")}}, nil
+	})
+
+	d := jsonrpc.NewClient(daemon.URL)
+	resolved, err := d.Resolve("lbry://video")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (*resolved)["lbry://video"].Name != "video" {
+		t.Errorf("unexpected resolve %+v", resolved)
+	}
+	if _, err := d.Resolve("lbry://missing"); err == nil {
+		t.Error("expected an error")
+	}
+	if n := len(daemon.Calls("resolve")); n != 2 {
+		t.Errorf("expected 2 resolves, got %d", n)
+	}
+
+	// methods without a response fail, and are recorded
+	if _, err := d.Status(); err == nil {
+		t.Error("expected an error for a method without a response")
+	}
+	ft := &fakeT{TB: t}
+	daemon.AssertNoUnexpected(ft)
+	daemon.AssertCalled(ft, "resolve", map[string]interface{}{"urls": "lbry://other"})
+	if ft.errors != 2 {
+		t.Errorf("expected 2 failed assertions, got %d", ft.errors)
+	}
+}
+
+func TestServer_Batch(t *testing.T) {
+	daemon := mock.NewServer()
+	defer daemon.Close()
+	daemon.Respond("version", map[string]interface{}{"lbrynet_version": "0.113.0"})
+	daemon.Respond("address_is_mine", true)
+
+	b := jsonrpc.NewClient(daemon.URL).NewBatch()
+	version := new(jsonrpc.VersionResponse)
+	versionCall := b.Add(version, "version", nil)
+	var mine bool
+	mineCall := b.Add(&mine, "address_is_mine", map[string]interface{}{"address": "bHmNm1zGxZ1Y5N8WdLXAaUkRdUDSGnqhqe"})
+	if err := b.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if versionCall.Err != nil || mineCall.Err != nil || version.LbrynetVersion != "0.113.0" || !mine {
+		t.Errorf("unexpected batch results %+v %+v", versionCall, mineCall)
+	}
+}
+
+// fakeT counts failed assertions instead of failing the test
+type fakeT struct {
+	testing.TB
+	errors int
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors++
+}
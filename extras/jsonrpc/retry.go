@@ -0,0 +1,64 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// RetryPolicy says when a failed daemon call is tried again. Calls are retried when the daemon can't be
+// reached, when it answers with a 5xx status, and when it is still starting up. Calls that the daemon
+// answered with any other error are not retried.
+//
+// A call whose connection dropped after the daemon received it is retried too, so calls that publish or
+// send credits may happen twice.
+type RetryPolicy struct {
+	MaxAttempts    int           // total number of attempts, including the first. 0 or 1 means no retries
+	InitialBackoff time.Duration // wait before the first retry
+	MaxBackoff     time.Duration // longest wait between attempts, or 0 for no limit
+	Multiplier     float64       // how much the wait grows after each retry. 0 means 2
+}
+
+// DefaultRetryPolicy rides out a daemon restart
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+}
+
+// SetRetryPolicy sets when failed calls are retried. Clients don't retry calls unless a policy is set.
+func (d *Client) SetRetryPolicy(policy RetryPolicy) {
+	d.retry = policy
+}
+
+// backoff returns how long to wait after the attempt-th attempt failed
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(backoff)
+}
+
+func isRetryable(r *jsonrpc.RPCResponse, err error) bool {
+	if err != nil {
+		if httpErr, ok := err.(*jsonrpc.HTTPError); ok {
+			return httpErr.Code >= http.StatusInternalServerError
+		}
+		// the jsonrpc client only says the response was not json in the message. everything else is a
+		// failure to connect or to read the response.
+		return !strings.Contains(err.Error(), "could not decode body")
+	}
+
+	return newDaemonError("", r.Error).Is(ErrDaemonStarting)
+}
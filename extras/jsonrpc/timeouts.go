@@ -0,0 +1,81 @@
+package jsonrpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// DefaultMethodTimeouts are the timeouts new clients use for methods that are reliably fast or known to be
+// slow, as long as the client has no timeout of its own from its http client or SetRPCTimeout. Other methods
+// don't time out then.
+var DefaultMethodTimeouts = map[string]time.Duration{
+	"status":       10 * time.Second,
+	"version":      10 * time.Second,
+	"resolve":      time.Minute,
+	"claim_search": time.Minute,
+
+	"get":            5 * time.Minute,
+	"publish":        10 * time.Minute,
+	"stream_create":  10 * time.Minute,
+	"stream_update":  10 * time.Minute,
+	"channel_create": 5 * time.Minute,
+	"channel_update": 5 * time.Minute,
+	"sync_apply":     5 * time.Minute,
+	"txo_spend":      5 * time.Minute,
+}
+
+// methodTimeouts are a client's timeouts for particular methods. Copies made by WithContext share them.
+type methodTimeouts struct {
+	mu       sync.RWMutex
+	set      map[string]time.Duration // set with SetMethodTimeout
+	defaults map[string]time.Duration // DefaultMethodTimeouts when the client was made
+}
+
+func newMethodTimeouts() *methodTimeouts {
+	t := &methodTimeouts{
+		set:      make(map[string]time.Duration),
+		defaults: make(map[string]time.Duration, len(DefaultMethodTimeouts)),
+	}
+	for method, timeout := range DefaultMethodTimeouts {
+		t.defaults[method] = timeout
+	}
+	return t
+}
+
+// SetMethodTimeout sets the timeout for calls to a method, overriding the client's timeout. A timeout of 0
+// means calls to the method never time out. Copies made by WithContext share method timeouts.
+func (d *Client) SetMethodTimeout(method string, timeout time.Duration) {
+	if d.timeouts == nil {
+		d.timeouts = newMethodTimeouts()
+	}
+	d.timeouts.mu.Lock()
+	defer d.timeouts.mu.Unlock()
+	d.timeouts.set[method] = timeout
+}
+
+// MethodTimeout returns the timeout for calls to a method, or 0 if they don't time out
+func (d *Client) MethodTimeout(method string) time.Duration {
+	if d.timeouts == nil {
+		return d.timeout
+	}
+	d.timeouts.mu.RLock()
+	defer d.timeouts.mu.RUnlock()
+	if timeout, ok := d.timeouts.set[method]; ok {
+		return timeout
+	}
+	if d.timeout != 0 {
+		return d.timeout
+	}
+	return d.timeouts.defaults[method]
+}
+
+// connFor returns a connection whose calls time out after timeout. Connections share the client's transport,
+// so they are cheap to make.
+func (d *Client) connFor(timeout time.Duration) jsonrpc.RPCClient {
+	if timeout == d.timeout {
+		return d.conn
+	}
+	return d.newConnWithTimeout(timeout)
+}
@@ -0,0 +1,65 @@
+package null
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// The types' binary form, which encoding/gob uses, is a byte saying whether the value is valid, followed by the
+// value if it is. Unlike their text form, it keeps blank values apart from null.
+const (
+	binaryNull  byte = 0
+	binaryValid byte = 1
+)
+
+// marshalBinary prefixes a value's binary form with whether it is valid
+func marshalBinary(valid bool, value []byte) []byte {
+	if !valid {
+		return []byte{binaryNull}
+	}
+	return append([]byte{binaryValid}, value...)
+}
+
+// marshalBinaryUint64 is marshalBinary for an integer, which is written as 8 bytes
+func marshalBinaryUint64(valid bool, v uint64) []byte {
+	var value [8]byte
+	binary.BigEndian.PutUint64(value[:], v)
+	return marshalBinary(valid, value[:])
+}
+
+// unmarshalBinary splits data into whether it is valid and the value's binary form. If size isn't negative, the
+// value must be that long.
+func unmarshalBinary(data []byte, typ string, size int) ([]byte, bool, error) {
+	if len(data) == 0 {
+		return nil, false, fmt.Errorf("null: cannot unmarshal empty data into null.%s", typ)
+	}
+	switch data[0] {
+	case binaryNull:
+		if len(data) > 1 {
+			return nil, false, fmt.Errorf("null: cannot unmarshal null with a value into null.%s", typ)
+		}
+		return nil, false, nil
+	case binaryValid:
+		value := data[1:]
+		if size >= 0 && len(value) != size {
+			return nil, false, fmt.Errorf("null: cannot unmarshal %d bytes into null.%s", len(value), typ)
+		}
+		return value, true, nil
+	default:
+		return nil, false, fmt.Errorf("null: invalid binary data for null.%s", typ)
+	}
+}
+
+// unmarshalBinaryUint64 is unmarshalBinary for an integer written by marshalBinaryUint64
+func unmarshalBinaryUint64(data []byte, typ string) (uint64, bool, error) {
+	value, valid, err := unmarshalBinary(data, typ, 8)
+	if err != nil || !valid {
+		return 0, false, err
+	}
+	return binary.BigEndian.Uint64(value), true, nil
+}
+
+// binaryOverflow is the error for an integer that doesn't fit in the type it is unmarshalled into
+func binaryOverflow(v interface{}, typ string) error {
+	return fmt.Errorf("null: %v overflows null.%s", v, typ)
+}
@@ -0,0 +1,129 @@
+package null
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type gobValues struct {
+	Bool       Bool
+	Byte       Byte
+	Bytes      Bytes
+	Decimal    Decimal
+	Duration   Duration
+	Float32    Float32
+	Float64    Float64
+	Int        Int
+	Int8       Int8
+	Int16      Int16
+	Int32      Int32
+	Int64      Int64
+	JSON       JSON
+	RawMessage RawMessage
+	String     String
+	Time       Time
+	Uint       Uint
+	Uint8      Uint8
+	Uint16     Uint16
+	Uint32     Uint32
+	Uint64     Uint64
+	UUID       UUID
+}
+
+func gobRoundTrip(in, out interface{}) {
+	var buf bytes.Buffer
+	maybePanic(gob.NewEncoder(&buf).Encode(in))
+	maybePanic(gob.NewDecoder(&buf).Decode(out))
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	dec, _ := DecimalFromString("12345678901234567890.12345678")
+	id, _ := UUIDFromString(uuidString)
+	values := gobValues{
+		Bool:       BoolFrom(false),
+		Byte:       ByteFrom('b'),
+		Bytes:      BytesFrom([]byte{}),
+		Decimal:    dec,
+		Duration:   DurationFrom(-5 * time.Minute),
+		Float32:    Float32From(1.5),
+		Float64:    Float64From(-1.2345),
+		Int:        IntFrom(-12),
+		Int8:       Int8From(-8),
+		Int16:      Int16From(-16),
+		Int32:      Int32From(-32),
+		Int64:      Int64From(-64),
+		JSON:       JSONFrom([]byte{}),
+		RawMessage: RawMessageFrom([]byte(`null`)),
+		String:     StringFrom(""),
+		Time:       TimeFrom(time.Time{}),
+		Uint:       UintFrom(12),
+		Uint8:      Uint8From(8),
+		Uint16:     Uint16From(16),
+		Uint32:     Uint32From(32),
+		Uint64:     Uint64From(1<<64 - 1),
+		UUID:       id,
+	}
+
+	var decoded gobValues
+	gobRoundTrip(values, &decoded)
+	if !decoded.Decimal.Decimal.Equal(values.Decimal.Decimal) {
+		t.Errorf("bad decimal: %s ≠ %s", decoded.Decimal.Decimal, values.Decimal.Decimal)
+	}
+	decoded.Decimal = values.Decimal
+	if !reflect.DeepEqual(decoded, values) {
+		t.Errorf("expected %#v to round trip, got %#v", values, decoded)
+	}
+
+	// gob leaves out zero fields, so nulls are only written on their own
+	v := reflect.ValueOf(&values).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		in := reflect.Zero(v.Field(i).Type()).Interface()
+		out := v.Field(i).Addr().Interface()
+		gobRoundTrip(in, out)
+		if !out.(Nullable).IsNull() {
+			t.Errorf("expected %s to be null", v.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	var s String
+	bad := [][]byte{nil, {2}, {0, 'a'}}
+	for _, data := range bad {
+		if err := s.UnmarshalBinary(data); err == nil {
+			t.Errorf("expected an error for %v", data)
+		}
+	}
+
+	var i Int
+	if err := i.UnmarshalBinary([]byte{1, 0, 0, 12}); err == nil {
+		t.Error("expected an error for a short int")
+	}
+
+	data, err := IntFrom(300).MarshalBinary()
+	maybePanic(err)
+	var i8 Int8
+	if err = i8.UnmarshalBinary(data); err == nil {
+		t.Error("expected an error for an int8 overflow")
+	}
+	data, err = Int64From(-1).MarshalBinary()
+	maybePanic(err)
+	var u Uint64
+	err = u.UnmarshalBinary(data)
+	maybePanic(err)
+	if !u.Valid || u.Uint64 != 1<<64-1 {
+		t.Errorf("expected uint64 to hold the same bits, got %d", u.Uint64)
+	}
+	var u32 Uint32
+	if err = u32.UnmarshalBinary(data); err == nil {
+		t.Error("expected an error for a uint32 overflow")
+	}
+
+	var id UUID
+	if err = id.UnmarshalBinary([]byte{1, 2, 3}); err == nil {
+		t.Error("expected an error for a short uuid")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strconv"
 
 	"gopkg.in/nullbio/null.v6/convert"
 )
@@ -36,6 +37,11 @@ func BoolFromPtr(b *bool) Bool {
 	return NewBool(*b, true)
 }
 
+// BoolFromNonZero creates a new Bool that will be null if v is false.
+func BoolFromNonZero(v bool) Bool {
+	return NewBool(v, v)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (b *Bool) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -45,6 +51,7 @@ func (b *Bool) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &b.Bool); err != nil {
+		b.Valid = false
 		return err
 	}
 
@@ -56,7 +63,7 @@ func (b *Bool) UnmarshalJSON(data []byte) error {
 func (b *Bool) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		b.Valid = false
-		return nil
+		return blankText("Bool")
 	}
 
 	str := string(text)
@@ -101,6 +108,11 @@ func (b *Bool) SetValid(v bool) {
 	b.Valid = true
 }
 
+// SetNull sets this Bool to null, clearing its value.
+func (b *Bool) SetNull() {
+	b.Bool, b.Valid = false, false
+}
+
 // Ptr returns a pointer to this Bool's value, or a nil pointer if this Bool is null.
 func (b Bool) Ptr() *bool {
 	if !b.Valid {
@@ -109,19 +121,78 @@ func (b Bool) Ptr() *bool {
 	return &b.Bool
 }
 
-// IsNull returns true for invalid Bools, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Bool's value, or false if it is null.
+func (b Bool) ValueOrZero() bool {
+	if !b.Valid {
+		return false
+	}
+	return b.Bool
+}
+
+// ValueOr returns this Bool's value, or def if it is null.
+func (b Bool) ValueOr(def bool) bool {
+	if !b.Valid {
+		return def
+	}
+	return b.Bool
+}
+
+// IsNull returns true for invalid Bools.
 func (b Bool) IsNull() bool {
 	return !b.Valid
 }
 
+// IsZero returns true for null Bools, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid false is not zero.
+func (b Bool) IsZero() bool {
+	return !b.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (b Bool) isZeroValue() bool {
+	return !b.Bool
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Bool is null.
+func (b Bool) String() string {
+	if !b.Valid {
+		return ""
+	}
+	text, _ := b.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// It accepts the values strconv.ParseBool does, like the flag package's bool flags.
+func (b *Bool) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	b.SetValid(v)
+	return nil
+}
+
+// IsBoolFlag lets a Bool flag be passed without a value, like the flag package's bool flags.
+func (b *Bool) IsBoolFlag() bool {
+	return true
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (b *Bool) Type() string {
+	return "bool"
+}
+
 // Scan implements the Scanner interface.
 func (b *Bool) Scan(value interface{}) error {
 	if value == nil {
 		b.Bool, b.Valid = false, false
 		return nil
 	}
-	b.Valid = true
-	return convert.ConvertAssign(&b.Bool, value)
+	err := convert.ConvertAssign(&b.Bool, value)
+	b.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -131,3 +202,41 @@ func (b Bool) Value() (driver.Value, error) {
 	}
 	return b.Bool, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (b Bool) MarshalBinary() ([]byte, error) {
+	var v byte
+	if b.Bool {
+		v = 1
+	}
+	return marshalBinary(b.Valid, []byte{v}), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Bool) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "Bool", 1)
+	if err != nil {
+		return err
+	}
+	b.Bool, b.Valid = valid && v[0] != 0, valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Bool is null.
+func (b Bool) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.Bool, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *Bool) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *bool
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*b = BoolFromPtr(v)
+	return nil
+}
@@ -0,0 +1,487 @@
+//go:build bson
+// +build bson
+
+package null
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The types in this file implement bson.ValueMarshaler and bson.ValueUnmarshaler, so they can be stored in MongoDB
+// without registering codecs. Null values are stored as BSON null. They are only built with the bson build tag,
+// so the package doesn't depend on the mongo driver otherwise.
+
+// bsonBinaryUUID is the binary subtype for UUIDs, which newer drivers name bsontype.BinaryUUID
+const bsonBinaryUUID byte = 0x04
+
+// marshalBSONValue encodes v with the default codecs, or BSON null if it is not valid
+func marshalBSONValue(valid bool, v interface{}) (bsontype.Type, []byte, error) {
+	if !valid {
+		return bsontype.Null, nil, nil
+	}
+	return bson.MarshalValue(v)
+}
+
+// unmarshalBSONValue decodes a value into v, which should point to a pointer so that BSON null decodes to nil
+func unmarshalBSONValue(t bsontype.Type, data []byte, v interface{}) error {
+	return bson.RawValue{Type: t, Value: data}.Unmarshal(v)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (b Bool) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(b.Valid, b.Bool)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (b *Bool) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *bool
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*b = BoolFromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It is stored as a one character string.
+func (b Byte) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(b.Valid, string(b.Byte))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (b *Byte) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *string
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	if v == nil {
+		*b = Byte{}
+		return nil
+	}
+	return b.UnmarshalText([]byte(*v))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It is stored as generic binary data.
+func (b Bytes) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if b.Valid && b.Bytes == nil {
+		return marshalBSONValue(true, []byte{})
+	}
+	return marshalBSONValue(b.Valid, b.Bytes)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (b *Bytes) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *[]byte
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	if v != nil && *v == nil {
+		*v = []byte{}
+	}
+	*b = BytesFromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It is stored as a decimal128, so it must fit in 34 digits.
+func (d Decimal) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !d.Valid {
+		return marshalBSONValue(false, nil)
+	}
+	dec, err := primitive.ParseDecimal128(d.Decimal.String())
+	if err != nil {
+		return 0, nil, fmt.Errorf("null: %s does not fit in a decimal128: %v", d.Decimal, err)
+	}
+	return marshalBSONValue(true, dec)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It reads decimal128s, and the strings and numbers it can be read from in JSON.
+func (d *Decimal) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	*d = Decimal{}
+	var str string
+	switch t {
+	case bsontype.Null, bsontype.Undefined:
+		return nil
+	case bsontype.Decimal128:
+		var dec primitive.Decimal128
+		if err := unmarshalBSONValue(t, data, &dec); err != nil {
+			return err
+		}
+		str = dec.String()
+	case bsontype.Double:
+		var f float64
+		if err := unmarshalBSONValue(t, data, &f); err != nil {
+			return err
+		}
+		d.SetValid(decimal.NewFromFloat(f))
+		return nil
+	default:
+		if err := unmarshalBSONValue(t, data, &str); err != nil {
+			return err
+		}
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It is stored as a number of seconds, like in SQL.
+func (d Duration) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(d.Valid, int64(d.Duration/time.Second))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It reads numbers of seconds and duration strings.
+func (d *Duration) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	*d = Duration{}
+	if t == bsontype.String {
+		var str string
+		if err := unmarshalBSONValue(t, data, &str); err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(str))
+	}
+
+	var seconds *int64
+	if err := unmarshalBSONValue(t, data, &seconds); err != nil || seconds == nil {
+		return err
+	}
+	var err error
+	d.Duration, err = durationFromSeconds(*seconds)
+	d.Valid = err == nil
+	return err
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (f Float32) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(f.Valid, f.Float32)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (f *Float32) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *float32
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*f = Float32FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (f Float64) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(f.Valid, f.Float64)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (f *Float64) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *float64
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*f = Float64FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(i.Valid, i.Int)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *int
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*i = IntFromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int8) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(i.Valid, i.Int8)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int8) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *int8
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*i = Int8FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int16) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(i.Valid, i.Int16)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int16) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *int16
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*i = Int16FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int32) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(i.Valid, i.Int32)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int32) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *int32
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*i = Int32FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int64) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(i.Valid, i.Int64)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (i *Int64) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *int64
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*i = Int64FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// The JSON is stored as the equivalent BSON value, so it can be queried.
+func (j JSON) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !j.Valid {
+		return marshalBSONValue(false, nil)
+	}
+	var v interface{}
+	if err := json.Unmarshal(j.JSON, &v); err != nil {
+		return 0, nil, err
+	}
+	return marshalBSONValue(true, v)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// Any BSON value that JSON can represent is read.
+func (j *JSON) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v interface{}
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	if v == nil {
+		j.JSON, j.Valid = NullBytes, false
+		return nil
+	}
+	encoded, err := json.Marshal(bsonJSONValue(v))
+	if err != nil {
+		return err
+	}
+	j.JSON, j.Valid = encoded, true
+	return nil
+}
+
+// bsonJSONValue converts the documents and arrays BSON is decoded into to maps and slices, which marshal to JSON
+// objects and arrays
+func bsonJSONValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case primitive.D:
+		m := make(map[string]interface{}, len(x))
+		for _, e := range x {
+			m[e.Key] = bsonJSONValue(e.Value)
+		}
+		return m
+	case primitive.M:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[k] = bsonJSONValue(e)
+		}
+		return m
+	case primitive.A:
+		l := make([]interface{}, len(x))
+		for i, e := range x {
+			l[i] = bsonJSONValue(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// The message is stored like JSON, so a message holding JSON null is stored as BSON null.
+func (m RawMessage) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return JSON{JSON: m.raw(), Valid: m.Valid}.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (m *RawMessage) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var j JSON
+	if err := j.UnmarshalBSONValue(t, data); err != nil {
+		return err
+	}
+	if !j.Valid {
+		m.RawMessage, m.Valid = nil, false
+		return nil
+	}
+	m.RawMessage, m.Valid = j.JSON, true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (s String) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(s.Valid, s.String)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (s *String) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *string
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*s = StringFromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It is stored as a BSON datetime, which only keeps milliseconds.
+func (t Time) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(t.Valid, t.Time)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (t *Time) UnmarshalBSONValue(typ bsontype.Type, data []byte) error {
+	var v *time.Time
+	if err := unmarshalBSONValue(typ, data, &v); err != nil {
+		return err
+	}
+	*t = TimeFromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(u.Valid, u.Uint)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *uint
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*u = UintFromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint8) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(u.Valid, u.Uint8)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint8) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *uint8
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*u = Uint8FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint16) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(u.Valid, u.Uint16)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint16) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *uint16
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*u = Uint16FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (u Uint32) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(u.Valid, u.Uint32)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint32) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *uint32
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*u = Uint32FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// BSON has no unsigned integers, so values above math.MaxInt64 can't be stored.
+func (u Uint64) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(u.Valid, u.Uint64)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+func (u *Uint64) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	var v *uint64
+	if err := unmarshalBSONValue(t, data, &v); err != nil {
+		return err
+	}
+	*u = Uint64FromPtr(v)
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It is stored as binary data with the UUID subtype.
+func (u UUID) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalBSONValue(u.Valid, primitive.Binary{Subtype: bsonBinaryUUID, Data: u.UUID[:]})
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It reads 16 bytes of binary data, and UUID strings.
+func (u *UUID) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	*u = UUID{}
+	switch t {
+	case bsontype.Null, bsontype.Undefined:
+		return nil
+	case bsontype.String:
+		var str string
+		if err := unmarshalBSONValue(t, data, &str); err != nil {
+			return err
+		}
+		return u.UnmarshalText([]byte(str))
+	case bsontype.Binary:
+		var b primitive.Binary
+		if err := unmarshalBSONValue(t, data, &b); err != nil {
+			return err
+		}
+		if len(b.Data) != 16 {
+			return fmt.Errorf("null: cannot unmarshal %d bytes into null.UUID", len(b.Data))
+		}
+		copy(u.UUID[:], b.Data)
+		u.Valid = true
+		return nil
+	default:
+		return fmt.Errorf("null: cannot unmarshal BSON %s into null.UUID", t)
+	}
+}
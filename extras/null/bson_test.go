@@ -0,0 +1,163 @@
+//go:build bson
+// +build bson
+
+package null
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type bsonDocument struct {
+	Bool       Bool
+	Byte       Byte
+	Bytes      Bytes
+	Decimal    Decimal
+	Duration   Duration
+	Float32    Float32
+	Float64    Float64
+	Int        Int
+	Int8       Int8
+	Int16      Int16
+	Int32      Int32
+	Int64      Int64
+	JSON       JSON
+	RawMessage RawMessage
+	String     String
+	Time       Time
+	Uint       Uint
+	Uint8      Uint8
+	Uint16     Uint16
+	Uint32     Uint32
+	Uint64     Uint64
+	UUID       UUID
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	dec, _ := DecimalFromString("12345678901234567890.12345678")
+	id, _ := UUIDFromString(uuidString)
+	doc := bsonDocument{
+		Bool:       BoolFrom(true),
+		Byte:       ByteFrom('b'),
+		Bytes:      BytesFrom([]byte{}),
+		Decimal:    dec,
+		Duration:   DurationFrom(5 * time.Minute),
+		Float32:    Float32From(1.5),
+		Float64:    Float64From(1.2345),
+		Int:        IntFrom(-12),
+		Int8:       Int8From(-8),
+		Int16:      Int16From(-16),
+		Int32:      Int32From(-32),
+		Int64:      Int64From(-64),
+		JSON:       JSONFrom([]byte(`{"a":[1,"b"]}`)),
+		RawMessage: RawMessageFrom([]byte(`{"a":1}`)),
+		String:     StringFrom(""),
+		Time:       TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)),
+		Uint:       UintFrom(12),
+		Uint8:      Uint8From(8),
+		Uint16:     Uint16From(16),
+		Uint32:     Uint32From(32),
+		Uint64:     Uint64From(64),
+		UUID:       id,
+	}
+
+	data, err := bson.Marshal(doc)
+	maybePanic(err)
+	var decoded bsonDocument
+	err = bson.Unmarshal(data, &decoded)
+	maybePanic(err)
+	if !decoded.Decimal.Decimal.Equal(doc.Decimal.Decimal) {
+		t.Errorf("bad decimal: %s ≠ %s", decoded.Decimal.Decimal, doc.Decimal.Decimal)
+	}
+	decoded.Decimal = doc.Decimal
+	if !reflect.DeepEqual(decoded, doc) {
+		t.Errorf("expected %#v to round trip, got %#v", doc, decoded)
+	}
+
+	raw := bson.Raw(data)
+	types := map[string]bsontype.Type{
+		"decimal":  bsontype.Decimal128,
+		"duration": bsontype.Int64,
+		"json":     bsontype.EmbeddedDocument,
+		"time":     bsontype.DateTime,
+		"uuid":     bsontype.Binary,
+	}
+	for key, typ := range types {
+		if v := raw.Lookup(key); v.Type != typ {
+			t.Errorf("expected %s to be stored as %s, got %s", key, typ, v.Type)
+		}
+	}
+	if subtype, _ := raw.Lookup("uuid").Binary(); subtype != bsonBinaryUUID {
+		t.Errorf("expected the uuid binary subtype, got %d", subtype)
+	}
+
+	// every null value is stored as BSON null, and read back as null
+	data, err = bson.Marshal(bsonDocument{})
+	maybePanic(err)
+	elements, err := bson.Raw(data).Elements()
+	maybePanic(err)
+	for _, e := range elements {
+		if e.Value().Type != bsontype.Null {
+			t.Errorf("expected null %s to be stored as null, got %s", e.Key(), e.Value().Type)
+		}
+	}
+	decoded = doc
+	err = bson.Unmarshal(data, &decoded)
+	maybePanic(err)
+	v := reflect.ValueOf(decoded)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).Interface().(Nullable).IsNull() {
+			t.Errorf("expected %s to be null", v.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestUnmarshalBSONValue(t *testing.T) {
+	var dec Decimal
+	typ, data, err := bson.MarshalValue("0.00000001")
+	maybePanic(err)
+	err = dec.UnmarshalBSONValue(typ, data)
+	maybePanic(err)
+	if !dec.Valid || dec.Decimal.String() != "0.00000001" {
+		t.Errorf("bad decimal from string: %s", dec.Decimal)
+	}
+
+	var d Duration
+	typ, data, err = bson.MarshalValue(int32(300))
+	maybePanic(err)
+	err = d.UnmarshalBSONValue(typ, data)
+	maybePanic(err)
+	if !d.Valid || d.Duration != 5*time.Minute {
+		t.Errorf("expected 300 seconds to be 5m, got %v", d.Duration)
+	}
+
+	var u UUID
+	typ, data, err = bson.MarshalValue(uuidString)
+	maybePanic(err)
+	err = u.UnmarshalBSONValue(typ, data)
+	maybePanic(err)
+	assertUUID(t, u, "bson uuid string")
+
+	var short UUID
+	typ, data, err = bson.MarshalValue(primitive.Binary{Subtype: bsonBinaryUUID, Data: []byte{1, 2, 3}})
+	maybePanic(err)
+	if err = short.UnmarshalBSONValue(typ, data); err == nil {
+		t.Error("expected an error")
+	}
+	assertNullUUID(t, short, "short bson uuid")
+
+	var wrong Int
+	typ, data, err = bson.MarshalValue("twelve")
+	maybePanic(err)
+	if err = wrong.UnmarshalBSONValue(typ, data); err == nil {
+		t.Error("expected an error")
+	}
+	if wrong.Valid {
+		t.Error("wrong type should be null")
+	}
+}
@@ -34,20 +34,30 @@ func ByteFromPtr(b *byte) Byte {
 	return NewByte(*b, true)
 }
 
+// ByteFromNonZero creates a new Byte that will be null if v is zero.
+func ByteFromNonZero(v byte) Byte {
+	return NewByte(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (b *Byte) UnmarshalJSON(data []byte) error {
 	if len(data) == 0 || bytes.Equal(data, NullBytes) {
 		b.Valid = false
 		b.Byte = 0
+		if len(data) == 0 && Strict {
+			return errors.New("json: cannot unmarshal empty input into null.Byte")
+		}
 		return nil
 	}
 
 	var x string
 	if err := json.Unmarshal(data, &x); err != nil {
+		b.Valid = false
 		return err
 	}
 
 	if len(x) > 1 {
+		b.Valid = false
 		return errors.New("json: cannot convert to byte, text len is greater than one")
 	}
 
@@ -60,10 +70,11 @@ func (b *Byte) UnmarshalJSON(data []byte) error {
 func (b *Byte) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		b.Valid = false
-		return nil
+		return blankText("Byte")
 	}
 
 	if len(text) > 1 {
+		b.Valid = false
 		return errors.New("text: cannot convert to byte, text len is greater than one")
 	}
 
@@ -94,6 +105,11 @@ func (b *Byte) SetValid(n byte) {
 	b.Valid = true
 }
 
+// SetNull sets this Byte to null, clearing its value.
+func (b *Byte) SetNull() {
+	b.Byte, b.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Byte's value, or a nil pointer if this Byte is null.
 func (b Byte) Ptr() *byte {
 	if !b.Valid {
@@ -102,11 +118,59 @@ func (b Byte) Ptr() *byte {
 	return &b.Byte
 }
 
-// IsNull returns true for invalid Bytes, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Byte's value, or zero if it is null.
+func (b Byte) ValueOrZero() byte {
+	if !b.Valid {
+		return 0
+	}
+	return b.Byte
+}
+
+// ValueOr returns this Byte's value, or def if it is null.
+func (b Byte) ValueOr(def byte) byte {
+	if !b.Valid {
+		return def
+	}
+	return b.Byte
+}
+
+// IsNull returns true for invalid Bytes.
 func (b Byte) IsNull() bool {
 	return !b.Valid
 }
 
+// IsZero returns true for null Bytes, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (b Byte) IsZero() bool {
+	return !b.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (b Byte) isZeroValue() bool {
+	return b.Byte == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Byte is null.
+func (b Byte) String() string {
+	if !b.Valid {
+		return ""
+	}
+	text, _ := b.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (b *Byte) Set(s string) error {
+	return setFlag(b, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (b *Byte) Type() string {
+	return "byte"
+}
+
 // Scan implements the Scanner interface.
 func (b *Byte) Scan(value interface{}) error {
 	if value == nil {
@@ -133,3 +197,44 @@ func (b Byte) Value() (driver.Value, error) {
 	}
 	return []byte{b.Byte}, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (b Byte) MarshalBinary() ([]byte, error) {
+	return marshalBinary(b.Valid, []byte{b.Byte}), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Byte) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "Byte", 1)
+	if err != nil {
+		return err
+	}
+	b.Byte, b.Valid = 0, valid
+	if valid {
+		b.Byte = v[0]
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Byte is null.
+func (b Byte) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return string(b.Byte), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It reads the same forms as UnmarshalText.
+func (b *Byte) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str *string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == nil {
+		*b = Byte{}
+		return nil
+	}
+	return b.UnmarshalText([]byte(*str))
+}
@@ -11,7 +11,8 @@ import (
 // NullBytes is a global byte slice of JSON null
 var NullBytes = []byte("null")
 
-// Bytes is a nullable []byte.
+// Bytes is a nullable []byte. It is base64 encoded in JSON, like []byte, and an empty Bytes is distinct from a
+// null one.
 type Bytes struct {
 	Bytes []byte
 	Valid bool
@@ -39,6 +40,11 @@ func BytesFromPtr(b *[]byte) Bytes {
 	return n
 }
 
+// BytesFromNonZero creates a new Bytes that will be null if v is empty.
+func BytesFromNonZero(v []byte) Bytes {
+	return NewBytes(v, len(v) != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (b *Bytes) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -47,12 +53,16 @@ func (b *Bytes) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	var s string
-	if err := json.Unmarshal(data, &s); err != nil {
+	var decoded []byte
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		b.Valid = false
 		return err
 	}
+	if decoded == nil {
+		decoded = []byte{}
+	}
 
-	b.Bytes = []byte(s)
+	b.Bytes = decoded
 	b.Valid = true
 	return nil
 }
@@ -60,22 +70,30 @@ func (b *Bytes) UnmarshalJSON(data []byte) error {
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (b *Bytes) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
+		// in strict mode, blank text is empty rather than null
 		b.Bytes = nil
-		b.Valid = false
-	} else {
-		b.Bytes = append(b.Bytes[0:0], text...)
-		b.Valid = true
+		if Strict {
+			b.Bytes = []byte{}
+		}
+		b.Valid = Strict
+		return nil
 	}
 
+	b.Bytes = append(b.Bytes[0:0], text...)
+	b.Valid = true
 	return nil
 }
 
 // MarshalJSON implements json.Marshaler.
+// It will encode null if this Bytes is null, and a base64 string otherwise.
 func (b Bytes) MarshalJSON() ([]byte, error) {
-	if len(b.Bytes) == 0 || b.Bytes == nil {
+	if !b.Valid {
 		return NullBytes, nil
 	}
-	return b.Bytes, nil
+	if b.Bytes == nil {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(b.Bytes)
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -92,6 +110,11 @@ func (b *Bytes) SetValid(n []byte) {
 	b.Valid = true
 }
 
+// SetNull sets this Bytes to null, clearing its value.
+func (b *Bytes) SetNull() {
+	b.Bytes, b.Valid = nil, false
+}
+
 // Ptr returns a pointer to this Bytes's value, or a nil pointer if this Bytes is null.
 func (b Bytes) Ptr() *[]byte {
 	if !b.Valid {
@@ -100,19 +123,69 @@ func (b Bytes) Ptr() *[]byte {
 	return &b.Bytes
 }
 
-// IsNull returns true for null or zero Bytes's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Bytes's value, or nil if it is null.
+func (b Bytes) ValueOrZero() []byte {
+	if !b.Valid {
+		return nil
+	}
+	return b.Bytes
+}
+
+// ValueOr returns this Bytes's value, or def if it is null.
+func (b Bytes) ValueOr(def []byte) []byte {
+	if !b.Valid {
+		return def
+	}
+	return b.Bytes
+}
+
+// IsNull returns true for invalid Bytes.
 func (b Bytes) IsNull() bool {
 	return !b.Valid
 }
 
+// IsZero returns true for null Bytes, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid empty Bytes is not zero.
+func (b Bytes) IsZero() bool {
+	return !b.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (b Bytes) isZeroValue() bool {
+	return len(b.Bytes) == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Bytes is null.
+func (b Bytes) String() string {
+	if !b.Valid {
+		return ""
+	}
+	text, _ := b.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is set to empty rather than null.
+func (b *Bytes) Set(s string) error {
+	b.SetValid([]byte(s))
+	return nil
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (b *Bytes) Type() string {
+	return "bytes"
+}
+
 // Scan implements the Scanner interface.
 func (b *Bytes) Scan(value interface{}) error {
 	if value == nil {
 		b.Bytes, b.Valid = []byte{}, false
 		return nil
 	}
-	b.Valid = true
-	return convert.ConvertAssign(&b.Bytes, value)
+	err := convert.ConvertAssign(&b.Bytes, value)
+	b.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -120,5 +193,53 @@ func (b Bytes) Value() (driver.Value, error) {
 	if !b.Valid {
 		return nil, nil
 	}
+	if b.Bytes == nil {
+		// drivers store a nil []byte as NULL
+		return []byte{}, nil
+	}
 	return b.Bytes, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+// The value is kept apart from null even when it is blank, which the text form can't do.
+func (b Bytes) MarshalBinary() ([]byte, error) {
+	return marshalBinary(b.Valid, b.Bytes), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Bytes) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "Bytes", -1)
+	if err != nil {
+		return err
+	}
+	b.Bytes, b.Valid = nil, valid
+	if valid {
+		b.Bytes = append([]byte{}, v...)
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Bytes is null.
+func (b Bytes) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	if b.Bytes == nil {
+		return []byte{}, nil
+	}
+	return b.Bytes, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *Bytes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *[]byte
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v != nil && *v == nil {
+		*v = []byte{}
+	}
+	*b = BytesFromPtr(v)
+	return nil
+}
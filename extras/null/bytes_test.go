@@ -7,7 +7,8 @@ import (
 )
 
 var (
-	bytesJSON = []byte(`"hello"`)
+	bytesJSON      = []byte(`"aGVsbG8="`)
+	emptyBytesJSON = []byte(`""`)
 )
 
 func TestBytesFrom(t *testing.T) {
@@ -41,6 +42,20 @@ func TestUnmarshalBytes(t *testing.T) {
 	maybePanic(err)
 	assertBytes(t, i, "[]byte json")
 
+	var empty Bytes
+	err = json.Unmarshal(emptyBytesJSON, &empty)
+	maybePanic(err)
+	if !empty.Valid || empty.Bytes == nil || len(empty.Bytes) != 0 {
+		t.Errorf("expected valid empty Bytes, got %#v", empty)
+	}
+
+	var notBase64 Bytes
+	err = json.Unmarshal([]byte(`"hello"`), &notBase64)
+	if err == nil {
+		t.Errorf("Expected error")
+	}
+	assertNullBytes(t, notBase64, "non-base64 json")
+
 	var ni Bytes
 	err = ni.UnmarshalJSON([]byte{})
 	if err == nil {
@@ -70,10 +85,27 @@ func TestTextUnmarshalBytes(t *testing.T) {
 }
 
 func TestMarshalBytes(t *testing.T) {
-	i := BytesFrom([]byte(`"hello"`))
+	i := BytesFrom([]byte(`hello`))
 	data, err := json.Marshal(i)
 	maybePanic(err)
-	assertJSONEquals(t, data, `"hello"`, "non-empty json marshal")
+	assertJSONEquals(t, data, string(bytesJSON), "non-empty json marshal")
+
+	// binary data, like a hash, round trips
+	binary := BytesFrom([]byte{0x00, 0xff, '"', 0x80})
+	data, err = json.Marshal(binary)
+	maybePanic(err)
+	var roundTrip Bytes
+	err = json.Unmarshal(data, &roundTrip)
+	maybePanic(err)
+	if !roundTrip.Valid || !bytes.Equal(roundTrip.Bytes, binary.Bytes) {
+		t.Errorf("bad round trip: %#v ≠ %#v", roundTrip.Bytes, binary.Bytes)
+	}
+
+	// empty values are not null
+	empty := NewBytes(nil, true)
+	data, err = json.Marshal(empty)
+	maybePanic(err)
+	assertJSONEquals(t, data, `""`, "empty json marshal")
 
 	// invalid values should be encoded as null
 	null := NewBytes(nil, false)
@@ -145,10 +177,29 @@ func TestBytesScan(t *testing.T) {
 	maybePanic(err)
 	assertBytes(t, i, "Scan() []byte")
 
+	var blob Bytes
+	err = blob.Scan([]byte{0x00, 0xff})
+	maybePanic(err)
+	if !blob.Valid || !bytes.Equal(blob.Bytes, []byte{0x00, 0xff}) {
+		t.Errorf("bad scanned blob: %#v", blob)
+	}
+	if v, err := blob.Value(); !bytes.Equal(v.([]byte), []byte{0x00, 0xff}) || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
 	var null Bytes
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullBytes(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	// an empty value is stored as empty rather than NULL
+	empty := NewBytes(nil, true)
+	if v, err := empty.Value(); v == nil || v.([]byte) == nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
 }
 
 func assertBytes(t *testing.T, i Bytes, from string) {
@@ -0,0 +1,141 @@
+package null
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Conversions between the types, for mapping loosely typed input onto typed columns. Null converts to null, and
+// a conversion that would lose information returns an error and null instead.
+
+func conversionError(v interface{}, typ string) error {
+	return fmt.Errorf("null: cannot convert %v to null.%s exactly", v, typ)
+}
+
+// ToFloat64 converts this Int64 to a Float64. It returns an error if the value is too big for a float64 to hold
+// exactly.
+func (i Int64) ToFloat64() (Float64, error) {
+	if !i.Valid {
+		return Float64{}, nil
+	}
+	f := float64(i.Int64)
+	if f >= math.MaxInt64 || int64(f) != i.Int64 {
+		return Float64{}, conversionError(i.Int64, "Float64")
+	}
+	return Float64From(f), nil
+}
+
+// ToString converts this Int64 to a String in base 10.
+func (i Int64) ToString() String {
+	if !i.Valid {
+		return String{}
+	}
+	return StringFrom(strconv.FormatInt(i.Int64, 10))
+}
+
+// ToBool converts this Int64 to a Bool. It returns an error for anything but 0 and 1.
+func (i Int64) ToBool() (Bool, error) {
+	if !i.Valid {
+		return Bool{}, nil
+	}
+	if i.Int64 != 0 && i.Int64 != 1 {
+		return Bool{}, conversionError(i.Int64, "Bool")
+	}
+	return BoolFrom(i.Int64 == 1), nil
+}
+
+// ToFloat64 converts this Int to a Float64. It returns an error if the value is too big for a float64 to hold
+// exactly.
+func (i Int) ToFloat64() (Float64, error) {
+	return i.int64().ToFloat64()
+}
+
+// ToString converts this Int to a String in base 10.
+func (i Int) ToString() String {
+	return i.int64().ToString()
+}
+
+// ToBool converts this Int to a Bool. It returns an error for anything but 0 and 1.
+func (i Int) ToBool() (Bool, error) {
+	return i.int64().ToBool()
+}
+
+func (i Int) int64() Int64 {
+	return NewInt64(int64(i.Int), i.Valid)
+}
+
+// ToInt64 converts this Float64 to an Int64. It returns an error if the value has a fractional part or is out
+// of range.
+func (f Float64) ToInt64() (Int64, error) {
+	if !f.Valid {
+		return Int64{}, nil
+	}
+	if f.Float64 != math.Trunc(f.Float64) || f.Float64 < math.MinInt64 || f.Float64 >= math.MaxInt64 {
+		return Int64{}, conversionError(f.Float64, "Int64")
+	}
+	return Int64From(int64(f.Float64)), nil
+}
+
+// ToInt converts this Float64 to an Int. It returns an error if the value has a fractional part or is out of
+// range.
+func (f Float64) ToInt() (Int, error) {
+	i, err := f.ToInt64()
+	if err != nil {
+		return Int{}, conversionError(f.Float64, "Int")
+	}
+	return i.toInt()
+}
+
+// ToInt64 parses this String as a base 10 integer. It returns an error if it isn't one, including if it is
+// blank.
+func (s String) ToInt64() (Int64, error) {
+	if !s.Valid {
+		return Int64{}, nil
+	}
+	n, err := strconv.ParseInt(s.String, 10, 64)
+	if err != nil {
+		return Int64{}, conversionError(strconv.Quote(s.String), "Int64")
+	}
+	return Int64From(n), nil
+}
+
+// ToInt parses this String as a base 10 integer. It returns an error if it isn't one, including if it is blank.
+func (s String) ToInt() (Int, error) {
+	if !s.Valid {
+		return Int{}, nil
+	}
+	n, err := strconv.ParseInt(s.String, 10, strconv.IntSize)
+	if err != nil {
+		return Int{}, conversionError(strconv.Quote(s.String), "Int")
+	}
+	return IntFrom(int(n)), nil
+}
+
+// ToInt64 converts this Bool to an Int64 that is 1 for true and 0 for false.
+func (b Bool) ToInt64() Int64 {
+	if !b.Valid {
+		return Int64{}
+	}
+	if b.Bool {
+		return Int64From(1)
+	}
+	return Int64From(0)
+}
+
+// ToInt converts this Bool to an Int that is 1 for true and 0 for false.
+func (b Bool) ToInt() Int {
+	i, _ := b.ToInt64().toInt()
+	return i
+}
+
+// toInt converts an Int64 to an Int, which may be smaller
+func (i Int64) toInt() (Int, error) {
+	if !i.Valid {
+		return Int{}, nil
+	}
+	if int64(int(i.Int64)) != i.Int64 {
+		return Int{}, conversionError(i.Int64, "Int")
+	}
+	return IntFrom(int(i.Int64)), nil
+}
@@ -0,0 +1,106 @@
+package null
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntToFloat64(t *testing.T) {
+	f, err := Int64From(-1 << 53).ToFloat64()
+	maybePanic(err)
+	if !f.Valid || f.Float64 != -1<<53 {
+		t.Errorf("bad float: %v", f.Float64)
+	}
+	for _, n := range []int64{1<<53 + 1, math.MaxInt64} {
+		if _, err = Int64From(n).ToFloat64(); err == nil {
+			t.Errorf("expected an error converting %d", n)
+		}
+	}
+	f, err = IntFrom(12).ToFloat64()
+	maybePanic(err)
+	if !f.Valid || f.Float64 != 12 {
+		t.Errorf("bad float: %v", f.Float64)
+	}
+	f, err = Int{}.ToFloat64()
+	maybePanic(err)
+	if f.Valid {
+		t.Error("null should convert to null")
+	}
+}
+
+func TestFloatToInt(t *testing.T) {
+	i, err := Float64From(-12).ToInt64()
+	maybePanic(err)
+	if !i.Valid || i.Int64 != -12 {
+		t.Errorf("bad int64: %d", i.Int64)
+	}
+	n, err := Float64From(12).ToInt()
+	maybePanic(err)
+	if !n.Valid || n.Int != 12 {
+		t.Errorf("bad int: %d", n.Int)
+	}
+	for _, f := range []float64{1.5, math.MaxInt64, math.Inf(-1), math.NaN()} {
+		if _, err = Float64From(f).ToInt64(); err == nil {
+			t.Errorf("expected an error converting %v", f)
+		}
+		if _, err = Float64From(f).ToInt(); err == nil {
+			t.Errorf("expected an error converting %v", f)
+		}
+	}
+	if i, _ = (Float64{}).ToInt64(); i.Valid {
+		t.Error("null should convert to null")
+	}
+}
+
+func TestStringToInt(t *testing.T) {
+	i, err := StringFrom("-12").ToInt64()
+	maybePanic(err)
+	if !i.Valid || i.Int64 != -12 {
+		t.Errorf("bad int64: %d", i.Int64)
+	}
+	n, err := StringFrom("12").ToInt()
+	maybePanic(err)
+	if !n.Valid || n.Int != 12 {
+		t.Errorf("bad int: %d", n.Int)
+	}
+	for _, s := range []string{"", "1.5", "twelve", " 12", "9223372036854775808"} {
+		if _, err = StringFrom(s).ToInt64(); err == nil {
+			t.Errorf("expected an error converting %q", s)
+		}
+		if _, err = StringFrom(s).ToInt(); err == nil {
+			t.Errorf("expected an error converting %q", s)
+		}
+	}
+	if n, _ = (String{}).ToInt(); n.Valid {
+		t.Error("null should convert to null")
+	}
+
+	if s := Int64From(-12).ToString(); !s.Valid || s.String != "-12" {
+		t.Errorf("bad string: %q", s.String)
+	}
+	if s := (Int{}).ToString(); s.Valid {
+		t.Error("null should convert to null")
+	}
+}
+
+func TestBoolToInt(t *testing.T) {
+	if i := BoolFrom(true).ToInt64(); !i.Valid || i.Int64 != 1 {
+		t.Errorf("expected true to be 1, got %d", i.Int64)
+	}
+	if i := BoolFrom(false).ToInt(); !i.Valid || i.Int != 0 {
+		t.Errorf("expected false to be 0, got %d", i.Int)
+	}
+	if i := (Bool{}).ToInt(); i.Valid {
+		t.Error("null should convert to null")
+	}
+
+	b, err := IntFrom(1).ToBool()
+	maybePanic(err)
+	assertBool(t, b, "int 1")
+	b, err = Int64From(0).ToBool()
+	maybePanic(err)
+	assertFalseBool(t, b, "int 0")
+	if _, err = IntFrom(2).ToBool(); err == nil {
+		t.Error("expected an error converting 2")
+	}
+}
@@ -0,0 +1,272 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is a nullable arbitrary-precision decimal, for amounts like LBC that float64 would round. It is
+// encoded as a string in JSON, and stored as a NUMERIC in SQL.
+type Decimal struct {
+	Decimal decimal.Decimal
+	Valid   bool
+}
+
+// NewDecimal creates a new Decimal
+func NewDecimal(d decimal.Decimal, valid bool) Decimal {
+	return Decimal{
+		Decimal: d,
+		Valid:   valid,
+	}
+}
+
+// DecimalFrom creates a new Decimal that will always be valid.
+func DecimalFrom(d decimal.Decimal) Decimal {
+	return NewDecimal(d, true)
+}
+
+// DecimalFromPtr creates a new Decimal that will be null if d is nil.
+func DecimalFromPtr(d *decimal.Decimal) Decimal {
+	if d == nil {
+		return NewDecimal(decimal.Zero, false)
+	}
+	return NewDecimal(*d, true)
+}
+
+// DecimalFromNonZero creates a new Decimal that will be null if v is zero.
+func DecimalFromNonZero(v decimal.Decimal) Decimal {
+	return NewDecimal(v, !v.IsZero())
+}
+
+// DecimalFromString creates a new Decimal from a string like "1.5". It will be null if s is empty.
+func DecimalFromString(s string) (Decimal, error) {
+	var d Decimal
+	err := d.UnmarshalText([]byte(s))
+	return d, err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports strings and numbers, without going through float64.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	d.Decimal, d.Valid = decimal.Zero, false
+	if bytes.Equal(data, NullBytes) {
+		return nil
+	}
+	if !json.Valid(data) {
+		// for the same *json.SyntaxError the other types return
+		var v interface{}
+		return json.Unmarshal(data, &v)
+	}
+	if err := d.Decimal.UnmarshalJSON(data); err != nil {
+		d.Decimal = decimal.Zero
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if text == nil || len(text) == 0 {
+		d.Decimal, d.Valid = decimal.Zero, false
+		return blankText("Decimal")
+	}
+	var err error
+	d.Decimal, err = decimal.NewFromString(string(text))
+	d.Valid = err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Decimal is null, and a string otherwise.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(d.Decimal.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this Decimal is null.
+func (d Decimal) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return []byte(d.Decimal.String()), nil
+}
+
+// SetValid changes this Decimal's value and also sets it to be non-null.
+func (d *Decimal) SetValid(v decimal.Decimal) {
+	d.Decimal = v
+	d.Valid = true
+}
+
+// SetNull sets this Decimal to null, clearing its value.
+func (d *Decimal) SetNull() {
+	d.Decimal, d.Valid = decimal.Decimal{}, false
+}
+
+// Ptr returns a pointer to this Decimal's value, or a nil pointer if this Decimal is null.
+func (d Decimal) Ptr() *decimal.Decimal {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Decimal
+}
+
+// ValueOrZero returns this Decimal's value, or zero if it is null.
+func (d Decimal) ValueOrZero() decimal.Decimal {
+	if !d.Valid {
+		return decimal.Zero
+	}
+	return d.Decimal
+}
+
+// ValueOr returns this Decimal's value, or def if it is null.
+func (d Decimal) ValueOr(def decimal.Decimal) decimal.Decimal {
+	if !d.Valid {
+		return def
+	}
+	return d.Decimal
+}
+
+// IsNull returns true for invalid Decimals.
+func (d Decimal) IsNull() bool {
+	return !d.Valid
+}
+
+// IsZero returns true for null Decimals, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (d Decimal) IsZero() bool {
+	return !d.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (d Decimal) isZeroValue() bool {
+	return d.Decimal.IsZero()
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Decimal is null.
+func (d Decimal) String() string {
+	if !d.Valid {
+		return ""
+	}
+	text, _ := d.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (d *Decimal) Set(s string) error {
+	return setFlag(d, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (d *Decimal) Type() string {
+	return "decimal"
+}
+
+// Add returns d + d2. It is null if either is null, as in SQL.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	if !d.Valid || !d2.Valid {
+		return Decimal{}
+	}
+	return DecimalFrom(d.Decimal.Add(d2.Decimal))
+}
+
+// Sub returns d - d2. It is null if either is null, as in SQL.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	if !d.Valid || !d2.Valid {
+		return Decimal{}
+	}
+	return DecimalFrom(d.Decimal.Sub(d2.Decimal))
+}
+
+// Cmp compares d and d2 and returns -1, 0 or 1 if d is less than, equal to or greater than d2. Null sorts
+// before every valid value, and equal to null.
+func (d Decimal) Cmp(d2 Decimal) int {
+	switch {
+	case !d.Valid && !d2.Valid:
+		return 0
+	case !d.Valid:
+		return -1
+	case !d2.Valid:
+		return 1
+	}
+	return d.Decimal.Cmp(d2.Decimal)
+}
+
+// Scan implements the Scanner interface.
+func (d *Decimal) Scan(value interface{}) error {
+	if value == nil {
+		d.Decimal, d.Valid = decimal.Zero, false
+		return nil
+	}
+	err := d.Decimal.Scan(value)
+	d.Valid = err == nil
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Decimal.String(), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	if !d.Valid {
+		return marshalBinary(false, nil), nil
+	}
+	v, err := d.Decimal.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return marshalBinary(true, v), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "Decimal", -1)
+	if err != nil {
+		return err
+	}
+	d.Decimal, d.Valid = decimal.Zero, false
+	if !valid {
+		return nil
+	}
+	if err := d.Decimal.UnmarshalBinary(v); err != nil {
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Decimal is null.
+func (d Decimal) MarshalYAML() (interface{}, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Decimal.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It reads the same forms as UnmarshalText.
+func (d *Decimal) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str *string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == nil {
+		*d = Decimal{}
+		return nil
+	}
+	return d.UnmarshalText([]byte(*str))
+}
@@ -0,0 +1,244 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	decimalString    = "12345678901234567890.12345678"
+	decimalJSON      = []byte(`"` + decimalString + `"`)
+	decimalNumber    = []byte(decimalString)
+	decimalValue, _  = decimal.NewFromString(decimalString)
+	smallDecimalJSON = []byte(`"0.00000001"`)
+)
+
+func TestDecimalFrom(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	assertDecimal(t, d, "DecimalFrom()")
+
+	zero := DecimalFrom(decimal.Zero)
+	if !zero.Valid {
+		t.Error("DecimalFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestDecimalFromPtr(t *testing.T) {
+	d := DecimalFromPtr(&decimalValue)
+	assertDecimal(t, d, "DecimalFromPtr()")
+
+	null := DecimalFromPtr(nil)
+	assertNullDecimal(t, null, "DecimalFromPtr(nil)")
+}
+
+func TestDecimalFromString(t *testing.T) {
+	d, err := DecimalFromString(decimalString)
+	maybePanic(err)
+	assertDecimal(t, d, "DecimalFromString()")
+
+	null, err := DecimalFromString("")
+	maybePanic(err)
+	assertNullDecimal(t, null, "DecimalFromString(\"\")")
+
+	_, err = DecimalFromString("one")
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUnmarshalDecimal(t *testing.T) {
+	var d Decimal
+	err := json.Unmarshal(decimalJSON, &d)
+	maybePanic(err)
+	assertDecimal(t, d, "decimal string json")
+
+	// numbers are read exactly, not through float64
+	var number Decimal
+	err = json.Unmarshal(decimalNumber, &number)
+	maybePanic(err)
+	assertDecimal(t, number, "decimal number json")
+
+	var null Decimal
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDecimal(t, null, "null json")
+
+	var badType Decimal
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullDecimal(t, badType, "wrong type json")
+
+	var invalid Decimal
+	err = invalid.UnmarshalJSON(invalidJSON)
+	if _, ok := err.(*json.SyntaxError); !ok {
+		t.Errorf("expected json.SyntaxError, not %T", err)
+	}
+	assertNullDecimal(t, invalid, "invalid json")
+}
+
+func TestTextUnmarshalDecimal(t *testing.T) {
+	var d Decimal
+	err := d.UnmarshalText([]byte(decimalString))
+	maybePanic(err)
+	assertDecimal(t, d, "UnmarshalText() decimal")
+
+	var blank Decimal
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullDecimal(t, blank, "UnmarshalText() empty decimal")
+}
+
+func TestMarshalDecimal(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(decimalJSON), "non-empty json marshal")
+
+	var small Decimal
+	err = json.Unmarshal(smallDecimalJSON, &small)
+	maybePanic(err)
+	data, err = json.Marshal(small)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(smallDecimalJSON), "small json marshal")
+
+	// invalid values should be encoded as null
+	null := NewDecimal(decimal.Zero, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestMarshalDecimalText(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	data, err := d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, decimalString, "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewDecimal(decimal.Zero, false)
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestDecimalPointer(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	ptr := d.Ptr()
+	if !ptr.Equal(decimalValue) {
+		t.Errorf("bad %s decimal: %s ≠ %s\n", "pointer", ptr, decimalValue)
+	}
+
+	null := NewDecimal(decimal.Zero, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s decimal: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDecimalIsNull(t *testing.T) {
+	d := DecimalFrom(decimalValue)
+	if d.IsNull() {
+		t.Errorf("IsNull() should be false")
+	}
+
+	null := NewDecimal(decimal.Zero, false)
+	if !null.IsNull() {
+		t.Errorf("IsNull() should be true")
+	}
+
+	zero := NewDecimal(decimal.Zero, true)
+	if zero.IsNull() {
+		t.Errorf("IsNull() should be false")
+	}
+
+	var testInt interface{}
+	testInt = zero
+	if _, ok := testInt.(Nullable); !ok {
+		t.Errorf("Nullable interface should be implemented")
+	}
+}
+
+func TestDecimalSetValid(t *testing.T) {
+	change := NewDecimal(decimal.Zero, false)
+	assertNullDecimal(t, change, "SetValid()")
+	change.SetValid(decimalValue)
+	assertDecimal(t, change, "SetValid()")
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	// 0.1 + 0.2 is exactly 0.3, unlike with float64
+	a, _ := DecimalFromString("0.1")
+	b, _ := DecimalFromString("0.2")
+	c, _ := DecimalFromString("0.3")
+	if sum := a.Add(b); !sum.Valid || sum.Cmp(c) != 0 {
+		t.Errorf("bad sum: %s ≠ %s", sum.Decimal, c.Decimal)
+	}
+	if diff := c.Sub(b); !diff.Valid || diff.Cmp(a) != 0 {
+		t.Errorf("bad difference: %s ≠ %s", diff.Decimal, a.Decimal)
+	}
+	if a.Cmp(b) != -1 || b.Cmp(a) != 1 {
+		t.Error("bad comparison of 0.1 and 0.2")
+	}
+
+	var null Decimal
+	if sum := a.Add(null); sum.Valid {
+		t.Error("adding null should be null")
+	}
+	if diff := null.Sub(a); diff.Valid {
+		t.Error("subtracting from null should be null")
+	}
+	if null.Cmp(a) != -1 || a.Cmp(null) != 1 || null.Cmp(Decimal{}) != 0 {
+		t.Error("null should sort before valid values")
+	}
+}
+
+func TestDecimalScanValue(t *testing.T) {
+	var d Decimal
+	err := d.Scan([]byte(decimalString))
+	maybePanic(err)
+	assertDecimal(t, d, "scanned numeric")
+	if v, err := d.Value(); v != decimalString || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var integer Decimal
+	err = integer.Scan(int64(42))
+	maybePanic(err)
+	if !integer.Valid || integer.Decimal.String() != "42" {
+		t.Errorf("bad scanned integer: %s", integer.Decimal)
+	}
+
+	var null Decimal
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullDecimal(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong Decimal
+	err = wrong.Scan("not a number")
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDecimal(t, wrong, "scanned wrong")
+}
+
+func assertDecimal(t *testing.T, d Decimal, from string) {
+	if !d.Decimal.Equal(decimalValue) {
+		t.Errorf("bad %v decimal: %s ≠ %s\n", from, d.Decimal, decimalValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDecimal(t *testing.T, d Decimal, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,254 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Duration is a nullable time.Duration. It is read from duration strings like "5m" or "300s", or from a
+// number of seconds, and written as a duration string. In SQL it is stored as a number of seconds.
+type Duration struct {
+	Duration time.Duration
+	Valid    bool
+}
+
+// NewDuration creates a new Duration
+func NewDuration(d time.Duration, valid bool) Duration {
+	return Duration{
+		Duration: d,
+		Valid:    valid,
+	}
+}
+
+// DurationFrom creates a new Duration that will always be valid.
+func DurationFrom(d time.Duration) Duration {
+	return NewDuration(d, true)
+}
+
+// DurationFromPtr creates a new Duration that will be null if d is nil.
+func DurationFromPtr(d *time.Duration) Duration {
+	if d == nil {
+		return NewDuration(0, false)
+	}
+	return NewDuration(*d, true)
+}
+
+// DurationFromNonZero creates a new Duration that will be null if v is zero.
+func DurationFromNonZero(v time.Duration) Duration {
+	return NewDuration(v, v != 0)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports duration strings and numbers of seconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	d.Duration, d.Valid = 0, false
+	if bytes.Equal(data, NullBytes) {
+		return nil
+	}
+
+	var err error
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err = json.Unmarshal(data, &str); err == nil {
+			d.Duration, err = parseDuration(str)
+		}
+	} else {
+		var seconds int64
+		if err = json.Unmarshal(data, &seconds); err == nil {
+			d.Duration, err = durationFromSeconds(seconds)
+		}
+	}
+	d.Valid = err == nil
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports duration strings and numbers of seconds.
+func (d *Duration) UnmarshalText(text []byte) error {
+	if text == nil || len(text) == 0 {
+		d.Valid = false
+		return blankText("Duration")
+	}
+	var err error
+	d.Duration, err = parseDuration(string(text))
+	d.Valid = err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Duration is null.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(d.Duration.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this Duration is null.
+func (d Duration) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return []byte(d.Duration.String()), nil
+}
+
+// SetValid changes this Duration's value and also sets it to be non-null.
+func (d *Duration) SetValid(v time.Duration) {
+	d.Duration = v
+	d.Valid = true
+}
+
+// SetNull sets this Duration to null, clearing its value.
+func (d *Duration) SetNull() {
+	d.Duration, d.Valid = 0, false
+}
+
+// Ptr returns a pointer to this Duration's value, or a nil pointer if this Duration is null.
+func (d Duration) Ptr() *time.Duration {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Duration
+}
+
+// ValueOrZero returns this Duration's value, or zero if it is null.
+func (d Duration) ValueOrZero() time.Duration {
+	if !d.Valid {
+		return 0
+	}
+	return d.Duration
+}
+
+// ValueOr returns this Duration's value, or def if it is null.
+func (d Duration) ValueOr(def time.Duration) time.Duration {
+	if !d.Valid {
+		return def
+	}
+	return d.Duration
+}
+
+// IsNull returns true for invalid Durations.
+func (d Duration) IsNull() bool {
+	return !d.Valid
+}
+
+// IsZero returns true for null Durations, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (d Duration) IsZero() bool {
+	return !d.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (d Duration) isZeroValue() bool {
+	return d.Duration == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Duration is null.
+func (d Duration) String() string {
+	if !d.Valid {
+		return ""
+	}
+	text, _ := d.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (d *Duration) Set(s string) error {
+	return setFlag(d, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (d *Duration) Type() string {
+	return "duration"
+}
+
+// Scan implements the Scanner interface.
+// Integer columns are read as a number of seconds, and text columns as a duration string.
+func (d *Duration) Scan(value interface{}) error {
+	var err error
+	switch x := value.(type) {
+	case nil:
+		d.Duration, d.Valid = 0, false
+		return nil
+	case int64:
+		d.Duration, err = durationFromSeconds(x)
+	case []byte:
+		d.Duration, err = parseDuration(string(x))
+	case string:
+		d.Duration, err = parseDuration(x)
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Duration: %v", value, value)
+	}
+	d.Valid = err == nil
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The duration is stored as a whole number of seconds.
+func (d Duration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return int64(d.Duration / time.Second), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (d Duration) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(d.Valid, uint64(d.Duration)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Duration")
+	if err != nil {
+		return err
+	}
+	d.Duration, d.Valid = time.Duration(v), valid
+	return nil
+}
+
+// parseDuration parses a duration string like "5m", or a number of seconds
+func parseDuration(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return durationFromSeconds(seconds)
+	}
+	return time.ParseDuration(s)
+}
+
+func durationFromSeconds(seconds int64) (time.Duration, error) {
+	if seconds > math.MaxInt64/int64(time.Second) || seconds < math.MinInt64/int64(time.Second) {
+		return 0, fmt.Errorf("null: %d seconds overflows null.Duration", seconds)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Duration is null.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Duration.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It reads the same forms as UnmarshalText.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str *string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == nil {
+		*d = Duration{}
+		return nil
+	}
+	return d.UnmarshalText([]byte(*str))
+}
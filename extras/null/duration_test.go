@@ -0,0 +1,233 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	durationValue         = 5 * time.Minute
+	durationJSON          = []byte(`"5m"`)
+	durationSecondsJSON   = []byte(`300`)
+	durationStringSecJSON = []byte(`"300s"`)
+)
+
+func TestDurationFrom(t *testing.T) {
+	d := DurationFrom(durationValue)
+	assertDuration(t, d, "DurationFrom()")
+
+	zero := DurationFrom(0)
+	if !zero.Valid {
+		t.Error("DurationFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestDurationFromPtr(t *testing.T) {
+	d := DurationFromPtr(&durationValue)
+	assertDuration(t, d, "DurationFromPtr()")
+
+	null := DurationFromPtr(nil)
+	assertNullDuration(t, null, "DurationFromPtr(nil)")
+}
+
+func TestUnmarshalDuration(t *testing.T) {
+	var d Duration
+	err := json.Unmarshal(durationJSON, &d)
+	maybePanic(err)
+	assertDuration(t, d, "duration string json")
+
+	var seconds Duration
+	err = json.Unmarshal(durationSecondsJSON, &seconds)
+	maybePanic(err)
+	assertDuration(t, seconds, "integer seconds json")
+
+	var stringSeconds Duration
+	err = json.Unmarshal(durationStringSecJSON, &stringSeconds)
+	maybePanic(err)
+	assertDuration(t, stringSeconds, "seconds string json")
+
+	var null Duration
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDuration(t, null, "null json")
+
+	var badType Duration
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullDuration(t, badType, "wrong type json")
+
+	var fraction Duration
+	err = json.Unmarshal(float64JSON, &fraction)
+	if err == nil {
+		panic("err should be present; non-integer number of seconds")
+	}
+	assertNullDuration(t, fraction, "non-integer seconds json")
+
+	var badString Duration
+	err = json.Unmarshal([]byte(`"five minutes"`), &badString)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullDuration(t, badString, "bad string json")
+
+	var overflow Duration
+	err = json.Unmarshal([]byte(`9223372036854775807`), &overflow)
+	if err == nil {
+		panic("err should be present; seconds overflow a duration")
+	}
+	assertNullDuration(t, overflow, "overflowing seconds json")
+
+	var invalid Duration
+	err = invalid.UnmarshalJSON(invalidJSON)
+	if _, ok := err.(*json.SyntaxError); !ok {
+		t.Errorf("expected json.SyntaxError, not %T", err)
+	}
+	assertNullDuration(t, invalid, "invalid json")
+}
+
+func TestTextUnmarshalDuration(t *testing.T) {
+	var d Duration
+	err := d.UnmarshalText([]byte("5m"))
+	maybePanic(err)
+	assertDuration(t, d, "UnmarshalText() duration")
+
+	var seconds Duration
+	err = seconds.UnmarshalText([]byte("300"))
+	maybePanic(err)
+	assertDuration(t, seconds, "UnmarshalText() seconds")
+
+	var blank Duration
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullDuration(t, blank, "UnmarshalText() empty duration")
+
+	var invalid Duration
+	err = invalid.UnmarshalText([]byte("hello world"))
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDuration(t, invalid, "bad string")
+}
+
+func TestMarshalDuration(t *testing.T) {
+	d := DurationFrom(durationValue)
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"5m0s"`, "non-empty json marshal")
+
+	var roundTrip Duration
+	err = json.Unmarshal(data, &roundTrip)
+	maybePanic(err)
+	assertDuration(t, roundTrip, "round trip json")
+
+	// invalid values should be encoded as null
+	null := NewDuration(0, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestMarshalDurationText(t *testing.T) {
+	d := DurationFrom(durationValue)
+	data, err := d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "5m0s", "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewDuration(0, false)
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestDurationPointer(t *testing.T) {
+	d := DurationFrom(durationValue)
+	ptr := d.Ptr()
+	if *ptr != durationValue {
+		t.Errorf("bad %s duration: %#v ≠ %s\n", "pointer", ptr, durationValue)
+	}
+
+	null := NewDuration(0, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s duration: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDurationIsNull(t *testing.T) {
+	d := DurationFrom(durationValue)
+	if d.IsNull() {
+		t.Errorf("IsNull() should be false")
+	}
+
+	null := NewDuration(0, false)
+	if !null.IsNull() {
+		t.Errorf("IsNull() should be true")
+	}
+
+	zero := NewDuration(0, true)
+	if zero.IsNull() {
+		t.Errorf("IsNull() should be false")
+	}
+
+	var testInt interface{}
+	testInt = zero
+	if _, ok := testInt.(Nullable); !ok {
+		t.Errorf("Nullable interface should be implemented")
+	}
+}
+
+func TestDurationSetValid(t *testing.T) {
+	change := NewDuration(0, false)
+	assertNullDuration(t, change, "SetValid()")
+	change.SetValid(durationValue)
+	assertDuration(t, change, "SetValid()")
+}
+
+func TestDurationScanValue(t *testing.T) {
+	var d Duration
+	err := d.Scan(int64(300))
+	maybePanic(err)
+	assertDuration(t, d, "scanned seconds")
+	if v, err := d.Value(); v != int64(300) || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var text Duration
+	err = text.Scan([]byte("5m"))
+	maybePanic(err)
+	assertDuration(t, text, "scanned text")
+
+	var null Duration
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullDuration(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong Duration
+	err = wrong.Scan(1.5)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDuration(t, wrong, "scanned wrong")
+}
+
+func assertDuration(t *testing.T, d Duration, from string) {
+	if d.Duration != durationValue {
+		t.Errorf("bad %v duration: %v ≠ %v\n", from, d.Duration, durationValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDuration(t *testing.T, d Duration, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,98 @@
+package null
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestFlags(t *testing.T) {
+	var (
+		b     Bool
+		count Int
+		limit Uint64
+		rate  Float64
+		wait  Duration
+		since Time
+		id    UUID
+		name  String
+		unset Int
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&b, "b", "")
+	fs.Var(&count, "count", "")
+	fs.Var(&limit, "limit", "")
+	fs.Var(&rate, "rate", "")
+	fs.Var(&wait, "wait", "")
+	fs.Var(&since, "since", "")
+	fs.Var(&id, "id", "")
+	fs.Var(name.Flag(), "name", "")
+	fs.Var(&unset, "unset", "")
+
+	err := fs.Parse([]string{"-b", "-count=0", "-limit", "18446744073709551615", "-rate", "0.5", "-wait", "5m",
+		"-since", "2012-12-21T21:21:21Z", "-id", uuidString, "-name="})
+	maybePanic(err)
+
+	if !b.Valid || !b.Bool {
+		t.Error("bool flag passed without a value should be true")
+	}
+	if !count.Valid || count.Int != 0 {
+		t.Error("int flag passed with 0 should be a valid 0")
+	}
+	if !limit.Valid || limit.Uint64 != 18446744073709551615 {
+		t.Errorf("bad uint64 flag %v", limit)
+	}
+	if !rate.Valid || rate.Float64 != 0.5 {
+		t.Errorf("bad float64 flag %v", rate)
+	}
+	if !wait.Valid || wait.Duration != 5*time.Minute {
+		t.Errorf("bad duration flag %v", wait)
+	}
+	if !since.Valid || !since.Time.Equal(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)) {
+		t.Errorf("bad time flag %v", since)
+	}
+	assertUUID(t, id, "uuid flag")
+	if !name.Valid || name.String != "" {
+		t.Error("string flag passed with a blank value should be valid")
+	}
+	if unset.Valid {
+		t.Error("flag that wasn't passed should be null")
+	}
+
+	for _, args := range [][]string{{"-count="}, {"-count=ten"}, {"-b=maybe"}, {"-id=1234"}} {
+		if err := fs.Parse(args); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestFlagStrings(t *testing.T) {
+	tests := []struct {
+		value    FlagValue
+		expected string
+		typ      string
+	}{
+		{&Bool{true, true}, "true", "bool"},
+		{&Bytes{[]byte("hello"), true}, "hello", "bytes"},
+		{&Int{-12, true}, "-12", "int"},
+		{&Uint8{8, true}, "8", "uint8"},
+		{&Float32{1.5, true}, "1.5", "float32"},
+		{&Duration{time.Second, true}, "1s", "duration"},
+		{&Time{time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC), true}, "2012-12-21T21:21:21Z", "time"},
+		{&UUID{uuidValue, true}, uuidString, "uuid"},
+		{(&String{"test", true}).Flag(), "test", "string"},
+		{&Int{}, "", "int"},
+		{&Time{}, "", "time"},
+		{(&String{}).Flag(), "", "string"},
+	}
+	for _, test := range tests {
+		if s := test.value.String(); s != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, s)
+		}
+		if typ := test.value.Type(); typ != test.typ {
+			t.Errorf("expected type %s, got %s", test.typ, typ)
+		}
+	}
+}
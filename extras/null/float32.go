@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"strconv"
 
 	"gopkg.in/nullbio/null.v6/convert"
@@ -36,6 +37,11 @@ func Float32FromPtr(f *float32) Float32 {
 	return NewFloat32(*f, true)
 }
 
+// Float32FromNonZero creates a new Float32 that will be null if v is zero.
+func Float32FromNonZero(v float32) Float32 {
+	return NewFloat32(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (f *Float32) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -46,6 +52,7 @@ func (f *Float32) UnmarshalJSON(data []byte) error {
 
 	var x float64
 	if err := json.Unmarshal(data, &x); err != nil {
+		f.Valid = false
 		return err
 	}
 
@@ -58,7 +65,7 @@ func (f *Float32) UnmarshalJSON(data []byte) error {
 func (f *Float32) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		f.Valid = false
-		return nil
+		return blankText("Float32")
 	}
 	var err error
 	res, err := strconv.ParseFloat(string(text), 32)
@@ -91,6 +98,11 @@ func (f *Float32) SetValid(n float32) {
 	f.Valid = true
 }
 
+// SetNull sets this Float32 to null, clearing its value.
+func (f *Float32) SetNull() {
+	f.Float32, f.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Float32's value, or a nil pointer if this Float32 is null.
 func (f Float32) Ptr() *float32 {
 	if !f.Valid {
@@ -99,19 +111,68 @@ func (f Float32) Ptr() *float32 {
 	return &f.Float32
 }
 
-// IsNull returns true for invalid Float32s, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Float32's value, or zero if it is null.
+func (f Float32) ValueOrZero() float32 {
+	if !f.Valid {
+		return 0
+	}
+	return f.Float32
+}
+
+// ValueOr returns this Float32's value, or def if it is null.
+func (f Float32) ValueOr(def float32) float32 {
+	if !f.Valid {
+		return def
+	}
+	return f.Float32
+}
+
+// IsNull returns true for invalid Float32s.
 func (f Float32) IsNull() bool {
 	return !f.Valid
 }
 
+// IsZero returns true for null Float32s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (f Float32) IsZero() bool {
+	return !f.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (f Float32) isZeroValue() bool {
+	return f.Float32 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Float32 is null.
+func (f Float32) String() string {
+	if !f.Valid {
+		return ""
+	}
+	text, _ := f.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (f *Float32) Set(s string) error {
+	return setFlag(f, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (f *Float32) Type() string {
+	return "float32"
+}
+
 // Scan implements the Scanner interface.
 func (f *Float32) Scan(value interface{}) error {
 	if value == nil {
 		f.Float32, f.Valid = 0, false
 		return nil
 	}
-	f.Valid = true
-	return convert.ConvertAssign(&f.Float32, value)
+	err := convert.ConvertAssign(&f.Float32, value)
+	f.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -121,3 +182,40 @@ func (f Float32) Value() (driver.Value, error) {
 	}
 	return float64(f.Float32), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (f Float32) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(f.Valid, uint64(math.Float32bits(f.Float32))), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *Float32) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Float32")
+	if err != nil {
+		return err
+	}
+	if uint64(uint32(v)) != v {
+		return binaryOverflow(v, "Float32")
+	}
+	f.Float32, f.Valid = math.Float32frombits(uint32(v)), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Float32 is null.
+func (f Float32) MarshalYAML() (interface{}, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return f.Float32, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *Float32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *float32
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*f = Float32FromPtr(v)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"strconv"
 
 	"gopkg.in/nullbio/null.v6/convert"
@@ -36,6 +37,11 @@ func Float64FromPtr(f *float64) Float64 {
 	return NewFloat64(*f, true)
 }
 
+// Float64FromNonZero creates a new Float64 that will be null if v is zero.
+func Float64FromNonZero(v float64) Float64 {
+	return NewFloat64(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (f *Float64) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -45,6 +51,7 @@ func (f *Float64) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &f.Float64); err != nil {
+		f.Valid = false
 		return err
 	}
 
@@ -56,7 +63,7 @@ func (f *Float64) UnmarshalJSON(data []byte) error {
 func (f *Float64) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		f.Valid = false
-		return nil
+		return blankText("Float64")
 	}
 	var err error
 	f.Float64, err = strconv.ParseFloat(string(text), 64)
@@ -86,6 +93,11 @@ func (f *Float64) SetValid(n float64) {
 	f.Valid = true
 }
 
+// SetNull sets this Float64 to null, clearing its value.
+func (f *Float64) SetNull() {
+	f.Float64, f.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Float64's value, or a nil pointer if this Float64 is null.
 func (f Float64) Ptr() *float64 {
 	if !f.Valid {
@@ -94,19 +106,68 @@ func (f Float64) Ptr() *float64 {
 	return &f.Float64
 }
 
-// IsNull returns true for invalid Float64s, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Float64's value, or zero if it is null.
+func (f Float64) ValueOrZero() float64 {
+	if !f.Valid {
+		return 0
+	}
+	return f.Float64
+}
+
+// ValueOr returns this Float64's value, or def if it is null.
+func (f Float64) ValueOr(def float64) float64 {
+	if !f.Valid {
+		return def
+	}
+	return f.Float64
+}
+
+// IsNull returns true for invalid Float64s.
 func (f Float64) IsNull() bool {
 	return !f.Valid
 }
 
+// IsZero returns true for null Float64s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (f Float64) IsZero() bool {
+	return !f.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (f Float64) isZeroValue() bool {
+	return f.Float64 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Float64 is null.
+func (f Float64) String() string {
+	if !f.Valid {
+		return ""
+	}
+	text, _ := f.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (f *Float64) Set(s string) error {
+	return setFlag(f, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (f *Float64) Type() string {
+	return "float64"
+}
+
 // Scan implements the Scanner interface.
 func (f *Float64) Scan(value interface{}) error {
 	if value == nil {
 		f.Float64, f.Valid = 0, false
 		return nil
 	}
-	f.Valid = true
-	return convert.ConvertAssign(&f.Float64, value)
+	err := convert.ConvertAssign(&f.Float64, value)
+	f.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -116,3 +177,37 @@ func (f Float64) Value() (driver.Value, error) {
 	}
 	return f.Float64, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (f Float64) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(f.Valid, math.Float64bits(f.Float64)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *Float64) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Float64")
+	if err != nil {
+		return err
+	}
+	f.Float64, f.Valid = math.Float64frombits(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Float64 is null.
+func (f Float64) MarshalYAML() (interface{}, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return f.Float64, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *Float64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *float64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*f = Float64FromPtr(v)
+	return nil
+}
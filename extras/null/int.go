@@ -36,6 +36,11 @@ func IntFromPtr(i *int) Int {
 	return NewInt(*i, true)
 }
 
+// IntFromNonZero creates a new Int that will be null if v is zero.
+func IntFromNonZero(v int) Int {
+	return NewInt(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (i *Int) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -46,6 +51,7 @@ func (i *Int) UnmarshalJSON(data []byte) error {
 
 	var x int64
 	if err := json.Unmarshal(data, &x); err != nil {
+		i.Valid = false
 		return err
 	}
 
@@ -58,7 +64,7 @@ func (i *Int) UnmarshalJSON(data []byte) error {
 func (i *Int) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		i.Valid = false
-		return nil
+		return blankText("Int")
 	}
 	var err error
 	res, err := strconv.ParseInt(string(text), 10, 0)
@@ -91,6 +97,11 @@ func (i *Int) SetValid(n int) {
 	i.Valid = true
 }
 
+// SetNull sets this Int to null, clearing its value.
+func (i *Int) SetNull() {
+	i.Int, i.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Int's value, or a nil pointer if this Int is null.
 func (i Int) Ptr() *int {
 	if !i.Valid {
@@ -99,19 +110,68 @@ func (i Int) Ptr() *int {
 	return &i.Int
 }
 
-// IsNull returns true for invalid Ints, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Int's value, or zero if it is null.
+func (i Int) ValueOrZero() int {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int
+}
+
+// ValueOr returns this Int's value, or def if it is null.
+func (i Int) ValueOr(def int) int {
+	if !i.Valid {
+		return def
+	}
+	return i.Int
+}
+
+// IsNull returns true for invalid Ints.
 func (i Int) IsNull() bool {
 	return !i.Valid
 }
 
+// IsZero returns true for null Ints, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (i Int) IsZero() bool {
+	return !i.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (i Int) isZeroValue() bool {
+	return i.Int == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Int is null.
+func (i Int) String() string {
+	if !i.Valid {
+		return ""
+	}
+	text, _ := i.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (i *Int) Set(s string) error {
+	return setFlag(i, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (i *Int) Type() string {
+	return "int"
+}
+
 // Scan implements the Scanner interface.
 func (i *Int) Scan(value interface{}) error {
 	if value == nil {
 		i.Int, i.Valid = 0, false
 		return nil
 	}
-	i.Valid = true
-	return convert.ConvertAssign(&i.Int, value)
+	err := convert.ConvertAssign(&i.Int, value)
+	i.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -121,3 +181,40 @@ func (i Int) Value() (driver.Value, error) {
 	}
 	return int64(i.Int), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (i Int) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(i.Valid, uint64(i.Int)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Int")
+	if err != nil {
+		return err
+	}
+	if int64(int(int64(v))) != int64(v) {
+		return binaryOverflow(int64(v), "Int")
+	}
+	i.Int, i.Valid = int(int64(v)), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int is null.
+func (i Int) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *int
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*i = IntFromPtr(v)
+	return nil
+}
@@ -38,6 +38,11 @@ func Int16FromPtr(i *int16) Int16 {
 	return NewInt16(*i, true)
 }
 
+// Int16FromNonZero creates a new Int16 that will be null if v is zero.
+func Int16FromNonZero(v int16) Int16 {
+	return NewInt16(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (i *Int16) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -48,11 +53,13 @@ func (i *Int16) UnmarshalJSON(data []byte) error {
 
 	var x int64
 	if err := json.Unmarshal(data, &x); err != nil {
+		i.Valid = false
 		return err
 	}
 
-	if x > math.MaxInt16 {
-		return fmt.Errorf("json: %d overflows max int16 value", x)
+	if x > math.MaxInt16 || x < math.MinInt16 {
+		i.Valid = false
+		return fmt.Errorf("json: %d overflows int16", x)
 	}
 
 	i.Int16 = int16(x)
@@ -64,7 +71,7 @@ func (i *Int16) UnmarshalJSON(data []byte) error {
 func (i *Int16) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		i.Valid = false
-		return nil
+		return blankText("Int16")
 	}
 	var err error
 	res, err := strconv.ParseInt(string(text), 10, 16)
@@ -97,6 +104,11 @@ func (i *Int16) SetValid(n int16) {
 	i.Valid = true
 }
 
+// SetNull sets this Int16 to null, clearing its value.
+func (i *Int16) SetNull() {
+	i.Int16, i.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Int16's value, or a nil pointer if this Int16 is null.
 func (i Int16) Ptr() *int16 {
 	if !i.Valid {
@@ -105,19 +117,68 @@ func (i Int16) Ptr() *int16 {
 	return &i.Int16
 }
 
-// IsNull returns true for invalid Int16's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Int16's value, or zero if it is null.
+func (i Int16) ValueOrZero() int16 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int16
+}
+
+// ValueOr returns this Int16's value, or def if it is null.
+func (i Int16) ValueOr(def int16) int16 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int16
+}
+
+// IsNull returns true for invalid Int16's.
 func (i Int16) IsNull() bool {
 	return !i.Valid
 }
 
+// IsZero returns true for null Int16s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (i Int16) IsZero() bool {
+	return !i.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (i Int16) isZeroValue() bool {
+	return i.Int16 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Int16 is null.
+func (i Int16) String() string {
+	if !i.Valid {
+		return ""
+	}
+	text, _ := i.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (i *Int16) Set(s string) error {
+	return setFlag(i, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (i *Int16) Type() string {
+	return "int16"
+}
+
 // Scan implements the Scanner interface.
 func (i *Int16) Scan(value interface{}) error {
 	if value == nil {
 		i.Int16, i.Valid = 0, false
 		return nil
 	}
-	i.Valid = true
-	return convert.ConvertAssign(&i.Int16, value)
+	err := convert.ConvertAssign(&i.Int16, value)
+	i.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -127,3 +188,40 @@ func (i Int16) Value() (driver.Value, error) {
 	}
 	return int64(i.Int16), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (i Int16) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(i.Valid, uint64(i.Int16)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int16) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Int16")
+	if err != nil {
+		return err
+	}
+	if int64(int16(int64(v))) != int64(v) {
+		return binaryOverflow(int64(v), "Int16")
+	}
+	i.Int16, i.Valid = int16(int64(v)), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int16 is null.
+func (i Int16) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int16, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *int16
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*i = Int16FromPtr(v)
+	return nil
+}
@@ -80,6 +80,29 @@ func TestUnmarshalInt16Overflow(t *testing.T) {
 	}
 }
 
+func TestUnmarshalInt16Underflow(t *testing.T) {
+	// Min int16 should decode successfully
+	var i Int16
+	err := json.Unmarshal([]byte(strconv.FormatInt(math.MinInt16, 10)), &i)
+	maybePanic(err)
+	// Attempt to underflow
+	err = json.Unmarshal([]byte(strconv.FormatInt(math.MinInt16-1, 10)), &i)
+	if err == nil {
+		panic("err should be present; decoded value underflows int16")
+	}
+	assertNullInt16(t, i, "underflowed json")
+
+	err = i.UnmarshalText([]byte(strconv.FormatInt(math.MinInt16-1, 10)))
+	if err == nil {
+		panic("err should be present; decoded value underflows int16")
+	}
+	err = i.Scan(int64(math.MinInt16 - 1))
+	if err == nil {
+		panic("err should be present; scanned value underflows int16")
+	}
+	assertNullInt16(t, i, "underflowed scan")
+}
+
 func TestTextUnmarshalInt16(t *testing.T) {
 	var i Int16
 	err := i.UnmarshalText([]byte("32766"))
@@ -38,6 +38,11 @@ func Int32FromPtr(i *int32) Int32 {
 	return NewInt32(*i, true)
 }
 
+// Int32FromNonZero creates a new Int32 that will be null if v is zero.
+func Int32FromNonZero(v int32) Int32 {
+	return NewInt32(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (i *Int32) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -48,11 +53,13 @@ func (i *Int32) UnmarshalJSON(data []byte) error {
 
 	var x int64
 	if err := json.Unmarshal(data, &x); err != nil {
+		i.Valid = false
 		return err
 	}
 
-	if x > math.MaxInt32 {
-		return fmt.Errorf("json: %d overflows max int32 value", x)
+	if x > math.MaxInt32 || x < math.MinInt32 {
+		i.Valid = false
+		return fmt.Errorf("json: %d overflows int32", x)
 	}
 
 	i.Int32 = int32(x)
@@ -64,7 +71,7 @@ func (i *Int32) UnmarshalJSON(data []byte) error {
 func (i *Int32) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		i.Valid = false
-		return nil
+		return blankText("Int32")
 	}
 	var err error
 	res, err := strconv.ParseInt(string(text), 10, 32)
@@ -97,6 +104,11 @@ func (i *Int32) SetValid(n int32) {
 	i.Valid = true
 }
 
+// SetNull sets this Int32 to null, clearing its value.
+func (i *Int32) SetNull() {
+	i.Int32, i.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Int32's value, or a nil pointer if this Int32 is null.
 func (i Int32) Ptr() *int32 {
 	if !i.Valid {
@@ -105,19 +117,68 @@ func (i Int32) Ptr() *int32 {
 	return &i.Int32
 }
 
-// IsNull returns true for invalid Int32's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Int32's value, or zero if it is null.
+func (i Int32) ValueOrZero() int32 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int32
+}
+
+// ValueOr returns this Int32's value, or def if it is null.
+func (i Int32) ValueOr(def int32) int32 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int32
+}
+
+// IsNull returns true for invalid Int32's.
 func (i Int32) IsNull() bool {
 	return !i.Valid
 }
 
+// IsZero returns true for null Int32s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (i Int32) IsZero() bool {
+	return !i.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (i Int32) isZeroValue() bool {
+	return i.Int32 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Int32 is null.
+func (i Int32) String() string {
+	if !i.Valid {
+		return ""
+	}
+	text, _ := i.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (i *Int32) Set(s string) error {
+	return setFlag(i, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (i *Int32) Type() string {
+	return "int32"
+}
+
 // Scan implements the Scanner interface.
 func (i *Int32) Scan(value interface{}) error {
 	if value == nil {
 		i.Int32, i.Valid = 0, false
 		return nil
 	}
-	i.Valid = true
-	return convert.ConvertAssign(&i.Int32, value)
+	err := convert.ConvertAssign(&i.Int32, value)
+	i.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -127,3 +188,40 @@ func (i Int32) Value() (driver.Value, error) {
 	}
 	return int64(i.Int32), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (i Int32) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(i.Valid, uint64(i.Int32)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int32) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Int32")
+	if err != nil {
+		return err
+	}
+	if int64(int32(int64(v))) != int64(v) {
+		return binaryOverflow(int64(v), "Int32")
+	}
+	i.Int32, i.Valid = int32(int64(v)), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int32 is null.
+func (i Int32) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int32, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *int32
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*i = Int32FromPtr(v)
+	return nil
+}
@@ -81,6 +81,29 @@ func TestUnmarshalInt32Overflow(t *testing.T) {
 	}
 }
 
+func TestUnmarshalInt32Underflow(t *testing.T) {
+	// Min int32 should decode successfully
+	var i Int32
+	err := json.Unmarshal([]byte(strconv.FormatInt(math.MinInt32, 10)), &i)
+	maybePanic(err)
+	// Attempt to underflow
+	err = json.Unmarshal([]byte(strconv.FormatInt(math.MinInt32-1, 10)), &i)
+	if err == nil {
+		panic("err should be present; decoded value underflows int32")
+	}
+	assertNullInt32(t, i, "underflowed json")
+
+	err = i.UnmarshalText([]byte(strconv.FormatInt(math.MinInt32-1, 10)))
+	if err == nil {
+		panic("err should be present; decoded value underflows int32")
+	}
+	err = i.Scan(int64(math.MinInt32 - 1))
+	if err == nil {
+		panic("err should be present; scanned value underflows int32")
+	}
+	assertNullInt32(t, i, "underflowed scan")
+}
+
 func TestTextUnmarshalInt32(t *testing.T) {
 	var i Int32
 	err := i.UnmarshalText([]byte("2147483646"))
@@ -36,6 +36,11 @@ func Int64FromPtr(i *int64) Int64 {
 	return NewInt64(*i, true)
 }
 
+// Int64FromNonZero creates a new Int64 that will be null if v is zero.
+func Int64FromNonZero(v int64) Int64 {
+	return NewInt64(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (i *Int64) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -45,6 +50,7 @@ func (i *Int64) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &i.Int64); err != nil {
+		i.Valid = false
 		return err
 	}
 
@@ -56,7 +62,7 @@ func (i *Int64) UnmarshalJSON(data []byte) error {
 func (i *Int64) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		i.Valid = false
-		return nil
+		return blankText("Int64")
 	}
 	var err error
 	i.Int64, err = strconv.ParseInt(string(text), 10, 64)
@@ -86,6 +92,11 @@ func (i *Int64) SetValid(n int64) {
 	i.Valid = true
 }
 
+// SetNull sets this Int64 to null, clearing its value.
+func (i *Int64) SetNull() {
+	i.Int64, i.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Int64's value, or a nil pointer if this Int64 is null.
 func (i Int64) Ptr() *int64 {
 	if !i.Valid {
@@ -94,19 +105,68 @@ func (i Int64) Ptr() *int64 {
 	return &i.Int64
 }
 
-// IsNull returns true for invalid Int64's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Int64's value, or zero if it is null.
+func (i Int64) ValueOrZero() int64 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int64
+}
+
+// ValueOr returns this Int64's value, or def if it is null.
+func (i Int64) ValueOr(def int64) int64 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int64
+}
+
+// IsNull returns true for invalid Int64's.
 func (i Int64) IsNull() bool {
 	return !i.Valid
 }
 
+// IsZero returns true for null Int64s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (i Int64) IsZero() bool {
+	return !i.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (i Int64) isZeroValue() bool {
+	return i.Int64 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Int64 is null.
+func (i Int64) String() string {
+	if !i.Valid {
+		return ""
+	}
+	text, _ := i.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (i *Int64) Set(s string) error {
+	return setFlag(i, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (i *Int64) Type() string {
+	return "int64"
+}
+
 // Scan implements the Scanner interface.
 func (i *Int64) Scan(value interface{}) error {
 	if value == nil {
 		i.Int64, i.Valid = 0, false
 		return nil
 	}
-	i.Valid = true
-	return convert.ConvertAssign(&i.Int64, value)
+	err := convert.ConvertAssign(&i.Int64, value)
+	i.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -116,3 +176,37 @@ func (i Int64) Value() (driver.Value, error) {
 	}
 	return i.Int64, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (i Int64) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(i.Valid, uint64(i.Int64)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int64) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Int64")
+	if err != nil {
+		return err
+	}
+	i.Int64, i.Valid = int64(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int64 is null.
+func (i Int64) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int64, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *int64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*i = Int64FromPtr(v)
+	return nil
+}
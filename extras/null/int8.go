@@ -38,6 +38,11 @@ func Int8FromPtr(i *int8) Int8 {
 	return NewInt8(*i, true)
 }
 
+// Int8FromNonZero creates a new Int8 that will be null if v is zero.
+func Int8FromNonZero(v int8) Int8 {
+	return NewInt8(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (i *Int8) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -48,11 +53,13 @@ func (i *Int8) UnmarshalJSON(data []byte) error {
 
 	var x int64
 	if err := json.Unmarshal(data, &x); err != nil {
+		i.Valid = false
 		return err
 	}
 
-	if x > math.MaxInt8 {
-		return fmt.Errorf("json: %d overflows max int8 value", x)
+	if x > math.MaxInt8 || x < math.MinInt8 {
+		i.Valid = false
+		return fmt.Errorf("json: %d overflows int8", x)
 	}
 
 	i.Int8 = int8(x)
@@ -64,7 +71,7 @@ func (i *Int8) UnmarshalJSON(data []byte) error {
 func (i *Int8) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		i.Valid = false
-		return nil
+		return blankText("Int8")
 	}
 	var err error
 	res, err := strconv.ParseInt(string(text), 10, 8)
@@ -97,6 +104,11 @@ func (i *Int8) SetValid(n int8) {
 	i.Valid = true
 }
 
+// SetNull sets this Int8 to null, clearing its value.
+func (i *Int8) SetNull() {
+	i.Int8, i.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Int8's value, or a nil pointer if this Int8 is null.
 func (i Int8) Ptr() *int8 {
 	if !i.Valid {
@@ -105,19 +117,68 @@ func (i Int8) Ptr() *int8 {
 	return &i.Int8
 }
 
-// IsNull returns true for invalid Int8's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Int8's value, or zero if it is null.
+func (i Int8) ValueOrZero() int8 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int8
+}
+
+// ValueOr returns this Int8's value, or def if it is null.
+func (i Int8) ValueOr(def int8) int8 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int8
+}
+
+// IsNull returns true for invalid Int8's.
 func (i Int8) IsNull() bool {
 	return !i.Valid
 }
 
+// IsZero returns true for null Int8s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (i Int8) IsZero() bool {
+	return !i.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (i Int8) isZeroValue() bool {
+	return i.Int8 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Int8 is null.
+func (i Int8) String() string {
+	if !i.Valid {
+		return ""
+	}
+	text, _ := i.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (i *Int8) Set(s string) error {
+	return setFlag(i, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (i *Int8) Type() string {
+	return "int8"
+}
+
 // Scan implements the Scanner interface.
 func (i *Int8) Scan(value interface{}) error {
 	if value == nil {
 		i.Int8, i.Valid = 0, false
 		return nil
 	}
-	i.Valid = true
-	return convert.ConvertAssign(&i.Int8, value)
+	err := convert.ConvertAssign(&i.Int8, value)
+	i.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -127,3 +188,40 @@ func (i Int8) Value() (driver.Value, error) {
 	}
 	return int64(i.Int8), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (i Int8) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(i.Valid, uint64(i.Int8)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int8) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Int8")
+	if err != nil {
+		return err
+	}
+	if int64(int8(int64(v))) != int64(v) {
+		return binaryOverflow(int64(v), "Int8")
+	}
+	i.Int8, i.Valid = int8(int64(v)), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int8 is null.
+func (i Int8) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int8, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *int8
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*i = Int8FromPtr(v)
+	return nil
+}
@@ -81,6 +81,29 @@ func TestUnmarshalInt8Overflow(t *testing.T) {
 	}
 }
 
+func TestUnmarshalInt8Underflow(t *testing.T) {
+	// Min int8 should decode successfully
+	var i Int8
+	err := json.Unmarshal([]byte(strconv.FormatInt(math.MinInt8, 10)), &i)
+	maybePanic(err)
+	// Attempt to underflow
+	err = json.Unmarshal([]byte(strconv.FormatInt(math.MinInt8-1, 10)), &i)
+	if err == nil {
+		panic("err should be present; decoded value underflows int8")
+	}
+	assertNullInt8(t, i, "underflowed json")
+
+	err = i.UnmarshalText([]byte(strconv.FormatInt(math.MinInt8-1, 10)))
+	if err == nil {
+		panic("err should be present; decoded value underflows int8")
+	}
+	err = i.Scan(int64(math.MinInt8 - 1))
+	if err == nil {
+		panic("err should be present; scanned value underflows int8")
+	}
+	assertNullInt8(t, i, "underflowed scan")
+}
+
 func TestTextUnmarshalInt8(t *testing.T) {
 	var i Int8
 	err := i.UnmarshalText([]byte("126"))
@@ -60,6 +60,7 @@ func (j JSON) Unmarshal(dest interface{}) error {
 // UnmarshalJSON implements json.Unmarshaler.
 func (j *JSON) UnmarshalJSON(data []byte) error {
 	if data == nil {
+		j.Valid = false
 		return fmt.Errorf("json: cannot unmarshal nil into Go value of type null.JSON")
 	}
 
@@ -81,11 +82,16 @@ func (j *JSON) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		j.JSON = nil
 		j.Valid = false
-	} else {
-		j.JSON = append(j.JSON[0:0], text...)
-		j.Valid = true
+		return blankText("JSON")
+	}
+	if Strict && !json.Valid(text) {
+		j.JSON = nil
+		j.Valid = false
+		return fmt.Errorf("null: cannot unmarshal invalid JSON text into null.JSON")
 	}
 
+	j.JSON = append(j.JSON[0:0], text...)
+	j.Valid = true
 	return nil
 }
 
@@ -125,6 +131,11 @@ func (j *JSON) SetValid(n []byte) {
 	j.Valid = true
 }
 
+// SetNull sets this JSON to null, clearing its value.
+func (j *JSON) SetNull() {
+	j.JSON, j.Valid = nil, false
+}
+
 // Ptr returns a pointer to this JSON's value, or a nil pointer if this JSON is null.
 func (j JSON) Ptr() *[]byte {
 	if !j.Valid {
@@ -133,19 +144,47 @@ func (j JSON) Ptr() *[]byte {
 	return &j.JSON
 }
 
-// IsNull returns true for null or zero JSON's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this JSON's value, or nil if it is null.
+func (j JSON) ValueOrZero() []byte {
+	if !j.Valid {
+		return nil
+	}
+	return j.JSON
+}
+
+// ValueOr returns this JSON's value, or def if it is null.
+func (j JSON) ValueOr(def []byte) []byte {
+	if !j.Valid {
+		return def
+	}
+	return j.JSON
+}
+
+// IsNull returns true for null or zero JSON's.
 func (j JSON) IsNull() bool {
 	return !j.Valid
 }
 
+// IsZero returns true for null JSONs, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid empty JSON is not zero.
+func (j JSON) IsZero() bool {
+	return !j.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (j JSON) isZeroValue() bool {
+	return len(j.JSON) == 0
+}
+
 // Scan implements the Scanner interface.
 func (j *JSON) Scan(value interface{}) error {
 	if value == nil {
 		j.JSON, j.Valid = []byte{}, false
 		return nil
 	}
-	j.Valid = true
-	return convert.ConvertAssign(&j.JSON, value)
+	err := convert.ConvertAssign(&j.JSON, value)
+	j.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -155,3 +194,81 @@ func (j JSON) Value() (driver.Value, error) {
 	}
 	return j.JSON, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+// The value is kept apart from null even when it is blank, which the text form can't do.
+func (j JSON) MarshalBinary() ([]byte, error) {
+	return marshalBinary(j.Valid, j.JSON), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (j *JSON) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "JSON", -1)
+	if err != nil {
+		return err
+	}
+	j.JSON, j.Valid = nil, valid
+	if valid {
+		j.JSON = append([]byte{}, v...)
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The JSON is written as the equivalent YAML, or null if this JSON is null.
+func (j JSON) MarshalYAML() (interface{}, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(j.JSON, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Any YAML value that JSON can represent is read.
+func (j *JSON) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		j.JSON, j.Valid = NullBytes, false
+		return nil
+	}
+	data, err := json.Marshal(jsonValue(v))
+	if err != nil {
+		return err
+	}
+	j.JSON, j.Valid = data, true
+	return nil
+}
+
+// jsonValue converts the maps YAML is decoded into, which can have keys that aren't strings, to maps that
+// encoding/json can marshal
+func jsonValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[k] = jsonValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(x))
+		for i, e := range x {
+			l[i] = jsonValue(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
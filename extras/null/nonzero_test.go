@@ -0,0 +1,150 @@
+package null
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFromNonZero(t *testing.T) {
+	tests := []struct {
+		name          string
+		zero, nonZero Nullable
+	}{
+		{"Bool", BoolFromNonZero(false), BoolFromNonZero(true)},
+		{"Byte", ByteFromNonZero(0), ByteFromNonZero('b')},
+		{"Bytes", BytesFromNonZero([]byte{}), BytesFromNonZero([]byte("hello"))},
+		{"Decimal", DecimalFromNonZero(decimal.New(0, 3)), DecimalFromNonZero(decimalValue)},
+		{"Duration", DurationFromNonZero(0), DurationFromNonZero(time.Second)},
+		{"Float32", Float32FromNonZero(0), Float32FromNonZero(1.5)},
+		{"Float64", Float64FromNonZero(0), Float64FromNonZero(1.5)},
+		{"Int", IntFromNonZero(0), IntFromNonZero(-1)},
+		{"Int8", Int8FromNonZero(0), Int8FromNonZero(-1)},
+		{"Int16", Int16FromNonZero(0), Int16FromNonZero(-1)},
+		{"Int32", Int32FromNonZero(0), Int32FromNonZero(-1)},
+		{"Int64", Int64FromNonZero(0), Int64FromNonZero(-1)},
+		{"String", StringFromNonZero(""), StringFromNonZero("test")},
+		{"Time", TimeFromNonZero(time.Time{}), TimeFromNonZero(time.Unix(0, 0))},
+		{"Uint", UintFromNonZero(0), UintFromNonZero(1)},
+		{"Uint8", Uint8FromNonZero(0), Uint8FromNonZero(1)},
+		{"Uint16", Uint16FromNonZero(0), Uint16FromNonZero(1)},
+		{"Uint32", Uint32FromNonZero(0), Uint32FromNonZero(1)},
+		{"Uint64", Uint64FromNonZero(0), Uint64FromNonZero(1)},
+		{"UUID", UUIDFromNonZero([16]byte{}), UUIDFromNonZero(uuidValue)},
+	}
+	for _, test := range tests {
+		if !test.zero.IsNull() {
+			t.Errorf("%sFromNonZero(zero) should be null", test.name)
+		}
+		if test.nonZero.IsNull() {
+			t.Errorf("%sFromNonZero() should not be null", test.name)
+		}
+	}
+}
+
+func TestValueOrZero(t *testing.T) {
+	tests := []struct {
+		name        string
+		null, valid interface{}
+		value       interface{}
+	}{
+		{"Bool", Bool{}.ValueOrZero(), BoolFrom(true).ValueOrZero(), true},
+		{"Byte", Byte{}.ValueOrZero(), ByteFrom('b').ValueOrZero(), byte('b')},
+		{"Bytes", Bytes{}.ValueOrZero(), BytesFrom([]byte("hello")).ValueOrZero(), []byte("hello")},
+		{"Decimal", Decimal{}.ValueOrZero(), DecimalFrom(decimalValue).ValueOrZero(), decimalValue},
+		{"Duration", Duration{}.ValueOrZero(), DurationFrom(time.Second).ValueOrZero(), time.Second},
+		{"Float32", Float32{}.ValueOrZero(), Float32From(1.5).ValueOrZero(), float32(1.5)},
+		{"Float64", Float64{}.ValueOrZero(), Float64From(1.5).ValueOrZero(), 1.5},
+		{"Int", Int{}.ValueOrZero(), IntFrom(-1).ValueOrZero(), -1},
+		{"Int8", Int8{}.ValueOrZero(), Int8From(-1).ValueOrZero(), int8(-1)},
+		{"Int16", Int16{}.ValueOrZero(), Int16From(-1).ValueOrZero(), int16(-1)},
+		{"Int32", Int32{}.ValueOrZero(), Int32From(-1).ValueOrZero(), int32(-1)},
+		{"Int64", Int64{}.ValueOrZero(), Int64From(-1).ValueOrZero(), int64(-1)},
+		{"String", String{}.ValueOrZero(), StringFrom("test").ValueOrZero(), "test"},
+		{"Time", Time{}.ValueOrZero(), TimeFrom(time.Unix(0, 0)).ValueOrZero(), time.Unix(0, 0)},
+		{"Uint", Uint{}.ValueOrZero(), UintFrom(1).ValueOrZero(), uint(1)},
+		{"Uint8", Uint8{}.ValueOrZero(), Uint8From(1).ValueOrZero(), uint8(1)},
+		{"Uint16", Uint16{}.ValueOrZero(), Uint16From(1).ValueOrZero(), uint16(1)},
+		{"Uint32", Uint32{}.ValueOrZero(), Uint32From(1).ValueOrZero(), uint32(1)},
+		{"Uint64", Uint64{}.ValueOrZero(), Uint64From(1).ValueOrZero(), uint64(1)},
+		{"UUID", UUID{}.ValueOrZero(), UUIDFrom(uuidValue).ValueOrZero(), uuidValue},
+	}
+	for _, test := range tests {
+		zero := reflect.Zero(reflect.TypeOf(test.value)).Interface()
+		if test.name == "Decimal" {
+			zero = decimal.Zero
+		}
+		if !reflect.DeepEqual(test.null, zero) {
+			t.Errorf("%s: expected null to be %#v, got %#v", test.name, zero, test.null)
+		}
+		if !reflect.DeepEqual(test.valid, test.value) {
+			t.Errorf("%s: expected %#v, got %#v", test.name, test.value, test.valid)
+		}
+	}
+
+	// converting between zero is null and zero is valid
+	if n := IntFromNonZero(IntFrom(0).ValueOrZero()); !n.IsNull() {
+		t.Error("a valid zero should convert to null")
+	}
+	if n := IntFrom(IntFromNonZero(0).ValueOrZero()); !n.Valid || n.Int != 0 {
+		t.Error("null should convert to a valid zero")
+	}
+}
+
+func TestValueOr(t *testing.T) {
+	tests := []struct {
+		name        string
+		null, valid interface{}
+		def, value  interface{}
+	}{
+		{"Bool", Bool{}.ValueOr(true), BoolFrom(false).ValueOr(true), true, false},
+		{"Bytes", Bytes{}.ValueOr([]byte("def")), BytesFrom([]byte{}).ValueOr([]byte("def")), []byte("def"), []byte{}},
+		{"Int", Int{}.ValueOr(5), IntFrom(0).ValueOr(5), 5, 0},
+		{"JSON", JSON{}.ValueOr([]byte("{}")), JSONFrom([]byte("[]")).ValueOr([]byte("{}")), []byte("{}"), []byte("[]")},
+		{"String", String{}.ValueOr("def"), StringFrom("").ValueOr("def"), "def", ""},
+		{"Time", Time{}.ValueOr(time.Unix(1, 0)), TimeFrom(time.Time{}).ValueOr(time.Unix(1, 0)), time.Unix(1, 0), time.Time{}},
+		{"Uint64", Uint64{}.ValueOr(5), Uint64From(0).ValueOr(5), uint64(5), uint64(0)},
+		{"UUID", UUID{}.ValueOr(uuidValue), UUIDFrom([16]byte{}).ValueOr(uuidValue), uuidValue, [16]byte{}},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.null, test.def) {
+			t.Errorf("%s: expected null to be %#v, got %#v", test.name, test.def, test.null)
+		}
+		if !reflect.DeepEqual(test.valid, test.value) {
+			t.Errorf("%s: expected %#v, got %#v", test.name, test.value, test.valid)
+		}
+	}
+
+	if v := (RawMessage{}).ValueOrZero(); v != nil {
+		t.Errorf("expected null RawMessage to be nil, got %s", v)
+	}
+	if v := RawMessageFrom([]byte("null")).ValueOrZero(); string(v) != "null" {
+		t.Errorf("expected RawMessage holding null, got %s", v)
+	}
+}
+
+func TestSetNull(t *testing.T) {
+	dec, _ := DecimalFromString("1.5")
+	values := []interface {
+		Nullable
+		SetNull()
+	}{
+		&Bool{true, true}, &Byte{'b', true}, &Bytes{[]byte("b"), true}, &dec, &Duration{time.Second, true},
+		&Float32{1, true}, &Float64{1, true}, &Int{1, true}, &Int8{1, true}, &Int16{1, true}, &Int32{1, true},
+		&Int64{1, true}, &JSON{[]byte("{}"), true}, &RawMessage{[]byte("{}"), true}, &String{"a", true},
+		&Time{time.Now(), true}, &Uint{1, true}, &Uint8{1, true}, &Uint16{1, true}, &Uint32{1, true},
+		&Uint64{1, true}, &UUID{uuidValue, true},
+	}
+	for _, v := range values {
+		v.SetNull()
+		if !v.IsNull() {
+			t.Errorf("expected %T to be null", v)
+		}
+		field := reflect.ValueOf(v).Elem().Field(0)
+		if zero := reflect.Zero(field.Type()).Interface(); !reflect.DeepEqual(field.Interface(), zero) {
+			t.Errorf("expected %T's value to be cleared, got %#v", v, field.Interface())
+		}
+	}
+}
@@ -1,5 +1,48 @@
 package null
 
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+)
+
 type Nullable interface {
 	IsNull() bool
 }
+
+// FlagValue is a flag.Value that also works with github.com/spf13/pflag. All types but JSON, RawMessage and
+// String implement it, and String.Flag returns one. The flag is null until it is passed, so a flag that wasn't
+// passed can be told apart from one passed with a zero value.
+type FlagValue interface {
+	flag.Value
+	Type() string
+}
+
+// setFlag sets a flag's value from its text form. Unlike UnmarshalText, a blank value is an error rather than
+// null, since a flag that was passed should have a value.
+func setFlag(v encoding.TextUnmarshaler, s string) error {
+	if s == "" {
+		return errors.New("null: flag needs a value")
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Strict makes parsing stricter, for services where silently getting null for bad input is worse than an error.
+// It should be set once, before anything is parsed.
+//
+// With Strict set, UnmarshalText returns an error for blank text rather than unmarshalling to null, except for
+// String and Bytes, which unmarshal it to a valid blank value. JSON's UnmarshalText also returns an error for
+// text that isn't JSON. Without it, blank text is null.
+//
+// Either way, the numeric types don't unmarshal from JSON strings, and a value that fails to unmarshal or scan
+// is left null.
+var Strict bool
+
+// blankText is what UnmarshalText returns for blank text, which is null unless Strict is set
+func blankText(typ string) error {
+	if Strict {
+		return fmt.Errorf("null: cannot unmarshal blank text into null.%s", typ)
+	}
+	return nil
+}
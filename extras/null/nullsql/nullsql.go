@@ -0,0 +1,90 @@
+// Package nullsql has predicates for building WHERE clauses from null values, which treat null as SQL NULL. They
+// implement github.com/Masterminds/squirrel's Sqlizer interface, so they can be passed to its Where methods, and
+// work without it too.
+package nullsql
+
+import (
+	"database/sql/driver"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/null"
+)
+
+// Sqlizer is anything that can be written as SQL with positional placeholders and their arguments. It matches
+// squirrel's Sqlizer.
+type Sqlizer interface {
+	ToSql() (string, []interface{}, error)
+}
+
+// NullableArg returns the argument to pass to a query for v: nil, which drivers send as NULL, if v is null, or
+// v's driver value otherwise.
+func NullableArg(v null.Nullable) interface{} {
+	if v == nil || v.IsNull() {
+		return nil
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			// the driver will return the error when it calls Value itself
+			return v
+		}
+		return value
+	}
+	return v
+}
+
+// EqOrIsNull is a predicate that compares a column to a value. A comparison with NULL is never true in SQL, so
+// if the value is null, the predicate checks that the column IS NULL instead.
+type EqOrIsNull struct {
+	Column string
+	Value  null.Nullable
+}
+
+// ToSql implements Sqlizer.
+func (e EqOrIsNull) ToSql() (string, []interface{}, error) {
+	arg := NullableArg(e.Value)
+	if arg == nil {
+		return e.Column + " IS NULL", nil, nil
+	}
+	return e.Column + " = ?", []interface{}{arg}, nil
+}
+
+// NotEqOrIsNotNull is the opposite of EqOrIsNull. If the value is null, it checks that the column IS NOT NULL.
+type NotEqOrIsNotNull struct {
+	Column string
+	Value  null.Nullable
+}
+
+// ToSql implements Sqlizer.
+func (n NotEqOrIsNotNull) ToSql() (string, []interface{}, error) {
+	arg := NullableArg(n.Value)
+	if arg == nil {
+		return n.Column + " IS NOT NULL", nil, nil
+	}
+	// a NULL column isn't equal to the value either
+	return "(" + n.Column + " <> ? OR " + n.Column + " IS NULL)", []interface{}{arg}, nil
+}
+
+// And joins predicates with AND, for writing a WHERE clause without squirrel. No predicates are always true.
+type And []Sqlizer
+
+// ToSql implements Sqlizer.
+func (a And) ToSql() (string, []interface{}, error) {
+	if len(a) == 0 {
+		return "1=1", nil, nil
+	}
+	parts := make([]string, 0, len(a))
+	var args []interface{}
+	for _, pred := range a {
+		sql, predArgs, err := pred.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, sql)
+		args = append(args, predArgs...)
+	}
+	if len(parts) == 1 {
+		return parts[0], args, nil
+	}
+	return "(" + strings.Join(parts, " AND ") + ")", args, nil
+}
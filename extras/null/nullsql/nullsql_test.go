@@ -0,0 +1,59 @@
+package nullsql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/null"
+)
+
+func TestNullableArg(t *testing.T) {
+	args := []struct {
+		value    null.Nullable
+		expected interface{}
+	}{
+		{nil, nil},
+		{null.Int{}, nil},
+		{null.String{}, nil},
+		{null.IntFrom(0), int64(0)},
+		{null.StringFrom(""), ""},
+		{null.BoolFrom(false), false},
+	}
+	for _, arg := range args {
+		if actual := NullableArg(arg.value); !reflect.DeepEqual(actual, arg.expected) {
+			t.Errorf("expected %#v to be %#v, got %#v", arg.value, arg.expected, actual)
+		}
+	}
+}
+
+func assertSql(t *testing.T, pred Sqlizer, sql string, args ...interface{}) {
+	t.Helper()
+	actualSQL, actualArgs, err := pred.ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actualSQL != sql || !reflect.DeepEqual(actualArgs, args) {
+		t.Errorf("expected %q %v, got %q %v", sql, args, actualSQL, actualArgs)
+	}
+}
+
+func TestEqOrIsNull(t *testing.T) {
+	assertSql(t, EqOrIsNull{"channel_id", null.StringFrom("abc")}, "channel_id = ?", "abc")
+	assertSql(t, EqOrIsNull{"channel_id", null.String{}}, "channel_id IS NULL")
+	assertSql(t, NotEqOrIsNotNull{"height", null.IntFrom(5)}, "(height <> ? OR height IS NULL)", int64(5))
+	assertSql(t, NotEqOrIsNotNull{"height", null.Int{}}, "height IS NOT NULL")
+}
+
+func TestAnd(t *testing.T) {
+	filter := struct {
+		ChannelID null.String
+		Height    null.Int
+	}{null.String{}, null.IntFrom(5)}
+	where := And{
+		EqOrIsNull{"channel_id", filter.ChannelID},
+		EqOrIsNull{"height", filter.Height},
+	}
+	assertSql(t, where, "(channel_id IS NULL AND height = ?)", int64(5))
+	assertSql(t, And{EqOrIsNull{"height", filter.Height}}, "height = ?", int64(5))
+	assertSql(t, And{}, "1=1")
+}
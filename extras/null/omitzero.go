@@ -0,0 +1,68 @@
+package null
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// OmitZero wraps a value so that its IsZero method also returns true when the value is a valid zero, like 0, ""
+// or an empty Bytes. The types' own IsZero only returns true when they are null. Use it for output fields where
+// a zero says no more than null does:
+//
+//	type Result struct {
+//		Count null.OmitZero `json:"count,omitzero"`
+//	}
+//
+// It marshals the same as the value it wraps, and is only meant for output.
+type OmitZero struct {
+	Value Nullable
+}
+
+// IsZero returns true if the value is null or a valid zero. Nullable types from outside this package are only
+// zero when they are null.
+func (o OmitZero) IsZero() bool {
+	if o.Value == nil {
+		return true
+	}
+	// a nil pointer can't be asked if it is null
+	if v := reflect.ValueOf(o.Value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return true
+	}
+	if o.Value.IsNull() {
+		return true
+	}
+	z, ok := o.Value.(zeroValuer)
+	return ok && z.isZeroValue()
+}
+
+// zeroValuer is implemented by every type in this package
+type zeroValuer interface {
+	isZeroValue() bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o OmitZero) MarshalJSON() ([]byte, error) {
+	if o.Value == nil {
+		return NullBytes, nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (o OmitZero) MarshalText() ([]byte, error) {
+	if o.Value == nil {
+		return []byte{}, nil
+	}
+	m, ok := o.Value.(encoding.TextMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("null: cannot marshal %T to text", o.Value)
+	}
+	return m.MarshalText()
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (o OmitZero) MarshalYAML() (interface{}, error) {
+	return o.Value, nil
+}
@@ -0,0 +1,63 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestIsZero(t *testing.T) {
+	nulls := []interface{ IsZero() bool }{Bool{}, Bytes{}, Int{}, JSON{}, RawMessage{}, String{}, Time{}, UUID{}}
+	for _, n := range nulls {
+		if !n.IsZero() {
+			t.Errorf("expected null %T to be zero", n)
+		}
+	}
+	valid := []interface{ IsZero() bool }{
+		BoolFrom(false), BytesFrom([]byte{}), IntFrom(0), RawMessageFrom([]byte("null")), StringFrom(""),
+		TimeFrom(time.Time{}), UUIDFrom([16]byte{}),
+	}
+	for _, v := range valid {
+		if v.IsZero() {
+			t.Errorf("expected valid %T to not be zero", v)
+		}
+	}
+}
+
+func TestOmitZero(t *testing.T) {
+	zero := []Nullable{
+		nil, (*Int)(nil), Int{}, IntFrom(0), &String{}, StringFrom(""), BytesFrom([]byte{}), BoolFrom(false),
+		TimeFrom(time.Time{}), DecimalFrom(Decimal{}.Decimal), UUIDFrom([16]byte{}), Float64From(0), Uint8From(0),
+		DurationFrom(0), JSONFrom(nil), RawMessage{Valid: true},
+	}
+	for _, v := range zero {
+		if !(OmitZero{v}).IsZero() {
+			t.Errorf("expected %#v to be zero", v)
+		}
+	}
+	nonZero := []Nullable{
+		IntFrom(1), StringFrom("a"), BoolFrom(true), TimeFrom(time.Now()), RawMessageFrom([]byte("null")), Float64From(0.5),
+		Uint8From(1), DurationFrom(time.Second), UUIDFrom([16]byte{1}), BytesFrom([]byte{0}),
+	}
+	for _, v := range nonZero {
+		if (OmitZero{v}).IsZero() {
+			t.Errorf("expected %#v to not be zero", v)
+		}
+	}
+
+	data, err := json.Marshal(OmitZero{IntFrom(12)})
+	maybePanic(err)
+	assertJSONEquals(t, data, "12", "OmitZero json")
+	data, err = json.Marshal(OmitZero{})
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "empty OmitZero json")
+	text, err := OmitZero{StringFrom("test")}.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, text, "test", "OmitZero text")
+}
+
+// every type must implement zeroValuer, or OmitZero treats its valid zeros as non-zero
+var _ = []zeroValuer{
+	Bool{}, Byte{}, Bytes{}, Decimal{}, Duration{}, Float32{}, Float64{}, Int{}, Int8{}, Int16{}, Int32{}, Int64{}, JSON{},
+	RawMessage{}, String{}, Time{}, UUID{}, Uint{}, Uint8{}, Uint16{}, Uint32{}, Uint64{},
+}
@@ -0,0 +1,209 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// RawMessage is a nullable json.RawMessage, for JSON and JSONB columns. Unlike JSON, a JSON null is a valid
+// RawMessage holding null, so a NULL column can be told apart from a column holding null. In JSON both are
+// encoded as null, but a struct field is only left null if its key is missing.
+type RawMessage struct {
+	RawMessage json.RawMessage
+	Valid      bool
+}
+
+// NewRawMessage creates a new RawMessage
+func NewRawMessage(m json.RawMessage, valid bool) RawMessage {
+	return RawMessage{
+		RawMessage: m,
+		Valid:      valid,
+	}
+}
+
+// RawMessageFrom creates a new RawMessage that will be invalid if nil.
+func RawMessageFrom(m json.RawMessage) RawMessage {
+	return NewRawMessage(m, m != nil)
+}
+
+// RawMessageFromPtr creates a new RawMessage that will be invalid if nil.
+func RawMessageFromPtr(m *json.RawMessage) RawMessage {
+	if m == nil {
+		return NewRawMessage(nil, false)
+	}
+	return NewRawMessage(*m, true)
+}
+
+// Unmarshal unmarshals the message into dest. A null RawMessage is unmarshalled like JSON null.
+func (m RawMessage) Unmarshal(dest interface{}) error {
+	return json.Unmarshal(m.raw(), dest)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// JSON null is kept as a valid message holding null.
+func (m *RawMessage) UnmarshalJSON(data []byte) error {
+	return m.set(data)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null RawMessage if the input is blank.
+func (m *RawMessage) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		m.RawMessage, m.Valid = nil, false
+		return blankText("RawMessage")
+	}
+	return m.set(text)
+}
+
+// MarshalJSON implements json.Marshaler.
+// The message is written as it is, or as null if this RawMessage is null.
+func (m RawMessage) MarshalJSON() ([]byte, error) {
+	return m.raw(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this RawMessage is null.
+func (m RawMessage) MarshalText() ([]byte, error) {
+	if !m.Valid {
+		return []byte{}, nil
+	}
+	return m.raw(), nil
+}
+
+// SetValid changes this RawMessage's value and also sets it to be non-null.
+func (m *RawMessage) SetValid(v json.RawMessage) {
+	m.RawMessage = v
+	m.Valid = true
+}
+
+// SetNull sets this RawMessage to null, clearing its value.
+func (m *RawMessage) SetNull() {
+	m.RawMessage, m.Valid = nil, false
+}
+
+// Ptr returns a pointer to this RawMessage's value, or a nil pointer if this RawMessage is null.
+func (m RawMessage) Ptr() *json.RawMessage {
+	if !m.Valid {
+		return nil
+	}
+	return &m.RawMessage
+}
+
+// ValueOrZero returns this RawMessage's value, or nil if it is null.
+func (m RawMessage) ValueOrZero() json.RawMessage {
+	if !m.Valid {
+		return nil
+	}
+	return m.RawMessage
+}
+
+// ValueOr returns this RawMessage's value, or def if it is null.
+func (m RawMessage) ValueOr(def json.RawMessage) json.RawMessage {
+	if !m.Valid {
+		return def
+	}
+	return m.RawMessage
+}
+
+// IsNull returns true for null RawMessages. A RawMessage holding JSON null is not null.
+func (m RawMessage) IsNull() bool {
+	return !m.Valid
+}
+
+// IsZero returns true for null RawMessages, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid empty RawMessage is not zero.
+func (m RawMessage) IsZero() bool {
+	return !m.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (m RawMessage) isZeroValue() bool {
+	return len(m.RawMessage) == 0
+}
+
+// Scan implements the Scanner interface.
+// The column's bytes are copied as they are, and must be valid JSON.
+func (m *RawMessage) Scan(value interface{}) error {
+	switch x := value.(type) {
+	case nil:
+		m.RawMessage, m.Valid = nil, false
+		return nil
+	case []byte:
+		return m.set(x)
+	case string:
+		return m.set([]byte(x))
+	default:
+		m.RawMessage, m.Valid = nil, false
+		return fmt.Errorf("null: cannot scan type %T into null.RawMessage: %v", value, value)
+	}
+}
+
+// Value implements the driver Valuer interface.
+func (m RawMessage) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	return []byte(m.raw()), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+// The value is kept apart from null even when it is blank, which the text form can't do.
+func (m RawMessage) MarshalBinary() ([]byte, error) {
+	return marshalBinary(m.Valid, m.RawMessage), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *RawMessage) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "RawMessage", -1)
+	if err != nil {
+		return err
+	}
+	m.RawMessage, m.Valid = nil, valid
+	if valid {
+		m.RawMessage = append(json.RawMessage{}, v...)
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The message is written as the equivalent YAML, or null if this RawMessage is null.
+func (m RawMessage) MarshalYAML() (interface{}, error) {
+	return JSON{JSON: m.raw(), Valid: m.Valid}.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Any YAML value that JSON can represent is read.
+func (m *RawMessage) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var j JSON
+	if err := j.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	if !j.Valid {
+		m.RawMessage, m.Valid = nil, false
+		return nil
+	}
+	m.RawMessage, m.Valid = j.JSON, true
+	return nil
+}
+
+// set copies data, which drivers and decoders may reuse, if it is valid JSON
+func (m *RawMessage) set(data []byte) error {
+	if !json.Valid(data) {
+		m.RawMessage, m.Valid = nil, false
+		// for the *json.SyntaxError saying what is wrong with it
+		var v interface{}
+		return json.Unmarshal(data, &v)
+	}
+	m.RawMessage = append(json.RawMessage(nil), data...)
+	m.Valid = true
+	return nil
+}
+
+// raw returns the message, or JSON null if this RawMessage is null or empty
+func (m RawMessage) raw() json.RawMessage {
+	if !m.Valid || len(m.RawMessage) == 0 {
+		return json.RawMessage(NullBytes)
+	}
+	return m.RawMessage
+}
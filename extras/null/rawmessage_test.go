@@ -0,0 +1,202 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var (
+	rawMessageJSON = []byte(`{"title": "hello", "tags": ["a", "b"]}`)
+)
+
+func TestRawMessageFrom(t *testing.T) {
+	m := RawMessageFrom(rawMessageJSON)
+	assertRawMessage(t, m, string(rawMessageJSON), "RawMessageFrom()")
+
+	null := RawMessageFrom(nil)
+	assertNullRawMessage(t, null, "RawMessageFrom(nil)")
+}
+
+func TestRawMessageFromPtr(t *testing.T) {
+	raw := json.RawMessage(rawMessageJSON)
+	m := RawMessageFromPtr(&raw)
+	assertRawMessage(t, m, string(rawMessageJSON), "RawMessageFromPtr()")
+
+	null := RawMessageFromPtr(nil)
+	assertNullRawMessage(t, null, "RawMessageFromPtr(nil)")
+}
+
+func TestUnmarshalRawMessage(t *testing.T) {
+	var doc struct {
+		Missing RawMessage `json:"missing"`
+		Null    RawMessage `json:"null"`
+		Value   RawMessage `json:"value"`
+	}
+	err := json.Unmarshal([]byte(`{"null": null, "value": `+string(rawMessageJSON)+`}`), &doc)
+	maybePanic(err)
+	assertNullRawMessage(t, doc.Missing, "missing key")
+	assertRawMessage(t, doc.Null, "null", "json null")
+	// the raw bytes are kept as they are, spaces and all
+	assertRawMessage(t, doc.Value, string(rawMessageJSON), "json object")
+
+	var invalid RawMessage
+	err = invalid.UnmarshalJSON(invalidJSON)
+	if _, ok := err.(*json.SyntaxError); !ok {
+		t.Errorf("expected json.SyntaxError, not %T", err)
+	}
+	assertNullRawMessage(t, invalid, "invalid json")
+}
+
+func TestTextUnmarshalRawMessage(t *testing.T) {
+	var m RawMessage
+	err := m.UnmarshalText(rawMessageJSON)
+	maybePanic(err)
+	assertRawMessage(t, m, string(rawMessageJSON), "UnmarshalText() object")
+
+	var blank RawMessage
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullRawMessage(t, blank, "UnmarshalText() empty")
+}
+
+func TestMarshalRawMessage(t *testing.T) {
+	m := RawMessageFrom([]byte(`{"a":1}`))
+	data, err := json.Marshal(m)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"a":1}`, "non-empty json marshal")
+
+	data, err = json.Marshal(NewRawMessage(nil, true))
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "empty json marshal")
+
+	// invalid values should be encoded as null
+	null := NewRawMessage(nil, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestMarshalRawMessageText(t *testing.T) {
+	m := RawMessageFrom([]byte(`{"a":1}`))
+	data, err := m.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"a":1}`, "non-empty text marshal")
+
+	// invalid values should be encoded as an empty string
+	null := NewRawMessage(nil, false)
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestRawMessageUnmarshal(t *testing.T) {
+	var dest struct {
+		Title string
+	}
+	m := RawMessageFrom(rawMessageJSON)
+	err := m.Unmarshal(&dest)
+	maybePanic(err)
+	if dest.Title != "hello" {
+		t.Errorf("expected hello, got %s", dest.Title)
+	}
+
+	var ptr *struct{}
+	err = NewRawMessage(nil, false).Unmarshal(&ptr)
+	maybePanic(err)
+	if ptr != nil {
+		t.Error("null should unmarshal like JSON null")
+	}
+}
+
+func TestRawMessagePointer(t *testing.T) {
+	m := RawMessageFrom(rawMessageJSON)
+	ptr := m.Ptr()
+	if string(*ptr) != string(rawMessageJSON) {
+		t.Errorf("bad %s raw message: %s ≠ %s\n", "pointer", *ptr, rawMessageJSON)
+	}
+
+	null := NewRawMessage(nil, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s raw message: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestRawMessageIsNull(t *testing.T) {
+	m := RawMessageFrom(NullBytes)
+	if m.IsNull() {
+		t.Errorf("IsNull() should be false for JSON null")
+	}
+
+	null := NewRawMessage(nil, false)
+	if !null.IsNull() {
+		t.Errorf("IsNull() should be true")
+	}
+
+	var testInt interface{}
+	testInt = m
+	if _, ok := testInt.(Nullable); !ok {
+		t.Errorf("Nullable interface should be implemented")
+	}
+}
+
+func TestRawMessageSetValid(t *testing.T) {
+	change := NewRawMessage(nil, false)
+	assertNullRawMessage(t, change, "SetValid()")
+	change.SetValid(rawMessageJSON)
+	assertRawMessage(t, change, string(rawMessageJSON), "SetValid()")
+}
+
+func TestRawMessageScanValue(t *testing.T) {
+	// drivers reuse the buffer they scan from
+	buf := append([]byte(nil), rawMessageJSON...)
+	var m RawMessage
+	err := m.Scan(buf)
+	maybePanic(err)
+	buf[0] = '['
+	assertRawMessage(t, m, string(rawMessageJSON), "scanned jsonb")
+	if v, err := m.Value(); string(v.([]byte)) != string(rawMessageJSON) || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var jsonNull RawMessage
+	err = jsonNull.Scan("null")
+	maybePanic(err)
+	assertRawMessage(t, jsonNull, "null", "scanned json null")
+	if v, err := jsonNull.Value(); string(v.([]byte)) != "null" || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var null RawMessage
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullRawMessage(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong RawMessage
+	if err = wrong.Scan([]byte("{")); err == nil {
+		t.Error("expected error")
+	}
+	assertNullRawMessage(t, wrong, "scanned invalid json")
+	if err = wrong.Scan(int64(1)); err == nil {
+		t.Error("expected error")
+	}
+	assertNullRawMessage(t, wrong, "scanned wrong type")
+}
+
+func assertRawMessage(t *testing.T, m RawMessage, expected string, from string) {
+	if string(m.RawMessage) != expected {
+		t.Errorf("bad %v raw message: %s ≠ %s\n", from, m.RawMessage, expected)
+	}
+	if !m.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullRawMessage(t *testing.T, m RawMessage, from string) {
+	if m.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
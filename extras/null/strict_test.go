@@ -0,0 +1,108 @@
+package null
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+)
+
+func TestStrictText(t *testing.T) {
+	Strict = true
+	defer func() { Strict = false }()
+
+	for _, v := range []encoding.TextUnmarshaler{
+		&Bool{}, &Byte{}, &Decimal{}, &Duration{}, &Float32{}, &Float64{}, &Int{}, &Int8{}, &Int16{}, &Int32{},
+		&Int64{}, &JSON{}, &RawMessage{}, &Time{}, &Uint{}, &Uint8{}, &Uint16{}, &Uint32{}, &Uint64{}, &UUID{},
+	} {
+		if err := v.UnmarshalText([]byte("")); err == nil {
+			t.Errorf("%T: expected an error for blank text", v)
+		}
+		if !v.(Nullable).IsNull() {
+			t.Errorf("%T: should be null", v)
+		}
+	}
+
+	var s String
+	err := s.UnmarshalText([]byte(""))
+	maybePanic(err)
+	if !s.Valid || s.String != "" {
+		t.Error("blank text should be a valid blank String")
+	}
+
+	var b Bytes
+	err = b.UnmarshalText([]byte(""))
+	maybePanic(err)
+	if !b.Valid || b.Bytes == nil || len(b.Bytes) != 0 {
+		t.Error("blank text should be a valid empty Bytes")
+	}
+
+	var j JSON
+	if err = j.UnmarshalText([]byte("{")); err == nil {
+		t.Error("expected an error for text that isn't JSON")
+	}
+	assertNullJSON(t, j, "invalid json text")
+
+	var by Byte
+	if err = by.UnmarshalJSON([]byte{}); err == nil {
+		t.Error("expected an error for empty json")
+	}
+}
+
+func TestLenientText(t *testing.T) {
+	var i Int
+	err := i.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullInt(t, i, "blank text")
+
+	var s String
+	err = s.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullStr(t, s, "blank text")
+
+	var j JSON
+	err = j.UnmarshalText([]byte("{"))
+	maybePanic(err)
+	if !j.Valid {
+		t.Error("text that isn't JSON is kept without Strict")
+	}
+}
+
+func TestNumbersRejectStrings(t *testing.T) {
+	for _, v := range []json.Unmarshaler{
+		&Float32{}, &Float64{}, &Int{}, &Int8{}, &Int16{}, &Int32{}, &Int64{},
+		&Uint{}, &Uint8{}, &Uint16{}, &Uint32{}, &Uint64{},
+	} {
+		if err := json.Unmarshal([]byte(`"12"`), v); err == nil {
+			t.Errorf("%T: expected an error for a JSON string", v)
+		}
+		if !v.(Nullable).IsNull() {
+			t.Errorf("%T: should be null", v)
+		}
+	}
+}
+
+func TestFailuresLeaveNull(t *testing.T) {
+	i := IntFrom(12)
+	if err := json.Unmarshal([]byte(`"twelve"`), &i); err == nil {
+		t.Error("expected an error")
+	}
+	assertNullInt(t, i, "failed json unmarshal")
+
+	i = IntFrom(12)
+	if err := i.UnmarshalText([]byte("twelve")); err == nil {
+		t.Error("expected an error")
+	}
+	assertNullInt(t, i, "failed text unmarshal")
+
+	i = IntFrom(12)
+	if err := i.Scan("twelve"); err == nil {
+		t.Error("expected an error")
+	}
+	assertNullInt(t, i, "failed scan")
+
+	tm := TimeFrom(timeValue)
+	if err := tm.UnmarshalText([]byte("yesterday")); err == nil {
+		t.Error("expected an error")
+	}
+	assertNullTime(t, tm, "failed text unmarshal")
+}
@@ -27,6 +27,11 @@ func StringFromPtr(s *string) String {
 	return NewString(*s, true)
 }
 
+// StringFromNonZero creates a new String that will be null if v is blank.
+func StringFromNonZero(v string) String {
+	return NewString(v, v != "")
+}
+
 // NewString creates a new String
 func NewString(s string, valid bool) String {
 	return String{
@@ -44,6 +49,7 @@ func (s *String) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &s.String); err != nil {
+		s.Valid = false
 		return err
 	}
 
@@ -70,7 +76,9 @@ func (s String) MarshalText() ([]byte, error) {
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (s *String) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
-		s.Valid = false
+		// in strict mode, blank text is a blank string
+		s.String = ""
+		s.Valid = Strict
 		return nil
 	}
 
@@ -85,6 +93,11 @@ func (s *String) SetValid(v string) {
 	s.Valid = true
 }
 
+// SetNull sets this String to null, clearing its value.
+func (s *String) SetNull() {
+	s.String, s.Valid = "", false
+}
+
 // Ptr returns a pointer to this String's value, or a nil pointer if this String is null.
 func (s String) Ptr() *string {
 	if !s.Valid {
@@ -93,19 +106,74 @@ func (s String) Ptr() *string {
 	return &s.String
 }
 
-// IsNull returns true for null strings, for potential future omitempty support.
+// ValueOrZero returns this String's value, or blank if it is null.
+func (s String) ValueOrZero() string {
+	if !s.Valid {
+		return ""
+	}
+	return s.String
+}
+
+// ValueOr returns this String's value, or def if it is null.
+func (s String) ValueOr(def string) string {
+	if !s.Valid {
+		return def
+	}
+	return s.String
+}
+
+// IsNull returns true for null strings.
 func (s String) IsNull() bool {
 	return !s.Valid
 }
 
+// IsZero returns true for null Strings, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid "" is not zero.
+func (s String) IsZero() bool {
+	return !s.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (s String) isZeroValue() bool {
+	return s.String == ""
+}
+
+// Flag returns a flag.Value that sets this String. String can't implement flag.Value itself, since its String
+// field takes the String method's name. A flag passed with a blank value is set to blank rather than null.
+func (s *String) Flag() FlagValue {
+	return stringFlag{s}
+}
+
+// stringFlag is the flag.Value for a String
+type stringFlag struct {
+	s *String
+}
+
+func (f stringFlag) String() string {
+	if f.s == nil || !f.s.Valid {
+		return ""
+	}
+	return f.s.String
+}
+
+func (f stringFlag) Set(v string) error {
+	f.s.SetValid(v)
+	return nil
+}
+
+func (f stringFlag) Type() string {
+	return "string"
+}
+
 // Scan implements the Scanner interface.
 func (s *String) Scan(value interface{}) error {
 	if value == nil {
 		s.String, s.Valid = "", false
 		return nil
 	}
-	s.Valid = true
-	return convert.ConvertAssign(&s.String, value)
+	err := convert.ConvertAssign(&s.String, value)
+	s.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -115,3 +183,38 @@ func (s String) Value() (driver.Value, error) {
 	}
 	return s.String, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+// The value is kept apart from null even when it is blank, which the text form can't do.
+func (s String) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.Valid, []byte(s.String)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *String) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "String", -1)
+	if err != nil {
+		return err
+	}
+	s.String, s.Valid = string(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this String is null.
+func (s String) MarshalYAML() (interface{}, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return s.String, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *String) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*s = StringFromPtr(v)
+	return nil
+}
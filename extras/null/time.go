@@ -34,6 +34,11 @@ func TimeFromPtr(t *time.Time) Time {
 	return NewTime(*t, true)
 }
 
+// TimeFromNonZero creates a new Time that will be null if v is the zero time.
+func TimeFromNonZero(v time.Time) Time {
+	return NewTime(v, !v.IsZero())
+}
+
 // MarshalJSON implements json.Marshaler.
 func (t Time) MarshalJSON() ([]byte, error) {
 	if !t.Valid {
@@ -51,6 +56,7 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := t.Time.UnmarshalJSON(data); err != nil {
+		t.Valid = false
 		return err
 	}
 
@@ -70,9 +76,10 @@ func (t Time) MarshalText() ([]byte, error) {
 func (t *Time) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		t.Valid = false
-		return nil
+		return blankText("Time")
 	}
 	if err := t.Time.UnmarshalText(text); err != nil {
+		t.Valid = false
 		return err
 	}
 	t.Valid = true
@@ -85,6 +92,11 @@ func (t *Time) SetValid(v time.Time) {
 	t.Valid = true
 }
 
+// SetNull sets this Time to null, clearing its value.
+func (t *Time) SetNull() {
+	t.Time, t.Valid = time.Time{}, false
+}
+
 // Ptr returns a pointer to this Time's value, or a nil pointer if this Time is null.
 func (t Time) Ptr() *time.Time {
 	if !t.Valid {
@@ -93,11 +105,59 @@ func (t Time) Ptr() *time.Time {
 	return &t.Time
 }
 
-// IsNull returns true for invalid Times, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Time's value, or the zero time if it is null.
+func (t Time) ValueOrZero() time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time
+}
+
+// ValueOr returns this Time's value, or def if it is null.
+func (t Time) ValueOr(def time.Time) time.Time {
+	if !t.Valid {
+		return def
+	}
+	return t.Time
+}
+
+// IsNull returns true for invalid Times.
 func (t Time) IsNull() bool {
 	return !t.Valid
 }
 
+// IsZero returns true for null Times, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid zero time is not zero.
+func (t Time) IsZero() bool {
+	return !t.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (t Time) isZeroValue() bool {
+	return t.Time.IsZero()
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Time is null.
+func (t Time) String() string {
+	if !t.Valid {
+		return ""
+	}
+	text, _ := t.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (t *Time) Set(s string) error {
+	return setFlag(t, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (t *Time) Type() string {
+	return "time"
+}
+
 // Scan implements the Scanner interface.
 func (t *Time) Scan(value interface{}) error {
 	var err error
@@ -121,3 +181,52 @@ func (t Time) Value() (driver.Value, error) {
 	}
 	return t.Time, nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+// Unlike the text form, a null Time can be read back.
+func (t Time) MarshalBinary() ([]byte, error) {
+	if !t.Valid {
+		return marshalBinary(false, nil), nil
+	}
+	v, err := t.Time.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return marshalBinary(true, v), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "Time", -1)
+	if err != nil {
+		return err
+	}
+	t.Time, t.Valid = time.Time{}, false
+	if !valid {
+		return nil
+	}
+	if err := t.Time.UnmarshalBinary(v); err != nil {
+		return err
+	}
+	t.Valid = true
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Time is null.
+func (t Time) MarshalYAML() (interface{}, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *time.Time
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*t = TimeFromPtr(v)
+	return nil
+}
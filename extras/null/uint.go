@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"strconv"
 
 	"gopkg.in/nullbio/null.v6/convert"
@@ -36,6 +37,11 @@ func UintFromPtr(i *uint) Uint {
 	return NewUint(*i, true)
 }
 
+// UintFromNonZero creates a new Uint that will be null if v is zero.
+func UintFromNonZero(v uint) Uint {
+	return NewUint(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (u *Uint) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -46,6 +52,7 @@ func (u *Uint) UnmarshalJSON(data []byte) error {
 
 	var x uint64
 	if err := json.Unmarshal(data, &x); err != nil {
+		u.Valid = false
 		return err
 	}
 
@@ -58,7 +65,7 @@ func (u *Uint) UnmarshalJSON(data []byte) error {
 func (u *Uint) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		u.Valid = false
-		return nil
+		return blankText("Uint")
 	}
 	var err error
 	res, err := strconv.ParseUint(string(text), 10, 0)
@@ -91,6 +98,11 @@ func (u *Uint) SetValid(n uint) {
 	u.Valid = true
 }
 
+// SetNull sets this Uint to null, clearing its value.
+func (u *Uint) SetNull() {
+	u.Uint, u.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Uint's value, or a nil pointer if this Uint is null.
 func (u Uint) Ptr() *uint {
 	if !u.Valid {
@@ -99,25 +111,116 @@ func (u Uint) Ptr() *uint {
 	return &u.Uint
 }
 
-// IsNull returns true for invalid Uints, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Uint's value, or zero if it is null.
+func (u Uint) ValueOrZero() uint {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint
+}
+
+// ValueOr returns this Uint's value, or def if it is null.
+func (u Uint) ValueOr(def uint) uint {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint
+}
+
+// IsNull returns true for invalid Uints.
 func (u Uint) IsNull() bool {
 	return !u.Valid
 }
 
+// IsZero returns true for null Uints, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (u Uint) IsZero() bool {
+	return !u.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (u Uint) isZeroValue() bool {
+	return u.Uint == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Uint is null.
+func (u Uint) String() string {
+	if !u.Valid {
+		return ""
+	}
+	text, _ := u.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (u *Uint) Set(s string) error {
+	return setFlag(u, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (u *Uint) Type() string {
+	return "uint"
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint) Scan(value interface{}) error {
 	if value == nil {
 		u.Uint, u.Valid = 0, false
 		return nil
 	}
-	u.Valid = true
-	return convert.ConvertAssign(&u.Uint, value)
+	err := convert.ConvertAssign(&u.Uint, value)
+	u.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
+// Values too big for an int64 are passed as a decimal string, which databases with unsigned columns accept,
+// rather than wrapping around to a negative number.
 func (u Uint) Value() (driver.Value, error) {
 	if !u.Valid {
 		return nil, nil
 	}
+	if uint64(u.Uint) > math.MaxInt64 {
+		return strconv.FormatUint(uint64(u.Uint), 10), nil
+	}
 	return int64(u.Uint), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (u Uint) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(u.Valid, uint64(u.Uint)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Uint")
+	if err != nil {
+		return err
+	}
+	if uint64(uint(v)) != v {
+		return binaryOverflow(v, "Uint")
+	}
+	u.Uint, u.Valid = uint(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint is null.
+func (u Uint) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *uint
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*u = UintFromPtr(v)
+	return nil
+}
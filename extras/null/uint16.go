@@ -38,6 +38,11 @@ func Uint16FromPtr(i *uint16) Uint16 {
 	return NewUint16(*i, true)
 }
 
+// Uint16FromNonZero creates a new Uint16 that will be null if v is zero.
+func Uint16FromNonZero(v uint16) Uint16 {
+	return NewUint16(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (u *Uint16) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -48,11 +53,13 @@ func (u *Uint16) UnmarshalJSON(data []byte) error {
 
 	var x uint64
 	if err := json.Unmarshal(data, &x); err != nil {
+		u.Valid = false
 		return err
 	}
 
 	if x > math.MaxUint16 {
-		return fmt.Errorf("json: %d overflows max uint8 value", x)
+		u.Valid = false
+		return fmt.Errorf("json: %d overflows max uint16 value", x)
 	}
 
 	u.Uint16 = uint16(x)
@@ -64,7 +71,7 @@ func (u *Uint16) UnmarshalJSON(data []byte) error {
 func (u *Uint16) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		u.Valid = false
-		return nil
+		return blankText("Uint16")
 	}
 	var err error
 	res, err := strconv.ParseUint(string(text), 10, 16)
@@ -97,6 +104,11 @@ func (u *Uint16) SetValid(n uint16) {
 	u.Valid = true
 }
 
+// SetNull sets this Uint16 to null, clearing its value.
+func (u *Uint16) SetNull() {
+	u.Uint16, u.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Uint16's value, or a nil pointer if this Uint16 is null.
 func (u Uint16) Ptr() *uint16 {
 	if !u.Valid {
@@ -105,19 +117,68 @@ func (u Uint16) Ptr() *uint16 {
 	return &u.Uint16
 }
 
-// IsNull returns true for invalid Uint16's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Uint16's value, or zero if it is null.
+func (u Uint16) ValueOrZero() uint16 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint16
+}
+
+// ValueOr returns this Uint16's value, or def if it is null.
+func (u Uint16) ValueOr(def uint16) uint16 {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint16
+}
+
+// IsNull returns true for invalid Uint16's.
 func (u Uint16) IsNull() bool {
 	return !u.Valid
 }
 
+// IsZero returns true for null Uint16s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (u Uint16) IsZero() bool {
+	return !u.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (u Uint16) isZeroValue() bool {
+	return u.Uint16 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Uint16 is null.
+func (u Uint16) String() string {
+	if !u.Valid {
+		return ""
+	}
+	text, _ := u.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (u *Uint16) Set(s string) error {
+	return setFlag(u, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (u *Uint16) Type() string {
+	return "uint16"
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint16) Scan(value interface{}) error {
 	if value == nil {
 		u.Uint16, u.Valid = 0, false
 		return nil
 	}
-	u.Valid = true
-	return convert.ConvertAssign(&u.Uint16, value)
+	err := convert.ConvertAssign(&u.Uint16, value)
+	u.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -127,3 +188,40 @@ func (u Uint16) Value() (driver.Value, error) {
 	}
 	return int64(u.Uint16), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (u Uint16) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(u.Valid, uint64(u.Uint16)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint16) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Uint16")
+	if err != nil {
+		return err
+	}
+	if uint64(uint16(v)) != v {
+		return binaryOverflow(v, "Uint16")
+	}
+	u.Uint16, u.Valid = uint16(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint16 is null.
+func (u Uint16) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint16, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *uint16
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*u = Uint16FromPtr(v)
+	return nil
+}
@@ -38,6 +38,11 @@ func Uint32FromPtr(i *uint32) Uint32 {
 	return NewUint32(*i, true)
 }
 
+// Uint32FromNonZero creates a new Uint32 that will be null if v is zero.
+func Uint32FromNonZero(v uint32) Uint32 {
+	return NewUint32(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (u *Uint32) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -48,10 +53,12 @@ func (u *Uint32) UnmarshalJSON(data []byte) error {
 
 	var x uint64
 	if err := json.Unmarshal(data, &x); err != nil {
+		u.Valid = false
 		return err
 	}
 
 	if x > math.MaxUint32 {
+		u.Valid = false
 		return fmt.Errorf("json: %d overflows max uint32 value", x)
 	}
 
@@ -64,7 +71,7 @@ func (u *Uint32) UnmarshalJSON(data []byte) error {
 func (u *Uint32) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		u.Valid = false
-		return nil
+		return blankText("Uint32")
 	}
 	var err error
 	res, err := strconv.ParseUint(string(text), 10, 32)
@@ -97,6 +104,11 @@ func (u *Uint32) SetValid(n uint32) {
 	u.Valid = true
 }
 
+// SetNull sets this Uint32 to null, clearing its value.
+func (u *Uint32) SetNull() {
+	u.Uint32, u.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Uint32's value, or a nil pointer if this Uint32 is null.
 func (u Uint32) Ptr() *uint32 {
 	if !u.Valid {
@@ -105,19 +117,68 @@ func (u Uint32) Ptr() *uint32 {
 	return &u.Uint32
 }
 
-// IsNull returns true for invalid Uint32's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Uint32's value, or zero if it is null.
+func (u Uint32) ValueOrZero() uint32 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint32
+}
+
+// ValueOr returns this Uint32's value, or def if it is null.
+func (u Uint32) ValueOr(def uint32) uint32 {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint32
+}
+
+// IsNull returns true for invalid Uint32's.
 func (u Uint32) IsNull() bool {
 	return !u.Valid
 }
 
+// IsZero returns true for null Uint32s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (u Uint32) IsZero() bool {
+	return !u.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (u Uint32) isZeroValue() bool {
+	return u.Uint32 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Uint32 is null.
+func (u Uint32) String() string {
+	if !u.Valid {
+		return ""
+	}
+	text, _ := u.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (u *Uint32) Set(s string) error {
+	return setFlag(u, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (u *Uint32) Type() string {
+	return "uint32"
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint32) Scan(value interface{}) error {
 	if value == nil {
 		u.Uint32, u.Valid = 0, false
 		return nil
 	}
-	u.Valid = true
-	return convert.ConvertAssign(&u.Uint32, value)
+	err := convert.ConvertAssign(&u.Uint32, value)
+	u.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -125,5 +186,42 @@ func (u Uint32) Value() (driver.Value, error) {
 	if !u.Valid {
 		return nil, nil
 	}
-	return uint64(u.Uint32), nil
+	return int64(u.Uint32), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (u Uint32) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(u.Valid, uint64(u.Uint32)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint32) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Uint32")
+	if err != nil {
+		return err
+	}
+	if uint64(uint32(v)) != v {
+		return binaryOverflow(v, "Uint32")
+	}
+	u.Uint32, u.Valid = uint32(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint32 is null.
+func (u Uint32) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint32, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *uint32
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*u = Uint32FromPtr(v)
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"strconv"
 
 	"gopkg.in/nullbio/null.v6/convert"
@@ -36,6 +37,11 @@ func Uint64FromPtr(i *uint64) Uint64 {
 	return NewUint64(*i, true)
 }
 
+// Uint64FromNonZero creates a new Uint64 that will be null if v is zero.
+func Uint64FromNonZero(v uint64) Uint64 {
+	return NewUint64(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (u *Uint64) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -45,6 +51,7 @@ func (u *Uint64) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &u.Uint64); err != nil {
+		u.Valid = false
 		return err
 	}
 
@@ -56,7 +63,7 @@ func (u *Uint64) UnmarshalJSON(data []byte) error {
 func (u *Uint64) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		u.Valid = false
-		return nil
+		return blankText("Uint64")
 	}
 	var err error
 	res, err := strconv.ParseUint(string(text), 10, 64)
@@ -89,6 +96,11 @@ func (u *Uint64) SetValid(n uint64) {
 	u.Valid = true
 }
 
+// SetNull sets this Uint64 to null, clearing its value.
+func (u *Uint64) SetNull() {
+	u.Uint64, u.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Uint64's value, or a nil pointer if this Uint64 is null.
 func (u Uint64) Ptr() *uint64 {
 	if !u.Valid {
@@ -97,25 +109,116 @@ func (u Uint64) Ptr() *uint64 {
 	return &u.Uint64
 }
 
-// IsNull returns true for invalid Uint64's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Uint64's value, or zero if it is null.
+func (u Uint64) ValueOrZero() uint64 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint64
+}
+
+// ValueOr returns this Uint64's value, or def if it is null.
+func (u Uint64) ValueOr(def uint64) uint64 {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint64
+}
+
+// IsNull returns true for invalid Uint64's.
 func (u Uint64) IsNull() bool {
 	return !u.Valid
 }
 
+// IsZero returns true for null Uint64s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (u Uint64) IsZero() bool {
+	return !u.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (u Uint64) isZeroValue() bool {
+	return u.Uint64 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Uint64 is null.
+func (u Uint64) String() string {
+	if !u.Valid {
+		return ""
+	}
+	text, _ := u.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (u *Uint64) Set(s string) error {
+	return setFlag(u, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (u *Uint64) Type() string {
+	return "uint64"
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint64) Scan(value interface{}) error {
 	if value == nil {
 		u.Uint64, u.Valid = 0, false
 		return nil
 	}
-	u.Valid = true
-	return convert.ConvertAssign(&u.Uint64, value)
+	err := convert.ConvertAssign(&u.Uint64, value)
+	u.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
+// Values too big for an int64 are passed as a decimal string, which databases with unsigned columns accept,
+// rather than wrapping around to a negative number.
 func (u Uint64) Value() (driver.Value, error) {
 	if !u.Valid {
 		return nil, nil
 	}
+	if u.Uint64 > math.MaxInt64 {
+		return strconv.FormatUint(u.Uint64, 10), nil
+	}
 	return int64(u.Uint64), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (u Uint64) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(u.Valid, uint64(u.Uint64)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint64) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Uint64")
+	if err != nil {
+		return err
+	}
+	if uint64(uint64(v)) != v {
+		return binaryOverflow(v, "Uint64")
+	}
+	u.Uint64, u.Valid = uint64(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint64 is null.
+func (u Uint64) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint64, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *uint64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*u = Uint64FromPtr(v)
+	return nil
+}
@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 )
 
@@ -157,6 +158,32 @@ func TestUint64Scan(t *testing.T) {
 	assertNullUint64(t, null, "scanned null")
 }
 
+func TestUint64Value(t *testing.T) {
+	small := Uint64From(math.MaxInt64)
+	if v, err := small.Value(); v != int64(math.MaxInt64) || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	// too big for an int64, so passed as a string rather than wrapping around
+	big := Uint64From(math.MaxUint64)
+	if v, err := big.Value(); v != "18446744073709551615" || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var scanned Uint64
+	err := scanned.Scan([]byte("18446744073709551615"))
+	maybePanic(err)
+	if !scanned.Valid || scanned.Uint64 != math.MaxUint64 {
+		t.Errorf("bad scanned uint64 %d", scanned.Uint64)
+	}
+
+	err = scanned.Scan(int64(-1))
+	if err == nil {
+		t.Error("expected an error for a negative value")
+	}
+	assertNullUint64(t, scanned, "scanned negative")
+}
+
 func assertUint64(t *testing.T, i Uint64, from string) {
 	if i.Uint64 != 18446744073709551614 {
 		t.Errorf("bad %s uint64: %d ≠ %d\n", from, i.Uint64, uint64(18446744073709551614))
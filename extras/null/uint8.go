@@ -38,6 +38,11 @@ func Uint8FromPtr(i *uint8) Uint8 {
 	return NewUint8(*i, true)
 }
 
+// Uint8FromNonZero creates a new Uint8 that will be null if v is zero.
+func Uint8FromNonZero(v uint8) Uint8 {
+	return NewUint8(v, v != 0)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (u *Uint8) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, NullBytes) {
@@ -48,10 +53,12 @@ func (u *Uint8) UnmarshalJSON(data []byte) error {
 
 	var x uint64
 	if err := json.Unmarshal(data, &x); err != nil {
+		u.Valid = false
 		return err
 	}
 
 	if x > math.MaxUint8 {
+		u.Valid = false
 		return fmt.Errorf("json: %d overflows max uint8 value", x)
 	}
 
@@ -64,7 +71,7 @@ func (u *Uint8) UnmarshalJSON(data []byte) error {
 func (u *Uint8) UnmarshalText(text []byte) error {
 	if text == nil || len(text) == 0 {
 		u.Valid = false
-		return nil
+		return blankText("Uint8")
 	}
 	var err error
 	res, err := strconv.ParseUint(string(text), 10, 8)
@@ -97,6 +104,11 @@ func (u *Uint8) SetValid(n uint8) {
 	u.Valid = true
 }
 
+// SetNull sets this Uint8 to null, clearing its value.
+func (u *Uint8) SetNull() {
+	u.Uint8, u.Valid = 0, false
+}
+
 // Ptr returns a pointer to this Uint8's value, or a nil pointer if this Uint8 is null.
 func (u Uint8) Ptr() *uint8 {
 	if !u.Valid {
@@ -105,19 +117,68 @@ func (u Uint8) Ptr() *uint8 {
 	return &u.Uint8
 }
 
-// IsNull returns true for invalid Uint8's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns this Uint8's value, or zero if it is null.
+func (u Uint8) ValueOrZero() uint8 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint8
+}
+
+// ValueOr returns this Uint8's value, or def if it is null.
+func (u Uint8) ValueOr(def uint8) uint8 {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint8
+}
+
+// IsNull returns true for invalid Uint8's.
 func (u Uint8) IsNull() bool {
 	return !u.Valid
 }
 
+// IsZero returns true for null Uint8s, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid 0 is not zero.
+func (u Uint8) IsZero() bool {
+	return !u.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (u Uint8) isZeroValue() bool {
+	return u.Uint8 == 0
+}
+
+// String implements flag.Value.
+// It returns a blank string if this Uint8 is null.
+func (u Uint8) String() string {
+	if !u.Valid {
+		return ""
+	}
+	text, _ := u.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (u *Uint8) Set(s string) error {
+	return setFlag(u, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (u *Uint8) Type() string {
+	return "uint8"
+}
+
 // Scan implements the Scanner interface.
 func (u *Uint8) Scan(value interface{}) error {
 	if value == nil {
 		u.Uint8, u.Valid = 0, false
 		return nil
 	}
-	u.Valid = true
-	return convert.ConvertAssign(&u.Uint8, value)
+	err := convert.ConvertAssign(&u.Uint8, value)
+	u.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -127,3 +188,40 @@ func (u Uint8) Value() (driver.Value, error) {
 	}
 	return int64(u.Uint8), nil
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (u Uint8) MarshalBinary() ([]byte, error) {
+	return marshalBinaryUint64(u.Valid, uint64(u.Uint8)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint8) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinaryUint64(data, "Uint8")
+	if err != nil {
+		return err
+	}
+	if uint64(uint8(v)) != v {
+		return binaryOverflow(v, "Uint8")
+	}
+	u.Uint8, u.Valid = uint8(v), valid
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint8 is null.
+func (u Uint8) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint8, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v *uint8
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*u = Uint8FromPtr(v)
+	return nil
+}
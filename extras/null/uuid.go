@@ -0,0 +1,265 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// UUID is a nullable UUID. It is read from the canonical form, like "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+// or the same in braces, and written in the canonical form.
+type UUID struct {
+	UUID  [16]byte
+	Valid bool
+}
+
+// NewUUID creates a new UUID
+func NewUUID(u [16]byte, valid bool) UUID {
+	return UUID{
+		UUID:  u,
+		Valid: valid,
+	}
+}
+
+// UUIDFrom creates a new UUID that will always be valid.
+func UUIDFrom(u [16]byte) UUID {
+	return NewUUID(u, true)
+}
+
+// UUIDFromPtr creates a new UUID that will be null if u is nil.
+func UUIDFromPtr(u *[16]byte) UUID {
+	if u == nil {
+		return NewUUID([16]byte{}, false)
+	}
+	return NewUUID(*u, true)
+}
+
+// UUIDFromNonZero creates a new UUID that will be null if v is the nil UUID.
+func UUIDFromNonZero(v [16]byte) UUID {
+	return NewUUID(v, v != [16]byte{})
+}
+
+// UUIDFromString parses a UUID in the canonical or braced form. An empty string is null.
+func UUIDFromString(s string) (UUID, error) {
+	var u UUID
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports UUID strings and null.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	u.UUID, u.Valid = [16]byte{}, false
+	if bytes.Equal(data, NullBytes) {
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return u.parse([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null UUID if the input is blank.
+func (u *UUID) UnmarshalText(text []byte) error {
+	u.UUID, u.Valid = [16]byte{}, false
+	if len(text) == 0 {
+		return blankText("UUID")
+	}
+	return u.parse(text)
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this UUID is null.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(u.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this UUID is null.
+func (u UUID) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte{}, nil
+	}
+	return []byte(u.String()), nil
+}
+
+// String returns the canonical form of this UUID, or a blank string if it is null.
+func (u UUID) String() string {
+	if !u.Valid {
+		return ""
+	}
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u.UUID[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u.UUID[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u.UUID[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u.UUID[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u.UUID[10:])
+	return string(buf)
+}
+
+// SetValid changes this UUID's value and also sets it to be non-null.
+func (u *UUID) SetValid(v [16]byte) {
+	u.UUID = v
+	u.Valid = true
+}
+
+// SetNull sets this UUID to null, clearing its value.
+func (u *UUID) SetNull() {
+	u.UUID, u.Valid = [16]byte{}, false
+}
+
+// Ptr returns a pointer to this UUID's value, or a nil pointer if this UUID is null.
+func (u UUID) Ptr() *[16]byte {
+	if !u.Valid {
+		return nil
+	}
+	return &u.UUID
+}
+
+// ValueOrZero returns this UUID's value, or the nil UUID if it is null.
+func (u UUID) ValueOrZero() [16]byte {
+	if !u.Valid {
+		return [16]byte{}
+	}
+	return u.UUID
+}
+
+// ValueOr returns this UUID's value, or def if it is null.
+func (u UUID) ValueOr(def [16]byte) [16]byte {
+	if !u.Valid {
+		return def
+	}
+	return u.UUID
+}
+
+// IsNull returns true for invalid UUIDs.
+func (u UUID) IsNull() bool {
+	return !u.Valid
+}
+
+// IsZero returns true for null UUIDs, so that encoding/json's omitzero option, and other encoders that check
+// IsZero, leave them out. A valid nil UUID is not zero.
+func (u UUID) IsZero() bool {
+	return !u.Valid
+}
+
+// isZeroValue returns true if the value is zero, whether or not it is valid. OmitZero uses it.
+func (u UUID) isZeroValue() bool {
+	return u.UUID == [16]byte{}
+}
+
+// Set implements flag.Value.
+// A flag passed with a blank value is an error rather than null.
+func (u *UUID) Set(s string) error {
+	return setFlag(u, s)
+}
+
+// Type returns the name of this flag's type, for pflag.
+func (u *UUID) Type() string {
+	return "uuid"
+}
+
+// Scan implements the Scanner interface.
+// It reads uuid and char columns, and binary(16) columns holding the raw bytes.
+func (u *UUID) Scan(value interface{}) error {
+	u.UUID, u.Valid = [16]byte{}, false
+	switch x := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if len(x) == 16 {
+			copy(u.UUID[:], x)
+			u.Valid = true
+			return nil
+		}
+		return u.parse(x)
+	case string:
+		return u.parse([]byte(x))
+	default:
+		return fmt.Errorf("null: cannot scan type %T into null.UUID: %v", value, value)
+	}
+}
+
+// Value implements the driver Valuer interface.
+// The UUID is stored in the canonical form.
+func (u UUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, which encoding/gob uses.
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Valid, u.UUID[:]), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	v, valid, err := unmarshalBinary(data, "UUID", 16)
+	if err != nil {
+		return err
+	}
+	u.UUID, u.Valid = [16]byte{}, valid
+	copy(u.UUID[:], v)
+	return nil
+}
+
+// parse sets u from the canonical or braced form
+func (u *UUID) parse(text []byte) error {
+	s := text
+	if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		s = s[1:37]
+	}
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return fmt.Errorf("null: invalid UUID %q", text)
+	}
+
+	var digits [32]byte
+	copy(digits[0:8], s[0:8])
+	copy(digits[8:12], s[9:13])
+	copy(digits[12:16], s[14:18])
+	copy(digits[16:20], s[19:23])
+	copy(digits[20:], s[24:])
+	var id [16]byte
+	if _, err := hex.Decode(id[:], digits[:]); err != nil {
+		return fmt.Errorf("null: invalid UUID %q", text)
+	}
+	u.UUID, u.Valid = id, true
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this UUID is null.
+func (u UUID) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It reads the same forms as UnmarshalText.
+func (u *UUID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str *string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == nil {
+		*u = UUID{}
+		return nil
+	}
+	return u.UnmarshalText([]byte(*str))
+}
@@ -0,0 +1,218 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var (
+	uuidString = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	uuidJSON   = []byte(`"` + uuidString + `"`)
+	uuidValue  = [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
+
+func TestUUIDFrom(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	assertUUID(t, u, "UUIDFrom()")
+
+	zero := UUIDFrom([16]byte{})
+	if !zero.Valid {
+		t.Error("UUIDFrom(nil uuid)", "is invalid, but should be valid")
+	}
+}
+
+func TestUUIDFromPtr(t *testing.T) {
+	u := UUIDFromPtr(&uuidValue)
+	assertUUID(t, u, "UUIDFromPtr()")
+
+	null := UUIDFromPtr(nil)
+	assertNullUUID(t, null, "UUIDFromPtr(nil)")
+}
+
+func TestUUIDFromString(t *testing.T) {
+	for _, s := range []string{
+		uuidString,
+		"{" + uuidString + "}",
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+	} {
+		u, err := UUIDFromString(s)
+		maybePanic(err)
+		assertUUID(t, u, "UUIDFromString("+s+")")
+	}
+
+	null, err := UUIDFromString("")
+	maybePanic(err)
+	assertNullUUID(t, null, "UUIDFromString(\"\")")
+
+	for _, s := range []string{
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4_00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cz",
+	} {
+		u, err := UUIDFromString(s)
+		if err == nil {
+			t.Errorf("expected an error for %s", s)
+		}
+		assertNullUUID(t, u, "UUIDFromString("+s+")")
+	}
+}
+
+func TestUnmarshalUUID(t *testing.T) {
+	var u UUID
+	err := json.Unmarshal(uuidJSON, &u)
+	maybePanic(err)
+	assertUUID(t, u, "uuid json")
+
+	var braced UUID
+	err = json.Unmarshal([]byte(`"{`+uuidString+`}"`), &braced)
+	maybePanic(err)
+	assertUUID(t, braced, "braced uuid json")
+
+	var null UUID
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullUUID(t, null, "null json")
+
+	var badType UUID
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		panic("err should not be nil")
+	}
+	assertNullUUID(t, badType, "wrong type json")
+
+	var invalid UUID
+	err = invalid.UnmarshalJSON(invalidJSON)
+	if _, ok := err.(*json.SyntaxError); !ok {
+		t.Errorf("expected json.SyntaxError, not %T", err)
+	}
+	assertNullUUID(t, invalid, "invalid json")
+}
+
+func TestTextUnmarshalUUID(t *testing.T) {
+	var u UUID
+	err := u.UnmarshalText([]byte(uuidString))
+	maybePanic(err)
+	assertUUID(t, u, "UnmarshalText() uuid")
+
+	var blank UUID
+	err = blank.UnmarshalText([]byte(""))
+	maybePanic(err)
+	assertNullUUID(t, blank, "UnmarshalText() empty uuid")
+}
+
+func TestMarshalUUID(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	data, err := json.Marshal(u)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(uuidJSON), "non-empty json marshal")
+
+	// invalid values should be encoded as null
+	null := NewUUID([16]byte{}, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestMarshalUUIDText(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	data, err := u.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, uuidString, "non-empty text marshal")
+
+	// invalid values should be encoded as null
+	null := NewUUID([16]byte{}, false)
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestUUIDPointer(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	ptr := u.Ptr()
+	if *ptr != uuidValue {
+		t.Errorf("bad %s uuid: %#v ≠ %#v\n", "pointer", ptr, uuidValue)
+	}
+
+	null := NewUUID([16]byte{}, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s uuid: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestUUIDIsNull(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	if u.IsNull() {
+		t.Errorf("IsNull() should be false")
+	}
+
+	null := NewUUID([16]byte{}, false)
+	if !null.IsNull() {
+		t.Errorf("IsNull() should be true")
+	}
+
+	var testInt interface{}
+	testInt = u
+	if _, ok := testInt.(Nullable); !ok {
+		t.Errorf("Nullable interface should be implemented")
+	}
+}
+
+func TestUUIDSetValid(t *testing.T) {
+	change := NewUUID([16]byte{}, false)
+	assertNullUUID(t, change, "SetValid()")
+	change.SetValid(uuidValue)
+	assertUUID(t, change, "SetValid()")
+}
+
+func TestUUIDScanValue(t *testing.T) {
+	var u UUID
+	err := u.Scan(uuidString)
+	maybePanic(err)
+	assertUUID(t, u, "scanned uuid")
+	if v, err := u.Value(); v != uuidString || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var char UUID
+	err = char.Scan([]byte(uuidString))
+	maybePanic(err)
+	assertUUID(t, char, "scanned char")
+
+	var binary UUID
+	err = binary.Scan(uuidValue[:])
+	maybePanic(err)
+	assertUUID(t, binary, "scanned binary")
+
+	var null UUID
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullUUID(t, null, "scanned null")
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong UUID
+	err = wrong.Scan(int64(42))
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullUUID(t, wrong, "scanned wrong")
+}
+
+func assertUUID(t *testing.T, u UUID, from string) {
+	if u.UUID != uuidValue {
+		t.Errorf("bad %v uuid: %s ≠ %s\n", from, u, uuidString)
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullUUID(t *testing.T, u UUID, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
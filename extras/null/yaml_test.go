@@ -0,0 +1,148 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// yamlDecoded returns an unmarshal func like the one a yaml decoder passes to UnmarshalYAML, for a document
+// that decodes to value
+func yamlDecoded(value interface{}) func(interface{}) error {
+	return func(out interface{}) error {
+		target := reflect.ValueOf(out).Elem()
+		if value == nil {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		if target.Kind() == reflect.Ptr {
+			p := reflect.New(target.Type().Elem())
+			target.Set(p)
+			target = p.Elem()
+		}
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(target.Type()) {
+			return fmt.Errorf("yaml: cannot unmarshal %T into %s", value, target.Type())
+		}
+		target.Set(v)
+		return nil
+	}
+}
+
+type yamlNullable interface {
+	MarshalYAML() (interface{}, error)
+	UnmarshalYAML(func(interface{}) error) error
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	dec, _ := DecimalFromString("0.00000001")
+	id, _ := UUIDFromString(uuidString)
+	tests := []struct {
+		value   yamlNullable
+		encoded interface{}
+	}{
+		{&Bool{true, true}, true},
+		{&Byte{'b', true}, "b"},
+		{&Bytes{[]byte("hello"), true}, []byte("hello")},
+		{&Bytes{[]byte{}, true}, []byte{}},
+		{&dec, "0.00000001"},
+		{&Duration{5 * time.Minute, true}, "5m0s"},
+		{&Float32{1.5, true}, float32(1.5)},
+		{&Float64{1.2345, true}, 1.2345},
+		{&Int{-12, true}, -12},
+		{&Int8{-8, true}, int8(-8)},
+		{&Int16{-16, true}, int16(-16)},
+		{&Int32{-32, true}, int32(-32)},
+		{&Int64{-64, true}, int64(-64)},
+		{&JSON{[]byte(`{"a":[1,"b"]}`), true}, map[string]interface{}{"a": []interface{}{1.0, "b"}}},
+		{&RawMessage{[]byte(`[1,{"b":null}]`), true}, []interface{}{1.0, map[string]interface{}{"b": nil}}},
+		{&String{"test", true}, "test"},
+		{&String{"", true}, ""},
+		{&Time{time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC), true}, time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)},
+		{&Uint{12, true}, uint(12)},
+		{&Uint8{8, true}, uint8(8)},
+		{&Uint16{16, true}, uint16(16)},
+		{&Uint32{32, true}, uint32(32)},
+		{&Uint64{64, true}, uint64(64)},
+		{&id, uuidString},
+	}
+
+	for _, test := range tests {
+		name := reflect.TypeOf(test.value).Elem().Name()
+		encoded, err := test.value.MarshalYAML()
+		maybePanic(err)
+		if !reflect.DeepEqual(encoded, test.encoded) {
+			t.Errorf("%s: expected %#v, got %#v", name, test.encoded, encoded)
+		}
+
+		decoded := reflect.New(reflect.TypeOf(test.value).Elem()).Interface().(yamlNullable)
+		err = decoded.UnmarshalYAML(yamlDecoded(encoded))
+		maybePanic(err)
+		if !reflect.DeepEqual(decoded, test.value) {
+			t.Errorf("%s: expected %#v to round trip, got %#v", name, test.value, decoded)
+		}
+		if decoded.(Nullable).IsNull() {
+			t.Errorf("%s: should not be null", name)
+		}
+
+		// null encodes as YAML null, and YAML null decodes as null
+		null := reflect.New(reflect.TypeOf(test.value).Elem()).Interface().(yamlNullable)
+		encoded, err = null.MarshalYAML()
+		maybePanic(err)
+		if encoded != nil {
+			t.Errorf("%s: expected null to encode as nil, got %#v", name, encoded)
+		}
+		err = test.value.UnmarshalYAML(yamlDecoded(nil))
+		maybePanic(err)
+		if !test.value.(Nullable).IsNull() {
+			t.Errorf("%s: should be null", name)
+		}
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	// yaml decodes scalars into strings as they are written
+	var d Duration
+	err := d.UnmarshalYAML(yamlDecoded("300"))
+	maybePanic(err)
+	if !d.Valid || d.Duration != 5*time.Minute {
+		t.Errorf("expected 300 seconds to be 5m, got %v", d.Duration)
+	}
+
+	var dec Decimal
+	err = dec.UnmarshalYAML(yamlDecoded("12345678901234567890.12345678"))
+	maybePanic(err)
+	if !dec.Valid || !dec.Decimal.Equal(decimalValue) {
+		t.Errorf("bad decimal %s", dec.Decimal)
+	}
+
+	var u UUID
+	err = u.UnmarshalYAML(yamlDecoded("{" + uuidString + "}"))
+	maybePanic(err)
+	assertUUID(t, u, "braced yaml uuid")
+
+	// yaml.v2 decodes mappings with interface{} keys
+	var j JSON
+	err = j.UnmarshalYAML(yamlDecoded(map[interface{}]interface{}{
+		"a": []interface{}{map[interface{}]interface{}{1: true}},
+	}))
+	maybePanic(err)
+	assertJSONEquals(t, j.JSON, `{"a":[{"1":true}]}`, "yaml mapping")
+
+	var wrong Int
+	err = wrong.UnmarshalYAML(yamlDecoded("twelve"))
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if wrong.Valid {
+		t.Error("wrong type should be null")
+	}
+
+	var bad UUID
+	err = bad.UnmarshalYAML(yamlDecoded("not a uuid"))
+	if err == nil {
+		t.Error("expected an error")
+	}
+	assertNullUUID(t, bad, "bad yaml uuid")
+}
@@ -7,9 +7,12 @@ require (
 	github.com/fatih/structs v1.1.0
 	github.com/go-errors/errors v1.0.1
 	github.com/go-ini/ini v1.48.0
+	github.com/go-stack/stack v1.8.0 // indirect, the mongo driver needs it but its go.mod does not say so
 	github.com/golang/protobuf v1.3.2
 	github.com/gorilla/mux v1.7.3
 	github.com/gorilla/rpc v1.2.0
+	github.com/klauspost/cpuid v1.3.1 // indirect
+	github.com/klauspost/reedsolomon v1.9.3
 	github.com/lbryio/lbry.go v1.1.2
 	github.com/lbryio/lbry.go/v2 v2.4.6
 	github.com/lbryio/lbryschema.go v0.0.0-20190602173230-6d2f69a36f46
@@ -26,8 +29,10 @@ require (
 	github.com/stretchr/testify v1.4.0
 	github.com/uber-go/atomic v1.4.0
 	github.com/ybbus/jsonrpc v0.0.0-20180411222309-2a548b7d822d
+	go.mongodb.org/mongo-driver v1.2.1 // only used by extras/null when built with the bson tag
 	golang.org/x/crypto v0.0.0-20191002192127-34f69633bfdc
 	golang.org/x/net v0.0.0-20191009170851-d66e71096ffb
+	golang.org/x/text v0.3.2
 	golang.org/x/time v0.0.0-20190921001708-c4c64cad1fd0
 	google.golang.org/grpc v1.24.0
 	gopkg.in/nullbio/null.v6 v6.0.0-20161116030900-40264a2e6b79
@@ -1,13 +1,18 @@
 package lbrycrd
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
+	"strings"
 
 	"github.com/lbryio/lbry.go/v2/extras/errors"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcutil"
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcutil/bech32"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -18,6 +23,11 @@ import (
 // When the address does not encode the network, such as in the case of a raw
 // public key, the address will be associated with the passed defaultNet.
 func DecodeAddress(addr string, defaultNet *chaincfg.Params) (btcutil.Address, error) {
+	// Native segwit addresses are bech32 encoded and start with the network's human-readable part.
+	if params := segwitParams(addr, defaultNet); params != nil {
+		return decodeSegWitAddress(addr, params)
+	}
+
 	// Serialized public keys are either 65 bytes (130 hex chars) if
 	// uncompressed/hybrid or 33 bytes (66 hex chars) if compressed.
 	if len(addr) == 130 || len(addr) == 66 {
@@ -56,3 +66,217 @@ func DecodeAddress(addr string, defaultNet *chaincfg.Params) (btcutil.Address, e
 		return nil, errors.Err("decoded address is of unknown size")
 	}
 }
+
+// Address validation errors. A valid address for another network gives a *WrongNetworkError instead.
+var (
+	ErrAddressFormat   = errors.Base("address is neither base58 nor bech32 encoded")
+	ErrAddressLength   = errors.Base("address has the wrong length")
+	ErrAddressChecksum = errors.Base("address checksum does not match")
+	ErrAddressPrefix   = errors.Base("address prefix is not used by any known network")
+)
+
+// WrongNetworkError means an address is valid, but for a different network than the one expected
+type WrongNetworkError struct {
+	Networks []string // the registered networks that use the address's prefix
+	Expected string
+}
+
+func (e *WrongNetworkError) Error() string {
+	return "address is for " + strings.Join(e.Networks, " or ") + ", not " + e.Expected
+}
+
+// bech32Charset are the characters of the data part of a bech32 string
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32WellFormed checks everything bech32.Decode does but the checksum, so an address that is well formed and
+// still can't be decoded has a bad checksum. bech32.Decode's errors don't say which check failed.
+func bech32WellFormed(addr string) bool {
+	if len(addr) < 8 || len(addr) > 90 {
+		return false
+	}
+	for _, c := range addr {
+		if c < 33 || c > 126 {
+			return false
+		}
+	}
+	lower := strings.ToLower(addr)
+	if addr != lower && addr != strings.ToUpper(addr) {
+		return false
+	}
+	one := strings.LastIndexByte(lower, '1')
+	if one < 1 || one+7 > len(lower) {
+		return false
+	}
+	for _, c := range lower[one+1:] {
+		if !strings.ContainsRune(bech32Charset, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateAddress checks that addr is a valid address for the network. The error says why it is not:
+// ErrAddressFormat, ErrAddressLength, ErrAddressChecksum, ErrAddressPrefix, or a *WrongNetworkError naming
+// the network the address is for. Use errors.Is for the first four and errors.Unwrap for the last.
+func ValidateAddress(addr string, params *chaincfg.Params) error {
+	if segwit := segwitParams(addr, nil); segwit != nil {
+		if _, _, err := bech32.Decode(addr); err != nil {
+			if bech32WellFormed(addr) {
+				return errors.Err(ErrAddressChecksum)
+			}
+			return errors.Err(ErrAddressFormat)
+		}
+		if segwit.Bech32HRPSegwit != params.Bech32HRPSegwit {
+			return errors.Err(&WrongNetworkError{Networks: networksWith(func(p *chaincfg.Params) bool {
+				return p.Bech32HRPSegwit == segwit.Bech32HRPSegwit
+			}), Expected: params.Name})
+		}
+		_, err := decodeSegWitAddress(addr, params)
+		return err
+	}
+
+	decoded := base58.Decode(addr)
+	if len(decoded) == 0 {
+		return errors.Err(ErrAddressFormat)
+	}
+	if len(decoded) != 1+ripemd160.Size+4 {
+		return errors.Err(ErrAddressLength)
+	}
+	_, version, err := base58.CheckDecode(addr)
+	if err != nil {
+		return errors.Err(ErrAddressChecksum)
+	}
+
+	if version == params.PubKeyHashAddrID || version == params.ScriptHashAddrID {
+		return nil
+	}
+	networks := networksWith(func(p *chaincfg.Params) bool {
+		return version == p.PubKeyHashAddrID || version == p.ScriptHashAddrID
+	})
+	if len(networks) == 0 {
+		return errors.Err(ErrAddressPrefix)
+	}
+	return errors.Err(&WrongNetworkError{Networks: networks, Expected: params.Name})
+}
+
+// networksWith returns the names of the registered networks whose params match
+func networksWith(match func(*chaincfg.Params) bool) []string {
+	var names []string
+	for _, name := range ChainNames() {
+		params, err := GetChainParams(name)
+		if err == nil && match(params) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// segwitParams returns the network params whose bech32 human-readable part prefixes addr, or nil if addr
+// is not a segwit address for defaultNet or any registered network
+func segwitParams(addr string, defaultNet *chaincfg.Params) *chaincfg.Params {
+	sep := strings.LastIndexByte(addr, '1')
+	if sep < 1 {
+		return nil
+	}
+	hrp := strings.ToLower(addr[:sep])
+
+	if defaultNet != nil && defaultNet.Bech32HRPSegwit == hrp {
+		return defaultNet
+	}
+	for _, name := range ChainNames() {
+		params, err := GetChainParams(name)
+		if err == nil && params.Bech32HRPSegwit == hrp {
+			return params
+		}
+	}
+	return nil
+}
+
+func decodeSegWitAddress(addr string, params *chaincfg.Params) (btcutil.Address, error) {
+	hrp, data, err := bech32.Decode(addr)
+	if err != nil {
+		return nil, errors.Prefix("invalid bech32 address", err)
+	}
+	if len(data) < 1 {
+		return nil, errors.Err("bech32 address has no witness version")
+	}
+
+	version := data[0]
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return nil, errors.Prefix("invalid bech32 address", err)
+	}
+	if version != 0 {
+		return nil, errors.Err(btcutil.UnsupportedWitnessVerError(version))
+	}
+	if hrp != params.Bech32HRPSegwit {
+		return nil, errors.Err("address is for network %s, not %s", hrp, params.Bech32HRPSegwit)
+	}
+
+	return NewWitnessAddress(program, params)
+}
+
+// NewWitnessAddress returns the native segwit (bech32) address for a version 0 witness program. A 20 byte
+// program is a pay-to-witness-pubkey-hash address and a 32 byte program is pay-to-witness-script-hash.
+func NewWitnessAddress(program []byte, params *chaincfg.Params) (btcutil.Address, error) {
+	if params.Bech32HRPSegwit == "" {
+		return nil, errors.Err("network %s does not support segwit addresses", params.Name)
+	}
+
+	var addr btcutil.Address
+	var err error
+	switch len(program) {
+	case ripemd160.Size:
+		addr, err = btcutil.NewAddressWitnessPubKeyHash(program, params)
+	case sha256.Size:
+		addr, err = btcutil.NewAddressWitnessScriptHash(program, params)
+	default:
+		return nil, errors.Err(btcutil.UnsupportedWitnessProgLenError(len(program)))
+	}
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return addr, nil
+}
+
+// AddressScriptType returns the type of output script that pays to the address
+func AddressScriptType(addr btcutil.Address) txscript.ScriptClass {
+	switch addr.(type) {
+	case *btcutil.AddressPubKeyHash:
+		return txscript.PubKeyHashTy
+	case *btcutil.AddressScriptHash:
+		return txscript.ScriptHashTy
+	case *btcutil.AddressWitnessPubKeyHash:
+		return txscript.WitnessV0PubKeyHashTy
+	case *btcutil.AddressWitnessScriptHash:
+		return txscript.WitnessV0ScriptHashTy
+	case *btcutil.AddressPubKey:
+		return txscript.PubKeyTy
+	default:
+		return txscript.NonStandardTy
+	}
+}
+
+// PubKeyHashAddress returns the pay-to-pubkey-hash address for a public key, using its compressed form
+func PubKeyHashAddress(pubKey *btcec.PublicKey, params *chaincfg.Params) (*btcutil.AddressPubKeyHash, error) {
+	if pubKey == nil {
+		return nil, errors.Err("public key is missing")
+	}
+	addr, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(pubKey.SerializeCompressed()), params)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return addr, nil
+}
+
+// ScriptHashAddress returns the pay-to-script-hash address for a redeem script
+func ScriptHashAddress(redeemScript []byte, params *chaincfg.Params) (*btcutil.AddressScriptHash, error) {
+	if len(redeemScript) == 0 {
+		return nil, errors.Err("redeem script is empty")
+	}
+	addr, err := btcutil.NewAddressScriptHash(redeemScript, params)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return addr, nil
+}
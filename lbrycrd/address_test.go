@@ -1,6 +1,17 @@
 package lbrycrd
 
-import "testing"
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+)
 
 func TestDecodeAddress(t *testing.T) {
 	addr := "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"
@@ -10,3 +21,174 @@ func TestDecodeAddress(t *testing.T) {
 	}
 	println(btcAddr.EncodeAddress())
 }
+
+func TestDecodeAddress_SegWit(t *testing.T) {
+	program := make([]byte, 20)
+	for i := range program {
+		program[i] = byte(i)
+	}
+
+	for _, name := range []string{LbrycrdMain, LbrycrdTestnet, LbrycrdRegtest} {
+		params, err := GetChainParams(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr, err := NewWitnessAddress(program, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded := addr.EncodeAddress()
+		if !strings.HasPrefix(encoded, params.Bech32HRPSegwit+"1") {
+			t.Errorf("%s: expected %s to start with %s1", name, encoded, params.Bech32HRPSegwit)
+		}
+
+		decoded, err := DecodeAddress(encoded, &MainNetParams)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if decoded.EncodeAddress() != encoded {
+			t.Errorf("%s: expected %s, got %s", name, encoded, decoded.EncodeAddress())
+		}
+		if AddressScriptType(decoded) != txscript.WitnessV0PubKeyHashTy {
+			t.Errorf("%s: expected a witness pubkey hash address, got %s", name, AddressScriptType(decoded))
+		}
+	}
+
+	scriptAddr, err := NewWitnessAddress(make([]byte, 32), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeAddress(strings.ToUpper(scriptAddr.EncodeAddress()), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if AddressScriptType(decoded) != txscript.WitnessV0ScriptHashTy {
+		t.Errorf("expected a witness script hash address, got %s", AddressScriptType(decoded))
+	}
+
+	_, err = DecodeAddress("lbc1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccq", &MainNetParams)
+	if err == nil {
+		t.Error("expected a checksum error")
+	}
+}
+
+func TestPubKeyHashAddress(t *testing.T) {
+	// the private key 1, whose public key is the curve generator
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), []byte{1})
+	addr, err := PubKeyHashAddress(pubKey, &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(addr.ScriptAddress()) != "751e76e8199196d454941c45d1b3a323f1433bd6" {
+		t.Errorf("unexpected pubkey hash %s", hex.EncodeToString(addr.ScriptAddress()))
+	}
+	if addr.EncodeAddress()[0] != 'b' {
+		t.Errorf("expected a mainnet address starting with b, got %s", addr.EncodeAddress())
+	}
+
+	decoded, err := DecodeAddress(addr.EncodeAddress(), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if AddressScriptType(decoded) != txscript.PubKeyHashTy {
+		t.Errorf("expected a pubkey hash address, got %s", AddressScriptType(decoded))
+	}
+}
+
+func TestScriptHashAddress(t *testing.T) {
+	params, err := GetChainParams(LbrycrdRegtest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ScriptHashAddress([]byte{txscript.OP_TRUE}, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeAddress(addr.EncodeAddress(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if AddressScriptType(decoded) != txscript.ScriptHashTy {
+		t.Errorf("expected a script hash address, got %s", AddressScriptType(decoded))
+	}
+	if !bytes.Equal(decoded.ScriptAddress(), btcutil.Hash160([]byte{txscript.OP_TRUE})) {
+		t.Error("script hash does not match the redeem script")
+	}
+
+	_, err = ScriptHashAddress(nil, params)
+	if err == nil {
+		t.Error("expected an error for an empty redeem script")
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	mainnet, err := GetChainParams(LbrycrdMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testnet, err := GetChainParams(LbrycrdTestnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ValidateAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", mainnet)
+	if err != nil {
+		t.Errorf("expected a valid address, got %v", err)
+	}
+
+	tests := map[string]error{
+		"bMUxfQVUeDi7ActVeZJZHzHKBceai7kHhb":            ErrAddressChecksum,
+		"bMUxfQVUeDi7ActVeZJZHzHKBceai7kH":              ErrAddressLength,
+		"bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha0":           ErrAddressFormat,
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2":            ErrAddressPrefix,
+		"lbc1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccq": ErrAddressChecksum,
+		"lbc1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccb": ErrAddressFormat,
+		"lbc1QQQsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccq": ErrAddressFormat,
+	}
+	for addr, expected := range tests {
+		err := ValidateAddress(addr, mainnet)
+		if !errors.Is(err, expected) {
+			t.Errorf("%s: expected %v, got %v", addr, expected, err)
+		}
+	}
+
+	testnetAddr, err := PubKeyHashAddress(mustNewKey(t).PubKey(), testnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	witnessAddr, err := NewWitnessAddress(make([]byte, 20), testnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range []btcutil.Address{testnetAddr, witnessAddr} {
+		err = ValidateAddress(addr.EncodeAddress(), mainnet)
+		wrongNetwork, ok := errors.Unwrap(err).(*WrongNetworkError)
+		if !ok {
+			t.Fatalf("%s: expected a WrongNetworkError, got %v", addr, err)
+		}
+		if wrongNetwork.Expected != LbrycrdMain || !containsString(wrongNetwork.Networks, LbrycrdTestnet) {
+			t.Errorf("%s: unexpected error %v", addr, wrongNetwork)
+		}
+		if err := ValidateAddress(addr.EncodeAddress(), testnet); err != nil {
+			t.Errorf("%s: expected a valid testnet address, got %v", addr, err)
+		}
+	}
+}
+
+func mustNewKey(t *testing.T) *btcec.PrivateKey {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
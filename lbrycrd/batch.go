@@ -0,0 +1,205 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Batch collects calls to send to lbrycrd in a single request. It is not safe for concurrent use.
+type Batch struct {
+	client *Client
+	calls  []*BatchCall
+}
+
+// BatchCall is a call in a batch. Err is set when the batch is sent, if the call failed.
+type BatchCall struct {
+	Method string
+	Err    error
+
+	params []json.RawMessage
+	result interface{}
+}
+
+// BatchError is returned by Send when some of the calls failed. The calls that succeeded have their results.
+type BatchError struct {
+	Failed []*BatchCall
+}
+
+func (e *BatchError) Error() string {
+	messages := make([]string, len(e.Failed))
+	for i, call := range e.Failed {
+		messages[i] = call.Method + ": " + call.Err.Error()
+	}
+	return fmt.Sprintf("%d calls in batch failed: %s", len(e.Failed), strings.Join(messages, "; "))
+}
+
+// NewBatch starts a batch of calls
+func (c *Client) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Add queues a call to an rpc method. Its result is decoded into result, which should be a pointer, when the
+// batch is sent. Trailing nil params are left off, so lbrycrd uses its defaults for them.
+func (b *Batch) Add(result interface{}, method string, params ...interface{}) *BatchCall {
+	call := &BatchCall{Method: method, result: result}
+	call.params, call.Err = marshalParams(params)
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Len returns the number of calls in the batch
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+type batchRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      int               `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type batchResponse struct {
+	ID     int               `json:"id"`
+	Result json.RawMessage   `json:"result"`
+	Error  *btcjson.RPCError `json:"error"`
+}
+
+// Send sends the calls and decodes their results in order. If some calls fail, the error is a *BatchError and
+// each failed call has its Err set. Any other error means the batch as a whole failed.
+func (b *Batch) Send() error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+	for _, call := range b.calls {
+		if call.Err != nil {
+			return errors.Prefix("encoding params of "+call.Method, call.Err)
+		}
+	}
+
+	requests := make([]batchRequest, len(b.calls))
+	for i, call := range b.calls {
+		params := call.params
+		if params == nil {
+			params = []json.RawMessage{}
+		}
+		requests[i] = batchRequest{JSONRPC: "1.0", ID: i, Method: call.Method, Params: params}
+	}
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return errors.Err(err)
+	}
+
+	responses, status, err := b.client.post(body)
+	if err != nil {
+		return err
+	}
+	// lbrycrd answers a batch with 200 even when calls in it fail
+	if status != http.StatusOK {
+		return errors.Err("lbrycrd responded with status %d: %s", status, strings.TrimSpace(string(responses)))
+	}
+	var results []batchResponse
+	if err := json.Unmarshal(responses, &results); err != nil {
+		return errors.Prefix("decoding batch response", err)
+	}
+
+	answered := make([]bool, len(b.calls))
+	for _, r := range results {
+		// responses can come back in any order
+		if r.ID < 0 || r.ID >= len(b.calls) || answered[r.ID] {
+			return errors.Err("unexpected id %d in batch response", r.ID)
+		}
+		answered[r.ID] = true
+		call := b.calls[r.ID]
+		if r.Error != nil {
+			call.Err = r.Error
+			continue
+		}
+		if call.result != nil {
+			if err := json.Unmarshal(r.Result, call.result); err != nil {
+				call.Err = errors.Prefix("decoding "+call.Method, err)
+			}
+		}
+	}
+
+	var failed []*BatchCall
+	for i, call := range b.calls {
+		if !answered[i] {
+			call.Err = errors.Err("no response")
+		}
+		if call.Err != nil {
+			failed = append(failed, call)
+		}
+	}
+	if len(failed) > 0 {
+		return &BatchError{Failed: failed}
+	}
+	return nil
+}
+
+// GetRawTransactions fetches transactions in one batch. Transactions that are not in the mempool or the
+// wallet can only be found if lbrycrd runs with -txindex. The error is a *BatchError if some weren't found,
+// and their transactions are nil.
+func (c *Client) GetRawTransactions(txids []*chainhash.Hash) ([]*wire.MsgTx, error) {
+	b := c.NewBatch()
+	hexes := make([]string, len(txids))
+	for i, txid := range txids {
+		b.Add(&hexes[i], "getrawtransaction", txid.String(), false)
+	}
+	err := b.Send()
+	if err != nil {
+		if _, ok := err.(*BatchError); !ok {
+			return nil, err
+		}
+	}
+
+	txs := make([]*wire.MsgTx, len(txids))
+	for i, call := range b.calls {
+		if call.Err != nil {
+			continue
+		}
+		raw, decodeErr := hex.DecodeString(hexes[i])
+		if decodeErr == nil {
+			tx := &wire.MsgTx{}
+			decodeErr = tx.Deserialize(bytes.NewReader(raw))
+			txs[i] = tx
+		}
+		if decodeErr != nil {
+			return nil, errors.Prefix("decoding transaction "+txids[i].String(), decodeErr)
+		}
+	}
+	return txs, err
+}
+
+// GetVerboseTransactions describes transactions in one batch, like GetVerboseTransaction. The error is a
+// *BatchError if some weren't found, and their results are nil.
+func (c *Client) GetVerboseTransactions(txids []*chainhash.Hash) ([]*VerboseTx, error) {
+	b := c.NewBatch()
+	results := make([]*VerboseTx, len(txids))
+	for i, txid := range txids {
+		results[i] = new(VerboseTx)
+		b.Add(results[i], "getrawtransaction", txid.String(), true)
+	}
+	err := b.Send()
+	if err != nil {
+		if _, ok := err.(*BatchError); !ok {
+			return nil, err
+		}
+	}
+	for i, call := range b.calls {
+		if call.Err != nil {
+			results[i] = nil
+		}
+	}
+	return results, err
+}
@@ -0,0 +1,154 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func TestBatch(t *testing.T) {
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		switch req.Method {
+		case "getblockcount":
+			return 812000, ""
+		case "getblockhash":
+			return "9c89283ba0f3227f6c03b70216b9f665f0118d5e0fa729cedf4fb34d6a34f463", ""
+		case "checknormalization":
+			return strings.ToLower(stringParams(t, req)[0]), ""
+		}
+		return nil, "Method not found"
+	})
+	defer server.Close()
+
+	b := c.NewBatch()
+	var count int64
+	var hash string
+	names := make([]string, 3)
+	b.Add(&count, "getblockcount")
+	b.Add(&hash, "getblockhash", 0)
+	for i, name := range []string{"Video", "MUSIC", "lbry"} {
+		b.Add(&names[i], "checknormalization", name)
+	}
+	if b.Len() != 5 {
+		t.Errorf("expected 5 calls, got %d", b.Len())
+	}
+	if err := b.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 812000 || hash != "9c89283ba0f3227f6c03b70216b9f665f0118d5e0fa729cedf4fb34d6a34f463" {
+		t.Errorf("unexpected results %d, %s", count, hash)
+	}
+	if names[0] != "video" || names[1] != "music" || names[2] != "lbry" {
+		t.Errorf("unexpected names %v", names)
+	}
+
+	b = c.NewBatch()
+	ok := b.Add(&count, "getblockcount")
+	missing := b.Add(nil, "nosuchmethod", "x", nil)
+	err := b.Send()
+	batchErr, isBatchErr := err.(*BatchError)
+	if !isBatchErr || len(batchErr.Failed) != 1 || batchErr.Failed[0] != missing {
+		t.Fatalf("expected a batch error for one call, got %v", err)
+	}
+	if rpcErr, isRPCErr := missing.Err.(*btcjson.RPCError); !isRPCErr || rpcErr.Code != -32601 {
+		t.Errorf("expected a method not found error, got %v", missing.Err)
+	}
+	if ok.Err != nil {
+		t.Errorf("expected the other call to succeed, got %v", ok.Err)
+	}
+
+	if err := c.NewBatch().Send(); err != nil {
+		t.Errorf("an empty batch should succeed, got %v", err)
+	}
+}
+
+func TestBatch_ResponseOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var reqs []fakeRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Error(err)
+			return
+		}
+		// answer in reverse, with each call's id as its result
+		var responses []map[string]interface{}
+		for i := len(reqs) - 1; i >= 0; i-- {
+			responses = append(responses, map[string]interface{}{"id": reqs[i].ID, "result": reqs[i].ID, "error": nil})
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	config := &rpcclient.ConnConfig{Host: strings.TrimPrefix(server.URL, "http://"), User: "user", Pass: "pass", DisableTLS: true}
+	c := &Client{config: config}
+	b := c.NewBatch()
+	results := make([]int, 4)
+	for i := range results {
+		b.Add(&results[i], "getblockcount")
+	}
+	if err := b.Send(); err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if r != i {
+			t.Errorf("expected result %d to be %d, got %d", i, i, r)
+		}
+	}
+
+	config.Pass = "wrong"
+	b = c.NewBatch()
+	b.Add(nil, "getblockcount")
+	if err := b.Send(); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestClient_GetRawTransactions(t *testing.T) {
+	txs := map[string]*wire.MsgTx{}
+	var txids []*chainhash.Hash
+	for i := 0; i < 3; i++ {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i)}, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(int64(i), []byte{0x51}))
+		hash := tx.TxHash()
+		txs[hash.String()] = tx
+		txids = append(txids, &hash)
+	}
+	txids = append(txids, &chainhash.Hash{0xff})
+
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		tx, ok := txs[stringParams(t, fakeRequest{Params: req.Params[:1]})[0]]
+		if !ok {
+			return nil, "No such mempool or blockchain transaction"
+		}
+		var b bytes.Buffer
+		tx.Serialize(&b)
+		return hex.EncodeToString(b.Bytes()), ""
+	})
+	defer server.Close()
+
+	got, err := c.GetRawTransactions(txids)
+	if batchErr, ok := err.(*BatchError); !ok || len(batchErr.Failed) != 1 {
+		t.Fatalf("expected one transaction to be missing, got %v", err)
+	}
+	if len(got) != 4 || got[3] != nil {
+		t.Fatalf("unexpected transactions %v", got)
+	}
+	for i, tx := range got[:3] {
+		if tx.TxHash() != *txids[i] {
+			t.Errorf("transaction %d is out of order", i)
+		}
+	}
+}
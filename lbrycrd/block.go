@@ -0,0 +1,232 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// BlockHeaderSize is the size of a serialized lbrycrd block header. It is bitcoin's 80 byte header with the
+// claimtrie root after the merkle root.
+const BlockHeaderSize = 112
+
+// maxBlockTxs bounds the transaction count of a block being decoded, so a corrupt count can't exhaust memory
+const maxBlockTxs = 8000000 / 60 // 8 MB of the smallest possible transactions
+
+// BlockHeader is a lbrycrd block header. btcd's wire.BlockHeader can't decode it because of ClaimTrieRoot, the
+// root hash of the claimtrie after the block's transactions are applied.
+type BlockHeader struct {
+	Version       int32
+	PrevBlock     chainhash.Hash
+	MerkleRoot    chainhash.Hash
+	ClaimTrieRoot chainhash.Hash
+	Timestamp     time.Time
+	Bits          uint32
+	Nonce         uint32
+}
+
+// Deserialize decodes a header from r
+func (h *BlockHeader) Deserialize(r io.Reader) error {
+	b := make([]byte, BlockHeaderSize)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return errors.Prefix("decoding block header", err)
+	}
+	h.Version = int32(binary.LittleEndian.Uint32(b[0:4]))
+	copy(h.PrevBlock[:], b[4:36])
+	copy(h.MerkleRoot[:], b[36:68])
+	copy(h.ClaimTrieRoot[:], b[68:100])
+	h.Timestamp = time.Unix(int64(binary.LittleEndian.Uint32(b[100:104])), 0)
+	h.Bits = binary.LittleEndian.Uint32(b[104:108])
+	h.Nonce = binary.LittleEndian.Uint32(b[108:112])
+	return nil
+}
+
+// Serialize encodes the header to w
+func (h *BlockHeader) Serialize(w io.Writer) error {
+	if _, err := w.Write(h.bytes()); err != nil {
+		return errors.Err(err)
+	}
+	return nil
+}
+
+func (h *BlockHeader) bytes() []byte {
+	b := make([]byte, BlockHeaderSize)
+	binary.LittleEndian.PutUint32(b[0:4], uint32(h.Version))
+	copy(b[4:36], h.PrevBlock[:])
+	copy(b[36:68], h.MerkleRoot[:])
+	copy(b[68:100], h.ClaimTrieRoot[:])
+	binary.LittleEndian.PutUint32(b[100:104], uint32(h.Timestamp.Unix()))
+	binary.LittleEndian.PutUint32(b[104:108], h.Bits)
+	binary.LittleEndian.PutUint32(b[108:112], h.Nonce)
+	return b
+}
+
+// BlockHash returns the hash that identifies the block
+func (h *BlockHeader) BlockHash() chainhash.Hash {
+	return chainhash.DoubleHashH(h.bytes())
+}
+
+// PoWHash returns the hash that must be below the target for the block to be valid. LBRY's proof of work
+// hashes the header with sha512 and two ripemd160s on top of the usual double sha256.
+func (h *BlockHeader) PoWHash() chainhash.Hash {
+	intermediate := sha512.Sum512(chainhash.DoubleHashB(h.bytes()))
+	left := ripemd160.New()
+	left.Write(intermediate[:32])
+	right := ripemd160.New()
+	right.Write(intermediate[32:])
+	return chainhash.DoubleHashH(right.Sum(left.Sum(nil)))
+}
+
+// CheckProofOfWork checks that the header's proof of work meets the target in its Bits. It does not check that
+// the target is the one the chain requires at the block's height.
+func (h *BlockHeader) CheckProofOfWork() error {
+	target := blockchain.CompactToBig(h.Bits)
+	if target.Sign() <= 0 {
+		return errors.Err("block target %08x is not positive", h.Bits)
+	}
+	powHash := h.PoWHash()
+	if new(big.Int).SetBytes(rev(powHash[:])).Cmp(target) > 0 {
+		return errors.Err("block hash %s is above the target %08x", powHash, h.Bits)
+	}
+	return nil
+}
+
+// Block is a lbrycrd block
+type Block struct {
+	Header       BlockHeader
+	Transactions []*wire.MsgTx
+}
+
+// Deserialize decodes a block from r. Transactions with witness data are decoded too.
+func (b *Block) Deserialize(r io.Reader) error {
+	if err := b.Header.Deserialize(r); err != nil {
+		return err
+	}
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return errors.Prefix("decoding block", err)
+	}
+	if count > maxBlockTxs {
+		return errors.Err("block has too many transactions: %d", count)
+	}
+	b.Transactions = make([]*wire.MsgTx, 0, count)
+	for i := uint64(0); i < count; i++ {
+		tx := &wire.MsgTx{}
+		if err := tx.Deserialize(r); err != nil {
+			return errors.Prefix("decoding block transaction", err)
+		}
+		b.Transactions = append(b.Transactions, tx)
+	}
+	return nil
+}
+
+// Serialize encodes the block to w
+func (b *Block) Serialize(w io.Writer) error {
+	if err := b.Header.Serialize(w); err != nil {
+		return err
+	}
+	if err := wire.WriteVarInt(w, 0, uint64(len(b.Transactions))); err != nil {
+		return errors.Err(err)
+	}
+	for _, tx := range b.Transactions {
+		if err := tx.Serialize(w); err != nil {
+			return errors.Err(err)
+		}
+	}
+	return nil
+}
+
+// BlockHash returns the hash that identifies the block
+func (b *Block) BlockHash() chainhash.Hash {
+	return b.Header.BlockHash()
+}
+
+// CheckMerkleRoot checks that the header's merkle root commits to the block's transactions
+func (b *Block) CheckMerkleRoot() error {
+	if len(b.Transactions) == 0 {
+		return errors.Err("block has no transactions")
+	}
+	hashes := make([]chainhash.Hash, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		hashes[i] = tx.TxHash()
+	}
+	for len(hashes) > 1 {
+		if len(hashes)%2 == 1 {
+			hashes = append(hashes, hashes[len(hashes)-1])
+		}
+		next := make([]chainhash.Hash, len(hashes)/2)
+		for i := range next {
+			pair := make([]byte, 2*chainhash.HashSize)
+			copy(pair, hashes[2*i][:])
+			copy(pair[chainhash.HashSize:], hashes[2*i+1][:])
+			next[i] = chainhash.DoubleHashH(pair)
+		}
+		hashes = next
+	}
+	if !hashes[0].IsEqual(&b.Header.MerkleRoot) {
+		return errors.Err("merkle root %s does not match the block's transactions", b.Header.MerkleRoot)
+	}
+	return nil
+}
+
+// ParseBlockHeader decodes a serialized block header
+func ParseBlockHeader(b []byte) (*BlockHeader, error) {
+	if len(b) != BlockHeaderSize {
+		return nil, errors.Err("block header must be %d bytes, got %d", BlockHeaderSize, len(b))
+	}
+	h := new(BlockHeader)
+	if err := h.Deserialize(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// ParseBlock decodes a serialized block
+func ParseBlock(b []byte) (*Block, error) {
+	r := bytes.NewReader(b)
+	block := new(Block)
+	if err := block.Deserialize(r); err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, errors.Err("block has %d extra bytes", r.Len())
+	}
+	return block, nil
+}
+
+// GetRawBlockHeader returns the header of a block. btcd's GetBlockHeader can't decode lbrycrd headers.
+func (c *Client) GetRawBlockHeader(hash *chainhash.Hash) (*BlockHeader, error) {
+	var result string
+	if err := c.rawCall(&result, "getblockheader", hash.String(), false); err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(result)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return ParseBlockHeader(b)
+}
+
+// GetRawBlock returns a block. btcd's GetBlock can't decode lbrycrd blocks.
+func (c *Client) GetRawBlock(hash *chainhash.Hash) (*Block, error) {
+	var result string
+	if err := c.rawCall(&result, "getblock", hash.String(), 0); err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(result)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return ParseBlock(b)
+}
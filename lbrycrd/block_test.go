@@ -0,0 +1,166 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func mustHash(t *testing.T, s string) chainhash.Hash {
+	t.Helper()
+	hash, err := chainhash.NewHashFromStr(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *hash
+}
+
+func TestBlockHeader_Genesis(t *testing.T) {
+	genesis := BlockHeader{
+		Version:       1,
+		MerkleRoot:    mustHash(t, "b8211c82c3d15bcd78bba57005b86fed515149a53a425eb592c07af99fe559cc"),
+		ClaimTrieRoot: mustHash(t, "0000000000000000000000000000000000000000000000000000000000000001"),
+		Timestamp:     time.Unix(1446058291, 0),
+		Bits:          0x1f00ffff,
+		Nonce:         1287,
+	}
+	if hash := genesis.BlockHash(); hash.String() != "9c89283ba0f3227f6c03b70216b9f665f0118d5e0fa729cedf4fb34d6a34f463" {
+		t.Errorf("unexpected genesis block hash %s", hash)
+	}
+	if err := genesis.CheckProofOfWork(); err != nil {
+		t.Error(err)
+	}
+
+	var b bytes.Buffer
+	if err := genesis.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != BlockHeaderSize {
+		t.Errorf("expected %d bytes, got %d", BlockHeaderSize, b.Len())
+	}
+	parsed, err := ParseBlockHeader(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *parsed != genesis {
+		t.Errorf("header did not round trip: %+v", parsed)
+	}
+
+	genesis.Nonce++
+	if err := genesis.CheckProofOfWork(); err == nil {
+		// the target is easy enough that a wrong nonce could pass, but not this one
+		t.Error("expected the proof of work check to fail with the wrong nonce")
+	}
+	if _, err := ParseBlockHeader(b.Bytes()[:80]); err == nil {
+		t.Error("expected an error for a bitcoin sized header")
+	}
+}
+
+func testBlock(t *testing.T, txCount int) *Block {
+	t.Helper()
+	block := &Block{Header: BlockHeader{Version: 536870912, Timestamp: time.Unix(1600000000, 0), Bits: 0x1a0b1e3c}}
+	for i := 0; i < txCount; i++ {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i)}, uint32(i)), []byte{0x51}, nil))
+		tx.AddTxOut(wire.NewTxOut(int64(i+1)*100000000, []byte{0x76, 0xa9}))
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	txs := make([]*btcutil.Tx, txCount)
+	for i, tx := range block.Transactions {
+		txs[i] = btcutil.NewTx(tx)
+	}
+	merkles := blockchain.BuildMerkleTreeStore(txs, false)
+	block.Header.MerkleRoot = *merkles[len(merkles)-1]
+	return block
+}
+
+func TestBlock_Serialize(t *testing.T) {
+	for _, txCount := range []int{1, 2, 5} {
+		block := testBlock(t, txCount)
+		if err := block.CheckMerkleRoot(); err != nil {
+			t.Errorf("%d transactions: %v", txCount, err)
+		}
+
+		var b bytes.Buffer
+		if err := block.Serialize(&b); err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := ParseBlock(b.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.BlockHash() != block.BlockHash() || len(parsed.Transactions) != txCount {
+			t.Errorf("%d transactions: block did not round trip", txCount)
+		}
+		for i, tx := range parsed.Transactions {
+			if tx.TxHash() != block.Transactions[i].TxHash() {
+				t.Errorf("%d transactions: transaction %d did not round trip", txCount, i)
+			}
+		}
+
+		if _, err := ParseBlock(append(b.Bytes(), 0)); err == nil {
+			t.Error("expected an error for extra bytes")
+		}
+		if _, err := ParseBlock(b.Bytes()[:b.Len()-1]); err == nil {
+			t.Error("expected an error for a truncated block")
+		}
+	}
+
+	block := testBlock(t, 3)
+	block.Transactions = block.Transactions[:2]
+	if err := block.CheckMerkleRoot(); err == nil {
+		t.Error("expected the merkle root not to match")
+	}
+}
+
+func TestClient_GetRawBlock(t *testing.T) {
+	block := testBlock(t, 2)
+	var b bytes.Buffer
+	if err := block.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	hash := block.BlockHash()
+
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		if len(req.Params) != 2 || string(req.Params[0]) != `"`+hash.String()+`"` {
+			return nil, "unexpected params"
+		}
+		switch req.Method {
+		case "getblock":
+			if string(req.Params[1]) != "0" {
+				return nil, "unexpected verbosity " + string(req.Params[1])
+			}
+			return hex.EncodeToString(b.Bytes()), ""
+		case "getblockheader":
+			if string(req.Params[1]) != "false" {
+				return nil, "unexpected verbose " + string(req.Params[1])
+			}
+			return json.RawMessage(`"` + hex.EncodeToString(b.Bytes()[:BlockHeaderSize]) + `"`), ""
+		}
+		return nil, "Method not found"
+	})
+	defer server.Close()
+
+	got, err := c.GetRawBlock(&hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.BlockHash() != hash || len(got.Transactions) != 2 {
+		t.Errorf("unexpected block %+v", got)
+	}
+	header, err := c.GetRawBlockHeader(&hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.BlockHash() != hash {
+		t.Errorf("unexpected header %+v", header)
+	}
+}
@@ -4,10 +4,19 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	"strconv"
+	"strings"
 
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"golang.org/x/crypto/ripemd160"
 )
 
+// Claim IDs are displayed in the reverse byte order of the claim hash, which is what the protobuf, scripts and
+// signatures contain. Transaction IDs are likewise displayed reversed from the byte order of a chainhash.Hash.
+
 // rev reverses a byte slice. useful for switching endian-ness
 func rev(b []byte) []byte {
 	r := make([]byte, len(b))
@@ -23,25 +32,64 @@ func ClaimIDFromOutpoint(txid string, nout int) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if len(txidBytes) != chainhash.HashSize {
+		return "", errors.Err("txid must be %d bytes", chainhash.HashSize)
+	}
 
 	// reverse (make big-endian)
-	txidBytes = rev(txidBytes)
+	var hash chainhash.Hash
+	copy(hash[:], rev(txidBytes))
+
+	return ClaimIDFromHash(ClaimHashFromOutpoint(wire.OutPoint{Hash: hash, Index: uint32(nout)})), nil
+}
 
+// ClaimHashFromOutpoint computes the hash of the claim created by the output at op
+func ClaimHashFromOutpoint(op wire.OutPoint) []byte {
 	// append nout
-	noutBytes := make([]byte, 4) // num bytes in uint32
-	binary.BigEndian.PutUint32(noutBytes, uint32(nout))
-	txidBytes = append(txidBytes, noutBytes...)
+	b := make([]byte, chainhash.HashSize+4)
+	copy(b, op.Hash[:])
+	binary.BigEndian.PutUint32(b[chainhash.HashSize:], op.Index)
 
 	// sha256 it
-	s := sha256.New()
-	s.Write(txidBytes)
+	s := sha256.Sum256(b)
 
 	// ripemd it
 	r := ripemd160.New()
-	r.Write(s.Sum(nil))
+	r.Write(s[:])
+	return r.Sum(nil)
+}
 
-	// reverse (make little-endian)
-	res := rev(r.Sum(nil))
+// ClaimIDFromHash converts a claim hash to a hex claim ID
+func ClaimIDFromHash(hash []byte) string {
+	return hex.EncodeToString(rev(hash))
+}
 
-	return hex.EncodeToString(res), nil
+// ClaimHashFromID converts a hex claim ID to a claim hash
+func ClaimHashFromID(claimID string) ([]byte, error) {
+	claimIDBytes, err := decodeClaimID(claimID)
+	if err != nil {
+		return nil, err
+	}
+	return rev(claimIDBytes), nil
+}
+
+// ParseOutpoint parses an outpoint in the txid:nout form lbrycrd and lbry-sdk use. OutPoint.String formats
+// one the same way.
+func ParseOutpoint(s string) (*wire.OutPoint, error) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return nil, errors.Err("outpoint %q is not txid:nout", s)
+	}
+	if len(s[:i]) != chainhash.MaxHashStringSize {
+		return nil, errors.Err("invalid txid in outpoint %q", s)
+	}
+	hash, err := chainhash.NewHashFromStr(s[:i])
+	if err != nil {
+		return nil, errors.Err("invalid txid in outpoint %q", s)
+	}
+	nout, err := strconv.ParseUint(s[i+1:], 10, 32)
+	if err != nil {
+		return nil, errors.Err("invalid nout in outpoint %q", s)
+	}
+	return wire.NewOutPoint(hash, uint32(nout)), nil
 }
@@ -1,6 +1,8 @@
 package lbrycrd_test
 
 import (
+	"bytes"
+	"strconv"
 	"testing"
 
 	"github.com/lbryio/lbry.go/v2/lbrycrd"
@@ -52,3 +54,43 @@ func TestGetClaimIDFromOutput(t *testing.T) {
 		}
 	}
 }
+
+func TestClaimHashFromOutpoint(t *testing.T) {
+	for _, test := range claimIdTests {
+		op, err := lbrycrd.ParseOutpoint(test.txHash + ":" + strconv.Itoa(test.n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := lbrycrd.ClaimHashFromOutpoint(*op)
+		if claimID := lbrycrd.ClaimIDFromHash(hash); claimID != test.claimID {
+			t.Errorf("expected %s, got %s", test.claimID, claimID)
+		}
+		fromID, err := lbrycrd.ClaimHashFromID(test.claimID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(fromID, hash) {
+			t.Errorf("%s: expected hash %x, got %x", test.claimID, hash, fromID)
+		}
+	}
+}
+
+func TestParseOutpoint(t *testing.T) {
+	s := "6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df:12"
+	op, err := lbrycrd.ParseOutpoint(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Index != 12 || op.Hash.String() != s[:64] {
+		t.Errorf("parsed %s as %s", s, op)
+	}
+	if op.String() != s {
+		t.Errorf("expected %s, got %s", s, op)
+	}
+
+	for _, invalid := range []string{"", s[:64], s[:63] + ":1", s[:64] + ":", s[:64] + ":-1", s[:64] + ":4294967296", "zz" + s[2:]} {
+		if _, err := lbrycrd.ParseOutpoint(invalid); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
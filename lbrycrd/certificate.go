@@ -0,0 +1,182 @@
+package lbrycrd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbryschema.go/claim"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	oidCurveP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidCurveP384      = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+)
+
+// subjectPublicKeyInfo is the DER structure channel claims store their public key in
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// Certificate is the public key of a channel, which the channel's claims and other messages are signed with
+type Certificate struct {
+	ClaimID   string // the channel's claim ID, if known
+	PublicKey *btcec.PublicKey
+}
+
+// CertificateFromClaim reads the certificate from a channel claim or a legacy certificate claim. claimID may
+// be empty if it is not known.
+func CertificateFromClaim(claim *c.ClaimHelper, claimID string) (*Certificate, error) {
+	var der []byte
+	switch {
+	case claim == nil:
+		return nil, errors.Err("claim is empty")
+	case claim.GetChannel() != nil:
+		der = claim.GetChannel().GetPublicKey()
+	case claim.LegacyClaim.GetCertificate() != nil:
+		der = claim.LegacyClaim.GetCertificate().GetPublicKey()
+	default:
+		return nil, errors.Err("claim is not a channel")
+	}
+
+	publicKey, err := ParsePublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{ClaimID: claimID, PublicKey: publicKey}, nil
+}
+
+// ParsePublicKey parses a secp256k1 public key in DER form, as channel claims store it, or as a compressed or
+// uncompressed SEC 1 point
+func ParsePublicKey(b []byte) (*btcec.PublicKey, error) {
+	if len(b) == btcec.PubKeyBytesLenCompressed || len(b) == btcec.PubKeyBytesLenUncompressed {
+		publicKey, err := btcec.ParsePubKey(b, btcec.S256())
+		if err != nil {
+			return nil, errors.Prefix("invalid public key", err)
+		}
+		return publicKey, nil
+	}
+
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(b, &info)
+	if err != nil {
+		return nil, errors.Prefix("invalid public key", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.Err("invalid public key: %d trailing bytes", len(rest))
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errors.Err("public key is not an ecdsa key")
+	}
+	var curve asn1.ObjectIdentifier
+	_, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve)
+	if err != nil || !curve.Equal(oidCurveSecp256k1) {
+		return nil, errors.Err("public key is not on the secp256k1 curve")
+	}
+
+	publicKey, err := btcec.ParsePubKey(info.PublicKey.RightAlign(), btcec.S256())
+	if err != nil {
+		return nil, errors.Prefix("invalid public key", err)
+	}
+	return publicKey, nil
+}
+
+// ParseChannelPublicKey parses a channel public key in DER form. Unlike ParsePublicKey, the key may also be on
+// NIST P-256 or P-384, which some legacy channels use.
+func ParseChannelPublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, errors.Prefix("invalid public key", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.Err("invalid public key: %d trailing bytes", len(rest))
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errors.Err("public key is not an ecdsa key")
+	}
+	var curveOID asn1.ObjectIdentifier
+	_, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curveOID)
+	if err != nil {
+		return nil, errors.Prefix("invalid public key curve", err)
+	}
+
+	var curve elliptic.Curve
+	switch {
+	case curveOID.Equal(oidCurveSecp256k1):
+		publicKey, err := btcec.ParsePubKey(info.PublicKey.RightAlign(), btcec.S256())
+		if err != nil {
+			return nil, errors.Prefix("invalid public key", err)
+		}
+		return publicKey.ToECDSA(), nil
+	case curveOID.Equal(oidCurveP256):
+		curve = elliptic.P256()
+	case curveOID.Equal(oidCurveP384):
+		curve = elliptic.P384()
+	default:
+		return nil, errors.Err(ErrUnsupportedKeyType)
+	}
+
+	x, y := elliptic.Unmarshal(curve, info.PublicKey.RightAlign())
+	if x == nil {
+		return nil, errors.Err("invalid %s public key", curve.Params().Name)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// DER returns the public key in the DER form channel claims store it in
+func (cert *Certificate) DER() ([]byte, error) {
+	der, err := c.PublicKeyToDER(cert.PublicKey)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return der, nil
+}
+
+// Compressed returns the public key as a 33-byte compressed point
+func (cert *Certificate) Compressed() []byte {
+	return cert.PublicKey.SerializeCompressed()
+}
+
+// Uncompressed returns the public key as a 65-byte uncompressed point
+func (cert *Certificate) Uncompressed() []byte {
+	return cert.PublicKey.SerializeUncompressed()
+}
+
+// Equal returns true if both certificates have the same public key. Claim IDs are not compared, since a
+// channel's key can be reused by another channel.
+func (cert *Certificate) Equal(other *Certificate) bool {
+	if cert == nil || other == nil {
+		return cert == other
+	}
+	return cert.PublicKey.IsEqual(other.PublicKey)
+}
+
+// VerifyDigest checks a compact or DER signature of a 32-byte digest against the certificate
+func (cert *Certificate) VerifyDigest(digest, signature []byte) bool {
+	return VerifyDigest(cert.PublicKey, digest, signature)
+}
+
+// VerifyClaim checks that a claim was signed by this channel, like VerifySignature. If the certificate's claim
+// ID is known, the claim must also name it as its channel.
+func (cert *Certificate) VerifyClaim(claim *c.ClaimHelper, firstInput wire.OutPoint, claimAddress string) (SignatureScheme, error) {
+	if cert.ClaimID != "" && claim != nil && claim.Version == c.WithSig && len(claim.ClaimID) == ClaimIDLength/2 {
+		channelID := ClaimIDFromHash(claim.ClaimID)
+		if claim.LegacyClaim != nil {
+			channelID = hex.EncodeToString(claim.ClaimID) // legacy claims keep the display byte order
+		}
+		if channelID != cert.ClaimID {
+			return SchemeUnknown, errors.Err("claim is signed by channel %s, not %s", channelID, cert.ClaimID)
+		}
+	}
+	return VerifySignature(claim, cert.PublicKey, firstInput, claimAddress)
+}
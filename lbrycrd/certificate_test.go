@@ -0,0 +1,173 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbryschema.go/claim"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func TestCertificateFromClaim(t *testing.T) {
+	channel, key, err := NewChannel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := CertificateFromClaim(channel, testChannelClaimID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cert.PublicKey.IsEqual(key.PubKey()) {
+		t.Fatal("certificate has the wrong public key")
+	}
+
+	der, err := cert.DER()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(der, channel.GetChannel().GetPublicKey()) {
+		t.Error("DER public key does not match the claim")
+	}
+	for _, b := range [][]byte{der, cert.Compressed(), cert.Uncompressed()} {
+		publicKey, err := ParsePublicKey(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cert.Equal(&Certificate{PublicKey: publicKey}) {
+			t.Errorf("%x parsed to a different key", b)
+		}
+	}
+
+	other, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.Equal(&Certificate{PublicKey: other.PubKey()}) {
+		t.Error("certificates with different keys are equal")
+	}
+
+	stream, err := NewStreamClaim("title", "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CertificateFromClaim(stream, ""); err == nil {
+		t.Error("expected an error for a stream claim")
+	}
+}
+
+func TestParsePublicKey_Invalid(t *testing.T) {
+	_, key, err := NewChannel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := (&Certificate{PublicKey: key.PubKey()}).DER()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var info subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		t.Fatal(err)
+	}
+	info.Algorithm.Parameters.FullBytes, _ = asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}) // P-256
+	otherCurve, err := asn1.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, b := range map[string][]byte{
+		"empty":          nil,
+		"trailing bytes": append(append([]byte{}, der...), 0),
+		"other curve":    otherCurve,
+		"not on curve":   append([]byte{4}, bytes.Repeat([]byte{1}, 64)...),
+	} {
+		if _, err := ParsePublicKey(b); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCertificate_VerifyClaim(t *testing.T) {
+	_, key, err := NewChannel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := NewStreamClaim("title", "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstInput := wire.OutPoint{Index: 1}
+	if _, err := SignClaimWithChannel(claim, key, testChannelClaimID, firstInput); err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &Certificate{ClaimID: testChannelClaimID, PublicKey: key.PubKey()}
+	scheme, err := cert.VerifyClaim(claim, firstInput, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scheme != SchemeCurrent {
+		t.Errorf("expected the current scheme, got %s", scheme)
+	}
+
+	cert.ClaimID = "0000000000000000000000000000000000000000"
+	if _, err := cert.VerifyClaim(claim, firstInput, ""); err == nil {
+		t.Error("expected an error for a claim signed by another channel")
+	}
+}
+
+func TestParseChannelPublicKey(t *testing.T) {
+	channel, err := c.DecodeClaimHex("00125a0a583056301006072a8648ce3d020106052b8104000a034200045a0343c155302280da01ae0001b7295241eb03c42a837acf92ccb9680892f7db50fd1d3c14b28bb594e304f05fc4ae7c1f222a85d1d1a3461b3cfb9906f66cb5", "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := channel.GetPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParseChannelPublicKey(channel.GetChannel().PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.X.Cmp(expected.X) != 0 || key.Y.Cmp(expected.Y) != 0 {
+		t.Error("parsed secp256k1 key does not match")
+	}
+
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		private, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := ParseChannelPublicKey(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key.Curve.Params() != curve.Params() || key.X.Cmp(private.X) != 0 || key.Y.Cmp(private.Y) != 0 {
+			t.Errorf("parsed %s key does not match", curve.Params().Name)
+		}
+	}
+
+	private, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseChannelPublicKey(der); !errors.Is(err, ErrUnsupportedKeyType) {
+		t.Errorf("expected ErrUnsupportedKeyType for a P-224 key, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package lbrycrd
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+const (
+	lbrycrdMainPubkeyPrefix    = byte(85)
+	lbrycrdMainScriptPrefix    = byte(122)
+	lbrycrdTestnetPubkeyPrefix = byte(111)
+	lbrycrdTestnetScriptPrefix = byte(196)
+	lbrycrdRegtestPubkeyPrefix = byte(111)
+	lbrycrdRegtestScriptPrefix = byte(196)
+	lbrycrdMainBech32HRP       = "lbc"
+	lbrycrdTestnetBech32HRP    = "tlbc"
+	lbrycrdRegtestBech32HRP    = "lbcrt"
+
+	LbrycrdMain    = "lbrycrd_main"
+	LbrycrdTestnet = "lbrycrd_testnet"
+	LbrycrdRegtest = "lbrycrd_regtest"
+)
+
+var mainNetParams = chaincfg.Params{
+	Name:             LbrycrdMain,
+	PubKeyHashAddrID: lbrycrdMainPubkeyPrefix,
+	ScriptHashAddrID: lbrycrdMainScriptPrefix,
+	PrivateKeyID:     0x1c,
+	Bech32HRPSegwit:  lbrycrdMainBech32HRP,
+	HDPrivateKeyID:   [4]byte{0x04, 0x88, 0xad, 0xe4},
+	HDPublicKeyID:    [4]byte{0x04, 0x88, 0xb2, 0x1e},
+	HDCoinType:       CoinType,
+}
+
+var testNetParams = chaincfg.Params{
+	Name:             LbrycrdTestnet,
+	PubKeyHashAddrID: lbrycrdTestnetPubkeyPrefix,
+	ScriptHashAddrID: lbrycrdTestnetScriptPrefix,
+	PrivateKeyID:     0x1c,
+	Bech32HRPSegwit:  lbrycrdTestnetBech32HRP,
+	HDPrivateKeyID:   [4]byte{0x04, 0x35, 0x83, 0x94},
+	HDPublicKeyID:    [4]byte{0x04, 0x35, 0x87, 0xcf},
+	HDCoinType:       1,
+}
+
+var regTestNetParams = chaincfg.Params{
+	Name:             LbrycrdRegtest,
+	PubKeyHashAddrID: lbrycrdRegtestPubkeyPrefix,
+	ScriptHashAddrID: lbrycrdRegtestScriptPrefix,
+	PrivateKeyID:     0x1c,
+	Bech32HRPSegwit:  lbrycrdRegtestBech32HRP,
+	HDPrivateKeyID:   [4]byte{0x04, 0x35, 0x83, 0x94},
+	HDPublicKeyID:    [4]byte{0x04, 0x35, 0x87, 0xcf},
+	HDCoinType:       1,
+}
+
+var (
+	chainParamsMu sync.RWMutex
+	chainParams   = map[string]chaincfg.Params{
+		LbrycrdMain:    mainNetParams,
+		LbrycrdTestnet: testNetParams,
+		LbrycrdRegtest: regTestNetParams,
+	}
+)
+
+// ChainParamsMap holds the params of every registered network, keyed by blockchain name. RegisterChainParams
+// keeps it up to date, but changes made to it directly are not seen by the registry, and reading it while
+// another goroutine registers params is a data race.
+//
+// Deprecated: use GetChainParams, ChainNames and RegisterChainParams.
+var ChainParamsMap = map[string]chaincfg.Params{
+	LbrycrdMain:    mainNetParams,
+	LbrycrdTestnet: testNetParams,
+	LbrycrdRegtest: regTestNetParams,
+}
+
+// RegisterChainParams makes a network's address parameters available under a blockchain name, so that
+// sidechains and private test networks can be used anywhere a blockchain name is accepted. Registering a
+// name that already exists replaces its params. The params name is set to the blockchain name.
+func RegisterChainParams(name string, params chaincfg.Params) error {
+	if name == "" {
+		return errors.Err("blockchain name is empty")
+	}
+	if params.PubKeyHashAddrID == params.ScriptHashAddrID {
+		return errors.Err("pubkey hash and script hash prefixes for %s must be different", name)
+	}
+	params.Name = name
+
+	chainParamsMu.Lock()
+	defer chainParamsMu.Unlock()
+	chainParams[name] = params
+	ChainParamsMap[name] = params
+	return nil
+}
+
+// GetChainParams returns a copy of the params registered under the blockchain name
+func GetChainParams(name string) (*chaincfg.Params, error) {
+	chainParamsMu.RLock()
+	defer chainParamsMu.RUnlock()
+	params, ok := chainParams[name]
+	if !ok {
+		return nil, errors.Err("invalid blockchain name %s", name)
+	}
+	return &params, nil
+}
+
+// ChainNames returns the names of all registered networks in sorted order
+func ChainNames() []string {
+	chainParamsMu.RLock()
+	defer chainParamsMu.RUnlock()
+	names := make([]string, 0, len(chainParams))
+	for name := range chainParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
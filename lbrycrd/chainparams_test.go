@@ -0,0 +1,51 @@
+package lbrycrd
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestRegisterChainParams(t *testing.T) {
+	err := RegisterChainParams("test_sidechain", chaincfg.Params{
+		PubKeyHashAddrID: 0x3f,
+		ScriptHashAddrID: 0x40,
+		Bech32HRPSegwit:  "side",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := GetChainParams("test_sidechain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.Name != "test_sidechain" {
+		t.Errorf("expected params name to be set, got %s", params.Name)
+	}
+	if ChainParamsMap["test_sidechain"].PubKeyHashAddrID != 0x3f {
+		t.Error("expected ChainParamsMap to include the registered params")
+	}
+
+	addr, err := NewWitnessAddress(make([]byte, 20), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeAddress(addr.EncodeAddress(), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.IsForNet(params) {
+		t.Errorf("expected %s to be for the registered network", decoded.EncodeAddress())
+	}
+
+	_, err = GetChainParams("not_registered")
+	if err == nil {
+		t.Error("expected an error for an unregistered blockchain name")
+	}
+
+	err = RegisterChainParams("", chaincfg.Params{PubKeyHashAddrID: 1, ScriptHashAddrID: 2})
+	if err == nil {
+		t.Error("expected an error for an empty blockchain name")
+	}
+}
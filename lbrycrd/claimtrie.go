@@ -0,0 +1,254 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// ClaimTrieSupport is a support for a claim in the claimtrie. Amounts are in LBC.
+type ClaimTrieSupport struct {
+	TxID          string  `json:"txId"`
+	N             uint32  `json:"n"`
+	Height        int32   `json:"height"`
+	ValidAtHeight int32   `json:"validAtHeight"`
+	Amount        float64 `json:"amount"`
+	Address       string  `json:"address,omitempty"`
+	Value         string  `json:"value,omitempty"`
+}
+
+// ClaimTrieClaim is a claim in the claimtrie. Value is the hex encoded claim value. Amounts are in LBC.
+type ClaimTrieClaim struct {
+	Name               string             `json:"name"`
+	NormalizedName     string             `json:"normalizedName,omitempty"`
+	ClaimID            string             `json:"claimId"`
+	TxID               string             `json:"txId"`
+	N                  uint32             `json:"n"`
+	Height             int32              `json:"height"`
+	ValidAtHeight      int32              `json:"validAtHeight"`
+	Amount             float64            `json:"amount"`
+	EffectiveAmount    float64            `json:"effectiveAmount"`
+	PendingAmount      float64            `json:"pendingAmount,omitempty"`
+	Bid                int32              `json:"bid,omitempty"`
+	Sequence           int32              `json:"sequence,omitempty"`
+	LastTakeoverHeight int32              `json:"lastTakeoverHeight,omitempty"`
+	Address            string             `json:"address,omitempty"`
+	Value              string             `json:"value,omitempty"`
+	Supports           []ClaimTrieSupport `json:"supports,omitempty"`
+}
+
+// ValueBytes decodes the claim's value
+func (c ClaimTrieClaim) ValueBytes() ([]byte, error) {
+	value, err := hex.DecodeString(c.Value)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return value, nil
+}
+
+// ClaimsForName are the claims for a name, ordered by bid
+type ClaimsForName struct {
+	NormalizedName       string             `json:"normalizedName"`
+	Claims               []ClaimTrieClaim   `json:"claims"`
+	LastTakeoverHeight   int32              `json:"lastTakeoverHeight"`
+	SupportsWithoutClaim []ClaimTrieSupport `json:"supportsWithoutClaim"`
+}
+
+// ClaimOperation is what a transaction output does in the claimtrie
+type ClaimOperation string
+
+const (
+	ClaimOperationClaim   = ClaimOperation("CLAIM")
+	ClaimOperationUpdate  = ClaimOperation("UPDATE")
+	ClaimOperationSupport = ClaimOperation("SUPPORT")
+)
+
+// ClaimForTx is a claimtrie output of a transaction
+type ClaimForTx struct {
+	N             uint32         `json:"n"`
+	Address       string         `json:"address"`
+	Operation     ClaimOperation `json:"operation"`
+	Name          string         `json:"name"`
+	ClaimID       string         `json:"claimId"`
+	Value         string         `json:"value,omitempty"`
+	SupportedID   string         `json:"supportedClaimId,omitempty"`
+	Depth         int32          `json:"depth"`
+	InClaimTrie   bool           `json:"inClaimTrie"`
+	IsControlling bool           `json:"isControlling"`
+	InSupportMap  bool           `json:"inSupportMap"`
+	InQueue       bool           `json:"inQueue"`
+	BlocksToValid int32          `json:"blocksToValid"`
+}
+
+// NameProofChild is a child of a node in a name proof. NodeHash is set for the children that are not on the
+// path to the name.
+type NameProofChild struct {
+	Character int    `json:"character"`
+	NodeHash  string `json:"nodeHash,omitempty"`
+}
+
+type NameProofNode struct {
+	Children  []NameProofChild `json:"children"`
+	ValueHash string           `json:"valueHash,omitempty"`
+}
+
+type NameProofPair struct {
+	Odd  bool   `json:"odd"`
+	Hash string `json:"hash"`
+}
+
+// NameProof proves that a name's controlling claim, or that there is none, is in the claimtrie of a block
+type NameProof struct {
+	Nodes              []NameProofNode `json:"nodes"`
+	Pairs              []NameProofPair `json:"pairs,omitempty"`
+	TxHash             string          `json:"txhash,omitempty"`
+	NOut               uint32          `json:"nOut,omitempty"`
+	LastTakeoverHeight int32           `json:"lastTakeoverHeight,omitempty"`
+}
+
+// rawCall calls an rpc method the btcd client doesn't know about. Trailing nil params are left off, so
+// lbrycrd uses its defaults for them. A nil result ignores what the call returns.
+func (c *Client) rawCall(result interface{}, method string, params ...interface{}) error {
+	raw, err := marshalParams(params)
+	if err != nil {
+		return err
+	}
+	var response json.RawMessage
+	if c.config != nil {
+		response, err = c.call(method, raw)
+	} else {
+		response, err = c.RawRequest(method, raw)
+	}
+	if err != nil {
+		return errors.Err(err)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response, result); err != nil {
+		return errors.Prefix("decoding "+method, err)
+	}
+	return nil
+}
+
+// marshalParams encodes the params of a call, leaving off the trailing nil ones
+func marshalParams(params []interface{}) ([]json.RawMessage, error) {
+	for len(params) > 0 && isNil(params[len(params)-1]) {
+		params = params[:len(params)-1]
+	}
+	raw := make([]json.RawMessage, len(params))
+	for i, p := range params {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		raw[i] = b
+	}
+	return raw, nil
+}
+
+func isNil(v interface{}) bool {
+	switch p := v.(type) {
+	case nil:
+		return true
+	case *string:
+		return p == nil
+	case *int32:
+		return p == nil
+	case *bool:
+		return p == nil
+	}
+	return false
+}
+
+// GetClaimsForName returns the claims for a name at the tip, or at blockHash if it is not nil
+func (c *Client) GetClaimsForName(name string, blockHash *string) (*ClaimsForName, error) {
+	result := new(ClaimsForName)
+	return result, c.rawCall(result, "getclaimsforname", name, blockHash)
+}
+
+// GetValueForName returns the controlling claim for a name at the tip, or at blockHash if it is not nil
+func (c *Client) GetValueForName(name string, blockHash *string) (*ClaimTrieClaim, error) {
+	result := new(ClaimTrieClaim)
+	return result, c.rawCall(result, "getvalueforname", name, blockHash)
+}
+
+// GetClaimByID returns the claim with the claim ID
+func (c *Client) GetClaimByID(claimID string) (*ClaimTrieClaim, error) {
+	if _, err := decodeClaimID(claimID); err != nil {
+		return nil, err
+	}
+	result := new(ClaimTrieClaim)
+	return result, c.rawCall(result, "getclaimbyid", claimID)
+}
+
+// GetClaimByBid returns the claim for a name at the position in the bid order, where 0 is the controlling claim
+func (c *Client) GetClaimByBid(name string, bid int32, blockHash *string) (*ClaimTrieClaim, error) {
+	result := new(ClaimTrieClaim)
+	return result, c.rawCall(result, "getclaimbybid", name, bid, blockHash)
+}
+
+// GetClaimBySeq returns the claim for a name at the position in the order the claims were made, starting at 1
+func (c *Client) GetClaimBySeq(name string, seq int32, blockHash *string) (*ClaimTrieClaim, error) {
+	result := new(ClaimTrieClaim)
+	return result, c.rawCall(result, "getclaimbyseq", name, seq, blockHash)
+}
+
+// GetClaimsForTx returns the claimtrie outputs of a transaction
+func (c *Client) GetClaimsForTx(txid string) ([]ClaimForTx, error) {
+	var result []ClaimForTx
+	err := c.rawCall(&result, "getclaimsfortx", txid)
+	return result, err
+}
+
+// GetNameProof returns a proof for a name at the tip, or at blockHash if it is not nil. The proof is for the
+// controlling claim, or for claimID if it is not nil.
+func (c *Client) GetNameProof(name string, blockHash *string, claimID *string) (*NameProof, error) {
+	if claimID != nil && blockHash == nil {
+		// params are positional, so the block hash can't be left off
+		bestBlockHash, err := c.GetBestBlockHash()
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		hash := bestBlockHash.String()
+		blockHash = &hash
+	}
+	result := new(NameProof)
+	return result, c.rawCall(result, "getnameproof", name, blockHash, claimID)
+}
+
+// GetNamesInTrie returns every name in the claimtrie at the tip, or at blockHash if it is not nil
+func (c *Client) GetNamesInTrie(blockHash *string) ([]string, error) {
+	var result []string
+	err := c.rawCall(&result, "getnamesintrie", blockHash)
+	return result, err
+}
+
+// GetTotalClaimedNames returns how many names have claims
+func (c *Client) GetTotalClaimedNames() (int64, error) {
+	var result int64
+	err := c.rawCall(&result, "gettotalclaimednames")
+	return result, err
+}
+
+// GetTotalClaims returns how many claims are in the claimtrie
+func (c *Client) GetTotalClaims() (int64, error) {
+	var result int64
+	err := c.rawCall(&result, "gettotalclaims")
+	return result, err
+}
+
+// GetTotalValueOfClaims returns the LBC staked in claims, or only in controlling claims if controllingOnly
+func (c *Client) GetTotalValueOfClaims(controllingOnly bool) (float64, error) {
+	var result float64
+	err := c.rawCall(&result, "gettotalvalueofclaims", controllingOnly)
+	return result, err
+}
+
+// CheckNormalization returns the name as lbrycrd normalizes it
+func (c *Client) CheckNormalization(name string) (string, error) {
+	var result string
+	err := c.rawCall(&result, "checknormalization", name)
+	return result, err
+}
@@ -0,0 +1,167 @@
+package lbrycrd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const claimsForNameResponse = `{
+  "normalizedName": "video",
+  "claims": [
+    {
+      "name": "video",
+      "normalizedName": "video",
+      "claimId": "f7c1c2e3b4a5968778695a4b3c2d1e0f1a2b3c4d",
+      "txId": "a3c4d6e1f2b3a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+      "n": 0,
+      "height": 812000,
+      "validAtHeight": 812000,
+      "amount": 1.5,
+      "effectiveAmount": 11.5,
+      "pendingAmount": 11.5,
+      "bid": 0,
+      "sequence": 1,
+      "address": "bHmNm1zGxZ1Y5N8WdLXAaUkRdUDSGnqhqe",
+      "value": "0a0b",
+      "supports": [
+        {
+          "txId": "b4d5e6f708192a3b4c5d6e7f8091a2b3c4da3c4d6e1f2b3a4c5d6e7f8091a2b3",
+          "n": 1,
+          "height": 812100,
+          "validAtHeight": 812100,
+          "amount": 10.0
+        }
+      ]
+    }
+  ],
+  "lastTakeoverHeight": 812000,
+  "supportsWithoutClaim": []
+}`
+
+func TestClient_ClaimTrie(t *testing.T) {
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		switch req.Method {
+		case "getclaimsforname":
+			return json.RawMessage(claimsForNameResponse), ""
+		case "getvalueforname", "getclaimbyid", "getclaimbybid", "getclaimbyseq":
+			var claims ClaimsForName
+			if err := json.Unmarshal([]byte(claimsForNameResponse), &claims); err != nil {
+				return nil, err.Error()
+			}
+			return claims.Claims[0], ""
+		case "getclaimsfortx":
+			return []ClaimForTx{{N: 0, Operation: ClaimOperationSupport, Name: "video", ClaimID: "f7c1c2e3b4a5968778695a4b3c2d1e0f1a2b3c4d", InSupportMap: true}}, ""
+		case "getnameproof":
+			if len(req.Params) != 3 {
+				return nil, "expected a block hash with a claim id"
+			}
+			return NameProof{Nodes: []NameProofNode{{Children: []NameProofChild{{Character: 118}}}}, NOut: 0}, ""
+		case "getbestblockhash":
+			return "0000000000000000000000000000000000000000000000000000000000000abc", ""
+		case "getnamesintrie":
+			return []string{"video", "@channel"}, ""
+		case "gettotalclaims":
+			return 1234567, ""
+		case "gettotalvalueofclaims":
+			return 98765.4321, ""
+		case "checknormalization":
+			return "video", ""
+		}
+		return nil, "Method not found"
+	})
+	defer server.Close()
+
+	claims, err := c.GetClaimsForName("video", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims.Claims) != 1 || claims.LastTakeoverHeight != 812000 {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+	claim := claims.Claims[0]
+	if claim.EffectiveAmount != 11.5 || len(claim.Supports) != 1 || claim.Supports[0].Amount != 10 {
+		t.Errorf("unexpected claim %+v", claim)
+	}
+	if value, err := claim.ValueBytes(); err != nil || len(value) != 2 {
+		t.Errorf("unexpected value %x %v", value, err)
+	}
+
+	controlling, err := c.GetValueForName("video", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if controlling.ClaimID != claim.ClaimID {
+		t.Errorf("unexpected controlling claim %+v", controlling)
+	}
+	if _, err := c.GetClaimByID(claim.ClaimID); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.GetClaimByID("not a claim id"); err == nil {
+		t.Error("expected an error for an invalid claim id")
+	}
+	if _, err := c.GetClaimByBid("video", 0, nil); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.GetClaimBySeq("video", 1, nil); err != nil {
+		t.Error(err)
+	}
+
+	outputs, err := c.GetClaimsForTx(claim.Supports[0].TxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 1 || outputs[0].Operation != ClaimOperationSupport || !outputs[0].InSupportMap {
+		t.Errorf("unexpected outputs %+v", outputs)
+	}
+
+	proof, err := c.GetNameProof("video", nil, &claim.ClaimID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proof.Nodes) != 1 || proof.Nodes[0].Children[0].Character != 'v' {
+		t.Errorf("unexpected proof %+v", proof)
+	}
+
+	names, err := c.GetNamesInTrie(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 {
+		t.Errorf("unexpected names %v", names)
+	}
+	if total, err := c.GetTotalClaims(); err != nil || total != 1234567 {
+		t.Errorf("unexpected total claims %d %v", total, err)
+	}
+	if value, err := c.GetTotalValueOfClaims(true); err != nil || value != 98765.4321 {
+		t.Errorf("unexpected total value %f %v", value, err)
+	}
+	if normalized, err := c.CheckNormalization("VIDEO"); err != nil || normalized != "video" {
+		t.Errorf("unexpected normalization %s %v", normalized, err)
+	}
+	if _, err := c.GetTotalClaimedNames(); err == nil {
+		t.Error("expected the daemon's error")
+	}
+}
+
+func TestClient_RawCallParams(t *testing.T) {
+	var params []string
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		params = stringParams(t, req)
+		return ClaimsForName{}, ""
+	})
+	defer server.Close()
+
+	if _, err := c.GetClaimsForName("video", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 1 || params[0] != "video" {
+		t.Errorf("expected the nil block hash to be left off, got %v", params)
+	}
+	blockHash := "0000000000000000000000000000000000000000000000000000000000000abc"
+	if _, err := c.GetClaimsForName("video", &blockHash); err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 2 || params[1] != blockHash {
+		t.Errorf("unexpected params %v", params)
+	}
+}
@@ -0,0 +1,100 @@
+package lbrycrd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeRequest is a request received by a fake lbrycrd
+type fakeRequest struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	ID     interface{}       `json:"id"`
+}
+
+// newFakeLbrycrd starts a fake lbrycrd that answers every request with handle's result, encoded to json, or
+// with its error if the error is not empty. It answers the calls New makes to connect itself, and batches.
+func newFakeLbrycrd(t *testing.T, handle func(req fakeRequest) (interface{}, string)) (*Client, *httptest.Server) {
+	server := httptest.NewServer(fakeLbrycrdHandler(t, handle))
+	c, err := New("rpc://user:pass@" + strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return c, server
+}
+
+// fakeLbrycrdHandler answers requests like the server newFakeLbrycrd starts, without checking credentials
+func fakeLbrycrdHandler(t *testing.T, handle func(req fakeRequest) (interface{}, string)) http.Handler {
+	respond := func(req fakeRequest) map[string]interface{} {
+		var result interface{}
+		var errMessage string
+		switch req.Method {
+		case "getblockchaininfo":
+			result = map[string]interface{}{"chain": "regtest", "blocks": 0}
+		case "getinfo":
+			// newer rpc clients call getinfo and getnetworkinfo to detect the node's version
+			errMessage = "Method not found"
+		case "getnetworkinfo":
+			result = map[string]interface{}{"version": 170300, "subversion": "/LBRYcrd:0.17.3/"}
+		default:
+			result, errMessage = handle(req)
+		}
+		response := map[string]interface{}{"result": result, "error": nil, "id": req.ID}
+		if errMessage != "" {
+			code := -1
+			if errMessage == "Method not found" {
+				code = -32601
+			}
+			response["result"] = nil
+			response["error"] = map[string]interface{}{"code": code, "message": errMessage}
+		}
+		return response
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request: %v", err)
+			return
+		}
+		var response interface{}
+		if strings.HasPrefix(string(body), "[") {
+			var reqs []fakeRequest
+			if err := json.Unmarshal(body, &reqs); err != nil {
+				t.Errorf("invalid batch: %v", err)
+				return
+			}
+			responses := make([]interface{}, len(reqs))
+			for i, req := range reqs {
+				responses[i] = respond(req)
+			}
+			response = responses
+		} else {
+			var req fakeRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("invalid request: %v", err)
+				return
+			}
+			response = respond(req)
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// stringParams decodes the params of a request that are all strings
+func stringParams(t *testing.T, req fakeRequest) []string {
+	t.Helper()
+	params := make([]string, len(req.Params))
+	for i, p := range req.Params {
+		if err := json.Unmarshal(p, &params[i]); err != nil {
+			t.Fatalf("param %d of %s is not a string: %s", i, req.Method, p)
+		}
+	}
+	return params
+}
@@ -0,0 +1,268 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// ErrInsufficientFunds is returned when the outputs that can be selected don't add up to what is needed
+var ErrInsufficientFunds = errors.Base("not enough spendable outputs")
+
+// ErrNoExactMatch is returned by SelectBranchAndBound when no set of outputs spends the target without change
+var ErrNoExactMatch = errors.Base("no set of outputs matches the target")
+
+// MaxConfirmations is the largest maxConf ListUnspentOutputs can be given, which leaves out no outputs
+const MaxConfirmations = 9999999
+
+// bnbMaxTries is how many steps SelectBranchAndBound searches for before it gives up, as lbrycrd does
+const bnbMaxTries = 100000
+
+// p2pkhInputSize is the size of a signed input that spends a pay-to-pubkey-hash output
+const p2pkhInputSize = 32 + 4 + 1 + p2pkhSigScriptSize + 4
+
+// Unspent is an output the wallet can spend. Claim is set if the output holds a claim, update or support, which
+// spending abandons.
+type Unspent struct {
+	OutPoint      wire.OutPoint
+	Address       string
+	PkScript      []byte
+	Amount        btcutil.Amount
+	Confirmations int64
+	Spendable     bool
+	Safe          bool // false for unconfirmed outputs of transactions the wallet didn't send
+	Claim         *ClaimScript
+}
+
+type listUnspentResult struct {
+	TxID          string  `json:"txid"`
+	Vout          uint32  `json:"vout"`
+	Address       string  `json:"address"`
+	ScriptPubKey  string  `json:"scriptPubKey"`
+	Amount        float64 `json:"amount"`
+	Confirmations int64   `json:"confirmations"`
+	Spendable     bool    `json:"spendable"`
+	Safe          *bool   `json:"safe"`
+}
+
+// ListUnspentOutputs lists the wallet's outputs with between minConf and maxConf confirmations. If addresses
+// is not empty, only outputs paying to them are listed.
+func (c *Client) ListUnspentOutputs(minConf, maxConf int, addresses []btcutil.Address) ([]Unspent, error) {
+	var encoded interface{}
+	if len(addresses) > 0 {
+		strs := make([]string, len(addresses))
+		for i, a := range addresses {
+			strs[i] = a.EncodeAddress()
+		}
+		encoded = strs
+	}
+	var results []listUnspentResult
+	if err := c.rawCall(&results, "listunspent", minConf, maxConf, encoded); err != nil {
+		return nil, err
+	}
+
+	unspent := make([]Unspent, len(results))
+	for i, r := range results {
+		hash, err := chainhash.NewHashFromStr(r.TxID)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		script, err := hex.DecodeString(r.ScriptPubKey)
+		if err != nil {
+			return nil, errors.Prefix("decoding scriptPubKey", err)
+		}
+		amount, err := btcutil.NewAmount(r.Amount)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		unspent[i] = Unspent{
+			OutPoint:      *wire.NewOutPoint(hash, r.Vout),
+			Address:       r.Address,
+			PkScript:      script,
+			Amount:        amount,
+			Confirmations: r.Confirmations,
+			Spendable:     r.Spendable,
+			Safe:          r.Safe == nil || *r.Safe, // older versions of lbrycrd don't say
+			Claim:         DecodeScript(script, &MainNetParams).Claim,
+		}
+	}
+	return unspent, nil
+}
+
+// SelectUnspent lists the wallet's outputs and selects some to spend with SelectCoins
+func (c *Client) SelectUnspent(target btcutil.Amount, opts SelectOptions) (*Selection, error) {
+	unspent, err := c.ListUnspentOutputs(int(opts.MinConf), MaxConfirmations, nil)
+	if err != nil {
+		return nil, err
+	}
+	return SelectCoins(unspent, target, opts)
+}
+
+// SelectOptions configures coin selection
+type SelectOptions struct {
+	// FeeRate is the fee per kB the transaction pays. The fee for spending each selected output is taken from
+	// what it adds, so outputs worth less than that are never selected.
+	FeeRate btcutil.Amount
+	// MinConf is how many confirmations an output needs to be selected
+	MinConf int64
+	// SpendClaims allows selecting outputs that hold claims, updates or supports. Spending one abandons it.
+	SpendClaims bool
+	// IncludeUnsafe allows selecting unconfirmed outputs the wallet didn't send itself
+	IncludeUnsafe bool
+	// CostOfChange is how much more than the target SelectBranchAndBound may spend, rather than add a change
+	// output. It defaults to the fee for adding a change output and spending it later.
+	CostOfChange btcutil.Amount
+}
+
+// Selection is a set of outputs to spend. Fee is what spending them adds to the transaction's fee, and Change
+// is what is left over once the target and Fee are paid.
+type Selection struct {
+	Inputs []Unspent
+	Total  btcutil.Amount
+	Fee    btcutil.Amount
+	Change btcutil.Amount
+}
+
+// AddToTx adds an input for each selected output to the transaction. It returns the outputs' scripts, in the
+// order the inputs were added, for SignTransaction.
+func (s *Selection) AddToTx(tx *wire.MsgTx) [][]byte {
+	prevScripts := make([][]byte, 0, len(s.Inputs))
+	for _, u := range s.Inputs {
+		outPoint := u.OutPoint
+		tx.AddTxIn(wire.NewTxIn(&outPoint, nil, nil))
+		prevScripts = append(prevScripts, u.PkScript)
+	}
+	return prevScripts
+}
+
+// SelectCoins selects outputs worth target plus the fee for spending them. It looks for outputs that spend
+// target without change first, then falls back to the largest outputs.
+func SelectCoins(unspent []Unspent, target btcutil.Amount, opts SelectOptions) (*Selection, error) {
+	s, err := SelectBranchAndBound(unspent, target, opts)
+	if errors.Is(err, ErrNoExactMatch) {
+		return SelectLargestFirst(unspent, target, opts)
+	}
+	return s, err
+}
+
+// SelectLargestFirst selects the largest outputs until they are worth target plus the fee for spending them
+func SelectLargestFirst(unspent []Unspent, target btcutil.Amount, opts SelectOptions) (*Selection, error) {
+	eligible := eligibleUnspent(unspent, opts)
+	s := &Selection{}
+	for _, u := range eligible {
+		s.add(u, opts.FeeRate)
+		if s.Total-s.Fee >= target {
+			s.Change = s.Total - s.Fee - target
+			return s, nil
+		}
+	}
+	return nil, errors.Err(ErrInsufficientFunds)
+}
+
+// SelectBranchAndBound searches for outputs that are worth between target and target plus CostOfChange, after
+// the fee for spending them, so the transaction needs no change output. What they are worth above target goes
+// to the fee. Of the sets it finds, it picks the one that spends least above target. It returns
+// ErrNoExactMatch if there is no such set, or ErrInsufficientFunds if the outputs don't add up to target.
+func SelectBranchAndBound(unspent []Unspent, target btcutil.Amount, opts SelectOptions) (*Selection, error) {
+	eligible := eligibleUnspent(unspent, opts)
+	costOfChange := opts.CostOfChange
+	if costOfChange <= 0 {
+		costOfChange = FeeForSize(OutputSize(p2pkhScriptSize)+p2pkhInputSize, opts.FeeRate)
+	}
+
+	values := make([]btcutil.Amount, len(eligible))
+	var available btcutil.Amount
+	for i, u := range eligible {
+		values[i] = effectiveValue(u, opts.FeeRate)
+		available += values[i]
+	}
+	if available < target {
+		return nil, errors.Err(ErrInsufficientFunds)
+	}
+
+	// a depth first search of including or leaving out each output, largest first. i is the next output to decide
+	// on and remaining is what the outputs from i on are worth. a branch is cut once it is worth more than target
+	// plus costOfChange, or once what is left can't reach target.
+	included := make([]bool, len(eligible))
+	var best []bool
+	bestExcess := btcutil.Amount(-1)
+	var value btcutil.Amount
+	remaining := available
+	i := 0
+	for tries := 0; tries < bnbMaxTries; tries++ {
+		backtrack := false
+		if value+remaining < target || value > target+costOfChange {
+			backtrack = true
+		} else if value >= target {
+			if excess := value - target; bestExcess < 0 || excess < bestExcess {
+				best, bestExcess = append([]bool(nil), included[:i]...), excess
+				if excess == 0 {
+					break
+				}
+			}
+			backtrack = true
+		}
+
+		if backtrack {
+			// go back to the last included output and leave it out instead
+			for i > 0 && !included[i-1] {
+				i--
+				remaining += values[i]
+			}
+			if i == 0 {
+				break
+			}
+			included[i-1] = false
+			value -= values[i-1]
+			continue
+		}
+		remaining -= values[i]
+		value += values[i]
+		included[i] = true
+		i++
+	}
+
+	if best == nil {
+		return nil, errors.Err(ErrNoExactMatch)
+	}
+	s := &Selection{}
+	for j, in := range best {
+		if in {
+			s.add(eligible[j], opts.FeeRate)
+		}
+	}
+	// without change, what is left over goes to the fee
+	s.Fee = s.Total - target
+	return s, nil
+}
+
+// add selects an output
+func (s *Selection) add(u Unspent, feeRate btcutil.Amount) {
+	s.Inputs = append(s.Inputs, u)
+	s.Total += u.Amount
+	s.Fee += FeeForSize(p2pkhInputSize, feeRate)
+}
+
+// effectiveValue is what an output adds to a transaction once the fee for spending it is paid
+func effectiveValue(u Unspent, feeRate btcutil.Amount) btcutil.Amount {
+	return u.Amount - FeeForSize(p2pkhInputSize, feeRate)
+}
+
+// eligibleUnspent returns the outputs the options allow selecting, that are worth spending, largest first
+func eligibleUnspent(unspent []Unspent, opts SelectOptions) []Unspent {
+	var eligible []Unspent
+	for _, u := range unspent {
+		if !u.Spendable || u.Confirmations < opts.MinConf || (!u.Safe && !opts.IncludeUnsafe) ||
+			(u.Claim != nil && !opts.SpendClaims) || effectiveValue(u, opts.FeeRate) <= 0 {
+			continue
+		}
+		eligible = append(eligible, u)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool { return eligible[i].Amount > eligible[j].Amount })
+	return eligible
+}
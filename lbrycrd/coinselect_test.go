@@ -0,0 +1,247 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// testUnspent makes spendable, confirmed outputs of the amounts, in satoshis
+func testUnspent(amounts ...btcutil.Amount) []Unspent {
+	unspent := make([]Unspent, len(amounts))
+	for i, a := range amounts {
+		unspent[i] = Unspent{
+			OutPoint:      wire.OutPoint{Hash: chainhash.Hash{byte(i + 1)}},
+			Amount:        a,
+			Confirmations: 6,
+			Spendable:     true,
+			Safe:          true,
+		}
+	}
+	return unspent
+}
+
+func selectedAmounts(s *Selection) []btcutil.Amount {
+	amounts := make([]btcutil.Amount, len(s.Inputs))
+	for i, u := range s.Inputs {
+		amounts[i] = u.Amount
+	}
+	return amounts
+}
+
+func TestSelectLargestFirst(t *testing.T) {
+	unspent := testUnspent(1000, 5000, 3000, 200)
+	s, err := SelectLargestFirst(unspent, 7000, SelectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amounts := selectedAmounts(s); len(amounts) != 2 || amounts[0] != 5000 || amounts[1] != 3000 {
+		t.Errorf("expected the two largest outputs, got %v", amounts)
+	}
+	if s.Total != 8000 || s.Fee != 0 || s.Change != 1000 {
+		t.Errorf("unexpected selection %+v", s)
+	}
+
+	// at 10000 per kB, spending an output costs 1480, so the 1000 and 200 are not worth spending
+	s, err = SelectLargestFirst(unspent, 5000, SelectOptions{FeeRate: 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Total != 8000 || s.Fee != 2*1480 || s.Change != 8000-2960-5000 {
+		t.Errorf("unexpected selection %+v", s)
+	}
+	if _, err := SelectLargestFirst(unspent, 6000, SelectOptions{FeeRate: 10000}); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("expected ErrInsufficientFunds, got %v", err)
+	}
+}
+
+func TestSelectBranchAndBound(t *testing.T) {
+	unspent := testUnspent(4000, 3000, 2500, 1500, 700)
+
+	// 3000 + 1500 is the only exact match, which largest first would miss
+	s, err := SelectBranchAndBound(unspent, 4500, SelectOptions{CostOfChange: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amounts := selectedAmounts(s); len(amounts) != 2 || amounts[0] != 3000 || amounts[1] != 1500 {
+		t.Errorf("expected 3000 and 1500, got %v", amounts)
+	}
+	if s.Change != 0 || s.Fee != 0 {
+		t.Errorf("expected no change or fee, got %+v", s)
+	}
+
+	// once spending each output costs 1, 3000 + 1500 falls short
+	s, err = SelectBranchAndBound(unspent, 4500, SelectOptions{CostOfChange: 1, FeeRate: 1})
+	if err == nil {
+		t.Errorf("expected no match once inputs cost a fee, got %v", selectedAmounts(s))
+	}
+	// 4000 + 700 is the closest to 4550, and the 150 over goes to the fee
+	s, err = SelectBranchAndBound(unspent, 4550, SelectOptions{CostOfChange: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Total != 4700 || s.Fee != 150 || s.Change != 0 {
+		t.Errorf("expected the closest match over the target, got %+v", s)
+	}
+
+	if _, err := SelectBranchAndBound(unspent, 100, SelectOptions{CostOfChange: 1}); !errors.Is(err, ErrNoExactMatch) {
+		t.Errorf("expected ErrNoExactMatch, got %v", err)
+	}
+	if _, err := SelectBranchAndBound(unspent, 20000, SelectOptions{}); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("expected ErrInsufficientFunds, got %v", err)
+	}
+
+	// without an exact match, SelectCoins falls back to the largest outputs
+	s, err = SelectCoins(unspent, 100, SelectOptions{CostOfChange: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amounts := selectedAmounts(s); len(amounts) != 1 || amounts[0] != 4000 || s.Change != 3900 {
+		t.Errorf("expected the largest output, got %+v", s)
+	}
+}
+
+func TestSelectCoins_Eligible(t *testing.T) {
+	address, err := btcutil.NewAddressPubKeyHash(make([]byte, 20), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimScript, err := ClaimNameScript("name", []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unspent := testUnspent(9000, 8000, 7000, 6000, 1000)
+	unspent[0].Claim, unspent[0].PkScript = &ClaimScript{Type: ClaimName}, claimScript
+	unspent[1].Spendable = false
+	unspent[2].Safe, unspent[2].Confirmations = false, 0
+	unspent[3].Confirmations = 1
+
+	s, err := SelectCoins(unspent, 500, SelectOptions{MinConf: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amounts := selectedAmounts(s); len(amounts) != 1 || amounts[0] != 1000 {
+		t.Errorf("expected only the output that is eligible, got %v", amounts)
+	}
+	s, err = SelectCoins(unspent, 500, SelectOptions{IncludeUnsafe: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amounts := selectedAmounts(s); amounts[0] != 7000 {
+		t.Errorf("expected the unsafe output, got %v", amounts)
+	}
+	s, err = SelectCoins(unspent, 500, SelectOptions{SpendClaims: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amounts := selectedAmounts(s); amounts[0] != 9000 {
+		t.Errorf("expected the claim, got %v", amounts)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	prevScripts := s.AddToTx(tx)
+	if len(tx.TxIn) != 1 || tx.TxIn[0].PreviousOutPoint != unspent[0].OutPoint {
+		t.Errorf("expected an input spending the claim, got %v", tx.TxIn)
+	}
+	if len(prevScripts) != 1 || !IsClaimScript(prevScripts[0]) {
+		t.Errorf("expected the claim's script, got %x", prevScripts)
+	}
+}
+
+func TestClient_ListUnspentOutputs(t *testing.T) {
+	address, err := btcutil.NewAddressPubKeyHash(make([]byte, 20), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimScript, err := SupportClaimScript("name", testChannelClaimID, nil, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txid := chainhash.Hash{7}.String()
+
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		if req.Method != "listunspent" {
+			return nil, "Method not found"
+		}
+		var addresses []string
+		if len(req.Params) > 2 {
+			json.Unmarshal(req.Params[2], &addresses)
+		}
+		unspent := []map[string]interface{}{
+			{"txid": txid, "vout": 0, "address": address.EncodeAddress(), "scriptPubKey": hex.EncodeToString(pkScript),
+				"amount": 1.5, "confirmations": 3, "spendable": true, "safe": true},
+			{"txid": txid, "vout": 1, "address": address.EncodeAddress(), "scriptPubKey": hex.EncodeToString(claimScript),
+				"amount": 0.25, "confirmations": 3, "spendable": true},
+		}
+		if len(addresses) > 0 && addresses[0] != address.EncodeAddress() {
+			return []interface{}{}, ""
+		}
+		return unspent, ""
+	})
+	defer server.Close()
+
+	unspent, err := c.ListUnspentOutputs(1, MaxConfirmations, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unspent) != 2 {
+		t.Fatalf("expected 2 outputs, got %d", len(unspent))
+	}
+	if unspent[0].Amount != 150000000 || unspent[0].OutPoint.Index != 0 || unspent[0].Claim != nil || !unspent[0].Safe {
+		t.Errorf("unexpected output %+v", unspent[0])
+	}
+	if unspent[1].Claim == nil || unspent[1].Claim.Type != ClaimSupport || !unspent[1].Safe {
+		t.Errorf("expected a support, got %+v", unspent[1])
+	}
+
+	other, err := btcutil.NewAddressPubKeyHash(make([]byte, 20), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.ScriptAddress()[0] = 1
+	if unspent, err := c.ListUnspentOutputs(1, MaxConfirmations, []btcutil.Address{other}); err != nil || len(unspent) != 0 {
+		t.Errorf("expected no outputs for another address, got %v, %v", unspent, err)
+	}
+
+	s, err := c.SelectUnspent(btcutil.SatoshiPerBitcoin, SelectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Inputs) != 1 || s.Inputs[0].Claim != nil {
+		t.Errorf("expected the output that isn't a support, got %+v", s.Inputs)
+	}
+}
+
+func TestOutputFinder_SkipsClaims(t *testing.T) {
+	address, err := btcutil.NewAddressPubKeyHash(make([]byte, 20), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimScript, err := ClaimNameScript("name", []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unspent := []btcjson.ListUnspentResult{
+		{ScriptPubKey: hex.EncodeToString(claimScript), Amount: 5, Spendable: true},
+		{ScriptPubKey: "76a914000000000000000000000000000000000000000088ac", Amount: 1, Spendable: true},
+	}
+	batch, err := newOutputFinder(unspent).nextBatch(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != 1 || batch[0].Amount != 1 {
+		t.Errorf("expected only the output that isn't a claim, got %v", batch)
+	}
+}
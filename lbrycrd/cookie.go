@@ -0,0 +1,93 @@
+package lbrycrd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// cookieFile is the name of the file lbrycrd writes its rpc credentials to when no rpcpassword is set
+const cookieFile = ".cookie"
+
+// cookieAuth holds the credentials from a lbrycrd cookie file. lbrycrd writes a new cookie each time it starts,
+// so the file is read again whenever it changes.
+type cookieAuth struct {
+	path string
+
+	mu      sync.Mutex
+	user    string
+	pass    string
+	modTime time.Time
+}
+
+// credentials returns the user and password in the cookie, reading the file again if it changed since it was
+// last read. If the file is gone, which happens while lbrycrd is down, the last cookie is returned.
+func (a *cookieAuth) credentials() (user, pass string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	info, err := os.Stat(a.path)
+	if err != nil {
+		if a.user != "" {
+			return a.user, a.pass, nil
+		}
+		return "", "", errors.Prefix("reading lbrycrd cookie", err)
+	}
+	if a.user != "" && info.ModTime().Equal(a.modTime) {
+		return a.user, a.pass, nil
+	}
+	if err := a.read(info.ModTime()); err != nil {
+		return "", "", err
+	}
+	return a.user, a.pass, nil
+}
+
+// reload reads the cookie file even if it doesn't look changed, and says whether the cookie is different
+func (a *cookieAuth) reload() (changed bool, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return false, errors.Prefix("reading lbrycrd cookie", err)
+	}
+	user, pass := a.user, a.pass
+	if err := a.read(info.ModTime()); err != nil {
+		return false, err
+	}
+	return a.user != user || a.pass != pass, nil
+}
+
+func (a *cookieAuth) read(modTime time.Time) error {
+	user, pass, err := readCookie(a.path)
+	if err != nil {
+		return err
+	}
+	a.user, a.pass, a.modTime = user, pass, modTime
+	return nil
+}
+
+// readCookie reads the user and password from a cookie file, which holds "user:password"
+func readCookie(path string) (user, pass string, err error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", errors.Prefix("reading lbrycrd cookie", err)
+	}
+	parts := strings.SplitN(strings.TrimSpace(string(b)), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Err("lbrycrd cookie %s is malformed", path)
+	}
+	return parts[0], parts[1], nil
+}
+
+// defaultCookiePath returns where lbrycrd writes its cookie for a data dir. Like bitcoin, it writes the cookie
+// for regtest into a regtest dir inside the data dir.
+func defaultCookiePath(dataDir string, regtest bool) string {
+	if regtest {
+		return filepath.Join(dataDir, "regtest", cookieFile)
+	}
+	return filepath.Join(dataDir, cookieFile)
+}
@@ -0,0 +1,213 @@
+package lbrycrd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Chains under an account's master key. lbry-sdk derives the keys for a chain as children of the chain's key,
+// so the nth channel key of an account is m/2/n.
+const (
+	ReceivingChain uint32 = 0
+	ChangeChain    uint32 = 1
+	ChannelChain   uint32 = 2
+)
+
+// HardenedKeyStart is the index of the first hardened child key
+const HardenedKeyStart uint32 = 0x80000000
+
+// seedIterations is the number of pbkdf2 rounds that turn a seed phrase into a seed
+const seedIterations = 2048
+
+var masterKeyHMACKey = []byte("Bitcoin seed")
+
+// ExtendedKey is a BIP32 private key together with its chain code. ParentFingerprint and ChildIndex say where
+// the key is in the tree, and are only needed to serialize it.
+type ExtendedKey struct {
+	PrivateKey        *btcec.PrivateKey
+	ChainCode         []byte
+	Depth             uint8
+	ParentFingerprint uint32
+	ChildIndex        uint32
+}
+
+// SeedFromMnemonic turns a seed phrase into a wallet seed the way lbry-sdk does. The phrase is normalized
+// first, so differences in case, accents and whitespace do not change the seed. Unlike electrum, which lbry-sdk's
+// seeds otherwise follow, the salt is the passphrase alone.
+func SeedFromMnemonic(mnemonic, passphrase string) []byte {
+	return pbkdf2.Key([]byte(normalizeMnemonic(mnemonic)), []byte(normalizeMnemonic(passphrase)), seedIterations, sha512.Size, sha512.New)
+}
+
+// normalizeMnemonic normalizes text like lbry-sdk's normalize_text: NFKD, lower case, no combining marks, single
+// spaces between words, and no spaces between CJK characters
+func normalizeMnemonic(s string) string {
+	s = strings.ToLower(norm.NFKD.String(s))
+	s = strings.Map(func(r rune) rune {
+		if norm.NFKD.PropertiesString(string(r)).CCC() != 0 {
+			return -1
+		}
+		return r
+	}, s)
+	runes := []rune(strings.Join(strings.Fields(s), " "))
+
+	normalized := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if r == ' ' && isCJK(runes[i-1]) && isCJK(runes[i+1]) {
+			continue
+		}
+		normalized = append(normalized, r)
+	}
+	return string(normalized)
+}
+
+// cjkRanges are the blocks electrum, and so lbry-sdk, counts as CJK when it removes the spaces between words
+var cjkRanges = []struct{ lo, hi rune }{
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A
+	{0x20000, 0x2A6DF}, // CJK Unified Ideographs Extension B
+	{0x2A700, 0x2B73F}, // CJK Unified Ideographs Extension C
+	{0x2B740, 0x2B81F}, // CJK Unified Ideographs Extension D
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs
+	{0x2F800, 0x2FA1D}, // CJK Compatibility Ideographs Supplement
+	{0x3190, 0x319F},   // Kanbun
+	{0x2E80, 0x2EFF},   // CJK Radicals Supplement
+	{0x2F00, 0x2FDF},   // CJK Radicals
+	{0x31C0, 0x31EF},   // CJK Strokes
+	{0x2FF0, 0x2FFF},   // Ideographic Description Characters
+	{0xE0100, 0xE01EF}, // Variation Selectors Supplement
+	{0x3100, 0x312F},   // Bopomofo
+	{0x31A0, 0x31BF},   // Bopomofo Extended
+	{0xFF00, 0xFFEF},   // Halfwidth and Fullwidth Forms
+	{0x3040, 0x309F},   // Hiragana
+	{0x30A0, 0x30FF},   // Katakana
+	{0x31F0, 0x31FF},   // Katakana Phonetic Extensions
+	{0x1B000, 0x1B0FF}, // Kana Supplement
+	{0xAC00, 0xD7AF},   // Hangul Syllables
+	{0x1100, 0x11FF},   // Hangul Jamo
+	{0xA960, 0xA97F},   // Hangul Jamo Extended A
+	{0xD7B0, 0xD7FF},   // Hangul Jamo Extended B
+	{0x3130, 0x318F},   // Hangul Compatibility Jamo
+	{0xA4D0, 0xA4FF},   // Lisu
+	{0x16F00, 0x16F9F}, // Miao
+	{0xA000, 0xA48F},   // Yi Syllables
+	{0xA490, 0xA4CF},   // Yi Radicals
+}
+
+func isCJK(r rune) bool {
+	for _, cjk := range cjkRanges {
+		if r >= cjk.lo && r <= cjk.hi {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMasterKey creates the BIP32 master key for a seed
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.Err("seed must be between 16 and 64 bytes, got %d", len(seed))
+	}
+	mac := hmac.New(sha512.New, masterKeyHMACKey)
+	mac.Write(seed)
+	return newExtendedKey(mac.Sum(nil), 0)
+}
+
+// Child derives the child key at index i. Indexes from HardenedKeyStart up give hardened keys.
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	if k.Depth == 255 {
+		return nil, errors.Err("cannot derive a key deeper than 255 levels")
+	}
+
+	data := make([]byte, 0, 37)
+	if i >= HardenedKeyStart {
+		data = append(data, 0)
+		data = append(data, k.PrivateKey.Serialize()...)
+	} else {
+		data = append(data, k.PrivateKey.PubKey().SerializeCompressed()...)
+	}
+	index := make([]byte, 4)
+	binary.BigEndian.PutUint32(index, i)
+	data = append(data, index...)
+
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	// the child key is the parent key plus the left half of the hmac
+	n := btcec.S256().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, errors.Err("key at index %d is invalid, use the next index", i)
+	}
+	childKey := tweak.Add(tweak, k.PrivateKey.D)
+	childKey.Mod(childKey, n)
+	if childKey.Sign() == 0 {
+		return nil, errors.Err("key at index %d is invalid, use the next index", i)
+	}
+
+	keyBytes := make([]byte, 32, 64)
+	childBytes := childKey.Bytes()
+	copy(keyBytes[32-len(childBytes):], childBytes)
+	child, err := newExtendedKey(append(keyBytes, sum[32:]...), k.Depth+1)
+	if err != nil {
+		return nil, err
+	}
+	child.ParentFingerprint = k.Fingerprint()
+	child.ChildIndex = i
+	return child, nil
+}
+
+// Derive follows a path of child indexes from the key
+func (k *ExtendedKey) Derive(path ...uint32) (*ExtendedKey, error) {
+	key := k
+	for _, i := range path {
+		var err error
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// ChannelKey derives the private key for the channel at index from an account's master key
+func (k *ExtendedKey) ChannelKey(index uint32) (*btcec.PrivateKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, errors.Err("channel key index %d is out of range", index)
+	}
+	key, err := k.Derive(ChannelChain, index)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey, nil
+}
+
+// ChannelKeyFromMnemonic recovers the private key for the channel at index from a seed phrase
+func ChannelKeyFromMnemonic(mnemonic, passphrase string, index uint32) (*btcec.PrivateKey, error) {
+	master, err := NewMasterKey(SeedFromMnemonic(mnemonic, passphrase))
+	if err != nil {
+		return nil, err
+	}
+	return master.ChannelKey(index)
+}
+
+// newExtendedKey splits 64 bytes into the private key and the chain code
+func newExtendedKey(b []byte, depth uint8) (*ExtendedKey, error) {
+	d := new(big.Int).SetBytes(b[:32])
+	if d.Sign() == 0 || d.Cmp(btcec.S256().N) >= 0 {
+		return nil, errors.Err("derived key is invalid")
+	}
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), b[:32])
+	chainCode := make([]byte, 32)
+	copy(chainCode, b[32:])
+	return &ExtendedKey{PrivateKey: privateKey, ChainCode: chainCode, Depth: depth}, nil
+}
@@ -0,0 +1,105 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestExtendedKey_Derive(t *testing.T) {
+	// BIP32 test vector 1
+	master, err := NewMasterKey(mustDecodeHex(t, "000102030405060708090a0b0c0d0e0f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path     []uint32
+		expected string
+	}{
+		{nil, "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{[]uint32{HardenedKeyStart}, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{[]uint32{HardenedKeyStart, 1}, "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{[]uint32{HardenedKeyStart, 1, HardenedKeyStart + 2}, "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	}
+	for _, test := range tests {
+		key, err := master.Derive(test.path...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(key.PrivateKey.Serialize()); got != test.expected {
+			t.Errorf("%v: expected %s, got %s", test.path, test.expected, got)
+		}
+		if int(key.Depth) != len(test.path) {
+			t.Errorf("%v: expected depth %d, got %d", test.path, len(test.path), key.Depth)
+		}
+	}
+}
+
+func TestSeedFromMnemonic(t *testing.T) {
+	// the vector from lbry-sdk's mnemonic tests
+	expected := "475a419db4e991cab14f08bde2d357e52b3e7241f72c6d8a2f92782367feeee9" +
+		"f403dc6a37c26a3f02ab9dec7f5063161eb139cea00da64cd77fba2f07c49ddc"
+	if got := hex.EncodeToString(SeedFromMnemonic("foobar", "torba")); got != expected {
+		t.Errorf("expected seed %s, got %s", expected, got)
+	}
+}
+
+func TestNormalizeMnemonic(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"  Travel\tNOWHERE  air\n", "travel nowhere air"},
+		{"caf\u00e9 na\u00efve", "cafe naive"},
+		{"\u7684 \u4e00 \u662f", "\u7684\u4e00\u662f"},
+		{"\u3042 \u3044 abc \u3046", "\u3042\u3044 abc \u3046"},
+		{"\uac00 \ub098", "\u1100\u1161\u1102\u1161"},
+	}
+	for _, test := range tests {
+		if got := normalizeMnemonic(test.text); got != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.text, test.expected, got)
+		}
+	}
+}
+
+func TestChannelKeyFromMnemonic(t *testing.T) {
+	mnemonic := "travel nowhere air position hill peace suffer parent beautiful rise blood power home crumble teach"
+
+	key0, err := ChannelKeyFromMnemonic(mnemonic, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key1, err := ChannelKeyFromMnemonic(mnemonic, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key0.D.Cmp(key1.D) == 0 {
+		t.Error("channel keys at different indexes should differ")
+	}
+
+	master, err := NewMasterKey(SeedFromMnemonic(mnemonic, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := master.Derive(ChannelChain, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected.PrivateKey.D.Cmp(key1.D) != 0 {
+		t.Error("channel key should be derived at m/2/index")
+	}
+
+	// the phrase is normalized before it is used
+	again, err := ChannelKeyFromMnemonic("  Travel NOWHERE air position hill peace suffer parent beautiful rise blood power home crumble teach\n", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.D.Cmp(key0.D) != 0 {
+		t.Error("expected the same key for a phrase that differs only in case and whitespace")
+	}
+
+	_, err = master.ChannelKey(HardenedKeyStart)
+	if err == nil {
+		t.Error("expected an error for a hardened channel key index")
+	}
+}
@@ -0,0 +1,400 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+)
+
+// DescriptorType is the kind of output a descriptor describes
+type DescriptorType string
+
+const (
+	DescriptorPKH     = DescriptorType("pkh")
+	DescriptorWPKH    = DescriptorType("wpkh")
+	DescriptorSHWPKH  = DescriptorType("sh(wpkh)")
+	DescriptorAddress = DescriptorType("addr")
+)
+
+// Descriptor is an output script descriptor, see https://github.com/bitcoin/bitcoin/blob/master/doc/descriptors.md.
+// The single key descriptors that wallets use are supported: pkh(KEY), wpkh(KEY), sh(wpkh(KEY)) and addr(ADDR).
+// KEY is a hex public key, or an xpub or xprv followed by a derivation path that can end in /* to describe a
+// range of keys. It can be preceded by its origin in brackets, e.g. [d34db33f/44'/140'/0'].
+type Descriptor struct {
+	Type DescriptorType
+
+	desc    string
+	params  *chaincfg.Params
+	key     *descriptorKey
+	address btcutil.Address
+}
+
+type descriptorKey struct {
+	publicKey []byte // a single key
+	extended  *ExtendedPublicKey
+	private   *ExtendedKey // set if the extended key is private, so hardened children can be derived
+	path      []uint32
+	ranged    bool
+}
+
+// ParseDescriptor parses a descriptor for the network. If the descriptor has a checksum, it must match.
+func ParseDescriptor(desc string, params *chaincfg.Params) (*Descriptor, error) {
+	if i := strings.IndexByte(desc, '#'); i >= 0 {
+		checksum, err := descriptorChecksum(desc[:i])
+		if err != nil {
+			return nil, err
+		}
+		if desc[i+1:] != checksum {
+			return nil, errors.Err("descriptor checksum does not match, expected %s", checksum)
+		}
+		desc = desc[:i]
+	} else if _, err := descriptorChecksum(desc); err != nil {
+		return nil, err
+	}
+
+	d := &Descriptor{desc: desc, params: params}
+	var inner string
+	var ok bool
+	if inner, ok = unwrapDescriptor(desc, "sh(wpkh("); ok {
+		inner, ok = unwrapDescriptor(inner, "")
+		d.Type = DescriptorSHWPKH
+	} else if inner, ok = unwrapDescriptor(desc, "wpkh("); ok {
+		d.Type = DescriptorWPKH
+	} else if inner, ok = unwrapDescriptor(desc, "pkh("); ok {
+		d.Type = DescriptorPKH
+	} else if inner, ok = unwrapDescriptor(desc, "addr("); ok {
+		d.Type = DescriptorAddress
+	}
+	if !ok {
+		return nil, errors.Err("unsupported descriptor %s", desc)
+	}
+
+	if d.Type == DescriptorAddress {
+		if err := ValidateAddress(inner, params); err != nil {
+			return nil, err
+		}
+		address, err := DecodeAddress(inner, params)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		d.address = address
+		return d, nil
+	}
+
+	key, err := parseDescriptorKey(inner, params)
+	if err != nil {
+		return nil, err
+	}
+	if d.Type != DescriptorPKH && key.publicKey != nil && len(key.publicKey) != btcec.PubKeyBytesLenCompressed {
+		return nil, errors.Err("segwit descriptors need a compressed public key")
+	}
+	d.key = key
+	return d, nil
+}
+
+// unwrapDescriptor returns what is between prefix and the closing parenthesis at the end of desc
+func unwrapDescriptor(desc, prefix string) (string, bool) {
+	if !strings.HasPrefix(desc, prefix) || !strings.HasSuffix(desc, ")") {
+		return "", false
+	}
+	return desc[len(prefix) : len(desc)-1], true
+}
+
+func parseDescriptorKey(s string, params *chaincfg.Params) (*descriptorKey, error) {
+	if strings.HasPrefix(s, "[") {
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return nil, errors.Err("descriptor key origin is not closed")
+		}
+		origin := strings.Split(s[1:end], "/")
+		if fingerprint, err := hex.DecodeString(origin[0]); err != nil || len(fingerprint) != 4 {
+			return nil, errors.Err("descriptor key origin has an invalid fingerprint %s", origin[0])
+		}
+		if _, _, err := parseDescriptorPath(origin[1:]); err != nil {
+			return nil, err
+		}
+		s = s[end+1:]
+	}
+
+	key := &descriptorKey{}
+	parts := strings.Split(s, "/")
+	if len(parts) == 1 && (len(s) == 2*btcec.PubKeyBytesLenCompressed || len(s) == 2*btcec.PubKeyBytesLenUncompressed) {
+		b, err := hex.DecodeString(s)
+		if err == nil {
+			_, err = btcec.ParsePubKey(b, btcec.S256())
+		}
+		if err != nil {
+			return nil, errors.Prefix("invalid descriptor public key", err)
+		}
+		key.publicKey = b
+		return key, nil
+	}
+
+	var err error
+	if key.private, err = ParseExtendedKey(parts[0], params); err == nil {
+		key.extended = key.private.Neuter()
+	} else if key.extended, err = ParseExtendedPublicKey(parts[0], params); err != nil {
+		return nil, errors.Prefix("invalid descriptor key", err)
+	}
+	if key.path, key.ranged, err = parseDescriptorPath(parts[1:]); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// parseDescriptorPath parses the steps of a derivation path. The last step can be * to make the path a range.
+func parseDescriptorPath(steps []string) ([]uint32, bool, error) {
+	path := make([]uint32, 0, len(steps))
+	for i, step := range steps {
+		if step == "*" && i == len(steps)-1 {
+			return path, true, nil
+		}
+		var index uint32
+		if strings.HasSuffix(step, "'") || strings.HasSuffix(step, "h") {
+			step = step[:len(step)-1]
+			index = HardenedKeyStart
+		}
+		if step == "*" {
+			return nil, false, errors.Err("hardened ranges are not supported")
+		}
+		n, err := strconv.ParseUint(step, 10, 31)
+		if err != nil {
+			return nil, false, errors.Err("invalid derivation path step %s", steps[i])
+		}
+		path = append(path, index+uint32(n))
+	}
+	return path, false, nil
+}
+
+// String returns the descriptor with its checksum
+func (d *Descriptor) String() string {
+	checksum, _ := descriptorChecksum(d.desc)
+	return d.desc + "#" + checksum
+}
+
+// IsRange says whether the descriptor describes a range of addresses, rather than one
+func (d *Descriptor) IsRange() bool {
+	return d.key != nil && d.key.ranged
+}
+
+// Address derives the address at index of a ranged descriptor. The index is ignored if the descriptor is not
+// ranged.
+func (d *Descriptor) Address(index uint32) (btcutil.Address, error) {
+	if d.Type == DescriptorAddress {
+		return d.address, nil
+	}
+
+	publicKey := d.key.publicKey
+	if publicKey == nil {
+		path := d.key.path
+		if d.key.ranged {
+			if index >= HardenedKeyStart {
+				return nil, errors.Err("index %d is out of range", index)
+			}
+			path = append(path[:len(path):len(path)], index)
+		}
+		var key *ExtendedPublicKey
+		if d.key.private != nil {
+			private, err := d.key.private.Derive(path...)
+			if err != nil {
+				return nil, err
+			}
+			key = private.Neuter()
+		} else {
+			var err error
+			if key, err = d.key.extended.Derive(path...); err != nil {
+				return nil, err
+			}
+		}
+		publicKey = key.PublicKey.SerializeCompressed()
+	}
+
+	hash := btcutil.Hash160(publicKey)
+	switch d.Type {
+	case DescriptorPKH:
+		address, err := btcutil.NewAddressPubKeyHash(hash, d.params)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		return address, nil
+	case DescriptorWPKH:
+		return NewWitnessAddress(hash, d.params)
+	default:
+		redeemScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(hash).Script()
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		return ScriptHashAddress(redeemScript, d.params)
+	}
+}
+
+// Addresses derives the addresses from begin to end, inclusive, of a ranged descriptor, like lbrycrd's
+// deriveaddresses
+func (d *Descriptor) Addresses(begin, end uint32) ([]btcutil.Address, error) {
+	if !d.IsRange() {
+		return nil, errors.Err("descriptor is not ranged")
+	}
+	if end < begin {
+		return nil, errors.Err("range end %d is before its beginning %d", end, begin)
+	}
+	addresses := make([]btcutil.Address, 0, end-begin+1)
+	for i := begin; i <= end; i++ {
+		address, err := d.Address(i)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+const (
+	descriptorInputCharset    = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+var descriptorGenerator = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+func descriptorPolymod(c uint64, value int) uint64 {
+	top := c >> 35
+	c = (c&0x7ffffffff)<<5 ^ uint64(value)
+	for i, g := range descriptorGenerator {
+		if top>>uint(i)&1 == 1 {
+			c ^= g
+		}
+	}
+	return c
+}
+
+// descriptorChecksum returns the checksum lbrycrd expects after the # of a descriptor
+func descriptorChecksum(desc string) (string, error) {
+	c := uint64(1)
+	group, groupCount := 0, 0
+	for _, r := range desc {
+		pos := strings.IndexRune(descriptorInputCharset, r)
+		if pos < 0 {
+			return "", errors.Err("descriptor has an invalid character %q", r)
+		}
+		// the low bits of each character, then the high bits of every three characters together
+		c = descriptorPolymod(c, pos&31)
+		group = group*3 + pos>>5
+		if groupCount++; groupCount == 3 {
+			c = descriptorPolymod(c, group)
+			group, groupCount = 0, 0
+		}
+	}
+	if groupCount > 0 {
+		c = descriptorPolymod(c, group)
+	}
+	for i := 0; i < 8; i++ {
+		c = descriptorPolymod(c, 0)
+	}
+	c ^= 1
+
+	checksum := make([]byte, 8)
+	for i := range checksum {
+		checksum[i] = descriptorChecksumCharset[c>>(5*uint(7-i))&31]
+	}
+	return string(checksum), nil
+}
+
+// ImportTimestamp is the unix time an import's keys were created at. lbrycrd rescans the blocks since then
+// for their transactions.
+type ImportTimestamp int64
+
+// ImportNow is the timestamp for keys that have never been used, so no rescan is needed
+const ImportNow = ImportTimestamp(-1)
+
+func (t ImportTimestamp) MarshalJSON() ([]byte, error) {
+	if t == ImportNow {
+		return []byte(`"now"`), nil
+	}
+	return json.Marshal(int64(t))
+}
+
+// ImportMultiRequest is something for lbrycrd's wallet to watch, given by either Descriptor or Address.
+// Range is the first and last index of a ranged descriptor to import.
+type ImportMultiRequest struct {
+	Descriptor   string          `json:"desc,omitempty"`
+	Address      string          `json:"-"`
+	Timestamp    ImportTimestamp `json:"timestamp"`
+	RedeemScript string          `json:"redeemscript,omitempty"`
+	PubKeys      []string        `json:"pubkeys,omitempty"`
+	Keys         []string        `json:"keys,omitempty"`
+	Range        []uint32        `json:"range,omitempty"`
+	Internal     bool            `json:"internal,omitempty"`
+	WatchOnly    bool            `json:"watchonly,omitempty"`
+	Label        string          `json:"label,omitempty"`
+	KeyPool      bool            `json:"keypool,omitempty"`
+}
+
+func (r ImportMultiRequest) MarshalJSON() ([]byte, error) {
+	type request ImportMultiRequest
+	if r.Address == "" {
+		return json.Marshal(request(r))
+	}
+	return json.Marshal(struct {
+		request
+		ScriptPubKey map[string]string `json:"scriptPubKey"`
+	}{request(r), map[string]string{"address": r.Address}})
+}
+
+// ImportDescriptorsRequest is a descriptor for a descriptor wallet to import. Active makes the wallet
+// generate new addresses from it. Range is the first and last index of a ranged descriptor to import.
+type ImportDescriptorsRequest struct {
+	Descriptor string          `json:"desc"`
+	Timestamp  ImportTimestamp `json:"timestamp"`
+	Active     bool            `json:"active,omitempty"`
+	Range      []uint32        `json:"range,omitempty"`
+	NextIndex  *uint32         `json:"next_index,omitempty"`
+	Internal   bool            `json:"internal,omitempty"`
+	Label      string          `json:"label,omitempty"`
+}
+
+// ImportResult says whether one of the requests of an import succeeded
+type ImportResult struct {
+	Success  bool              `json:"success"`
+	Warnings []string          `json:"warnings,omitempty"`
+	Error    *btcjson.RPCError `json:"error,omitempty"`
+}
+
+// ImportMulti adds addresses, scripts or keys to lbrycrd's wallet. The requests succeed or fail separately,
+// so check each result. rescan can be nil for lbrycrd's default, which is to rescan.
+func (c *Client) ImportMulti(requests []ImportMultiRequest, rescan *bool) ([]ImportResult, error) {
+	var options interface{}
+	if rescan != nil {
+		options = map[string]bool{"rescan": *rescan}
+	}
+	var result []ImportResult
+	err := c.rawCall(&result, "importmulti", requests, options)
+	return result, err
+}
+
+// ImportDescriptors adds descriptors to a descriptor wallet. The requests succeed or fail separately, so
+// check each result.
+func (c *Client) ImportDescriptors(requests []ImportDescriptorsRequest) ([]ImportResult, error) {
+	withChecksums := make([]ImportDescriptorsRequest, len(requests))
+	for i, r := range requests {
+		if !strings.Contains(r.Descriptor, "#") {
+			// lbrycrd only imports descriptors with a checksum
+			checksum, err := descriptorChecksum(r.Descriptor)
+			if err != nil {
+				return nil, err
+			}
+			r.Descriptor += "#" + checksum
+		}
+		withChecksums[i] = r
+	}
+	var result []ImportResult
+	err := c.rawCall(&result, "importdescriptors", withChecksums)
+	return result, err
+}
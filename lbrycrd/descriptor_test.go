@@ -0,0 +1,234 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestDescriptorChecksum(t *testing.T) {
+	tests := []struct {
+		desc     string
+		checksum string
+	}{
+		{"raw(deadbeef)", "89f8spxm"},
+		{"pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)", "ml40v0wf"},
+	}
+	for _, test := range tests {
+		checksum, err := descriptorChecksum(test.desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if checksum != test.checksum {
+			t.Errorf("%s: expected %s, got %s", test.desc, test.checksum, checksum)
+		}
+	}
+
+	if _, err := descriptorChecksum("pkh(é)"); err == nil {
+		t.Error("expected an error for a character descriptors can't contain")
+	}
+}
+
+func TestParseDescriptor(t *testing.T) {
+	master, err := NewMasterKey(mustDecodeHex(t, "000102030405060708090a0b0c0d0e0f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := master.Derive(AccountPath(0, &mainNetParams)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xpub := account.Neuter().Encode(&mainNetParams)
+	origin := fmt.Sprintf("[%08x/44'/140h/0']", master.Fingerprint())
+
+	pubKeyHash := func(path ...uint32) []byte {
+		key, err := account.Derive(path...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return btcutil.Hash160(key.PrivateKey.PubKey().SerializeCompressed())
+	}
+	pkh := func(hash []byte) btcutil.Address {
+		address, err := btcutil.NewAddressPubKeyHash(hash, &mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return address
+	}
+	wpkh := func(hash []byte) btcutil.Address {
+		address, err := NewWitnessAddress(hash, &mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return address
+	}
+	shwpkh := func(hash []byte) btcutil.Address {
+		redeemScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(hash).Script()
+		if err != nil {
+			t.Fatal(err)
+		}
+		address, err := ScriptHashAddress(redeemScript, &mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return address
+	}
+	single := hex.EncodeToString(account.PrivateKey.PubKey().SerializeCompressed())
+	singleHash := btcutil.Hash160(account.PrivateKey.PubKey().SerializeCompressed())
+
+	tests := []struct {
+		desc     string
+		typ      DescriptorType
+		ranged   bool
+		expected func(index uint32) btcutil.Address
+	}{
+		{"pkh(" + origin + xpub + "/0/*)", DescriptorPKH, true, func(i uint32) btcutil.Address { return pkh(pubKeyHash(0, i)) }},
+		{"wpkh(" + xpub + "/1/*)", DescriptorWPKH, true, func(i uint32) btcutil.Address { return wpkh(pubKeyHash(1, i)) }},
+		{"sh(wpkh(" + xpub + "/0/5))", DescriptorSHWPKH, false, func(uint32) btcutil.Address { return shwpkh(pubKeyHash(0, 5)) }},
+		{"pkh(" + single + ")", DescriptorPKH, false, func(uint32) btcutil.Address { return pkh(singleHash) }},
+		{"wpkh(" + single + ")", DescriptorWPKH, false, func(uint32) btcutil.Address { return wpkh(singleHash) }},
+		{"pkh(" + master.Encode(&mainNetParams) + "/44'/140'/0'/0/*)", DescriptorPKH, true, func(i uint32) btcutil.Address { return pkh(pubKeyHash(0, i)) }},
+		{"addr(bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha)", DescriptorAddress, false, func(uint32) btcutil.Address {
+			address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return address
+		}},
+	}
+	for _, test := range tests {
+		d, err := ParseDescriptor(test.desc, &mainNetParams)
+		if err != nil {
+			t.Errorf("%s: %v", test.desc, err)
+			continue
+		}
+		if d.Type != test.typ || d.IsRange() != test.ranged {
+			t.Errorf("%s: expected a %s descriptor, ranged %t, got %s, %t", test.desc, test.typ, test.ranged, d.Type, d.IsRange())
+		}
+		for _, i := range []uint32{0, 3} {
+			address, err := d.Address(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := test.expected(i); address.EncodeAddress() != expected.EncodeAddress() {
+				t.Errorf("%s at %d: expected %s, got %s", test.desc, i, expected.EncodeAddress(), address.EncodeAddress())
+			}
+		}
+
+		// the checksum is added, and is checked when parsing
+		again, err := ParseDescriptor(d.String(), &mainNetParams)
+		if err != nil {
+			t.Errorf("%s: %v", d.String(), err)
+		} else if again.String() != d.String() {
+			t.Errorf("%s did not round trip", d.String())
+		}
+	}
+
+	d, err := ParseDescriptor("pkh("+xpub+"/0/*)", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addresses, err := d.Addresses(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != 3 || addresses[0].EncodeAddress() != pkh(pubKeyHash(0, 2)).EncodeAddress() {
+		t.Errorf("unexpected addresses %v", addresses)
+	}
+	if _, err := d.Addresses(4, 2); err == nil {
+		t.Error("expected an error for a backwards range")
+	}
+}
+
+func TestParseDescriptor_Errors(t *testing.T) {
+	xpub := "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+	uncompressed := "04a34b99f22c790c4e36b2b3c2c35a36db06226e41c692fc82b8b56ac1c540c5bd5b8dec5235a0fa8722476c7709c02559e3aa73aa03918ba2d492eea75abea235"
+
+	for _, desc := range []string{
+		"pkh(" + xpub + "/0/*)#aaaaaaaa",
+		"tr(" + xpub + "/0/*)",
+		"pkh(" + xpub + "/0/*",
+		"wpkh(" + uncompressed + ")",
+		"pkh(" + xpub + "/0/*')",
+		"pkh(" + xpub + "/x/*)",
+		"pkh([d34db3/0']" + xpub + ")",
+		"addr(bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha)",
+	} {
+		params := &mainNetParams
+		if desc[:4] == "addr" {
+			params = &testNetParams
+		}
+		if _, err := ParseDescriptor(desc, params); err == nil {
+			t.Errorf("%s: expected an error", desc)
+		}
+	}
+
+	// hardened keys can't be derived from an xpub
+	d, err := ParseDescriptor("pkh("+xpub+"/0'/*)", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Address(0); err == nil {
+		t.Error("expected an error deriving a hardened key from an xpub")
+	}
+
+	d, err = ParseDescriptor("pkh("+uncompressed+")", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Addresses(0, 1); err == nil {
+		t.Error("expected an error for addresses of a descriptor that is not ranged")
+	}
+}
+
+func TestClient_Import(t *testing.T) {
+	var params []json.RawMessage
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		params = req.Params
+		return []ImportResult{
+			{Success: true, Warnings: []string{"Some private keys are missing"}},
+			{Success: false},
+		}, ""
+	})
+	defer server.Close()
+
+	rescan := false
+	results, err := c.ImportMulti([]ImportMultiRequest{
+		{Address: "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", Timestamp: ImportNow, WatchOnly: true, Label: "cold"},
+		{Descriptor: "pkh(xpub/0/*)#checksum", Timestamp: 1546300800, Range: []uint32{0, 99}},
+	}, &rescan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || !results[0].Success || len(results[0].Warnings) != 1 || results[1].Success {
+		t.Errorf("unexpected results %+v", results)
+	}
+	expected := `[{"timestamp":"now","watchonly":true,"label":"cold","scriptPubKey":{"address":"bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"}},` +
+		`{"desc":"pkh(xpub/0/*)#checksum","timestamp":1546300800,"range":[0,99]}]`
+	if len(params) != 2 || string(params[0]) != expected || string(params[1]) != `{"rescan":false}` {
+		t.Errorf("unexpected importmulti params %s", params)
+	}
+
+	if _, err := c.ImportMulti([]ImportMultiRequest{{Address: "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 1 {
+		t.Errorf("expected the options to be left off, got %s", params)
+	}
+
+	requests := []ImportDescriptorsRequest{{Descriptor: "raw(deadbeef)", Timestamp: ImportNow, Active: true}}
+	if _, err := c.ImportDescriptors(requests); err != nil {
+		t.Fatal(err)
+	}
+	expected = `[{"desc":"raw(deadbeef)#89f8spxm","timestamp":"now","active":true}]`
+	if len(params) != 1 || string(params[0]) != expected {
+		t.Errorf("unexpected importdescriptors params %s", params)
+	}
+	if requests[0].Descriptor != "raw(deadbeef)" {
+		t.Error("the requests should not be changed")
+	}
+}
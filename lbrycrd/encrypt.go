@@ -0,0 +1,232 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"strconv"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/stream"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrDecrypt means an encrypted value could not be decrypted, usually because the passphrase is wrong
+var ErrDecrypt = errors.Base("could not decrypt value, the passphrase may be wrong")
+
+// scrypt parameters lbry-sdk uses when it encrypts wallets for sync
+const (
+	scryptN = 1 << 13
+	scryptR = 16
+	scryptP = 1
+
+	maxScryptN = 1 << 20 // limits the work a value from elsewhere can ask for
+)
+
+// EncryptValue encrypts a value the way lbry-sdk encrypts the seed and private keys of the accounts in an
+// encrypted wallet: AES-256-CBC with a key that is the double sha256 of the passphrase. The result is the
+// base64 encoded IV and ciphertext.
+func EncryptValue(passphrase string, value []byte) (string, error) {
+	iv, err := randomIV()
+	if err != nil {
+		return "", err
+	}
+	return encryptValue(passphrase, value, iv)
+}
+
+func encryptValue(passphrase string, value, iv []byte) (string, error) {
+	encrypted, err := encryptCBC(doubleSha256([]byte(passphrase)), iv, value)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// DecryptValue decrypts a value encrypted with EncryptValue or by lbry-sdk
+func DecryptValue(passphrase, encrypted string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, errors.Prefix("encrypted value is not base64", err)
+	}
+	return decryptCBC(doubleSha256([]byte(passphrase)), data)
+}
+
+// EncryptValueScrypt encrypts a value the way lbry-sdk encrypts whole wallets for sync: AES-256-CBC with a key
+// derived from the passphrase by scrypt, salted with the IV. The result is the base64 encoded scrypt
+// parameters, IV and ciphertext.
+func EncryptValueScrypt(passphrase string, value []byte) (string, error) {
+	iv, err := randomIV()
+	if err != nil {
+		return "", err
+	}
+	return encryptValueScrypt(passphrase, value, iv)
+}
+
+func encryptValueScrypt(passphrase string, value, iv []byte) (string, error) {
+	key, err := scrypt.Key([]byte(passphrase), iv, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return "", errors.Err(err)
+	}
+	encrypted, err := encryptCBC(key, iv, value)
+	if err != nil {
+		return "", err
+	}
+	header := "s:" + strconv.Itoa(scryptN) + ":" + strconv.Itoa(scryptR) + ":" + strconv.Itoa(scryptP) + ":"
+	return base64.StdEncoding.EncodeToString(append([]byte(header), encrypted...)), nil
+}
+
+// DecryptValueScrypt decrypts a value encrypted with EncryptValueScrypt or by lbry-sdk
+func DecryptValueScrypt(passphrase, encrypted string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, errors.Prefix("encrypted value is not base64", err)
+	}
+	parts := bytes.SplitN(data, []byte(":"), 5)
+	if len(parts) != 5 || string(parts[0]) != "s" {
+		return nil, errors.Err("encrypted value has no scrypt parameters")
+	}
+	var params [3]int
+	for i := range params {
+		params[i], err = strconv.Atoi(string(parts[i+1]))
+		if err != nil || params[i] <= 0 {
+			return nil, errors.Err("invalid scrypt parameter %q", parts[i+1])
+		}
+	}
+	if params[0] > maxScryptN || params[1]*params[2] > 1<<10 {
+		return nil, errors.Err("scrypt parameters %d:%d:%d are too large", params[0], params[1], params[2])
+	}
+
+	data = parts[4]
+	if len(data) < aes.BlockSize {
+		return nil, errors.Err(ErrDecrypt)
+	}
+	key, err := scrypt.Key([]byte(passphrase), data[:aes.BlockSize], params[0], params[1], params[2], 32)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return decryptCBC(key, data)
+}
+
+// PrivateKeyToPEM encodes a private key as a PEM "EC PRIVATE KEY" block, which is how lbry-sdk stores channel
+// keys
+func PrivateKeyToPEM(key *btcec.PrivateKey) ([]byte, error) {
+	d := make([]byte, btcec.PrivKeyBytesLen)
+	b := key.D.Bytes()
+	copy(d[len(d)-len(b):], b)
+	publicKey := key.PubKey().SerializeUncompressed()
+
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    d,
+		NamedCurveOID: oidCurveSecp256k1,
+		PublicKey:     asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	})
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+// PrivateKeyFromPEM decodes a private key from a PEM "EC PRIVATE KEY" block
+func PrivateKeyFromPEM(b []byte) (*btcec.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != "EC PRIVATE KEY" {
+		return nil, errors.Err("no EC PRIVATE KEY block found")
+	}
+	var k ecPrivateKey
+	_, err := asn1.Unmarshal(block.Bytes, &k)
+	if err != nil {
+		return nil, errors.Prefix("invalid private key", err)
+	}
+	if k.Version != 1 || len(k.PrivateKey) != btcec.PrivKeyBytesLen {
+		return nil, errors.Err("invalid private key")
+	}
+	if len(k.NamedCurveOID) > 0 && !k.NamedCurveOID.Equal(oidCurveSecp256k1) {
+		return nil, errors.Err("private key is not on the secp256k1 curve")
+	}
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), k.PrivateKey)
+	return key, nil
+}
+
+// EncryptPrivateKey encrypts a private key in PEM form with EncryptValue
+func EncryptPrivateKey(passphrase string, key *btcec.PrivateKey) (string, error) {
+	pemKey, err := PrivateKeyToPEM(key)
+	if err != nil {
+		return "", err
+	}
+	return EncryptValue(passphrase, pemKey)
+}
+
+// DecryptPrivateKey decrypts a private key encrypted with EncryptPrivateKey
+func DecryptPrivateKey(passphrase, encrypted string) (*btcec.PrivateKey, error) {
+	pemKey, err := DecryptValue(passphrase, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	return PrivateKeyFromPEM(pemKey)
+}
+
+// ecPrivateKey is the SEC 1 private key structure
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// encryptCBC pads the value with PKCS #7 and encrypts it, returning the IV followed by the ciphertext
+func encryptCBC(key, iv, value []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	padded, err := stream.PKCS7Pad(value, aes.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := make([]byte, aes.BlockSize+len(padded))
+	copy(encrypted, iv)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted[aes.BlockSize:], padded)
+	return encrypted, nil
+}
+
+// decryptCBC decrypts an IV followed by a ciphertext and removes the PKCS #7 padding
+func decryptCBC(key, data []byte) ([]byte, error) {
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil, errors.Err(ErrDecrypt)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	value := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, data[:aes.BlockSize]).CryptBlocks(value, data[aes.BlockSize:])
+
+	value, err = stream.PKCS7Unpad(value, aes.BlockSize)
+	if err != nil {
+		return nil, errors.Err(ErrDecrypt)
+	}
+	return value, nil
+}
+
+func randomIV() ([]byte, error) {
+	iv := make([]byte, aes.BlockSize)
+	_, err := rand.Read(iv)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return iv, nil
+}
+
+func doubleSha256(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
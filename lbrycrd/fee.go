@@ -0,0 +1,121 @@
+package lbrycrd
+
+import (
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// ErrNoFeeEstimate is returned when lbrycrd hasn't seen enough transactions to estimate a fee
+var ErrNoFeeEstimate = errors.Base("lbrycrd has no fee estimate")
+
+// EstimateMode says how cautious a fee estimate is
+type EstimateMode string
+
+const (
+	EstimateModeEconomical   = EstimateMode("ECONOMICAL")
+	EstimateModeConservative = EstimateMode("CONSERVATIVE")
+)
+
+// SmartFeeEstimate is lbrycrd's estimate of the fee rate, in LBC per kB, that gets a transaction confirmed
+// within Blocks blocks. FeeRate is nil if lbrycrd has no estimate.
+type SmartFeeEstimate struct {
+	FeeRate *float64 `json:"feerate,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+	Blocks  int64    `json:"blocks"`
+}
+
+// EstimateSmartFee estimates the fee rate for confirmation within confTarget blocks. mode can be nil for
+// lbrycrd's default.
+func (c *Client) EstimateSmartFee(confTarget int64, mode *EstimateMode) (*SmartFeeEstimate, error) {
+	result := new(SmartFeeEstimate)
+	return result, c.rawCall(result, "estimatesmartfee", confTarget, mode)
+}
+
+// EstimateFeeRate returns the fee per kB for confirmation within confTarget blocks, or ErrNoFeeEstimate
+func (c *Client) EstimateFeeRate(confTarget int64) (btcutil.Amount, error) {
+	estimate, err := c.EstimateSmartFee(confTarget, nil)
+	if err != nil {
+		return 0, err
+	}
+	if estimate.FeeRate == nil || *estimate.FeeRate <= 0 {
+		return 0, errors.Err(ErrNoFeeEstimate)
+	}
+	rate, err := btcutil.NewAmount(*estimate.FeeRate)
+	if err != nil {
+		return 0, errors.Err(err)
+	}
+	return rate, nil
+}
+
+// RequiredFee returns the fee for the transaction to confirm within confTarget blocks once it is signed
+func (c *Client) RequiredFee(tx *wire.MsgTx, confTarget int64) (btcutil.Amount, error) {
+	rate, err := c.EstimateFeeRate(confTarget)
+	if err != nil {
+		return 0, err
+	}
+	return FeeForSize(EstimateTxSize(tx), rate), nil
+}
+
+// p2pkhSigScriptSize is the largest signature script that spends a pay-to-pubkey-hash output: a 72 byte
+// signature and a 33 byte compressed key, each with its push opcode
+const p2pkhSigScriptSize = 1 + 72 + 1 + 33
+
+// EstimateTxSize returns the size of the transaction once it is signed. Inputs without a signature script are
+// assumed to spend pay-to-pubkey-hash outputs, which is what lbrycrd's wallet makes.
+func EstimateTxSize(tx *wire.MsgTx) int {
+	size := tx.SerializeSize()
+	for _, in := range tx.TxIn {
+		if len(in.SignatureScript) == 0 {
+			size += wire.VarIntSerializeSize(p2pkhSigScriptSize) - 1 + p2pkhSigScriptSize
+		}
+	}
+	return size
+}
+
+// FeeForSize returns the fee for a transaction of size bytes at a rate per kB, rounded up
+func FeeForSize(size int, ratePerKB btcutil.Amount) btcutil.Amount {
+	return (ratePerKB*btcutil.Amount(size) + 999) / 1000
+}
+
+// OutputSize returns the size of an output with a script of scriptSize bytes
+func OutputSize(scriptSize int) int {
+	return 8 + wire.VarIntSerializeSize(uint64(scriptSize)) + scriptSize
+}
+
+// p2pkhScriptSize is the size of a pay-to-pubkey-hash script, which follows the claim in claim scripts
+const p2pkhScriptSize = 25
+
+// ClaimScriptSize returns the size of a claim, update or support script paying to a pubkey hash, so outputs
+// can be planned before the claim is built. valueSize is the size of the claim value, or of the support
+// payload, which is 0 for a support without one.
+func ClaimScriptSize(scriptType ScriptType, name string, valueSize int) int {
+	size := 1 + pushSize(len(name)) + 2 + p2pkhScriptSize // opcode, name, drops and the pubkey hash script
+	switch scriptType {
+	case ClaimName:
+		size += pushSize(valueSize)
+	case ClaimUpdate:
+		size += pushSize(ClaimIDLength/2) + pushSize(valueSize)
+	case ClaimSupport:
+		size += pushSize(ClaimIDLength / 2)
+		if valueSize > 0 {
+			size += pushSize(valueSize)
+		}
+	}
+	return size
+}
+
+// pushSize returns the size of pushing n bytes of data in a script
+func pushSize(n int) int {
+	switch {
+	case n < 0x4c: // OP_PUSHDATA1
+		return 1 + n
+	case n <= 0xff:
+		return 2 + n
+	case n <= 0xffff:
+		return 3 + n
+	default:
+		return 5 + n
+	}
+}
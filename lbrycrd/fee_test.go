@@ -0,0 +1,144 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestClaimScriptSize(t *testing.T) {
+	address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, valueSize := range []int{40, 200, 500} {
+		value := bytes.Repeat([]byte{0xaa}, valueSize)
+
+		script, err := ClaimNameScript("name", value, address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := ClaimScriptSize(ClaimName, "name", valueSize); size != len(script) {
+			t.Errorf("claim with a %d byte value: expected %d, got %d", valueSize, len(script), size)
+		}
+
+		script, err = UpdateClaimScript("name", testChannelClaimID, value, address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := ClaimScriptSize(ClaimUpdate, "name", valueSize); size != len(script) {
+			t.Errorf("update with a %d byte value: expected %d, got %d", valueSize, len(script), size)
+		}
+
+		script, err = SupportClaimScript("name", testChannelClaimID, value, address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size := ClaimScriptSize(ClaimSupport, "name", valueSize); size != len(script) {
+			t.Errorf("support with a %d byte payload: expected %d, got %d", valueSize, len(script), size)
+		}
+	}
+
+	script, err := SupportClaimScript("name", testChannelClaimID, nil, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := ClaimScriptSize(ClaimSupport, "name", 0); size != len(script) {
+		t.Errorf("support: expected %d, got %d", len(script), size)
+	}
+}
+
+func TestEstimateTxSize(t *testing.T) {
+	address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{2}, 1), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(100000000, pkScript))
+	unsigned := tx.SerializeSize()
+
+	// sign the inputs with the largest signature scripts
+	signed := tx.Copy()
+	for _, in := range signed.TxIn {
+		in.SignatureScript = bytes.Repeat([]byte{0}, p2pkhSigScriptSize)
+	}
+	if size := EstimateTxSize(tx); size != signed.SerializeSize() || size != unsigned+2*p2pkhSigScriptSize {
+		t.Errorf("expected %d, got %d", signed.SerializeSize(), size)
+	}
+	if size := EstimateTxSize(signed); size != signed.SerializeSize() {
+		t.Errorf("expected a signed transaction's own size %d, got %d", signed.SerializeSize(), size)
+	}
+
+	if size := OutputSize(len(pkScript)); size != tx.TxOut[0].SerializeSize() {
+		t.Errorf("expected output size %d, got %d", tx.TxOut[0].SerializeSize(), size)
+	}
+	if fee := FeeForSize(226, 1000); fee != 226 {
+		t.Errorf("expected a fee of 226, got %d", fee)
+	}
+	if fee := FeeForSize(250, 10); fee != 3 {
+		t.Errorf("expected the fee to be rounded up to 3, got %d", fee)
+	}
+}
+
+func TestClient_EstimateFeeRate(t *testing.T) {
+	estimate := `{"feerate": 0.00012, "blocks": 2}`
+	var mode []json.RawMessage
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		if req.Method != "estimatesmartfee" {
+			return nil, "Method not found"
+		}
+		mode = req.Params
+		return json.RawMessage(estimate), ""
+	})
+	defer server.Close()
+
+	conservative := EstimateModeConservative
+	e, err := c.EstimateSmartFee(2, &conservative)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.FeeRate == nil || *e.FeeRate != 0.00012 || e.Blocks != 2 {
+		t.Errorf("unexpected estimate %+v", e)
+	}
+	if len(mode) != 2 || string(mode[1]) != `"CONSERVATIVE"` {
+		t.Errorf("unexpected params %s", mode)
+	}
+
+	rate, err := c.EstimateFeeRate(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != btcutil.Amount(12000) {
+		t.Errorf("expected 12000 per kB, got %d", rate)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(100000000, make([]byte, p2pkhScriptSize)))
+	fee, err := c.RequiredFee(tx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := FeeForSize(EstimateTxSize(tx), rate); fee != expected {
+		t.Errorf("expected a fee of %d, got %d", expected, fee)
+	}
+
+	estimate = `{"errors": ["Insufficient data or no feerate found"], "blocks": 0}`
+	if _, err := c.EstimateFeeRate(2); !errors.Is(err, ErrNoFeeEstimate) {
+		t.Errorf("expected ErrNoFeeEstimate, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package lbrycrd
 
 import (
+	"encoding/hex"
+
 	"github.com/lbryio/lbry.go/v2/extras/errors"
 
 	"github.com/btcsuite/btcd/btcjson"
@@ -20,7 +22,7 @@ func (f *outputFinder) nextBatch(minAmount float64) ([]btcjson.ListUnspentResult
 	var lbcBatched float64
 	for i, unspent := range f.unspent {
 		if i > f.lastChecked {
-			if unspent.Spendable {
+			if unspent.Spendable && !isClaimUnspent(unspent) {
 				batch = append(batch, unspent)
 				lbcBatched = lbcBatched + unspent.Amount
 			}
@@ -36,3 +38,9 @@ func (f *outputFinder) nextBatch(minAmount float64) ([]btcjson.ListUnspentResult
 
 	return batch, nil
 }
+
+// isClaimUnspent says whether an output holds a claim, update or support, which spending would abandon
+func isClaimUnspent(unspent btcjson.ListUnspentResult) bool {
+	script, err := hex.DecodeString(unspent.ScriptPubKey)
+	return err == nil && IsClaimScript(script)
+}
@@ -0,0 +1,222 @@
+package lbrycrd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/extras/stop"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultPollInterval is how often a ChainFollower checks for new blocks if FollowOptions doesn't say
+const DefaultPollInterval = 10 * time.Second
+
+// followBatchSize is how many headers a ChainFollower asks its HeaderSync for at a time
+const followBatchSize = 500
+
+// ChainEventType says how a block changed the best chain
+type ChainEventType int
+
+const (
+	// RollForward adds a block on top of the chain
+	RollForward ChainEventType = iota
+	// Rollback removes the block at the top of the chain, because a reorg replaced it
+	Rollback
+)
+
+func (t ChainEventType) String() string {
+	switch t {
+	case RollForward:
+		return "rollforward"
+	case Rollback:
+		return "rollback"
+	default:
+		return "unknown"
+	}
+}
+
+// ChainEvent is a block added to or removed from the best chain. Rollbacks come newest first, and are followed
+// by the rollforwards of the blocks that replaced them.
+type ChainEvent struct {
+	Type  ChainEventType
+	Block SyncedHeader
+}
+
+// FollowOptions configures a ChainFollower
+type FollowOptions struct {
+	// Height is where to start following the chain, if Recent is empty
+	Height int32
+	// Recent are the last blocks an indexer processed, oldest first. Following resumes after them, and rolls
+	// them back if a reorg replaced them while the indexer wasn't following.
+	Recent []SyncedHeader
+	// PollInterval is how often to check for new blocks. It defaults to DefaultPollInterval.
+	PollInterval time.Duration
+	// ZMQ is an endpoint lbrycrd publishes hashblock notifications on, e.g. tcp://127.0.0.1:28332. New blocks
+	// are checked for as soon as lbrycrd announces them, and polling catches the ones that are missed.
+	ZMQ string
+}
+
+// ChainFollower follows lbrycrd's best chain and delivers an event for each block added to it or removed from
+// it by a reorg, so an indexer can apply and undo blocks in order
+type ChainFollower struct {
+	sync   *HeaderSync
+	opts   FollowOptions
+	zmq    *ZMQSubscriber // nil without a zmq endpoint
+	events chan ChainEvent
+	grp    *stop.Group
+
+	mu     sync.Mutex
+	height int32
+	err    error
+}
+
+// FollowChain starts following the chain. Following ends when the context is done, Close is called, or there
+// is a reorg too deep to follow, which Err returns as ErrReorgTooDeep. Other errors are retried at the next
+// poll. Close the follower when done with it.
+func (c *Client) FollowChain(ctx context.Context, opts FollowOptions) (*ChainFollower, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	f := &ChainFollower{
+		opts:   opts,
+		events: make(chan ChainEvent),
+		grp:    stop.New(),
+	}
+	if len(opts.Recent) > 0 {
+		f.sync = c.ResumeHeaderSync(opts.Recent)
+	} else {
+		f.sync = c.NewHeaderSync(opts.Height)
+	}
+	f.height = f.sync.Height()
+	if opts.ZMQ != "" {
+		zmq, err := SubscribeZMQ(ctx, opts.ZMQ, ZMQHashBlock)
+		if err != nil {
+			return nil, err
+		}
+		f.zmq = zmq
+	}
+
+	f.grp.Add(1)
+	go func() {
+		defer f.grp.Done()
+		f.run()
+	}()
+
+	f.grp.Add(1)
+	go func() {
+		defer f.grp.Done()
+		select {
+		case <-ctx.Done():
+			f.setErr(errors.Err(ctx.Err()))
+			f.grp.Stop()
+		case <-f.grp.Ch():
+		}
+	}()
+
+	return f, nil
+}
+
+// Events returns the channel that events are delivered on. It is closed when following ends.
+func (f *ChainFollower) Events() <-chan ChainEvent {
+	return f.events
+}
+
+// Err returns the reason following ended, or nil if it is still running or was closed
+func (f *ChainFollower) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// Height returns the height of the next block the follower looks for
+func (f *ChainFollower) Height() int32 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.height
+}
+
+// Close stops following and waits for it to shut down
+func (f *ChainFollower) Close() {
+	f.grp.StopAndWait()
+	if f.zmq != nil {
+		f.zmq.Close()
+	}
+}
+
+func (f *ChainFollower) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+// run delivers the blocks the HeaderSync finds until following ends
+func (f *ChainFollower) run() {
+	defer close(f.events)
+
+	var blocks <-chan ZMQEvent
+	if f.zmq != nil {
+		blocks = f.zmq.Events()
+	}
+
+	for {
+		connected, disconnected, err := f.sync.Next(followBatchSize)
+		f.mu.Lock()
+		f.height = f.sync.Height()
+		f.mu.Unlock()
+
+		for _, h := range disconnected {
+			if !f.send(ChainEvent{Type: Rollback, Block: h}) {
+				return
+			}
+		}
+		for _, h := range connected {
+			if !f.send(ChainEvent{Type: RollForward, Block: h}) {
+				return
+			}
+		}
+		if err != nil {
+			if errors.Is(err, ErrReorgTooDeep) {
+				f.setErr(err)
+				f.grp.Stop()
+				return
+			}
+			if isUnavailable(err) {
+				// lbrycrd may be restarting
+				log.Debugf("lbrycrd follower: checking for blocks failed: %v", err)
+			} else {
+				log.Warnf("lbrycrd follower: checking for blocks failed: %v", err)
+			}
+		}
+
+		wait := f.opts.PollInterval
+		if len(connected) == followBatchSize || (err == nil && len(disconnected) > 0 && len(connected) == 0) {
+			wait = 0 // catching up, or going back to where a reorg started
+		}
+		select {
+		case <-f.grp.Ch():
+			return
+		case <-time.After(wait):
+		case _, ok := <-blocks:
+			if !ok {
+				// the subscription ended. polling still finds new blocks
+				log.Debugf("lbrycrd follower: zmq subscription ended: %v", f.zmq.Err())
+				blocks = nil
+			}
+		}
+	}
+}
+
+// send delivers an event, or returns false if following ends first
+func (f *ChainFollower) send(e ChainEvent) bool {
+	select {
+	case f.events <- e:
+		return true
+	case <-f.grp.Ch():
+		return false
+	}
+}
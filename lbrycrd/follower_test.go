@@ -0,0 +1,165 @@
+package lbrycrd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+func nextChainEvent(t *testing.T, f *ChainFollower) ChainEvent {
+	t.Helper()
+	select {
+	case e, ok := <-f.Events():
+		if !ok {
+			t.Fatalf("following ended: %v", f.Err())
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return ChainEvent{}
+}
+
+// expectChainEvents checks that the next events are of type typ, at the heights
+func expectChainEvents(t *testing.T, f *ChainFollower, typ ChainEventType, heights ...int32) []ChainEvent {
+	t.Helper()
+	var events []ChainEvent
+	for _, height := range heights {
+		e := nextChainEvent(t, f)
+		if e.Type != typ || e.Block.Height != height {
+			t.Fatalf("expected %s at height %d, got %s at height %d", typ, height, e.Type, e.Block.Height)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestChainFollower(t *testing.T) {
+	chain := &fakeChain{headers: []BlockHeader{{Version: 1}}}
+	chain.extend(0, 3, 0)
+	c, server := newFakeLbrycrd(t, chain.handle)
+	defer server.Close()
+
+	f, err := c.FollowChain(context.Background(), FollowOptions{Height: 1, PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	first := expectChainEvents(t, f, RollForward, 1, 2, 3)
+	if first[1].Block.Header.PrevBlock != first[0].Block.Hash {
+		t.Error("blocks should be chained")
+	}
+
+	chain.extend(3, 1, 0)
+	expectChainEvents(t, f, RollForward, 4)
+
+	// replace the blocks above 2 with a longer chain
+	chain.extend(2, 3, 1)
+	rolledBack := expectChainEvents(t, f, Rollback, 4, 3)
+	if rolledBack[1].Block.Hash != first[2].Block.Hash {
+		t.Error("expected the replaced block to be rolled back")
+	}
+	replaced := expectChainEvents(t, f, RollForward, 3, 4, 5)
+	if replaced[0].Block.Header.Nonce != 1 {
+		t.Error("expected the new block to be rolled forward")
+	}
+	if f.Height() != 6 {
+		t.Errorf("expected to look for height 6 next, got %d", f.Height())
+	}
+
+	f.Close()
+	if _, open := <-f.Events(); open {
+		t.Error("events channel should be closed")
+	}
+	if f.Err() != nil {
+		t.Errorf("closing should not set an error, got %v", f.Err())
+	}
+}
+
+func TestChainFollower_Resume(t *testing.T) {
+	chain := &fakeChain{headers: []BlockHeader{{Version: 1}}}
+	chain.extend(0, 5, 0)
+	c, server := newFakeLbrycrd(t, chain.handle)
+	defer server.Close()
+
+	s := c.NewHeaderSync(0)
+	recent, _, err := s.Next(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a reorg while nothing was following
+	chain.extend(3, 3, 1)
+	f, err := c.FollowChain(context.Background(), FollowOptions{Recent: recent, PollInterval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	expectChainEvents(t, f, Rollback, 5, 4)
+	expectChainEvents(t, f, RollForward, 4, 5, 6)
+}
+
+func TestChainFollower_ZMQ(t *testing.T) {
+	chain := &fakeChain{headers: []BlockHeader{{Version: 1}}}
+	c, server := newFakeLbrycrd(t, chain.handle)
+	defer server.Close()
+	p := newFakePublisher(t)
+	defer p.close()
+
+	// with polling this slow, blocks are only found because of the notifications
+	f, err := c.FollowChain(context.Background(), FollowOptions{PollInterval: time.Hour, ZMQ: p.address()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if topics := <-p.subscriptions; len(topics) != 1 || topics[0] != string(ZMQHashBlock) {
+		t.Errorf("unexpected subscriptions %v", topics)
+	}
+	expectChainEvents(t, f, RollForward, 0)
+
+	chain.extend(0, 2, 0)
+	hash := chain.headers[2].BlockHash()
+	p.messages <- zmqMessage(ZMQHashBlock, rev(hash[:]), 0)
+	expectChainEvents(t, f, RollForward, 1, 2)
+}
+
+func TestChainFollower_Errors(t *testing.T) {
+	chain := &fakeChain{headers: []BlockHeader{{Version: 1}}}
+	chain.extend(0, MaxReorgDepth+10, 0)
+	c, server := newFakeLbrycrd(t, chain.handle)
+	defer server.Close()
+
+	f, err := c.FollowChain(context.Background(), FollowOptions{PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for i := 0; i <= MaxReorgDepth+10; i++ {
+		nextChainEvent(t, f)
+	}
+	chain.extend(5, MaxReorgDepth+10, 1)
+	for range f.Events() {
+	}
+	if !errors.Is(f.Err(), ErrReorgTooDeep) {
+		t.Errorf("expected ErrReorgTooDeep, got %v", f.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f, err = c.FollowChain(ctx, FollowOptions{Height: 1000, PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cancel()
+	for range f.Events() {
+	}
+	if !errors.Is(f.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", f.Err())
+	}
+
+	if _, err := c.FollowChain(context.Background(), FollowOptions{ZMQ: "udp://127.0.0.1:1"}); err == nil {
+		t.Error("expected an error for an unsupported zmq address")
+	}
+}
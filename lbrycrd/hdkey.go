@@ -0,0 +1,237 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// CoinType is LBRY's BIP44 coin type, registered in SLIP-0044
+const CoinType uint32 = 140
+
+// extendedKeySize is the size of a serialized extended key, without the checksum
+const extendedKeySize = 4 + 1 + 4 + 4 + 32 + 33
+
+// AccountPath returns the BIP44 path of an account's key, m/44'/coin'/account'. The network's HDCoinType is
+// used as the coin type. Addresses are at chain/index under the account key.
+func AccountPath(account uint32, params *chaincfg.Params) []uint32 {
+	return []uint32{HardenedKeyStart + 44, HardenedKeyStart + params.HDCoinType, HardenedKeyStart + account}
+}
+
+// ExtendedPublicKey is a BIP32 public key together with its chain code. It derives the public keys of its
+// non-hardened children, so a watch-only wallet can generate addresses without any private keys.
+type ExtendedPublicKey struct {
+	PublicKey         *btcec.PublicKey
+	ChainCode         []byte
+	Depth             uint8
+	ParentFingerprint uint32
+	ChildIndex        uint32
+}
+
+// Fingerprint identifies the key. It is the start of the hash of its public key.
+func (k *ExtendedKey) Fingerprint() uint32 {
+	return fingerprint(k.PrivateKey.PubKey())
+}
+
+// Neuter returns the public half of the key
+func (k *ExtendedKey) Neuter() *ExtendedPublicKey {
+	return &ExtendedPublicKey{
+		PublicKey:         k.PrivateKey.PubKey(),
+		ChainCode:         k.ChainCode,
+		Depth:             k.Depth,
+		ParentFingerprint: k.ParentFingerprint,
+		ChildIndex:        k.ChildIndex,
+	}
+}
+
+// Encode serializes the key for the network, e.g. as an xprv
+func (k *ExtendedKey) Encode(params *chaincfg.Params) string {
+	keyData := make([]byte, 33)
+	privateKey := k.PrivateKey.Serialize()
+	copy(keyData[33-len(privateKey):], privateKey)
+	return encodeExtendedKey(params.HDPrivateKeyID, k.Depth, k.ParentFingerprint, k.ChildIndex, k.ChainCode, keyData)
+}
+
+// Fingerprint identifies the key. It is the start of the hash of its public key.
+func (k *ExtendedPublicKey) Fingerprint() uint32 {
+	return fingerprint(k.PublicKey)
+}
+
+// Encode serializes the key for the network, e.g. as an xpub
+func (k *ExtendedPublicKey) Encode(params *chaincfg.Params) string {
+	return encodeExtendedKey(params.HDPublicKeyID, k.Depth, k.ParentFingerprint, k.ChildIndex, k.ChainCode, k.PublicKey.SerializeCompressed())
+}
+
+// Address returns the pay-to-pubkey-hash address of the key
+func (k *ExtendedPublicKey) Address(params *chaincfg.Params) (*btcutil.AddressPubKeyHash, error) {
+	return PubKeyHashAddress(k.PublicKey, params)
+}
+
+// Child derives the public key of the child at index i. Hardened children can only be derived from the
+// private key.
+func (k *ExtendedPublicKey) Child(i uint32) (*ExtendedPublicKey, error) {
+	if i >= HardenedKeyStart {
+		return nil, errors.Err("cannot derive hardened key at index %d from a public key", i)
+	}
+	if k.Depth == 255 {
+		return nil, errors.Err("cannot derive a key deeper than 255 levels")
+	}
+
+	data := make([]byte, 0, 37)
+	data = append(data, k.PublicKey.SerializeCompressed()...)
+	index := make([]byte, 4)
+	binary.BigEndian.PutUint32(index, i)
+	data = append(data, index...)
+
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	// the child key is the parent key plus the point of the left half of the hmac
+	curve := btcec.S256()
+	if new(big.Int).SetBytes(sum[:32]).Cmp(curve.N) >= 0 {
+		return nil, errors.Err("key at index %d is invalid, use the next index", i)
+	}
+	tweakX, tweakY := curve.ScalarBaseMult(sum[:32])
+	x, y := curve.Add(tweakX, tweakY, k.PublicKey.X, k.PublicKey.Y)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, errors.Err("key at index %d is invalid, use the next index", i)
+	}
+
+	chainCode := make([]byte, 32)
+	copy(chainCode, sum[32:])
+	return &ExtendedPublicKey{
+		PublicKey:         &btcec.PublicKey{Curve: curve, X: x, Y: y},
+		ChainCode:         chainCode,
+		Depth:             k.Depth + 1,
+		ParentFingerprint: k.Fingerprint(),
+		ChildIndex:        i,
+	}, nil
+}
+
+// Derive follows a path of non-hardened child indexes from the key
+func (k *ExtendedPublicKey) Derive(path ...uint32) (*ExtendedPublicKey, error) {
+	key := k
+	for _, i := range path {
+		var err error
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// ParseExtendedKey decodes a serialized private key for the network, e.g. an xprv
+func ParseExtendedKey(s string, params *chaincfg.Params) (*ExtendedKey, error) {
+	d, err := decodeExtendedKey(s)
+	if err != nil {
+		return nil, err
+	}
+	if d.version != params.HDPrivateKeyID {
+		if d.version == params.HDPublicKeyID {
+			return nil, errors.Err("extended key is public, not private")
+		}
+		return nil, errors.Err("extended key is not a private key for %s", params.Name)
+	}
+	if d.keyData[0] != 0 {
+		return nil, errors.Err("extended private key is malformed")
+	}
+	b := make([]byte, 0, 64)
+	b = append(b, d.keyData[1:]...)
+	key, err := newExtendedKey(append(b, d.chainCode...), d.depth)
+	if err != nil {
+		return nil, err
+	}
+	key.ParentFingerprint = d.parentFingerprint
+	key.ChildIndex = d.childIndex
+	return key, nil
+}
+
+// ParseExtendedPublicKey decodes a serialized public key for the network, e.g. an xpub. A private key is
+// accepted too, and its public half is returned.
+func ParseExtendedPublicKey(s string, params *chaincfg.Params) (*ExtendedPublicKey, error) {
+	d, err := decodeExtendedKey(s)
+	if err != nil {
+		return nil, err
+	}
+	switch d.version {
+	case params.HDPrivateKeyID:
+		key, err := ParseExtendedKey(s, params)
+		if err != nil {
+			return nil, err
+		}
+		return key.Neuter(), nil
+	case params.HDPublicKeyID:
+	default:
+		return nil, errors.Err("extended key is not for %s", params.Name)
+	}
+	publicKey, err := btcec.ParsePubKey(d.keyData, btcec.S256())
+	if err != nil {
+		return nil, errors.Prefix("extended public key is malformed", err)
+	}
+	return &ExtendedPublicKey{
+		PublicKey:         publicKey,
+		ChainCode:         d.chainCode,
+		Depth:             d.depth,
+		ParentFingerprint: d.parentFingerprint,
+		ChildIndex:        d.childIndex,
+	}, nil
+}
+
+func fingerprint(publicKey *btcec.PublicKey) uint32 {
+	return binary.BigEndian.Uint32(btcutil.Hash160(publicKey.SerializeCompressed())[:4])
+}
+
+func encodeExtendedKey(version [4]byte, depth uint8, parentFingerprint, childIndex uint32, chainCode, keyData []byte) string {
+	b := make([]byte, extendedKeySize, extendedKeySize+4)
+	copy(b, version[:])
+	b[4] = depth
+	binary.BigEndian.PutUint32(b[5:], parentFingerprint)
+	binary.BigEndian.PutUint32(b[9:], childIndex)
+	copy(b[13:], chainCode)
+	copy(b[45:], keyData)
+	return base58.Encode(append(b, chainhash.DoubleHashB(b)[:4]...))
+}
+
+type decodedExtendedKey struct {
+	version           [4]byte
+	depth             uint8
+	parentFingerprint uint32
+	childIndex        uint32
+	chainCode         []byte
+	keyData           []byte
+}
+
+func decodeExtendedKey(s string) (*decodedExtendedKey, error) {
+	b := base58.Decode(s)
+	if len(b) != extendedKeySize+4 {
+		return nil, errors.Err("extended key has the wrong length")
+	}
+	payload := b[:extendedKeySize]
+	if !bytes.Equal(chainhash.DoubleHashB(payload)[:4], b[extendedKeySize:]) {
+		return nil, errors.Err("extended key checksum does not match")
+	}
+	d := &decodedExtendedKey{
+		depth:             payload[4],
+		parentFingerprint: binary.BigEndian.Uint32(payload[5:9]),
+		childIndex:        binary.BigEndian.Uint32(payload[9:13]),
+		chainCode:         payload[13:45],
+		keyData:           payload[45:],
+	}
+	copy(d.version[:], payload[:4])
+	if d.depth == 0 && (d.parentFingerprint != 0 || d.childIndex != 0) {
+		return nil, errors.Err("extended master key has a parent")
+	}
+	return d, nil
+}
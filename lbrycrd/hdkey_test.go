@@ -0,0 +1,134 @@
+package lbrycrd
+
+import (
+	"testing"
+)
+
+func TestExtendedKey_Encode(t *testing.T) {
+	// BIP32 test vector 1. lbrycrd uses the same version bytes as bitcoin.
+	master, err := NewMasterKey(mustDecodeHex(t, "000102030405060708090a0b0c0d0e0f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path []uint32
+		xprv string
+		xpub string
+	}{
+		{
+			nil,
+			"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			[]uint32{HardenedKeyStart},
+			"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+			"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		},
+		{
+			[]uint32{HardenedKeyStart, 1},
+			"xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+			"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		},
+	}
+	for _, test := range tests {
+		key, err := master.Derive(test.path...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := key.Encode(&mainNetParams); got != test.xprv {
+			t.Errorf("%v: expected %s, got %s", test.path, test.xprv, got)
+		}
+		if got := key.Neuter().Encode(&mainNetParams); got != test.xpub {
+			t.Errorf("%v: expected %s, got %s", test.path, test.xpub, got)
+		}
+
+		parsed, err := ParseExtendedKey(test.xprv, &mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.PrivateKey.D.Cmp(key.PrivateKey.D) != 0 || parsed.Encode(&mainNetParams) != test.xprv {
+			t.Errorf("%v: xprv did not round trip", test.path)
+		}
+		public, err := ParseExtendedPublicKey(test.xpub, &mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if public.Encode(&mainNetParams) != test.xpub {
+			t.Errorf("%v: xpub did not round trip", test.path)
+		}
+	}
+}
+
+func TestExtendedPublicKey_Derive(t *testing.T) {
+	master, err := NewMasterKey(mustDecodeHex(t, "000102030405060708090a0b0c0d0e0f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := master.Derive(AccountPath(0, &mainNetParams)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xpub, err := ParseExtendedPublicKey(account.Neuter().Encode(&mainNetParams), &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// public derivation gives the public keys of private derivation
+	for _, path := range [][]uint32{{ReceivingChain, 0}, {ReceivingChain, 7}, {ChangeChain, 3}} {
+		private, err := account.Derive(path...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		public, err := xpub.Derive(path...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if public.Encode(&mainNetParams) != private.Neuter().Encode(&mainNetParams) {
+			t.Errorf("%v: public and private derivation differ", path)
+		}
+		address, err := public.Address(&mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := PubKeyHashAddress(private.PrivateKey.PubKey(), &mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if address.EncodeAddress() != expected.EncodeAddress() {
+			t.Errorf("%v: expected address %s, got %s", path, expected.EncodeAddress(), address.EncodeAddress())
+		}
+	}
+
+	if _, err := xpub.Child(HardenedKeyStart); err == nil {
+		t.Error("expected an error deriving a hardened key from a public key")
+	}
+}
+
+func TestParseExtendedKey_Errors(t *testing.T) {
+	xprv := "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+	xpub := "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+
+	if _, err := ParseExtendedKey(xpub, &mainNetParams); err == nil {
+		t.Error("expected an error parsing an xpub as a private key")
+	}
+	if _, err := ParseExtendedKey(xprv, &testNetParams); err == nil {
+		t.Error("expected an error parsing an xprv for testnet")
+	}
+	if _, err := ParseExtendedPublicKey(xpub[:len(xpub)-1]+"9", &mainNetParams); err == nil {
+		t.Error("expected a checksum error")
+	}
+	if _, err := ParseExtendedPublicKey("xpub661MyMwAqRbc", &mainNetParams); err == nil {
+		t.Error("expected a length error")
+	}
+
+	// an xprv is accepted where a public key is expected
+	public, err := ParseExtendedPublicKey(xprv, &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public.Encode(&mainNetParams) != xpub {
+		t.Errorf("expected %s, got %s", xpub, public.Encode(&mainNetParams))
+	}
+}
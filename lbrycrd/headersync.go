@@ -0,0 +1,147 @@
+package lbrycrd
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// MaxReorgDepth is how many headers a HeaderSync remembers. A reorg deeper than that can't be followed.
+const MaxReorgDepth = 100
+
+// ErrReorgTooDeep is returned when a reorg replaced more headers than a HeaderSync remembers
+var ErrReorgTooDeep = errors.Base("reorg is deeper than the headers that were synced")
+
+// SyncedHeader is a header in the chain a HeaderSync follows
+type SyncedHeader struct {
+	Height int32
+	Hash   chainhash.Hash
+	Header *BlockHeader
+}
+
+// HeaderSync follows lbrycrd's best chain one header at a time, so an indexer can process each block in order
+// and undo the blocks that reorgs replace
+type HeaderSync struct {
+	client *Client
+	start  int32
+	next   int32
+	recent []SyncedHeader // the last synced headers, oldest first
+}
+
+// NewHeaderSync starts following the chain at height
+func (c *Client) NewHeaderSync(height int32) *HeaderSync {
+	return &HeaderSync{client: c, start: height, next: height}
+}
+
+// ResumeHeaderSync continues following the chain after headers that were synced before, oldest first, like the
+// ones an indexer saved before it stopped. If a reorg replaced them in the meantime, Next returns them in
+// disconnected. recent can't be empty.
+func (c *Client) ResumeHeaderSync(recent []SyncedHeader) *HeaderSync {
+	if len(recent) > MaxReorgDepth {
+		recent = recent[len(recent)-MaxReorgDepth:]
+	}
+	last := recent[len(recent)-1]
+	return &HeaderSync{
+		client: c,
+		start:  recent[0].Height - 1, // the first header's parent is unknown, so a reorg that replaces it is too deep
+		next:   last.Height + 1,
+		recent: append([]SyncedHeader(nil), recent...),
+	}
+}
+
+// Height returns the height of the next header Next will return
+func (s *HeaderSync) Height() int32 {
+	return s.next
+}
+
+// Next returns up to max headers that follow the ones it returned before. If a reorg replaced headers it
+// returned, they are returned in disconnected, newest first, and connected starts with their replacements.
+func (s *HeaderSync) Next(max int) (connected, disconnected []SyncedHeader, err error) {
+	count, err := s.client.blockCount()
+	if err != nil {
+		return nil, nil, errors.Err(err)
+	}
+
+	for int64(s.next) <= count && len(connected) < max {
+		hash, err := s.client.blockHash(s.next)
+		if err != nil {
+			return connected, disconnected, errors.Err(err)
+		}
+		header, err := s.client.GetRawBlockHeader(hash)
+		if err != nil {
+			return connected, disconnected, err
+		}
+
+		if len(s.recent) > 0 && !header.PrevBlock.IsEqual(&s.recent[len(s.recent)-1].Hash) {
+			if len(connected) > 0 {
+				// the chain changed under headers returned by this call. return them and go back next time
+				break
+			}
+			last := s.recent[len(s.recent)-1]
+			if len(s.recent) == 1 && last.Height > s.start {
+				// the parent of the oldest header it remembers is unknown
+				return nil, disconnected, errors.Prefix("height "+strconv.Itoa(int(last.Height)), ErrReorgTooDeep)
+			}
+			s.recent = s.recent[:len(s.recent)-1]
+			disconnected = append(disconnected, last)
+			s.next = last.Height
+			continue
+		}
+
+		synced := SyncedHeader{Height: s.next, Hash: *hash, Header: header}
+		connected = append(connected, synced)
+		s.recent = append(s.recent, synced)
+		if len(s.recent) > MaxReorgDepth {
+			s.recent = s.recent[1:]
+		}
+		s.next++
+	}
+	return connected, disconnected, nil
+}
+
+// Run calls handle with each batch of headers Next returns, checking for new ones every interval, until the
+// context is done or handle returns an error
+func (s *HeaderSync) Run(ctx context.Context, interval time.Duration, handle func(connected, disconnected []SyncedHeader) error) error {
+	const batchSize = 500
+	for {
+		connected, disconnected, err := s.Next(batchSize)
+		if len(connected) > 0 || len(disconnected) > 0 {
+			if err := handle(connected, disconnected); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		wait := interval
+		if len(connected) == batchSize {
+			wait = 0 // catching up
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Err(ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// blockCount and blockHash are like the btcd client's GetBlockCount and GetBlockHash, but go through rawCall so
+// they skip the relay
+func (c *Client) blockCount() (int64, error) {
+	var count int64
+	err := c.rawCall(&count, "getblockcount")
+	return count, err
+}
+
+func (c *Client) blockHash(height int32) (*chainhash.Hash, error) {
+	var hash string
+	if err := c.rawCall(&hash, "getblockhash", height); err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(hash)
+}
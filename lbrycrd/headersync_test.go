@@ -0,0 +1,157 @@
+package lbrycrd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// fakeChain is a chain of headers served by a fake lbrycrd
+type fakeChain struct {
+	mu      sync.Mutex
+	headers []BlockHeader
+}
+
+// extend adds n headers on top of the header at height, replacing any above it. nonce makes the new headers
+// differ from the ones they replace.
+func (c *fakeChain) extend(height, n int, nonce uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headers = c.headers[:height+1]
+	for i := 0; i < n; i++ {
+		prev := c.headers[len(c.headers)-1]
+		c.headers = append(c.headers, BlockHeader{Version: 1, PrevBlock: prev.BlockHash(), Timestamp: time.Unix(int64(len(c.headers)), 0), Nonce: nonce})
+	}
+}
+
+func (c *fakeChain) handle(req fakeRequest) (interface{}, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch req.Method {
+	case "getblockcount":
+		return len(c.headers) - 1, ""
+	case "getblockhash":
+		var height int
+		if err := json.Unmarshal(req.Params[0], &height); err != nil || height >= len(c.headers) {
+			return nil, "Block height out of range"
+		}
+		return c.headers[height].BlockHash().String(), ""
+	case "getblockheader":
+		var hash string
+		json.Unmarshal(req.Params[0], &hash)
+		for _, h := range c.headers {
+			if h.BlockHash().String() == hash {
+				return hex.EncodeToString(h.bytes()), ""
+			}
+		}
+		return nil, "Block not found"
+	}
+	return nil, "Method not found"
+}
+
+func heights(headers []SyncedHeader) []int32 {
+	var h []int32
+	for _, s := range headers {
+		h = append(h, s.Height)
+	}
+	return h
+}
+
+func TestHeaderSync(t *testing.T) {
+	chain := &fakeChain{headers: []BlockHeader{{Version: 1}}}
+	chain.extend(0, 10, 0)
+	c, server := newFakeLbrycrd(t, chain.handle)
+	defer server.Close()
+
+	s := c.NewHeaderSync(2)
+	connected, disconnected, err := s.Next(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disconnected) != 0 || len(connected) != 5 || connected[0].Height != 2 || connected[4].Height != 6 {
+		t.Errorf("unexpected headers %v, disconnected %v", heights(connected), heights(disconnected))
+	}
+	if connected[1].Header.PrevBlock != connected[0].Hash {
+		t.Error("headers should be chained")
+	}
+
+	connected, _, err = s.Next(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connected) != 4 || s.Height() != 11 {
+		t.Errorf("unexpected headers %v, next height %d", heights(connected), s.Height())
+	}
+	connected, _, err = s.Next(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connected) != 0 {
+		t.Errorf("expected no new headers, got %v", heights(connected))
+	}
+
+	// replace the headers above 7 with a longer chain
+	chain.extend(7, 5, 1)
+	connected, disconnected, err = s.Next(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disconnected) != 3 || disconnected[0].Height != 10 || disconnected[2].Height != 8 {
+		t.Errorf("unexpected disconnected headers %v", heights(disconnected))
+	}
+	if len(connected) != 5 || connected[0].Height != 8 || connected[0].Header.Nonce != 1 || s.Height() != 13 {
+		t.Errorf("unexpected connected headers %v", heights(connected))
+	}
+}
+
+func TestHeaderSync_DeepReorg(t *testing.T) {
+	chain := &fakeChain{headers: []BlockHeader{{Version: 1}}}
+	chain.extend(0, MaxReorgDepth+10, 0)
+	c, server := newFakeLbrycrd(t, chain.handle)
+	defer server.Close()
+
+	s := c.NewHeaderSync(0)
+	if _, _, err := s.Next(1000); err != nil {
+		t.Fatal(err)
+	}
+	chain.extend(5, MaxReorgDepth+10, 1)
+	if _, _, err := s.Next(1000); err == nil {
+		t.Error("expected an error for a reorg deeper than the sync remembers")
+	}
+}
+
+func TestHeaderSync_Run(t *testing.T) {
+	chain := &fakeChain{headers: []BlockHeader{{Version: 1}}}
+	chain.extend(0, 3, 0)
+	c, server := newFakeLbrycrd(t, chain.handle)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []chainhash.Hash
+	err := c.NewHeaderSync(0).Run(ctx, 10*time.Millisecond, func(connected, disconnected []SyncedHeader) error {
+		for _, h := range connected {
+			got = append(got, h.Hash)
+		}
+		if len(got) == 4 {
+			chain.extend(3, 2, 0)
+		}
+		if len(got) == 6 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(got) != 6 {
+		t.Errorf("expected 6 headers, got %d", len(got))
+	}
+}
@@ -0,0 +1,95 @@
+package lbrycrd
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// CompactSignatureLength is the length of a signature encoded as the 32-byte R and S values, which is how
+// lbry-sdk stores signatures in claims and supports
+const CompactSignatureLength = 64
+
+// ErrInvalidSignature means a signature could not be parsed as either DER or compact R||S
+var ErrInvalidSignature = errors.Base("signature is neither DER nor 64-byte compact encoded")
+
+// SignDigest signs a 32-byte digest with a deterministic (RFC 6979) nonce and returns the compact R||S
+// signature. S is always in the lower half of the curve order, as lbrycrd and lbry-sdk require.
+func SignDigest(key *btcec.PrivateKey, digest []byte) ([]byte, error) {
+	if key == nil {
+		return nil, errors.Err("private key is missing")
+	}
+	if len(digest) != sha256.Size {
+		return nil, errors.Err("digest must be %d bytes, got %d", sha256.Size, len(digest))
+	}
+	sig, err := key.Sign(digest)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return CompactSignature(sig), nil
+}
+
+// SignMessage hashes the message with sha256 and signs the digest
+func SignMessage(key *btcec.PrivateKey, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	return SignDigest(key, digest[:])
+}
+
+// VerifyDigest checks a DER or compact signature of a digest against a public key
+func VerifyDigest(pubKey *btcec.PublicKey, digest, signature []byte) bool {
+	if pubKey == nil {
+		return false
+	}
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return false
+	}
+	return sig.Verify(digest, pubKey)
+}
+
+// VerifyMessage hashes the message with sha256 and checks the signature of the digest
+func VerifyMessage(pubKey *btcec.PublicKey, message, signature []byte) bool {
+	digest := sha256.Sum256(message)
+	return VerifyDigest(pubKey, digest[:], signature)
+}
+
+// ParseSignature parses a 64-byte compact R||S signature or a strict DER signature
+func ParseSignature(signature []byte) (*btcec.Signature, error) {
+	if len(signature) == CompactSignatureLength {
+		sig := &btcec.Signature{
+			R: new(big.Int).SetBytes(signature[:32]),
+			S: new(big.Int).SetBytes(signature[32:]),
+		}
+		if sig.R.Sign() == 0 || sig.S.Sign() == 0 || sig.R.Cmp(btcec.S256().N) >= 0 || sig.S.Cmp(btcec.S256().N) >= 0 {
+			return nil, errors.Err(ErrInvalidSignature)
+		}
+		return sig, nil
+	}
+	sig, err := btcec.ParseDERSignature(signature, btcec.S256())
+	if err != nil {
+		return nil, errors.Err(ErrInvalidSignature)
+	}
+	return sig, nil
+}
+
+// CompactSignature encodes a signature as the 32-byte R and S values
+func CompactSignature(sig *btcec.Signature) []byte {
+	encoded := make([]byte, CompactSignatureLength)
+	rBytes := sig.R.Bytes()
+	sBytes := sig.S.Bytes()
+	copy(encoded[32-len(rBytes):32], rBytes)
+	copy(encoded[CompactSignatureLength-len(sBytes):], sBytes)
+	return encoded
+}
+
+// SignatureToDER converts a compact R||S signature to DER
+func SignatureToDER(signature []byte) ([]byte, error) {
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize(), nil
+}
@@ -0,0 +1,68 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func TestSignMessage(t *testing.T) {
+	// RFC 6979 vector for secp256k1 and sha256, also used by the ecdsa library that lbry-sdk signs with
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	message := []byte("Satoshi Nakamoto")
+	expected := "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8" +
+		"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"
+
+	sig, err := SignMessage(key, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(sig) != expected {
+		t.Errorf("expected signature %s, got %x", expected, sig)
+	}
+	if !VerifyMessage(key.PubKey(), message, sig) {
+		t.Error("compact signature does not verify")
+	}
+
+	der, err := SignatureToDER(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDER := "3045022100" + expected[:64] + "0220" + expected[64:]
+	if hex.EncodeToString(der) != expectedDER {
+		t.Errorf("expected DER signature %s, got %x", expectedDER, der)
+	}
+	if !VerifyMessage(key.PubKey(), message, der) {
+		t.Error("DER signature does not verify")
+	}
+
+	if VerifyMessage(key.PubKey(), []byte("Satoshi Nakamoto!"), sig) {
+		t.Error("signature verified for a different message")
+	}
+	other, _ := btcec.NewPrivateKey(btcec.S256())
+	if VerifyMessage(other.PubKey(), message, sig) {
+		t.Error("signature verified for a different key")
+	}
+}
+
+func TestParseSignature_Invalid(t *testing.T) {
+	for _, sig := range [][]byte{nil, make([]byte, CompactSignatureLength), make([]byte, 70), {0x30, 0x02, 0x02, 0x00}} {
+		_, err := ParseSignature(sig)
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%x: expected ErrInvalidSignature, got %v", sig, err)
+		}
+	}
+
+	_, err := SignDigest(nil, make([]byte, 32))
+	if err == nil {
+		t.Error("expected an error for a missing key")
+	}
+	key, _ := btcec.NewPrivateKey(btcec.S256())
+	_, err = SignDigest(key, []byte("short"))
+	if err == nil {
+		t.Error("expected an error for a digest that is not 32 bytes")
+	}
+}
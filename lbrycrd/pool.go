@@ -0,0 +1,268 @@
+package lbrycrd
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/extras/stop"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/rpcclient"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrNoHealthyNodes is returned by a Pool when none of its lbrycrd nodes can be reached
+var ErrNoHealthyNodes = errors.Base("no healthy lbrycrd nodes")
+
+// noHealthyNodesError is ErrNoHealthyNodes, caused by the error a node failed with
+type noHealthyNodesError struct {
+	cause error
+}
+
+func (e *noHealthyNodesError) Error() string {
+	return ErrNoHealthyNodes.Error() + ": " + e.cause.Error()
+}
+
+// Is makes errors.Is match ErrNoHealthyNodes
+func (e *noHealthyNodesError) Is(target error) bool { return target == ErrNoHealthyNodes }
+
+// Unwrap returns the error the node failed with
+func (e *noHealthyNodesError) Unwrap() error { return e.cause }
+
+// DefaultHealthCheckInterval is how often a Pool checks its nodes if PoolOptions doesn't say
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// rpcInWarmup is the error code lbrycrd returns while it is starting up
+const rpcInWarmup = -28
+
+// PoolOptions configures a Pool
+type PoolOptions struct {
+	// HealthCheckInterval is how often each node is checked. It defaults to DefaultHealthCheckInterval.
+	HealthCheckInterval time.Duration
+	// MaxBlocksBehind is how far behind the node with the most blocks a node can be and still be used first.
+	// 0 doesn't compare nodes' blocks.
+	MaxBlocksBehind int64
+}
+
+// NodeStatus is what a Pool knows about one of its nodes from its last health check
+type NodeStatus struct {
+	Host      string
+	Healthy   bool
+	Blocks    int64
+	Err       error
+	CheckedAt time.Time
+}
+
+// Pool spreads calls over several lbrycrd nodes. Calls go to the first healthy node, in the order the nodes
+// were given, and move to the next node when a node can't be reached. Nodes are checked in the background, so
+// calls go back to a node once it recovers.
+type Pool struct {
+	opts  PoolOptions
+	nodes []*poolNode
+	grp   *stop.Group
+}
+
+type poolNode struct {
+	url string
+
+	mu     sync.RWMutex
+	client *Client // nil until the node has been reached
+	status NodeStatus
+}
+
+// NewPool connects to lbrycrd nodes given by urls like the one New takes. At least one node must be reachable,
+// or the error matches ErrNoHealthyNodes and wraps the error the first node failed with. Close the pool to stop
+// checking the nodes.
+func NewPool(urls []string, opts PoolOptions) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, errors.Err("no lbrycrd urls")
+	}
+	if opts.HealthCheckInterval <= 0 {
+		opts.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+
+	p := &Pool{opts: opts, grp: stop.New()}
+	for _, lbrycrdURL := range urls {
+		u, err := url.Parse(lbrycrdURL)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		p.nodes = append(p.nodes, &poolNode{url: lbrycrdURL, status: NodeStatus{Host: u.Host}})
+	}
+
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *poolNode) {
+			defer wg.Done()
+			n.check()
+		}(n)
+	}
+	wg.Wait()
+	if _, err := p.Client(); err != nil {
+		p.shutdown()
+		return nil, errors.Err(&noHealthyNodesError{cause: p.nodes[0].getStatus().Err})
+	}
+
+	for _, n := range p.nodes {
+		p.grp.Add(1)
+		go func(n *poolNode) {
+			defer p.grp.Done()
+			for {
+				select {
+				case <-p.grp.Ch():
+					return
+				case <-time.After(p.opts.HealthCheckInterval):
+				}
+				n.check()
+			}
+		}(n)
+	}
+	return p, nil
+}
+
+// Close stops checking the nodes and shuts down their clients
+func (p *Pool) Close() {
+	p.grp.StopAndWait()
+	p.shutdown()
+}
+
+// shutdown shuts down the client of every node that has been reached
+func (p *Pool) shutdown() {
+	for _, n := range p.nodes {
+		if client := n.getClient(); client != nil {
+			client.Shutdown()
+		}
+	}
+}
+
+// Status returns the status of each node, in the order they were given
+func (p *Pool) Status() []NodeStatus {
+	statuses := make([]NodeStatus, len(p.nodes))
+	for i, n := range p.nodes {
+		statuses[i] = n.getStatus()
+	}
+	return statuses
+}
+
+// Client returns the client for the node calls go to first, or ErrNoHealthyNodes
+func (p *Pool) Client() (*Client, error) {
+	nodes := p.ordered()
+	if len(nodes) == 0 || !nodes[0].getStatus().Healthy {
+		return nil, errors.Err(ErrNoHealthyNodes)
+	}
+	return nodes[0].getClient(), nil
+}
+
+// Do calls fn with the client for the first healthy node. If fn fails because the node can't be reached, the
+// node is marked unhealthy and fn is called again with the next node, so fn must be safe to repeat. Wallet
+// calls go to each node's own wallet.
+func (p *Pool) Do(fn func(*Client) error) error {
+	nodes := p.ordered()
+	if len(nodes) == 0 {
+		return errors.Err(ErrNoHealthyNodes)
+	}
+	var err error
+	for _, n := range nodes {
+		err = fn(n.getClient())
+		if err == nil || !isUnavailable(err) {
+			return err
+		}
+		log.Debugf("lbrycrd node %s is unavailable, trying the next one: %v", n.getStatus().Host, err)
+		n.setUnhealthy(err)
+	}
+	return err
+}
+
+// ordered returns the nodes that have been reached, healthy ones first. Nodes that are too far behind come after
+// the other healthy nodes.
+func (p *Pool) ordered() []*poolNode {
+	var best int64
+	for _, n := range p.nodes {
+		if s := n.getStatus(); s.Healthy && s.Blocks > best {
+			best = s.Blocks
+		}
+	}
+
+	var healthy, behind, unhealthy []*poolNode
+	for _, n := range p.nodes {
+		s := n.getStatus()
+		switch {
+		case n.getClient() == nil:
+		case !s.Healthy:
+			unhealthy = append(unhealthy, n)
+		case p.opts.MaxBlocksBehind > 0 && best-s.Blocks > p.opts.MaxBlocksBehind:
+			behind = append(behind, n)
+		default:
+			healthy = append(healthy, n)
+		}
+	}
+	return append(append(healthy, behind...), unhealthy...)
+}
+
+// check connects to the node if it hasn't been reached yet, and updates its status
+func (n *poolNode) check() {
+	client := n.getClient()
+	var err error
+	if client == nil {
+		client, err = New(n.url)
+		if err == nil {
+			n.mu.Lock()
+			n.client = client
+			n.mu.Unlock()
+		}
+	}
+	var blocks int64
+	if err == nil {
+		blocks, err = client.blockCount()
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Healthy = err == nil
+	n.status.Blocks = blocks
+	n.status.Err = err
+	n.status.CheckedAt = time.Now()
+}
+
+func (n *poolNode) getClient() *Client {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.client
+}
+
+func (n *poolNode) getStatus() NodeStatus {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.status
+}
+
+func (n *poolNode) setUnhealthy(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Healthy = false
+	n.status.Err = err
+}
+
+// isUnavailable says whether an error means the node couldn't be reached or isn't ready, rather than that the
+// call failed
+func isUnavailable(err error) bool {
+	err = errors.Unwrap(err)
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	if rpcErr, ok := err.(*btcjson.RPCError); ok {
+		return rpcErr.Code == rpcInWarmup
+	}
+	switch err {
+	case io.EOF, io.ErrUnexpectedEOF, rpcclient.ErrClientShutdown:
+		return true
+	}
+	// the rpc client reports other http errors by status code
+	return strings.HasPrefix(err.Error(), "status code: 5")
+}
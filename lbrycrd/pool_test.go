@@ -0,0 +1,228 @@
+package lbrycrd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// fakeNode is a fake lbrycrd that can be taken down
+type fakeNode struct {
+	*httptest.Server
+	fake *httptest.Server
+
+	mu     sync.Mutex
+	down   bool
+	blocks int64
+	calls  int
+}
+
+func newFakeNode(t *testing.T, name string, blocks int64) *fakeNode {
+	n := &fakeNode{blocks: blocks}
+	_, n.fake = newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		switch req.Method {
+		case "getblockcount":
+			return n.blocks, ""
+		case "checknormalization":
+			n.calls++
+			if stringParams(t, req)[0] == "" {
+				return nil, "Name is empty"
+			}
+			return name, ""
+		}
+		return nil, "Method not found"
+	})
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.mu.Lock()
+		down := n.down
+		n.mu.Unlock()
+		if down {
+			http.Error(w, "lbrycrd is down", http.StatusServiceUnavailable)
+			return
+		}
+		n.fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	return n
+}
+
+func (n *fakeNode) url() string {
+	return "rpc://user:pass@" + strings.TrimPrefix(n.URL, "http://")
+}
+
+func (n *fakeNode) close() {
+	n.Close()
+	n.fake.Close()
+}
+
+func (n *fakeNode) set(down bool, blocks int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.down = down
+	n.blocks = blocks
+}
+
+func poolCall(t *testing.T, p *Pool, name string) string {
+	t.Helper()
+	var got string
+	err := p.Do(func(c *Client) error {
+		var err error
+		got, err = c.CheckNormalization(name)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func waitForStatus(t *testing.T, p *Pool, node int, healthy bool) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if p.Status()[node].Healthy == healthy {
+			return
+		}
+	}
+	t.Fatalf("node %d did not become healthy=%t", node, healthy)
+}
+
+func TestPool_Failover(t *testing.T) {
+	a := newFakeNode(t, "a", 100)
+	defer a.close()
+	b := newFakeNode(t, "b", 100)
+	defer b.close()
+
+	p, err := NewPool([]string{a.url(), b.url()}, PoolOptions{HealthCheckInterval: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	if got := poolCall(t, p, "x"); got != "a" {
+		t.Errorf("expected the first node, got %s", got)
+	}
+
+	// calls move to the next node as soon as one fails
+	a.set(true, 100)
+	if got := poolCall(t, p, "x"); got != "b" {
+		t.Errorf("expected the second node, got %s", got)
+	}
+	if status := p.Status()[0]; status.Healthy || status.Err == nil {
+		t.Errorf("expected the first node to be unhealthy, got %+v", status)
+	}
+
+	// and go back once it recovers
+	a.set(false, 100)
+	waitForStatus(t, p, 0, true)
+	if got := poolCall(t, p, "x"); got != "a" {
+		t.Errorf("expected the first node after it recovered, got %s", got)
+	}
+
+	// errors from the call itself are not retried
+	b.mu.Lock()
+	before := b.calls
+	b.mu.Unlock()
+	if err := p.Do(func(c *Client) error {
+		_, err := c.CheckNormalization("")
+		return err
+	}); err == nil {
+		t.Error("expected an error")
+	}
+	b.mu.Lock()
+	if b.calls != before {
+		t.Error("a failed call should not be retried on another node")
+	}
+	b.mu.Unlock()
+
+	a.set(true, 100)
+	b.set(true, 100)
+	waitForStatus(t, p, 0, false)
+	waitForStatus(t, p, 1, false)
+	if _, err := p.Client(); err == nil {
+		t.Error("expected an error with every node down")
+	}
+	if err := p.Do(func(c *Client) error {
+		_, err := c.CheckNormalization("x")
+		return err
+	}); err == nil {
+		t.Error("expected an error with every node down")
+	}
+}
+
+func TestPool_MaxBlocksBehind(t *testing.T) {
+	a := newFakeNode(t, "a", 90)
+	defer a.close()
+	b := newFakeNode(t, "b", 100)
+	defer b.close()
+
+	p, err := NewPool([]string{a.url(), b.url()}, PoolOptions{HealthCheckInterval: 20 * time.Millisecond, MaxBlocksBehind: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	if got := poolCall(t, p, "x"); got != "b" {
+		t.Errorf("expected the node that isn't behind, got %s", got)
+	}
+	a.set(false, 98)
+	for start := time.Now(); p.Status()[0].Blocks != 98; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("node was not checked again")
+		}
+	}
+	if got := poolCall(t, p, "x"); got != "a" {
+		t.Errorf("expected the first node once it caught up, got %s", got)
+	}
+}
+
+func TestNewPool_Errors(t *testing.T) {
+	if _, err := NewPool(nil, PoolOptions{}); err == nil {
+		t.Error("expected an error without urls")
+	}
+
+	a := newFakeNode(t, "a", 100)
+	defer a.close()
+	a.set(true, 100)
+	_, err := NewPool([]string{a.url()}, PoolOptions{})
+	if !errors.Is(err, ErrNoHealthyNodes) {
+		t.Errorf("expected ErrNoHealthyNodes when no node can be reached, got %v", err)
+	} else if !strings.Contains(err.Error(), "503") {
+		t.Errorf("expected the error to include the node's error, got %v", err)
+	}
+
+	// a node that is down at the start is connected to once it comes up
+	b := newFakeNode(t, "b", 100)
+	defer b.close()
+	p, err := NewPool([]string{a.url(), b.url()}, PoolOptions{HealthCheckInterval: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := poolCall(t, p, "x"); got != "b" {
+		t.Errorf("expected the node that is up, got %s", got)
+	}
+	a.set(false, 100)
+	waitForStatus(t, p, 0, true)
+	if got := poolCall(t, p, "x"); got != "a" {
+		t.Errorf("expected the first node once it came up, got %s", got)
+	}
+
+	p.Close()
+	for i, n := range p.nodes {
+		done := make(chan struct{})
+		go func(client *Client) {
+			client.WaitForShutdown()
+			close(done)
+		}(n.getClient())
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Errorf("expected node %d's client to be shut down", i)
+		}
+	}
+}
@@ -0,0 +1,679 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"strconv"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// psbtMagic starts every serialized PSBT
+var psbtMagic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// key types of the PSBT fields this package knows, see BIP 174
+const (
+	psbtGlobalUnsignedTx = 0x00
+
+	psbtInNonWitnessUTXO     = 0x00
+	psbtInWitnessUTXO        = 0x01
+	psbtInPartialSig         = 0x02
+	psbtInSigHashType        = 0x03
+	psbtInRedeemScript       = 0x04
+	psbtInWitnessScript      = 0x05
+	psbtInBip32Derivation    = 0x06
+	psbtInFinalScriptSig     = 0x07
+	psbtInFinalScriptWitness = 0x08
+
+	psbtOutRedeemScript    = 0x00
+	psbtOutWitnessScript   = 0x01
+	psbtOutBip32Derivation = 0x02
+)
+
+// PSBT is a partially signed transaction, see BIP 174. Tx is the transaction without signatures. Fields this
+// package doesn't know are kept in Unknown, so they survive being parsed and serialized again.
+type PSBT struct {
+	Tx      *wire.MsgTx
+	Inputs  []PSBTInput
+	Outputs []PSBTOutput
+	Unknown []PSBTField
+}
+
+// PSBTField is a key and value in a PSBT
+type PSBTField struct {
+	Key   []byte
+	Value []byte
+}
+
+// PSBTPartialSig is a signature of an input, with the key that made it
+type PSBTPartialSig struct {
+	PubKey    []byte
+	Signature []byte // DER, followed by the hash type
+}
+
+// PSBTDerivation says how the key with PubKey is derived from the master key with Fingerprint
+type PSBTDerivation struct {
+	PubKey      []byte
+	Fingerprint uint32
+	Path        []uint32
+}
+
+// PSBTInput is what a PSBT knows about an input. SigHashType is 0 if signers can choose.
+type PSBTInput struct {
+	NonWitnessUTXO     *wire.MsgTx
+	WitnessUTXO        *wire.TxOut
+	PartialSigs        []PSBTPartialSig
+	SigHashType        txscript.SigHashType
+	RedeemScript       []byte
+	WitnessScript      []byte
+	Derivations        []PSBTDerivation
+	FinalScriptSig     []byte
+	FinalScriptWitness wire.TxWitness
+	Unknown            []PSBTField
+}
+
+// PSBTOutput is what a PSBT knows about an output
+type PSBTOutput struct {
+	RedeemScript  []byte
+	WitnessScript []byte
+	Derivations   []PSBTDerivation
+	Unknown       []PSBTField
+}
+
+// ParsePSBT decodes a serialized PSBT
+func ParsePSBT(b []byte) (*PSBT, error) {
+	if !bytes.HasPrefix(b, psbtMagic) {
+		return nil, errors.Err("psbt has no magic bytes")
+	}
+	r := bytes.NewReader(b[len(psbtMagic):])
+
+	fields, err := readPSBTMap(r)
+	if err != nil {
+		return nil, err
+	}
+	p := new(PSBT)
+	for _, f := range fields {
+		if f.Key[0] != psbtGlobalUnsignedTx {
+			p.Unknown = append(p.Unknown, f)
+			continue
+		}
+		if len(f.Key) != 1 {
+			return nil, errors.Err("invalid psbt key %x", f.Key)
+		}
+		p.Tx = &wire.MsgTx{}
+		if err := p.Tx.DeserializeNoWitness(bytes.NewReader(f.Value)); err != nil {
+			return nil, errors.Prefix("decoding psbt transaction", err)
+		}
+	}
+	if p.Tx == nil {
+		return nil, errors.Err("psbt has no transaction")
+	}
+	for _, in := range p.Tx.TxIn {
+		if len(in.SignatureScript) > 0 || len(in.Witness) > 0 {
+			return nil, errors.Err("psbt transaction has signatures")
+		}
+	}
+
+	p.Inputs = make([]PSBTInput, len(p.Tx.TxIn))
+	for i := range p.Inputs {
+		fields, err := readPSBTMap(r)
+		if err == nil {
+			err = p.Inputs[i].decode(fields)
+		}
+		if err != nil {
+			return nil, errors.Prefix("psbt input "+strconv.Itoa(i), err)
+		}
+	}
+	p.Outputs = make([]PSBTOutput, len(p.Tx.TxOut))
+	for i := range p.Outputs {
+		fields, err := readPSBTMap(r)
+		if err == nil {
+			err = p.Outputs[i].decode(fields)
+		}
+		if err != nil {
+			return nil, errors.Prefix("psbt output "+strconv.Itoa(i), err)
+		}
+	}
+	if r.Len() > 0 {
+		return nil, errors.Err("psbt has %d extra bytes", r.Len())
+	}
+	return p, nil
+}
+
+// ParsePSBTBase64 decodes a PSBT in base64, the way lbrycrd's rpcs take and return them
+func ParsePSBTBase64(s string) (*PSBT, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return ParsePSBT(b)
+}
+
+// Serialize encodes the PSBT
+func (p *PSBT) Serialize() ([]byte, error) {
+	if p.Tx == nil {
+		return nil, errors.Err("psbt has no transaction")
+	}
+	if len(p.Inputs) != len(p.Tx.TxIn) || len(p.Outputs) != len(p.Tx.TxOut) {
+		return nil, errors.Err("psbt has %d inputs and %d outputs but its transaction has %d and %d",
+			len(p.Inputs), len(p.Outputs), len(p.Tx.TxIn), len(p.Tx.TxOut))
+	}
+	var tx bytes.Buffer
+	if err := p.Tx.SerializeNoWitness(&tx); err != nil {
+		return nil, errors.Err(err)
+	}
+
+	w := &psbtWriter{}
+	w.buf.Write(psbtMagic)
+	w.field(psbtGlobalUnsignedTx, nil, tx.Bytes())
+	w.end(p.Unknown)
+	for _, in := range p.Inputs {
+		if err := in.encode(w); err != nil {
+			return nil, err
+		}
+	}
+	for _, out := range p.Outputs {
+		out.encode(w)
+	}
+	return w.buf.Bytes(), nil
+}
+
+// Base64 encodes the PSBT in base64
+func (p *PSBT) Base64() (string, error) {
+	b, err := p.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// PrevOutput returns the output input i spends, or nil if the PSBT doesn't include it
+func (p *PSBT) PrevOutput(i int) (*wire.TxOut, error) {
+	if i < 0 || i >= len(p.Inputs) {
+		return nil, errors.Err("psbt has no input %d", i)
+	}
+	in := p.Inputs[i]
+	if in.WitnessUTXO != nil {
+		return in.WitnessUTXO, nil
+	}
+	if in.NonWitnessUTXO == nil {
+		return nil, nil
+	}
+	outpoint := p.Tx.TxIn[i].PreviousOutPoint
+	if hash := in.NonWitnessUTXO.TxHash(); !hash.IsEqual(&outpoint.Hash) {
+		return nil, errors.Err("previous transaction of input %d is %s, not %s", i, hash, outpoint.Hash)
+	}
+	if int(outpoint.Index) >= len(in.NonWitnessUTXO.TxOut) {
+		return nil, errors.Err("previous transaction of input %d has no output %d", i, outpoint.Index)
+	}
+	return in.NonWitnessUTXO.TxOut[outpoint.Index], nil
+}
+
+// InputClaim returns the claim, update or support input i spends, or nil if it spends something else or the
+// PSBT doesn't include the output it spends
+func (p *PSBT) InputClaim(i int) (*ClaimScript, error) {
+	out, err := p.PrevOutput(i)
+	if err != nil || out == nil || !IsClaimScript(out.PkScript) {
+		return nil, err
+	}
+	return ParseClaimScript(out.PkScript)
+}
+
+// OutputClaim returns the claim, update or support output i makes, or nil if it is a plain output
+func (p *PSBT) OutputClaim(i int) (*ClaimScript, error) {
+	if i < 0 || i >= len(p.Tx.TxOut) {
+		return nil, errors.Err("psbt has no output %d", i)
+	}
+	if !IsClaimScript(p.Tx.TxOut[i].PkScript) {
+		return nil, nil
+	}
+	return ParseClaimScript(p.Tx.TxOut[i].PkScript)
+}
+
+// Fee returns what the transaction pays in fees. The PSBT must include every output the inputs spend.
+func (p *PSBT) Fee() (btcutil.Amount, error) {
+	var fee int64
+	for i := range p.Inputs {
+		out, err := p.PrevOutput(i)
+		if err != nil {
+			return 0, err
+		}
+		if out == nil {
+			return 0, errors.Err("psbt does not have the output input %d spends", i)
+		}
+		fee += out.Value
+	}
+	for _, out := range p.Tx.TxOut {
+		fee -= out.Value
+	}
+	return btcutil.Amount(fee), nil
+}
+
+// Sign adds key's signature to input i, which must spend a P2PKH or claim output the PSBT includes. It uses the
+// input's hash type, or SigHashAll if it has none.
+func (p *PSBT) Sign(i int, key *btcec.PrivateKey) error {
+	out, err := p.PrevOutput(i)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return errors.Err("psbt does not have the output input %d spends", i)
+	}
+	// sign a copy, so the unsigned transaction keeps its empty signature scripts
+	tx := p.Tx.Copy()
+	if err := SignInput(tx, i, out.PkScript, key, p.Inputs[i].SigHashType); err != nil {
+		return err
+	}
+	pushes, err := txscript.PushedData(tx.TxIn[i].SignatureScript)
+	if err != nil {
+		return errors.Err(err)
+	}
+	sig := PSBTPartialSig{PubKey: pushes[1], Signature: pushes[0]}
+	for j, existing := range p.Inputs[i].PartialSigs {
+		if bytes.Equal(existing.PubKey, sig.PubKey) {
+			p.Inputs[i].PartialSigs[j] = sig
+			return nil
+		}
+	}
+	p.Inputs[i].PartialSigs = append(p.Inputs[i].PartialSigs, sig)
+	return nil
+}
+
+// Finalize builds the signature scripts of the inputs that spend P2PKH or claim outputs and have a signature.
+// Inputs that are already final are left alone. It returns whether every input is final.
+func (p *PSBT) Finalize() (bool, error) {
+	complete := true
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		if in.FinalScriptSig != nil || in.FinalScriptWitness != nil {
+			continue
+		}
+		out, err := p.PrevOutput(i)
+		if err != nil {
+			return false, err
+		}
+		if out == nil || len(in.PartialSigs) == 0 {
+			complete = false
+			continue
+		}
+		hash, err := pubKeyHash(out.PkScript)
+		if err != nil {
+			complete = false // lbrycrd's finalizepsbt can finish other kinds of inputs
+			continue
+		}
+		var sig *PSBTPartialSig
+		for j := range in.PartialSigs {
+			if bytes.Equal(btcutil.Hash160(in.PartialSigs[j].PubKey), hash) {
+				sig = &in.PartialSigs[j]
+			}
+		}
+		if sig == nil {
+			complete = false
+			continue
+		}
+		script, err := txscript.NewScriptBuilder().AddData(sig.Signature).AddData(sig.PubKey).Script()
+		if err != nil {
+			return false, errors.Err(err)
+		}
+		// BIP 174 says to drop what only signers need once an input is final
+		*in = PSBTInput{NonWitnessUTXO: in.NonWitnessUTXO, WitnessUTXO: in.WitnessUTXO, FinalScriptSig: script, Unknown: in.Unknown}
+	}
+	return complete, nil
+}
+
+// Extract returns the signed transaction once every input is final
+func (p *PSBT) Extract() (*wire.MsgTx, error) {
+	tx := p.Tx.Copy()
+	for i, in := range p.Inputs {
+		if in.FinalScriptSig == nil && in.FinalScriptWitness == nil {
+			return nil, errors.Err("psbt input %d is not final", i)
+		}
+		tx.TxIn[i].SignatureScript = in.FinalScriptSig
+		tx.TxIn[i].Witness = in.FinalScriptWitness
+	}
+	return tx, nil
+}
+
+func (in *PSBTInput) decode(fields []PSBTField) error {
+	for _, f := range fields {
+		keyType, keyData := f.Key[0], f.Key[1:]
+		switch keyType {
+		case psbtInPartialSig, psbtInBip32Derivation:
+			if len(keyData) != btcec.PubKeyBytesLenCompressed && len(keyData) != btcec.PubKeyBytesLenUncompressed {
+				return errors.Err("invalid psbt key %x", f.Key)
+			}
+		case psbtInNonWitnessUTXO, psbtInWitnessUTXO, psbtInSigHashType, psbtInRedeemScript, psbtInWitnessScript,
+			psbtInFinalScriptSig, psbtInFinalScriptWitness:
+			if len(keyData) != 0 {
+				return errors.Err("invalid psbt key %x", f.Key)
+			}
+		default:
+			in.Unknown = append(in.Unknown, f)
+			continue
+		}
+
+		var err error
+		switch keyType {
+		case psbtInNonWitnessUTXO:
+			in.NonWitnessUTXO = &wire.MsgTx{}
+			err = in.NonWitnessUTXO.Deserialize(bytes.NewReader(f.Value))
+		case psbtInWitnessUTXO:
+			in.WitnessUTXO, err = decodeTxOut(f.Value)
+		case psbtInPartialSig:
+			in.PartialSigs = append(in.PartialSigs, PSBTPartialSig{PubKey: keyData, Signature: f.Value})
+		case psbtInSigHashType:
+			if len(f.Value) != 4 {
+				return errors.Err("psbt hash type must be 4 bytes")
+			}
+			in.SigHashType = txscript.SigHashType(binary.LittleEndian.Uint32(f.Value))
+		case psbtInRedeemScript:
+			in.RedeemScript = f.Value
+		case psbtInWitnessScript:
+			in.WitnessScript = f.Value
+		case psbtInBip32Derivation:
+			var d PSBTDerivation
+			d, err = decodeDerivation(keyData, f.Value)
+			in.Derivations = append(in.Derivations, d)
+		case psbtInFinalScriptSig:
+			in.FinalScriptSig = f.Value
+		case psbtInFinalScriptWitness:
+			in.FinalScriptWitness, err = decodeWitness(f.Value)
+		}
+		if err != nil {
+			return errors.Prefix("decoding psbt field "+strconv.Itoa(int(keyType)), err)
+		}
+	}
+	return nil
+}
+
+func (in *PSBTInput) encode(w *psbtWriter) error {
+	if in.NonWitnessUTXO != nil {
+		var tx bytes.Buffer
+		if err := in.NonWitnessUTXO.Serialize(&tx); err != nil {
+			return errors.Err(err)
+		}
+		w.field(psbtInNonWitnessUTXO, nil, tx.Bytes())
+	}
+	if in.WitnessUTXO != nil {
+		var out bytes.Buffer
+		if err := wire.WriteTxOut(&out, 0, 0, in.WitnessUTXO); err != nil {
+			return errors.Err(err)
+		}
+		w.field(psbtInWitnessUTXO, nil, out.Bytes())
+	}
+	for _, sig := range in.PartialSigs {
+		w.field(psbtInPartialSig, sig.PubKey, sig.Signature)
+	}
+	if in.SigHashType != 0 {
+		w.field(psbtInSigHashType, nil, uint32Bytes(uint32(in.SigHashType)))
+	}
+	w.optional(psbtInRedeemScript, in.RedeemScript)
+	w.optional(psbtInWitnessScript, in.WitnessScript)
+	for _, d := range in.Derivations {
+		w.field(psbtInBip32Derivation, d.PubKey, d.encode())
+	}
+	w.optional(psbtInFinalScriptSig, in.FinalScriptSig)
+	if in.FinalScriptWitness != nil {
+		var witness bytes.Buffer
+		wire.WriteVarInt(&witness, 0, uint64(len(in.FinalScriptWitness)))
+		for _, item := range in.FinalScriptWitness {
+			wire.WriteVarBytes(&witness, 0, item)
+		}
+		w.field(psbtInFinalScriptWitness, nil, witness.Bytes())
+	}
+	w.end(in.Unknown)
+	return nil
+}
+
+func (out *PSBTOutput) decode(fields []PSBTField) error {
+	for _, f := range fields {
+		keyType, keyData := f.Key[0], f.Key[1:]
+		switch {
+		case keyType == psbtOutRedeemScript && len(keyData) == 0:
+			out.RedeemScript = f.Value
+		case keyType == psbtOutWitnessScript && len(keyData) == 0:
+			out.WitnessScript = f.Value
+		case keyType == psbtOutBip32Derivation &&
+			(len(keyData) == btcec.PubKeyBytesLenCompressed || len(keyData) == btcec.PubKeyBytesLenUncompressed):
+			d, err := decodeDerivation(keyData, f.Value)
+			if err != nil {
+				return err
+			}
+			out.Derivations = append(out.Derivations, d)
+		case keyType <= psbtOutBip32Derivation:
+			return errors.Err("invalid psbt key %x", f.Key)
+		default:
+			out.Unknown = append(out.Unknown, f)
+		}
+	}
+	return nil
+}
+
+func (out *PSBTOutput) encode(w *psbtWriter) {
+	w.optional(psbtOutRedeemScript, out.RedeemScript)
+	w.optional(psbtOutWitnessScript, out.WitnessScript)
+	for _, d := range out.Derivations {
+		w.field(psbtOutBip32Derivation, d.PubKey, d.encode())
+	}
+	w.end(out.Unknown)
+}
+
+func decodeDerivation(pubKey, value []byte) (PSBTDerivation, error) {
+	if len(value) < 4 || len(value)%4 != 0 {
+		return PSBTDerivation{}, errors.Err("psbt derivation must be a fingerprint and a path of 4 byte indexes")
+	}
+	d := PSBTDerivation{PubKey: pubKey, Fingerprint: binary.BigEndian.Uint32(value)}
+	for i := 4; i < len(value); i += 4 {
+		d.Path = append(d.Path, binary.LittleEndian.Uint32(value[i:]))
+	}
+	return d, nil
+}
+
+func (d PSBTDerivation) encode() []byte {
+	b := make([]byte, 4, 4+4*len(d.Path))
+	binary.BigEndian.PutUint32(b, d.Fingerprint)
+	for _, index := range d.Path {
+		b = append(b, uint32Bytes(index)...)
+	}
+	return b
+}
+
+func decodeTxOut(b []byte) (*wire.TxOut, error) {
+	r := bytes.NewReader(b)
+	var value [8]byte
+	if _, err := io.ReadFull(r, value[:]); err != nil {
+		return nil, errors.Err(err)
+	}
+	script, err := wire.ReadVarBytes(r, 0, wire.MaxMessagePayload, "pkScript")
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	if r.Len() > 0 {
+		return nil, errors.Err("output has %d extra bytes", r.Len())
+	}
+	return wire.NewTxOut(int64(binary.LittleEndian.Uint64(value[:])), script), nil
+}
+
+func decodeWitness(b []byte) (wire.TxWitness, error) {
+	r := bytes.NewReader(b)
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	if count > uint64(len(b)) {
+		return nil, errors.Err("witness has too many items: %d", count)
+	}
+	witness := make(wire.TxWitness, count)
+	for i := range witness {
+		witness[i], err = wire.ReadVarBytes(r, 0, wire.MaxMessagePayload, "witness")
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+	}
+	if r.Len() > 0 {
+		return nil, errors.Err("witness has %d extra bytes", r.Len())
+	}
+	return witness, nil
+}
+
+// readPSBTMap reads the fields of a PSBT map up to the separator that ends it
+func readPSBTMap(r *bytes.Reader) ([]PSBTField, error) {
+	var fields []PSBTField
+	seen := make(map[string]bool)
+	for {
+		key, err := wire.ReadVarBytes(r, 0, wire.MaxMessagePayload, "psbt key")
+		if err != nil {
+			return nil, errors.Prefix("decoding psbt", err)
+		}
+		if len(key) == 0 {
+			return fields, nil
+		}
+		if seen[string(key)] {
+			return nil, errors.Err("psbt has key %x twice", key)
+		}
+		seen[string(key)] = true
+		value, err := wire.ReadVarBytes(r, 0, wire.MaxMessagePayload, "psbt value")
+		if err != nil {
+			return nil, errors.Prefix("decoding psbt", err)
+		}
+		fields = append(fields, PSBTField{Key: key, Value: value})
+	}
+}
+
+// psbtWriter writes PSBT maps. Writes to a bytes.Buffer can't fail, so their errors are ignored.
+type psbtWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *psbtWriter) field(keyType byte, keyData, value []byte) {
+	wire.WriteVarBytes(&w.buf, 0, append([]byte{keyType}, keyData...))
+	wire.WriteVarBytes(&w.buf, 0, value)
+}
+
+func (w *psbtWriter) optional(keyType byte, value []byte) {
+	if value != nil {
+		w.field(keyType, nil, value)
+	}
+}
+
+// end writes the unknown fields of a map and the separator after it
+func (w *psbtWriter) end(unknown []PSBTField) {
+	for _, f := range unknown {
+		wire.WriteVarBytes(&w.buf, 0, f.Key)
+		wire.WriteVarBytes(&w.buf, 0, f.Value)
+	}
+	w.buf.WriteByte(0)
+}
+
+func uint32Bytes(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+// CreatePSBT creates a PSBT that spends the inputs to the outputs. Claim outputs can't be created this way, so
+// build a transaction with them and use ConvertToPSBT instead.
+func (c *Client) CreatePSBT(inputs []btcjson.TransactionInput, outputs map[btcutil.Address]btcutil.Amount, lockTime *int64) (*PSBT, error) {
+	if inputs == nil {
+		inputs = []btcjson.TransactionInput{}
+	}
+	amounts := make(map[string]float64, len(outputs))
+	for address, amount := range outputs {
+		amounts[address.EncodeAddress()] = amount.ToBTC()
+	}
+	var result string
+	if err := c.rawCall(&result, "createpsbt", inputs, amounts, lockTime); err != nil {
+		return nil, err
+	}
+	return ParsePSBTBase64(result)
+}
+
+// ConvertToPSBT turns a transaction without signatures into a PSBT
+func (c *Client) ConvertToPSBT(tx *wire.MsgTx) (*PSBT, error) {
+	txHex, err := encodeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	var result string
+	if err := c.rawCall(&result, "converttopsbt", txHex); err != nil {
+		return nil, err
+	}
+	return ParsePSBTBase64(result)
+}
+
+type psbtResult struct {
+	PSBT     string `json:"psbt"`
+	Hex      string `json:"hex"`
+	Complete bool   `json:"complete"`
+}
+
+// WalletProcessPSBT adds what lbrycrd's wallet knows about the inputs to the PSBT and, if sign is true, signs the
+// inputs it has keys for. hashType 0 signs with SigHashAll. It returns whether every input is signed.
+func (c *Client) WalletProcessPSBT(p *PSBT, sign bool, hashType txscript.SigHashType) (*PSBT, bool, error) {
+	encoded, err := p.Base64()
+	if err != nil {
+		return nil, false, err
+	}
+	name, err := sigHashName(hashType)
+	if err != nil {
+		return nil, false, err
+	}
+	var result psbtResult
+	if err := c.rawCall(&result, "walletprocesspsbt", encoded, sign, name); err != nil {
+		return nil, false, err
+	}
+	processed, err := ParsePSBTBase64(result.PSBT)
+	if err != nil {
+		return nil, false, err
+	}
+	return processed, result.Complete, nil
+}
+
+// CombinePSBT merges PSBTs for the same transaction, such as ones signed by different signers
+func (c *Client) CombinePSBT(psbts []*PSBT) (*PSBT, error) {
+	encoded := make([]string, len(psbts))
+	for i, p := range psbts {
+		var err error
+		if encoded[i], err = p.Base64(); err != nil {
+			return nil, err
+		}
+	}
+	var result string
+	if err := c.rawCall(&result, "combinepsbt", encoded); err != nil {
+		return nil, err
+	}
+	return ParsePSBTBase64(result)
+}
+
+// FinalizePSBT finishes the inputs of a PSBT. If every input is final, the signed transaction is returned.
+// Otherwise the transaction is nil and the PSBT has the inputs that could be finished.
+func (c *Client) FinalizePSBT(p *PSBT) (*wire.MsgTx, *PSBT, error) {
+	encoded, err := p.Base64()
+	if err != nil {
+		return nil, nil, err
+	}
+	var result psbtResult
+	if err := c.rawCall(&result, "finalizepsbt", encoded, true); err != nil {
+		return nil, nil, err
+	}
+	if !result.Complete {
+		finalized, err := ParsePSBTBase64(result.PSBT)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, finalized, nil
+	}
+	tx, err := decodeTx(result.Hex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, nil, nil
+}
@@ -0,0 +1,299 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// testPSBT returns a PSBT that spends a P2PKH output and a claim of key's, and makes a support
+func testPSBT(t *testing.T, key *btcec.PrivateKey) *PSBT {
+	t.Helper()
+	address, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(key.PubKey().SerializeCompressed()), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2pkh, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := ClaimNameScript("test", []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	support, err := SupportClaimScript("test", testChannelClaimID, nil, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev := wire.NewMsgTx(wire.TxVersion)
+	prev.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), []byte{txscript.OP_TRUE}, nil))
+	prev.AddTxOut(wire.NewTxOut(50000, p2pkh))
+	prev.AddTxOut(wire.NewTxOut(10000, claim))
+	prevHash := prev.TxHash()
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 0), nil, nil))
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 1), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(40000, support))
+	tx.AddTxOut(wire.NewTxOut(19000, p2pkh))
+
+	return &PSBT{
+		Tx:      tx,
+		Inputs:  []PSBTInput{{NonWitnessUTXO: prev}, {NonWitnessUTXO: prev}},
+		Outputs: make([]PSBTOutput, 2),
+	}
+}
+
+func TestPSBT_RoundTrip(t *testing.T) {
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	p := testPSBT(t, key)
+	p.Unknown = []PSBTField{{Key: []byte{0xfc, 0x01}, Value: []byte("proprietary")}}
+	p.Inputs[0].SigHashType = txscript.SigHashAll
+	p.Inputs[0].Derivations = []PSBTDerivation{{PubKey: key.PubKey().SerializeCompressed(), Fingerprint: 0xd34db33f, Path: []uint32{HardenedKeyStart + 44, HardenedKeyStart + 140, HardenedKeyStart, 0, 1}}}
+	p.Inputs[1].WitnessUTXO = wire.NewTxOut(10000, []byte{txscript.OP_TRUE})
+	p.Inputs[1].FinalScriptWitness = wire.TxWitness{{1, 2}, {}}
+	p.Outputs[1].RedeemScript = []byte{txscript.OP_TRUE}
+	p.Outputs[1].Unknown = []PSBTField{{Key: []byte{0x10}, Value: []byte{}}}
+
+	b, err := p.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := p.Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParsePSBTBase64(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := parsed.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, again) {
+		t.Errorf("expected the psbt to serialize the same after parsing\n%x\n%x", b, again)
+	}
+	d := parsed.Inputs[0].Derivations
+	if len(d) != 1 || d[0].Fingerprint != 0xd34db33f || len(d[0].Path) != 5 || d[0].Path[1] != HardenedKeyStart+140 {
+		t.Errorf("unexpected derivation %+v", d)
+	}
+	if len(parsed.Unknown) != 1 || string(parsed.Unknown[0].Value) != "proprietary" {
+		t.Errorf("expected the unknown global field to be kept, got %+v", parsed.Unknown)
+	}
+	if len(parsed.Inputs[1].FinalScriptWitness) != 2 || parsed.Outputs[1].RedeemScript == nil {
+		t.Errorf("expected the input and output fields to be kept, got %+v %+v", parsed.Inputs[1], parsed.Outputs[1])
+	}
+}
+
+func TestPSBT_Claims(t *testing.T) {
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	p := testPSBT(t, key)
+
+	if claim, err := p.InputClaim(0); err != nil || claim != nil {
+		t.Errorf("expected input 0 not to spend a claim, got %v: %v", claim, err)
+	}
+	claim, err := p.InputClaim(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim == nil || claim.Type != ClaimName || claim.Name != "test" {
+		t.Errorf("expected input 1 to spend the claim for test, got %+v", claim)
+	}
+	support, err := p.OutputClaim(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if support == nil || support.Type != ClaimSupport || support.ClaimID != testChannelClaimID {
+		t.Errorf("expected output 0 to be a support, got %+v", support)
+	}
+	if claim, err := p.OutputClaim(1); err != nil || claim != nil {
+		t.Errorf("expected output 1 not to be a claim, got %v: %v", claim, err)
+	}
+
+	fee, err := p.Fee()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != 1000 {
+		t.Errorf("expected a fee of 1000, got %d", fee)
+	}
+
+	p.Inputs[0].NonWitnessUTXO = wire.NewMsgTx(wire.TxVersion)
+	if _, err := p.PrevOutput(0); err == nil {
+		t.Error("expected an error for a previous transaction that doesn't match the input")
+	}
+	p.Inputs[0].NonWitnessUTXO = nil
+	if _, err := p.Fee(); err == nil {
+		t.Error("expected an error without the previous output")
+	}
+}
+
+func TestPSBT_SignAndFinalize(t *testing.T) {
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	p := testPSBT(t, key)
+
+	if err := p.Sign(0, key); err != nil {
+		t.Fatal(err)
+	}
+	complete, err := p.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if complete {
+		t.Error("expected the psbt to be incomplete with one input signed")
+	}
+	if _, err := p.Extract(); err == nil {
+		t.Error("expected an error extracting an incomplete psbt")
+	}
+
+	// a signer that only has the serialized psbt signs the claim
+	b, err := p.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err = ParsePSBT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Sign(1, key); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Tx.TxIn[1].SignatureScript) != 0 {
+		t.Error("expected the unsigned transaction to stay unsigned")
+	}
+	complete, err = p.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !complete {
+		t.Fatal("expected the psbt to be complete")
+	}
+	if len(p.Inputs[1].PartialSigs) != 0 || p.Inputs[1].NonWitnessUTXO == nil {
+		t.Error("expected the final input to keep only its previous transaction")
+	}
+
+	tx, err := p.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range tx.TxIn {
+		prev, err := p.PrevOutput(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyInput(tx, i, prev.PkScript); err != nil {
+			t.Error(err)
+		}
+	}
+
+	other, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testPSBT(t, key).Sign(0, other); err == nil {
+		t.Error("expected an error signing with the wrong key")
+	}
+}
+
+func TestParsePSBT_Invalid(t *testing.T) {
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	valid, err := testPSBT(t, key).Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParsePSBT(valid); err != nil {
+		t.Fatal(err)
+	}
+
+	signed := testPSBT(t, key)
+	signed.Tx.TxIn[0].SignatureScript = []byte{txscript.OP_TRUE}
+	signedBytes, err := signed.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a global map with the transaction key twice
+	duplicate := append([]byte{}, psbtMagic...)
+	duplicate = append(duplicate, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00)
+
+	tests := map[string][]byte{
+		"no magic":       valid[1:],
+		"extra bytes":    append(append([]byte{}, valid...), 0x00),
+		"truncated":      valid[:len(valid)-1],
+		"signed":         signedBytes,
+		"duplicate key":  duplicate,
+		"no transaction": append(append([]byte{}, psbtMagic...), 0x00),
+		"empty":          nil,
+		"only the magic": psbtMagic,
+		"base64":         []byte("cHNidP8"),
+	}
+	for name, b := range tests {
+		if _, err := ParsePSBT(b); err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+}
+
+func TestClient_PSBT(t *testing.T) {
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	p := testPSBT(t, key)
+	encoded, err := p.Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	txHex, err := encodeTx(p.Tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		switch req.Method {
+		case "converttopsbt":
+			if params := stringParams(t, req); params[0] != txHex {
+				t.Errorf("expected tx %s, got %s", txHex, params[0])
+			}
+			return encoded, ""
+		case "walletprocesspsbt":
+			var sign bool
+			json.Unmarshal(req.Params[1], &sign)
+			if !sign {
+				t.Error("expected to sign")
+			}
+			return map[string]interface{}{"psbt": encoded, "complete": false}, ""
+		case "finalizepsbt":
+			return map[string]interface{}{"hex": txHex, "complete": true}, ""
+		}
+		return nil, "Method not found"
+	})
+	defer server.Close()
+
+	converted, err := c.ConvertToPSBT(p.Tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim, err := converted.OutputClaim(0); err != nil || claim == nil {
+		t.Errorf("expected the converted psbt to have the support, got %v: %v", claim, err)
+	}
+	processed, complete, err := c.WalletProcessPSBT(converted, true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if complete || processed.Inputs[0].NonWitnessUTXO == nil {
+		t.Errorf("expected an incomplete psbt with the previous transactions, got %+v", processed)
+	}
+	tx, unfinished, err := c.FinalizePSBT(processed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unfinished != nil || tx == nil || tx.TxHash() != p.Tx.TxHash() {
+		t.Errorf("expected the finalized transaction, got %v %v", tx, unfinished)
+	}
+}
@@ -0,0 +1,128 @@
+package lbrycrd
+
+import (
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/golang/protobuf/proto"
+)
+
+// PurchaseStartByte is the first byte of the purchase data in an OP_RETURN output, as written by lbry-sdk
+const PurchaseStartByte = 'P'
+
+// Purchase is a record that a transaction paid for a claim. The claim ID is stored in an OP_RETURN output
+// next to the payment output. The amount is the value of the payment output, not part of the purchase data.
+type Purchase struct {
+	ClaimID string
+	Amount  btcutil.Amount
+}
+
+// purchasePB is the Purchase message from lbry-sdk's purchase.proto
+type purchasePB struct {
+	ClaimHash []byte `protobuf:"bytes,1,opt,name=claim_hash,json=claimHash,proto3"`
+}
+
+func (m *purchasePB) Reset()         { *m = purchasePB{} }
+func (m *purchasePB) String() string { return proto.CompactTextString(m) }
+func (*purchasePB) ProtoMessage()    {}
+
+// Data returns the purchase data that goes in the OP_RETURN output: the start byte followed by the protobuf
+func (p Purchase) Data() ([]byte, error) {
+	claimHash, err := ClaimHashFromID(p.ClaimID)
+	if err != nil {
+		return nil, err
+	}
+	message, err := proto.Marshal(&purchasePB{ClaimHash: claimHash})
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return append([]byte{PurchaseStartByte}, message...), nil
+}
+
+// DecodePurchaseData parses the purchase data from an OP_RETURN output. The amount is left at zero.
+func DecodePurchaseData(data []byte) (*Purchase, error) {
+	if len(data) == 0 || data[0] != PurchaseStartByte {
+		return nil, errors.Err("purchase start byte not found")
+	}
+	message := &purchasePB{}
+	err := proto.Unmarshal(data[1:], message)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	if len(message.ClaimHash) != ClaimIDLength/2 {
+		return nil, errors.Err(ErrInvalidClaimID)
+	}
+	return &Purchase{ClaimID: ClaimIDFromHash(message.ClaimHash)}, nil
+}
+
+// PurchaseScript returns the OP_RETURN script that holds the purchase data
+func PurchaseScript(p Purchase) ([]byte, error) {
+	data, err := p.Data()
+	if err != nil {
+		return nil, err
+	}
+	script, err := txscript.NullDataScript(data)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return script, nil
+}
+
+// IsPurchaseScript returns true if the script is an OP_RETURN output holding purchase data
+func IsPurchaseScript(script []byte) bool {
+	_, ok := purchaseData(script)
+	return ok
+}
+
+func purchaseData(script []byte) ([]byte, bool) {
+	if txscript.GetScriptClass(script) != txscript.NullDataTy {
+		return nil, false
+	}
+	pushes, err := txscript.PushedData(script)
+	if err != nil || len(pushes) != 1 || len(pushes[0]) == 0 || pushes[0][0] != PurchaseStartByte {
+		return nil, false
+	}
+	return pushes[0], true
+}
+
+// AddPurchaseToTx adds the payment to the fee address and the purchase data output after it, in the same
+// order as lbry-sdk
+func AddPurchaseToTx(rawTx *wire.MsgTx, p Purchase, feeAddress btcutil.Address) error {
+	if p.Amount <= 0 {
+		return errors.Err("purchase amount must be positive")
+	}
+	paymentScript, err := txscript.PayToAddrScript(feeAddress)
+	if err != nil {
+		return errors.Err(err)
+	}
+	dataScript, err := PurchaseScript(p)
+	if err != nil {
+		return err
+	}
+
+	rawTx.AddTxOut(wire.NewTxOut(int64(p.Amount), paymentScript))
+	rawTx.AddTxOut(wire.NewTxOut(0, dataScript))
+	return nil
+}
+
+// PurchaseFromTx finds the purchase in a transaction. The amount is taken from the output before the
+// purchase data, which is where AddPurchaseToTx and lbry-sdk put the payment.
+func PurchaseFromTx(rawTx *wire.MsgTx) (*Purchase, error) {
+	for i, out := range rawTx.TxOut {
+		data, ok := purchaseData(out.PkScript)
+		if !ok {
+			continue
+		}
+		p, err := DecodePurchaseData(data)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			p.Amount = btcutil.Amount(rawTx.TxOut[i-1].Value)
+		}
+		return p, nil
+	}
+	return nil, errors.Err("transaction has no purchase data")
+}
@@ -0,0 +1,62 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestPurchase(t *testing.T) {
+	claimID := "2bfbc5be8c5fe8ebd39e4b49c8a8f04a8b6d8b5c"
+	p := Purchase{ClaimID: claimID, Amount: btcutil.Amount(150000000)}
+
+	data, err := p.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 'P', then field 1 (claim_hash) with length 20, then the claim ID reversed
+	expected := "500a14" + hex.EncodeToString(rev(mustDecodeHex(t, claimID)))
+	if hex.EncodeToString(data) != expected {
+		t.Errorf("expected data %s, got %x", expected, data)
+	}
+
+	feeAddress, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	err = AddPurchaseToTx(tx, p, feeAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.TxOut) != 2 || !IsPurchaseScript(tx.TxOut[1].PkScript) || IsPurchaseScript(tx.TxOut[0].PkScript) {
+		t.Fatalf("expected a payment output followed by a purchase output, got %v", tx.TxOut)
+	}
+
+	found, err := PurchaseFromTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *found != p {
+		t.Errorf("expected %v, got %v", p, *found)
+	}
+
+	_, err = DecodePurchaseData([]byte{'X', 0x0a, 0x00})
+	if err == nil {
+		t.Error("expected an error for data without the purchase start byte")
+	}
+	_, err = PurchaseFromTx(wire.NewMsgTx(wire.TxVersion))
+	if err == nil {
+		t.Error("expected an error for a transaction without a purchase")
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
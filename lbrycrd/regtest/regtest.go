@@ -0,0 +1,315 @@
+// Package regtest runs lbrycrd in regtest mode for integration tests. Start launches a node with a fresh chain
+// in a temporary data dir, or attaches to one that is already running, and Node has helpers to mine blocks and
+// fund addresses.
+package regtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+)
+
+const (
+	// BinaryEnv names the environment variable Start looks in for the lbrycrdd binary
+	BinaryEnv = "LBRYCRDD"
+	// URLEnv names the environment variable Start looks in for the url of a running regtest node
+	URLEnv = "LBRYCRD_REGTEST_URL"
+
+	// DefaultStartTimeout is how long Start waits for a launched node to answer
+	DefaultStartTimeout = 30 * time.Second
+
+	// coinbaseMaturity is how many blocks must be mined on top of a coinbase before it can be spent
+	coinbaseMaturity = 100
+)
+
+// Options configures Start
+type Options struct {
+	// URL attaches to a running regtest node, like the url lbrycrd.New takes. It defaults to $LBRYCRD_REGTEST_URL.
+	// If neither is set, a node is launched.
+	URL string
+	// Binary is the lbrycrdd to launch. It defaults to $LBRYCRDD, then lbrycrdd on the PATH.
+	Binary string
+	// Args are passed to a launched lbrycrdd after the ones Start sets
+	Args []string
+	// StartTimeout is how long to wait for a launched node to answer. It defaults to DefaultStartTimeout.
+	StartTimeout time.Duration
+}
+
+// Node is a regtest lbrycrd node. Its wallet has spendable coins, so it can fund the addresses tests use.
+type Node struct {
+	*lbrycrd.Client
+	URL string
+
+	cmd      *exec.Cmd // nil if the node was attached to
+	exited   chan struct{}
+	dataDir  string
+	mineAddr string
+}
+
+// Start launches a regtest node, or attaches to a running one if opts says to, and mines enough blocks for its
+// wallet to spend. Stop the node when done with it.
+func Start(opts Options) (*Node, error) {
+	if opts.URL == "" {
+		opts.URL = os.Getenv(URLEnv)
+	}
+	var n *Node
+	var err error
+	if opts.URL != "" {
+		n, err = attach(opts.URL)
+	} else {
+		n, err = launch(opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := n.setup(); err != nil {
+		n.Stop()
+		return nil, err
+	}
+	return n, nil
+}
+
+// TestingNode starts a node for a test, and skips the test if there is no lbrycrdd to launch or node to attach
+// to. Defer the node's Stop.
+func TestingNode(t *testing.T) *Node {
+	t.Helper()
+	if os.Getenv(URLEnv) == "" {
+		if _, err := exec.LookPath(binary("")); err != nil {
+			t.Skipf("no lbrycrdd found. set %s or %s to run this test", BinaryEnv, URLEnv)
+		}
+	}
+	n, err := Start(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func attach(lbrycrdURL string) (*Node, error) {
+	client, err := lbrycrd.New(lbrycrdURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{Client: client, URL: lbrycrdURL}, nil
+}
+
+func launch(opts Options) (*Node, error) {
+	if opts.StartTimeout <= 0 {
+		opts.StartTimeout = DefaultStartTimeout
+	}
+	rpcPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	p2pPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	dataDir, err := ioutil.TempDir("", "lbrycrd-regtest")
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+
+	args := append([]string{
+		"-regtest",
+		"-server",
+		"-datadir=" + dataDir,
+		"-rpcbind=127.0.0.1",
+		"-rpcallowip=127.0.0.1",
+		"-rpcport=" + strconv.Itoa(rpcPort),
+		"-port=" + strconv.Itoa(p2pPort),
+		"-listen=0",
+		"-txindex",
+		"-fallbackfee=0.0001",
+		"-printtoconsole=0",
+	}, opts.Args...)
+	cmd := exec.Command(binary(opts.Binary), args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dataDir)
+		return nil, errors.Prefix("starting lbrycrdd", err)
+	}
+	n := &Node{cmd: cmd, exited: make(chan struct{}), dataDir: dataDir}
+	go func() {
+		cmd.Wait()
+		close(n.exited)
+	}()
+
+	// lbrycrd authenticates with a cookie when no password is set. it writes the cookie once it is listening,
+	// and answers that it is warming up until it has loaded the chain.
+	host := "127.0.0.1:" + strconv.Itoa(rpcPort)
+	cookie := filepath.Join(dataDir, "regtest", ".cookie")
+	n.URL = "rpc://" + host + "?cookie=" + url.QueryEscape(cookie)
+	deadline := time.After(opts.StartTimeout)
+	for {
+		if _, statErr := os.Stat(cookie); statErr == nil {
+			n.Client, err = lbrycrd.NewWithCookie(host, cookie)
+			if err == nil {
+				return n, nil
+			}
+		}
+		select {
+		case <-n.exited:
+			os.RemoveAll(dataDir)
+			return nil, errors.Err("lbrycrdd exited: %s", bytes.TrimSpace(stderr.Bytes()))
+		case <-deadline:
+			n.Stop()
+			return nil, errors.Err("lbrycrdd did not answer within %s: %v", opts.StartTimeout, err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// setup mines to a wallet address until the wallet has coins it can spend
+func (n *Node) setup() error {
+	if err := n.call(&n.mineAddr, "getnewaddress", "", "legacy"); err != nil {
+		return err
+	}
+	height, err := n.Height()
+	if err != nil {
+		return err
+	}
+	if height <= coinbaseMaturity {
+		_, err = n.Generate(coinbaseMaturity + 1 - int(height))
+	}
+	return err
+}
+
+// Stop stops a launched node and removes its data. A node that was attached to is left running.
+func (n *Node) Stop() {
+	if n.Client != nil {
+		if n.cmd != nil {
+			n.call(nil, "stop")
+		}
+		n.Shutdown()
+	}
+	if n.cmd == nil {
+		return
+	}
+	if n.Client == nil {
+		// the node never answered, so it can't be asked to stop
+		n.cmd.Process.Kill()
+	}
+	select {
+	case <-n.exited:
+	case <-time.After(10 * time.Second):
+		n.cmd.Process.Kill()
+		<-n.exited
+	}
+	os.RemoveAll(n.dataDir)
+}
+
+// Params returns the regtest address params
+func (n *Node) Params() *chaincfg.Params {
+	params, _ := lbrycrd.GetChainParams(lbrycrd.LbrycrdRegtest)
+	return params
+}
+
+// Height returns the height of the best block
+func (n *Node) Height() (int64, error) {
+	var height int64
+	err := n.call(&height, "getblockcount")
+	return height, err
+}
+
+// Generate mines blocks and returns their hashes. The rewards go to the node's wallet.
+func (n *Node) Generate(blocks int) ([]*chainhash.Hash, error) {
+	var hashes []string
+	if err := n.call(&hashes, "generatetoaddress", blocks, n.mineAddr); err != nil {
+		return nil, err
+	}
+	result := make([]*chainhash.Hash, len(hashes))
+	for i, h := range hashes {
+		hash, err := chainhash.NewHashFromStr(h)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		result[i] = hash
+	}
+	return result, nil
+}
+
+// NewAddress returns a new P2PKH address from the node's wallet. Claims can be paid to it.
+func (n *Node) NewAddress() (btcutil.Address, error) {
+	var address string
+	if err := n.call(&address, "getnewaddress", "", "legacy"); err != nil {
+		return nil, err
+	}
+	return lbrycrd.DecodeAddress(address, n.Params())
+}
+
+// Fund sends amount from the node's wallet to address and mines a block to confirm it
+func (n *Node) Fund(address btcutil.Address, amount btcutil.Amount) (*chainhash.Hash, error) {
+	var txid string
+	if err := n.call(&txid, "sendtoaddress", address.EncodeAddress(), amount.ToBTC()); err != nil {
+		return nil, err
+	}
+	if _, err := n.Generate(1); err != nil {
+		return nil, err
+	}
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return hash, nil
+}
+
+// call sends a call through the btcd client. result can be nil to ignore what the call returns.
+func (n *Node) call(result interface{}, method string, params ...interface{}) error {
+	raw := make([]json.RawMessage, len(params))
+	for i, p := range params {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return errors.Err(err)
+		}
+		raw[i] = b
+	}
+	response, err := n.RawRequest(method, raw)
+	if err != nil {
+		return errors.Prefix(method, err)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response, result); err != nil {
+		return errors.Prefix("decoding "+method, err)
+	}
+	return nil
+}
+
+func binary(path string) string {
+	if path == "" {
+		path = os.Getenv(BinaryEnv)
+	}
+	if path == "" {
+		path = "lbrycrdd"
+	}
+	return path
+}
+
+// freePort returns a local port that nothing is listening on
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Err(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,168 @@
+package regtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestNode(t *testing.T) {
+	n := TestingNode(t)
+	defer n.Stop()
+
+	address, err := n.NewAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.Fund(address, btcutil.SatoshiPerBitcoin); err != nil {
+		t.Fatal(err)
+	}
+
+	claim, err := n.WalletClaimName("regtest", []byte("value"), btcutil.SatoshiPerBitcoin/10, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.Generate(1); err != nil {
+		t.Fatal(err)
+	}
+	claims, err := n.GetClaimsForName("regtest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims.Claims) != 1 || claims.Claims[0].ClaimID != claim.ClaimID {
+		t.Errorf("expected claim %s in the claimtrie, got %+v", claim.ClaimID, claims.Claims)
+	}
+
+	// clients made from the node's url reach the same node
+	c, err := lbrycrd.New(n.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Shutdown()
+	count, err := c.GetBlockCount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	height, err := n.Height()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != height {
+		t.Errorf("expected height %d, got %d", height, count)
+	}
+}
+
+func TestLaunchHelpers(t *testing.T) {
+	if b := binary("/opt/lbrycrdd"); b != "/opt/lbrycrdd" {
+		t.Errorf("expected the given binary, got %s", b)
+	}
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port <= 0 {
+		t.Errorf("expected a port, got %d", port)
+	}
+}
+
+// fakeNode answers the calls a Node makes, keeping a chain of fake block hashes
+type fakeNode struct {
+	mu     sync.Mutex
+	height int
+	sent   map[string]float64
+}
+
+func (f *fakeNode) handle(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			ID     interface{}       `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var result interface{}
+		switch req.Method {
+		case "getblockchaininfo":
+			result = map[string]interface{}{"chain": "regtest", "blocks": f.height}
+		case "getnewaddress":
+			result = "mfWxJ45yp2SFn7UciZyNpvDKrzbhyfKrY8"
+		case "getblockcount":
+			result = f.height
+		case "generatetoaddress":
+			var blocks int
+			json.Unmarshal(req.Params[0], &blocks)
+			hashes := make([]string, blocks)
+			for i := range hashes {
+				f.height++
+				hashes[i] = chainhash.Hash{byte(f.height)}.String()
+			}
+			result = hashes
+		case "sendtoaddress":
+			var address string
+			var amount float64
+			json.Unmarshal(req.Params[0], &address)
+			json.Unmarshal(req.Params[1], &amount)
+			f.sent[address] += amount
+			result = chainhash.Hash{0xff}.String()
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": result, "error": nil, "id": req.ID})
+	}
+}
+
+func TestStart_Attach(t *testing.T) {
+	f := &fakeNode{height: 5, sent: map[string]float64{}}
+	server := httptest.NewServer(f.handle(t))
+	defer server.Close()
+
+	n, err := Start(Options{URL: "rpc://user:pass@" + strings.TrimPrefix(server.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Stop()
+	height, err := n.Height()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != coinbaseMaturity+1 {
+		t.Errorf("expected blocks to be mined up to %d, got %d", coinbaseMaturity+1, height)
+	}
+
+	address, err := n.NewAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	txid, err := n.Fund(address, btcutil.SatoshiPerBitcoin/2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *txid != (chainhash.Hash{0xff}) {
+		t.Errorf("unexpected txid %s", txid)
+	}
+	if f.sent[address.EncodeAddress()] != 0.5 {
+		t.Errorf("expected 0.5 to be sent to %s, got %v", address, f.sent)
+	}
+	if height, _ := n.Height(); height != coinbaseMaturity+2 {
+		t.Errorf("expected a block to confirm the funding, got height %d", height)
+	}
+
+	hashes, err := n.Generate(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != 3 || *hashes[2] != (chainhash.Hash{byte(coinbaseMaturity + 5)}) {
+		t.Errorf("unexpected block hashes %v", hashes)
+	}
+}
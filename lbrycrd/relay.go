@@ -0,0 +1,79 @@
+package lbrycrd
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/rpcclient"
+)
+
+// relay takes the btcd client's calls on a loopback address and sends them on with Client.post. The btcd client
+// only takes a host and credentials when it's created, so relaying its calls is how its methods use the
+// configured http client and read a new cookie when lbrycrd restarts, like the calls this package sends itself.
+type relay struct {
+	listener net.Listener
+	// pass is random, so nothing but the btcd client can send calls through the relay
+	pass string
+}
+
+const relayUser = "lbrycrd"
+
+// startRelay starts relaying calls to c's node
+func startRelay(c *Client) (*relay, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.Err(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	r := &relay{listener: listener, pass: hex.EncodeToString(b)}
+	// Serve returns once the relay is closed
+	go http.Serve(listener, r.handler(c))
+	return r, nil
+}
+
+// config is the connection config for a btcd client that sends its calls through the relay
+func (r *relay) config() *rpcclient.ConnConfig {
+	return &rpcclient.ConnConfig{
+		Host:         r.listener.Addr().String(),
+		User:         relayUser,
+		Pass:         r.pass,
+		HTTPPostMode: true, // Bitcoin core only supports HTTP POST mode
+		DisableTLS:   true, // the relay is on the loopback address. calls to the node use the client's tls config.
+	}
+}
+
+func (r *relay) handler(c *Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != relayUser || subtle.ConstantTimeCompare([]byte(pass), []byte(r.pass)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response, status, err := c.post(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(response)
+	})
+}
+
+func (r *relay) close() {
+	r.listener.Close()
+}
@@ -0,0 +1,86 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbryschema.go/claim"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// ClaimIDLength is the length of a full claim ID in hex
+const ClaimIDLength = 40
+
+var ErrInvalidClaimID = errors.Base("claim ID must be 40 hex characters")
+
+// NewRepostClaim creates an unsigned claim that reposts the claim with the given ID
+func NewRepostClaim(claimID string) (*c.ClaimHelper, error) {
+	claimHash, err := ClaimHashFromID(claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	repost := new(pb.Claim_Repost)
+	repost.Repost = &pb.ClaimReference{ClaimHash: claimHash}
+
+	pbClaim := new(pb.Claim)
+	pbClaim.Type = repost
+
+	helper := c.ClaimHelper{Claim: pbClaim}
+	helper.Version = c.NoSig
+
+	return &helper, nil
+}
+
+// NewRepostClaimFromURL creates an unsigned claim that reposts the claim at the given URL. The URL must
+// contain the full claim ID (e.g. lbry://name#claimid), since short IDs can only be resolved by a daemon.
+func NewRepostClaimFromURL(url string) (*c.ClaimHelper, error) {
+	path := strings.TrimPrefix(url, "lbry://")
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		path = path[i+1:]
+	}
+
+	i := strings.LastIndexAny(path, "#:")
+	if i < 0 {
+		return nil, errors.Err("url %s does not contain a claim ID", url)
+	}
+
+	return NewRepostClaim(path[i+1:])
+}
+
+// RepostedClaimID returns the ID of the claim that a repost claim points to
+func RepostedClaimID(claim *c.ClaimHelper) (string, error) {
+	err := ValidateRepost(claim)
+	if err != nil {
+		return "", err
+	}
+	return ClaimIDFromHash(claim.GetRepost().GetClaimHash()), nil
+}
+
+// ValidateRepost checks that the claim is a repost and that it references a well-formed claim ID
+func ValidateRepost(claim *c.ClaimHelper) error {
+	if claim == nil || claim.Claim == nil {
+		return errors.Err("claim is empty")
+	}
+	repost := claim.GetRepost()
+	if repost == nil {
+		return errors.Err("claim is not a repost")
+	}
+	if len(repost.GetClaimHash()) != ClaimIDLength/2 {
+		return errors.Err(ErrInvalidClaimID)
+	}
+	return nil
+}
+
+// decodeClaimID decodes a hex claim ID, keeping the display byte order
+func decodeClaimID(claimID string) ([]byte, error) {
+	if len(claimID) != ClaimIDLength {
+		return nil, errors.Err(ErrInvalidClaimID)
+	}
+	claimIDBytes, err := hex.DecodeString(claimID)
+	if err != nil {
+		return nil, errors.Err(ErrInvalidClaimID)
+	}
+	return claimIDBytes, nil
+}
@@ -0,0 +1,48 @@
+package lbrycrd
+
+import (
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+func TestNewRepostClaim(t *testing.T) {
+	claimID := "2bfbc5be8c5fe8ebd39e4b49c8a8f04a8b6d8b5c"
+	repost, err := NewRepostClaim(claimID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposted, err := RepostedClaimID(repost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reposted != claimID {
+		t.Errorf("expected %s, got %s", claimID, reposted)
+	}
+
+	fromURL, err := NewRepostClaimFromURL("lbry://@channel#1/video#" + claimID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposted, err = RepostedClaimID(fromURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reposted != claimID {
+		t.Errorf("expected %s, got %s", claimID, reposted)
+	}
+}
+
+func TestNewRepostClaim_InvalidClaimID(t *testing.T) {
+	for _, claimID := range []string{"", "2bfbc5be", "zzfbc5be8c5fe8ebd39e4b49c8a8f04a8b6d8b5c", "2bfbc5be8c5fe8ebd39e4b49c8a8f04a8b6d8b5c00"} {
+		_, err := NewRepostClaim(claimID)
+		if !errors.Is(err, ErrInvalidClaimID) {
+			t.Errorf("%q: expected ErrInvalidClaimID, got %v", claimID, err)
+		}
+	}
+
+	_, err := NewRepostClaimFromURL("lbry://video")
+	if err == nil {
+		t.Error("expected an error for a url without a claim ID")
+	}
+}
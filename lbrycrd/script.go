@@ -1,76 +1,292 @@
 package lbrycrd
 
 import (
-	"encoding/hex"
+	"encoding/binary"
 
 	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbryschema.go/claim"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 )
 
-func getClaimSupportPayoutScript(name, claimid string, address btcutil.Address) ([]byte, error) {
-	//OP_SUPPORT_CLAIM <name> <claimid> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+// claim script opcodes, which lbrycrd took from the unused NOPs
+const (
+	opClaimName    = txscript.OP_NOP6
+	opSupportClaim = txscript.OP_NOP7
+	opUpdateClaim  = txscript.OP_NOP8
+)
 
-	pkscript, err := txscript.PayToAddrScript(address)
-	if err != nil {
-		return nil, errors.Err(err)
-	}
+// ClaimScript is a claim, update or support output script taken apart
+type ClaimScript struct {
+	Type     ScriptType
+	Name     string
+	ClaimID  string // the claim updated or supported, empty for ClaimName
+	Value    []byte // the claim value, or the support payload if the support has one
+	PkScript []byte // the script that follows the claim, which pays to the owner's address
+}
+
+// ClaimNameScript builds the script of an output that creates a claim
+func ClaimNameScript(name string, value []byte, address btcutil.Address) ([]byte, error) {
+	//OP_CLAIM_NAME <name> <value> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 
-	bytes, err := hex.DecodeString(claimid)
+	pkscript, err := txscript.PayToAddrScript(address)
 	if err != nil {
 		return nil, errors.Err(err)
 	}
 
 	return txscript.NewScriptBuilder().
-		AddOp(txscript.OP_NOP7).  //OP_SUPPORT_CLAIM
+		AddOp(opClaimName).       //OP_CLAIMNAME
 		AddData([]byte(name)).    //<name>
-		AddData(rev(bytes)).      //<claimid>
+		AddData(value).           //<value>
 		AddOp(txscript.OP_2DROP). //OP_2DROP
 		AddOp(txscript.OP_DROP).  //OP_DROP
 		AddOps(pkscript).         //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 		Script()
-
 }
 
-func getClaimNamePayoutScript(name string, value []byte, address btcutil.Address) ([]byte, error) {
-	//OP_CLAIM_NAME <name> <value> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+// UpdateClaimScript builds the script of an output that updates a claim
+func UpdateClaimScript(name, claimID string, value []byte, address btcutil.Address) ([]byte, error) {
+	//OP_UPDATE_CLAIM <name> <claimid> <value> OP_2DROP OP_2DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 
 	pkscript, err := txscript.PayToAddrScript(address)
 	if err != nil {
 		return nil, errors.Err(err)
 	}
 
+	claimHash, err := ClaimHashFromID(claimID)
+	if err != nil {
+		return nil, err
+	}
+
 	return txscript.NewScriptBuilder().
-		AddOp(txscript.OP_NOP6).  //OP_CLAIMNAME
+		AddOp(opUpdateClaim).     //OP_UPDATE_CLAIM
 		AddData([]byte(name)).    //<name>
+		AddData(claimHash).       //<claimid>
 		AddData(value).           //<value>
 		AddOp(txscript.OP_2DROP). //OP_2DROP
-		AddOp(txscript.OP_DROP).  //OP_DROP
+		AddOp(txscript.OP_2DROP). //OP_2DROP
 		AddOps(pkscript).         //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 		Script()
 }
 
-func getUpdateClaimPayoutScript(name, claimid string, value []byte, address btcutil.Address) ([]byte, error) {
-	//OP_UPDATE_CLAIM <name> <claimid> <value> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+// SupportClaimScript builds the script of an output that supports a claim. payload is the serialized
+// SupportPayload, or nil for a support without one.
+func SupportClaimScript(name, claimID string, payload []byte, address btcutil.Address) ([]byte, error) {
+	//OP_SUPPORT_CLAIM <name> <claimid> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+	//or with a payload
+	//OP_SUPPORT_CLAIM <name> <claimid> <payload> OP_2DROP OP_2DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 
 	pkscript, err := txscript.PayToAddrScript(address)
 	if err != nil {
 		return nil, errors.Err(err)
 	}
 
-	bytes, err := hex.DecodeString(claimid)
+	claimHash, err := ClaimHashFromID(claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := txscript.NewScriptBuilder().
+		AddOp(opSupportClaim). //OP_SUPPORT_CLAIM
+		AddData([]byte(name)). //<name>
+		AddData(claimHash)     //<claimid>
+	if payload == nil {
+		builder.AddOp(txscript.OP_2DROP).AddOp(txscript.OP_DROP)
+	} else {
+		builder.AddData(payload).AddOp(txscript.OP_2DROP).AddOp(txscript.OP_2DROP)
+	}
+	return builder.AddOps(pkscript).Script() //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+}
+
+// IsClaimScript returns true if the script is a claim, update or support script
+func IsClaimScript(script []byte) bool {
+	_, err := ParseClaimScript(script)
+	return err == nil
+}
+
+// ParseClaimScript takes apart a claim, update or support script
+func ParseClaimScript(script []byte) (*ClaimScript, error) {
+	if len(script) == 0 {
+		return nil, errors.Err("script is empty")
+	}
+
+	s := &ClaimScript{}
+	var pushes int
+	switch script[0] {
+	case opClaimName:
+		s.Type, pushes = ClaimName, 2
+	case opUpdateClaim:
+		s.Type, pushes = ClaimUpdate, 3
+	case opSupportClaim:
+		s.Type, pushes = ClaimSupport, 2
+	default:
+		return nil, errors.Err("script is not a claim script")
+	}
+
+	var data [3][]byte
+	rest := script[1:]
+	for i := 0; i < pushes; i++ {
+		var ok bool
+		data[i], rest, ok = readPush(rest)
+		if !ok {
+			return nil, errors.Err("claim script is missing data")
+		}
+	}
+	// supports may carry a payload
+	if s.Type == ClaimSupport && len(rest) > 0 && rest[0] != txscript.OP_2DROP {
+		var ok bool
+		data[2], rest, ok = readPush(rest)
+		if !ok {
+			return nil, errors.Err("claim script is missing data")
+		}
+		pushes++
+	}
+
+	// the claim data is dropped before the rest of the script runs: OP_2DROP OP_DROP for two pushes,
+	// OP_2DROP OP_2DROP for three
+	drop := byte(txscript.OP_DROP)
+	if pushes == 3 {
+		drop = txscript.OP_2DROP
+	}
+	if len(rest) < 2 || rest[0] != txscript.OP_2DROP || rest[1] != drop {
+		return nil, errors.Err("claim script does not drop its data")
+	}
+	s.PkScript = rest[2:]
+
+	s.Name = string(data[0])
+	if s.Type == ClaimName {
+		s.Value = data[1]
+		return s, nil
+	}
+	if len(data[1]) != ClaimIDLength/2 {
+		return nil, errors.Err(ErrInvalidClaimID)
+	}
+	s.ClaimID = ClaimIDFromHash(data[1])
+	s.Value = data[2]
+	return s, nil
+}
+
+// DecodedScript is an output script taken apart. Claim is the claim, update or support at the start of the
+// script, or nil if the output isn't in the claimtrie. The rest describes the standard script that pays the
+// output's owner, which is the script after the claim, or the whole script if there is no claim.
+type DecodedScript struct {
+	Claim     *ClaimScript
+	PkScript  []byte
+	Class     txscript.ScriptClass
+	ReqSigs   int
+	Addresses []btcutil.Address
+}
+
+// DecodeScript takes apart any output script. A script that starts with a claim opcode but isn't a valid claim
+// is left whole and is nonstandard, as it is to lbrycrd.
+func DecodeScript(script []byte, params *chaincfg.Params) *DecodedScript {
+	d := &DecodedScript{PkScript: script}
+	if len(script) > 0 && (script[0] == opClaimName || script[0] == opUpdateClaim || script[0] == opSupportClaim) {
+		claim, err := ParseClaimScript(script)
+		if err != nil {
+			d.Class = txscript.NonStandardTy
+			return d
+		}
+		d.Claim, d.PkScript = claim, claim.PkScript
+	}
+
+	class, addresses, reqSigs, err := txscript.ExtractPkScriptAddrs(d.PkScript, params)
+	if err != nil {
+		d.Class = txscript.NonStandardTy
+		return d
+	}
+	d.Class, d.Addresses, d.ReqSigs = class, addresses, reqSigs
+	return d
+}
+
+// readPush reads one data push from the start of a script
+func readPush(script []byte) (data, rest []byte, ok bool) {
+	if len(script) == 0 {
+		return nil, nil, false
+	}
+	op := script[0]
+	script = script[1:]
+
+	var length int
+	switch {
+	case op == txscript.OP_0:
+		return []byte{}, script, true
+	case op >= txscript.OP_DATA_1 && op <= txscript.OP_DATA_75:
+		length = int(op)
+	case op == txscript.OP_PUSHDATA1 && len(script) >= 1:
+		length, script = int(script[0]), script[1:]
+	case op == txscript.OP_PUSHDATA2 && len(script) >= 2:
+		length, script = int(binary.LittleEndian.Uint16(script)), script[2:]
+	case op == txscript.OP_PUSHDATA4 && len(script) >= 4:
+		length, script = int(binary.LittleEndian.Uint32(script)), script[4:]
+	default:
+		return nil, nil, false
+	}
+	if length < 0 || length > len(script) {
+		return nil, nil, false
+	}
+	return script[:length], script[length:], true
+}
+
+// Claim decodes the claim of a ClaimName or ClaimUpdate script
+func (s *ClaimScript) Claim(blockchainName string) (*c.ClaimHelper, error) {
+	if s.Type == ClaimSupport {
+		return nil, errors.Err("script is a support")
+	}
+	return c.DecodeClaimBytes(s.Value, blockchainName)
+}
+
+// SupportPayload decodes the payload of a ClaimSupport script, or returns nil if the support has none
+func (s *ClaimScript) SupportPayload() (*SupportPayload, error) {
+	if s.Type != ClaimSupport {
+		return nil, errors.Err("script is not a support")
+	}
+	if s.Value == nil {
+		return nil, nil
+	}
+	return DecodeSupportPayload(s.Value)
+}
+
+// Address returns the address the output pays to
+func (s *ClaimScript) Address(params *chaincfg.Params) (btcutil.Address, error) {
+	_, addresses, _, err := txscript.ExtractPkScriptAddrs(s.PkScript, params)
 	if err != nil {
 		return nil, errors.Err(err)
 	}
+	if len(addresses) != 1 {
+		return nil, errors.Err("claim script does not pay to a single address")
+	}
+	return addresses[0], nil
+}
 
-	return txscript.NewScriptBuilder().
-		AddOp(txscript.OP_NOP8).  //OP_UPDATE_CLAIM
-		AddData([]byte(name)).    //<name>
-		AddData(rev(bytes)).      //<claimid>
-		AddData(value).           //<value>
-		AddOp(txscript.OP_2DROP). //OP_2DROP
-		AddOp(txscript.OP_DROP).  //OP_DROP
-		AddOps(pkscript).         //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
-		Script()
+// AddClaimToTx adds an output that claims the name with the claim
+func AddClaimToTx(rawTx *wire.MsgTx, name string, claim *c.ClaimHelper, amount btcutil.Amount, address btcutil.Address) error {
+	value, err := claim.CompileValue()
+	if err != nil {
+		return errors.Err(err)
+	}
+	script, err := ClaimNameScript(name, value, address)
+	if err != nil {
+		return err
+	}
+	rawTx.AddTxOut(wire.NewTxOut(int64(amount), script))
+	return nil
+}
+
+// AddClaimUpdateToTx adds an output that updates the claim with claimID. The transaction must also spend the
+// claim's current output.
+func AddClaimUpdateToTx(rawTx *wire.MsgTx, name, claimID string, claim *c.ClaimHelper, amount btcutil.Amount, address btcutil.Address) error {
+	value, err := claim.CompileValue()
+	if err != nil {
+		return errors.Err(err)
+	}
+	script, err := UpdateClaimScript(name, claimID, value, address)
+	if err != nil {
+		return err
+	}
+	rawTx.AddTxOut(wire.NewTxOut(int64(amount), script))
+	return nil
 }
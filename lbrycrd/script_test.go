@@ -0,0 +1,202 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestClaimScripts(t *testing.T) {
+	address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := NewStreamClaim("title", "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := claim.CompileValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := (&SupportPayload{Emoji: "🚀"}).Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := AddClaimToTx(tx, "name", claim, btcutil.Amount(1), address); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddClaimUpdateToTx(tx, "name", testChannelClaimID, claim, btcutil.Amount(1), address); err != nil {
+		t.Fatal(err)
+	}
+	supportScript, err := SupportClaimScript("name", testChannelClaimID, nil, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.AddTxOut(wire.NewTxOut(1, supportScript))
+	if err := AddSupportWithPayloadToTx(tx, "name", testChannelClaimID, &SupportPayload{Emoji: "🚀"}, btcutil.Amount(1), address); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ClaimScript{
+		{Type: ClaimName, Name: "name", Value: value},
+		{Type: ClaimUpdate, Name: "name", ClaimID: testChannelClaimID, Value: value},
+		{Type: ClaimSupport, Name: "name", ClaimID: testChannelClaimID},
+		{Type: ClaimSupport, Name: "name", ClaimID: testChannelClaimID, Value: payload},
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, out := range tx.TxOut {
+		s, err := ParseClaimScript(out.PkScript)
+		if err != nil {
+			t.Fatalf("output %d: %v", i, err)
+		}
+		e := expected[i]
+		if s.Type != e.Type || s.Name != e.Name || s.ClaimID != e.ClaimID || !bytes.Equal(s.Value, e.Value) {
+			t.Errorf("output %d: expected %+v, got %+v", i, e, s)
+		}
+		if !bytes.Equal(s.PkScript, pkScript) {
+			t.Errorf("output %d: expected pk script %x, got %x", i, pkScript, s.PkScript)
+		}
+		payTo, err := s.Address(&mainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if payTo.EncodeAddress() != address.EncodeAddress() {
+			t.Errorf("output %d: expected address %s, got %s", i, address, payTo)
+		}
+	}
+
+	decoded, err := ParseClaimScript(tx.TxOut[0].PkScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedClaim, err := decoded.Claim(LbrycrdMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedClaim.GetTitle() != "title" {
+		t.Errorf("expected title %q, got %q", "title", decodedClaim.GetTitle())
+	}
+	if _, err := decoded.SupportPayload(); err == nil {
+		t.Error("expected an error for the support payload of a claim")
+	}
+}
+
+func TestParseClaimScript_Invalid(t *testing.T) {
+	address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := ClaimNameScript("name", []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, script := range map[string][]byte{
+		"empty":          nil,
+		"pay to address": pkScript,
+		"truncated":      valid[:8],
+		"missing drops":  append([]byte{txscript.OP_NOP6, 1, 'a', 1, 'b'}, pkScript...),
+		"wrong drops":    append([]byte{txscript.OP_NOP8, 1, 'a', 1, 'b', 1, 'c', txscript.OP_2DROP, txscript.OP_DROP}, pkScript...),
+		"short claim id": append([]byte{txscript.OP_NOP7, 1, 'a', 1, 'b', txscript.OP_2DROP, txscript.OP_DROP}, pkScript...),
+	} {
+		if _, err := ParseClaimScript(script); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if IsClaimScript(script) {
+			t.Errorf("%s: IsClaimScript returned true", name)
+		}
+	}
+	if !IsClaimScript(valid) {
+		t.Error("IsClaimScript returned false for a claim script")
+	}
+}
+
+func TestDecodeScript(t *testing.T) {
+	address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptHash, err := btcutil.NewAddressScriptHashFromHash(make([]byte, 20), &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2shScript, err := txscript.PayToAddrScript(scriptHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimScript, err := ClaimNameScript("name", []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateScript, err := UpdateClaimScript("name", testChannelClaimID, []byte("value"), scriptHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supportScript, err := SupportClaimScript("name", testChannelClaimID, []byte("payload"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nullData, err := txscript.NullDataScript([]byte("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		script    []byte
+		claimType ScriptType
+		claim     bool
+		class     txscript.ScriptClass
+		address   btcutil.Address
+		pkScript  []byte
+	}{
+		{name: "pay to address", script: pkScript, class: txscript.PubKeyHashTy, address: address, pkScript: pkScript},
+		{name: "pay to script hash", script: p2shScript, class: txscript.ScriptHashTy, address: scriptHash, pkScript: p2shScript},
+		{name: "claim", script: claimScript, claim: true, claimType: ClaimName, class: txscript.PubKeyHashTy, address: address, pkScript: pkScript},
+		{name: "update to script hash", script: updateScript, claim: true, claimType: ClaimUpdate, class: txscript.ScriptHashTy, address: scriptHash, pkScript: p2shScript},
+		{name: "support with payload", script: supportScript, claim: true, claimType: ClaimSupport, class: txscript.PubKeyHashTy, address: address, pkScript: pkScript},
+		{name: "null data", script: nullData, class: txscript.NullDataTy, pkScript: nullData},
+		{name: "invalid claim", script: claimScript[:8], class: txscript.NonStandardTy, pkScript: claimScript[:8]},
+		{name: "unparsable", script: []byte{txscript.OP_DATA_5, 1}, class: txscript.NonStandardTy, pkScript: []byte{txscript.OP_DATA_5, 1}},
+		{name: "empty", script: nil, class: txscript.NonStandardTy},
+	}
+	for _, test := range tests {
+		d := DecodeScript(test.script, &mainNetParams)
+		if (d.Claim != nil) != test.claim {
+			t.Errorf("%s: expected claim %v, got %+v", test.name, test.claim, d.Claim)
+		} else if test.claim && (d.Claim.Type != test.claimType || d.Claim.Name != "name") {
+			t.Errorf("%s: unexpected claim %+v", test.name, d.Claim)
+		}
+		if d.Class != test.class {
+			t.Errorf("%s: expected class %s, got %s", test.name, test.class, d.Class)
+		}
+		if !bytes.Equal(d.PkScript, test.pkScript) {
+			t.Errorf("%s: expected script %x, got %x", test.name, test.pkScript, d.PkScript)
+		}
+		if test.address == nil {
+			if len(d.Addresses) != 0 {
+				t.Errorf("%s: expected no addresses, got %v", test.name, d.Addresses)
+			}
+		} else if len(d.Addresses) != 1 || d.Addresses[0].EncodeAddress() != test.address.EncodeAddress() || d.ReqSigs != 1 {
+			t.Errorf("%s: expected to pay %s, got %v with %d sigs", test.name, test.address, d.Addresses, d.ReqSigs)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package lbrycrd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+	"math/big"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbryschema.go/claim"
+	legacy_pb "github.com/lbryio/types/v1/go"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/protobuf/proto"
+)
+
+// SignatureScheme identifies the digest rules used to sign a claim with a channel key
+type SignatureScheme int
+
+const (
+	// SchemeUnknown means no scheme was identified
+	SchemeUnknown SignatureScheme = iota
+	// SchemeLegacy is used by legacy (v1 protobuf) claims. The digest is the sha256 of the decoded claim
+	// address, the claim without its signature, and the channel claim ID in display byte order.
+	SchemeLegacy
+	// SchemeCurrent is used by v2 claims. The digest is the sha256 of the first input's outpoint, the channel
+	// claim hash (the claim ID reversed), and the claim protobuf.
+	SchemeCurrent
+)
+
+func (s SignatureScheme) String() string {
+	switch s {
+	case SchemeLegacy:
+		return "legacy"
+	case SchemeCurrent:
+		return "current"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrSignatureMismatch means a claim signature did not verify under any known digest scheme
+var ErrSignatureMismatch = errors.Base("claim signature does not match the channel key under any known scheme")
+
+// ErrUnsupportedKeyType means a channel key is on a curve, or used with a claim format, that claim signatures
+// cannot be made with
+var ErrUnsupportedKeyType = errors.Base("unsupported channel key type")
+
+// SignClaimWithChannel signs a v2 claim with a channel's private key and returns the signed claim value: the
+// version byte, the channel claim hash, the 64-byte signature and the claim protobuf. firstInput must be the
+// first input of the transaction that will contain the claim. The claim is updated with the signature.
+func SignClaimWithChannel(claim *c.ClaimHelper, channelKey *btcec.PrivateKey, channelClaimID string, firstInput wire.OutPoint) ([]byte, error) {
+	if claim == nil || claim.Claim == nil {
+		return nil, errors.Err("claim is empty")
+	}
+	if claim.LegacyClaim != nil {
+		return nil, errors.Err("legacy claims must be signed with SignLegacyClaimWithChannel")
+	}
+	channelHash, err := ClaimHashFromID(channelClaimID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := unsignedPayload(claim)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := SignDigest(channelKey, claimSignatureDigest(firstInput, channelHash, payload))
+	if err != nil {
+		return nil, err
+	}
+
+	claim.Version = c.WithSig
+	claim.ClaimID = channelHash
+	claim.Signature = signature
+	claim.Payload = payload
+
+	value := make([]byte, 0, 1+len(channelHash)+len(signature)+len(payload))
+	value = append(value, byte(c.WithSig))
+	value = append(value, channelHash...)
+	value = append(value, signature...)
+	value = append(value, payload...)
+	return value, nil
+}
+
+// SignLegacyClaimWithChannel signs a legacy (v1 protobuf) claim with a channel's private key and returns the
+// serialized legacy claim with its publisher signature set. claimAddress is the address the claim is sent to.
+func SignLegacyClaimWithChannel(claim *c.ClaimHelper, channelKey *btcec.PrivateKey, channelClaimID, claimAddress string) ([]byte, error) {
+	if claim == nil || claim.LegacyClaim == nil {
+		return nil, errors.Err("claim is not a legacy claim")
+	}
+	channelID, err := decodeClaimID(channelClaimID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := decodeAddressBytes(claimAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyClaim, payload, err := legacyUnsignedPayload(claim)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := SignDigest(channelKey, legacyClaimSignatureDigest(sha256.New(), address, payload, channelID))
+	if err != nil {
+		return nil, err
+	}
+
+	version := legacy_pb.Signature__0_0_1
+	keyType := legacy_pb.KeyType_SECP256k1
+	legacyClaim.PublisherSignature = &legacy_pb.Signature{
+		Version:       &version,
+		SignatureType: &keyType,
+		Signature:     signature,
+		CertificateId: channelID,
+	}
+	value, err := proto.Marshal(legacyClaim)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+
+	claim.LegacyClaim = legacyClaim
+	claim.Version = c.WithSig
+	claim.ClaimID = channelID
+	claim.Signature = signature
+
+	return value, nil
+}
+
+// claimSignatureDigest computes the digest for SchemeCurrent
+func claimSignatureDigest(firstInput wire.OutPoint, channelHash, payload []byte) []byte {
+	nout := make([]byte, 4)
+	binary.LittleEndian.PutUint32(nout, firstInput.Index)
+
+	h := sha256.New()
+	h.Write(firstInput.Hash[:])
+	h.Write(nout)
+	h.Write(channelHash)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// legacyClaimSignatureDigest computes the digest for SchemeLegacy with h, which is sha256 for every curve except
+// NIST P-384, which uses sha384
+func legacyClaimSignatureDigest(h hash.Hash, address, payload, channelID []byte) []byte {
+	h.Write(address)
+	h.Write(payload)
+	h.Write(channelID)
+	return h.Sum(nil)
+}
+
+// unsignedPayload returns the protobuf bytes of a v2 claim, without the version byte or any signature
+func unsignedPayload(claim *c.ClaimHelper) ([]byte, error) {
+	unsigned := *claim
+	unsigned.Version = c.NoSig
+	value, err := unsigned.CompileValue()
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return value[1:], nil
+}
+
+// legacyUnsignedPayload returns a copy of a legacy claim without its publisher signature, and its serialized bytes
+func legacyUnsignedPayload(claim *c.ClaimHelper) (*legacy_pb.Claim, []byte, error) {
+	legacyClaim := proto.Clone(claim.LegacyClaim).(*legacy_pb.Claim)
+	legacyClaim.PublisherSignature = nil
+	payload, err := proto.Marshal(legacyClaim)
+	if err != nil {
+		return nil, nil, errors.Err(err)
+	}
+	return legacyClaim, payload, nil
+}
+
+// decodeAddressBytes returns the full base58-decoded address (version byte, hash and checksum)
+func decodeAddressBytes(address string) ([]byte, error) {
+	_, _, err := base58.CheckDecode(address)
+	if err != nil {
+		return nil, errors.Prefix("invalid claim address", err)
+	}
+	decoded := base58.Decode(address)
+	if len(decoded) != 25 {
+		return nil, errors.Err("invalid claim address length %d", len(decoded))
+	}
+	return decoded, nil
+}
+
+// VerifySignature checks a signed claim against its channel's public key and returns the scheme the signature
+// was made with. firstInput is the first input of the claim's transaction, needed for SchemeCurrent, and
+// claimAddress is the address the claim was sent to, needed for SchemeLegacy. The data for schemes that
+// cannot apply to the claim may be left empty.
+func VerifySignature(claim *c.ClaimHelper, channelPublicKey *btcec.PublicKey, firstInput wire.OutPoint, claimAddress string) (SignatureScheme, error) {
+	if channelPublicKey == nil {
+		return SchemeUnknown, errors.Err("channel public key is missing")
+	}
+	return VerifyChannelSignature(claim, channelPublicKey.ToECDSA(), firstInput, claimAddress)
+}
+
+// VerifyChannelSignature is VerifySignature for a channel key on any curve ParseChannelPublicKey supports.
+// Legacy claims from NIST P-256 and P-384 channels are signed with the compact R||S values, over a sha256 or
+// sha384 digest respectively. v2 claims can only be signed with secp256k1 keys, so they fail with
+// ErrUnsupportedKeyType under any other key.
+func VerifyChannelSignature(claim *c.ClaimHelper, channelPublicKey *ecdsa.PublicKey, firstInput wire.OutPoint, claimAddress string) (SignatureScheme, error) {
+	if claim == nil || (claim.Claim == nil && claim.LegacyClaim == nil) {
+		return SchemeUnknown, errors.Err("claim is empty")
+	}
+	if channelPublicKey == nil || channelPublicKey.Curve == nil {
+		return SchemeUnknown, errors.Err("channel public key is missing")
+	}
+	if claim.Version != c.WithSig || len(claim.Signature) == 0 {
+		return SchemeUnknown, errors.Err("claim is not signed")
+	}
+
+	switch channelPublicKey.Curve.Params() {
+	case btcec.S256().Params():
+		return verifySecp256k1Signature(claim, (*btcec.PublicKey)(channelPublicKey), firstInput, claimAddress)
+	case elliptic.P256().Params(), elliptic.P384().Params():
+		if claim.LegacyClaim == nil {
+			return SchemeUnknown, errors.Prefix("v2 claims must be signed with secp256k1 keys", ErrUnsupportedKeyType)
+		}
+		return verifyNISTLegacySignature(claim, channelPublicKey, claimAddress)
+	default:
+		return SchemeUnknown, errors.Err(ErrUnsupportedKeyType)
+	}
+}
+
+// verifyNISTLegacySignature checks a legacy claim signed with a NIST P-256 or P-384 channel key
+func verifyNISTLegacySignature(claim *c.ClaimHelper, channelPublicKey *ecdsa.PublicKey, claimAddress string) (SignatureScheme, error) {
+	params := channelPublicKey.Curve.Params()
+	size := (params.BitSize + 7) / 8
+	if len(claim.Signature) != 2*size {
+		return SchemeUnknown, errors.Err("claim is not signed")
+	}
+	h := sha256.New()
+	if params.BitSize == 384 {
+		h = sha512.New384()
+	}
+
+	address, err := decodeAddressBytes(claimAddress)
+	if err != nil {
+		return SchemeUnknown, err
+	}
+	_, payload, err := legacyUnsignedPayload(claim)
+	if err != nil {
+		return SchemeUnknown, err
+	}
+	r := new(big.Int).SetBytes(claim.Signature[:size])
+	s := new(big.Int).SetBytes(claim.Signature[size:])
+	if ecdsa.Verify(channelPublicKey, legacyClaimSignatureDigest(h, address, payload, claim.ClaimID), r, s) {
+		return SchemeLegacy, nil
+	}
+	return SchemeUnknown, errors.Err(ErrSignatureMismatch)
+}
+
+// verifySecp256k1Signature checks a legacy or v2 claim signed with a secp256k1 channel key
+func verifySecp256k1Signature(claim *c.ClaimHelper, channelPublicKey *btcec.PublicKey, firstInput wire.OutPoint, claimAddress string) (SignatureScheme, error) {
+	if len(claim.Signature) != CompactSignatureLength {
+		return SchemeUnknown, errors.Err("claim is not signed")
+	}
+
+	sig, err := ParseSignature(claim.Signature)
+	if err != nil {
+		return SchemeUnknown, err
+	}
+
+	if claim.LegacyClaim != nil {
+		address, err := decodeAddressBytes(claimAddress)
+		if err != nil {
+			return SchemeUnknown, err
+		}
+		_, payload, err := legacyUnsignedPayload(claim)
+		if err != nil {
+			return SchemeUnknown, err
+		}
+		// legacy claims store the channel claim ID in display byte order
+		if sig.Verify(legacyClaimSignatureDigest(sha256.New(), address, payload, claim.ClaimID), channelPublicKey) {
+			return SchemeLegacy, nil
+		}
+		return SchemeUnknown, errors.Err(ErrSignatureMismatch)
+	}
+
+	// verify against the bytes that were decoded, if there are any, since re-serializing may not reproduce them
+	payload := claim.Payload
+	if len(payload) == 0 {
+		payload, err = unsignedPayload(claim)
+		if err != nil {
+			return SchemeUnknown, err
+		}
+	}
+	if sig.Verify(claimSignatureDigest(firstInput, claim.ClaimID, payload), channelPublicKey) {
+		return SchemeCurrent, nil
+	}
+	return SchemeUnknown, errors.Err(ErrSignatureMismatch)
+}
@@ -0,0 +1,215 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbryschema.go/claim"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	testChannelClaimID = "e67323a67a42307410f9679bf7fb344a428eb75c"
+	testFirstInputTxID = "becb96a4a2e66bd24f083772fe9da904654ea9b5f07cc5bfbee233355911ddb1"
+)
+
+func TestSignClaimWithChannel(t *testing.T) {
+	channel, key, err := NewChannel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := NewStreamClaim("title", "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txid, err := chainhash.NewHashFromStr(testFirstInputTxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := SignClaimWithChannel(claim, key, testChannelClaimID, *wire.NewOutPoint(txid, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compiled, err := claim.CompileValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, compiled) {
+		t.Error("signed value does not match the compiled claim")
+	}
+
+	decoded, err := c.DecodeClaimBytes(value, "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outpointHash, err := c.GetOutpointHash(testFirstInputTxID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := decoded.ValidateClaimSignature(channel, outpointHash, testChannelClaimID, "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Error("signature is not valid")
+	}
+}
+
+func TestSignLegacyClaimWithChannel(t *testing.T) {
+	signedClaimHex := "080110011ad7010801128f01080410011a0c47616d65206f66206c696665221047616d65206f66206c696665206769662a0b4a6f686e20436f6e776179322e437265617469766520436f6d6d6f6e73204174747269627574696f6e20342e3020496e7465726e6174696f6e616c38004224080110011a195569c917f18bf5d2d67f1346aa467b218ba90cdbf2795676da250000803f4a0052005a001a41080110011a30b6adf6e2a62950407ea9fb045a96127b67d39088678d2f738c359894c88d95698075ee6203533d3c204330713aa7acaf2209696d6167652f6769662a5c080110031a40c73fe1be4f1743c2996102eec6ce0509e03744ab940c97d19ddb3b25596206367ab1a3d2583b16c04d2717eeb983ae8f84fee2a46621ffa5c4726b30174c6ff82214251305ca93d4dbedb50dceb282ebcb7b07b7ac65"
+	claimAddress := "bSkUov7HMWpYBiXackDwRnR5ishhGHvtJt"
+
+	claim, err := c.DecodeClaimHex(signedClaimHex, "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channel, key, err := NewChannel()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := SignLegacyClaimWithChannel(claim, key, testChannelClaimID, claimAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := c.DecodeClaimBytes(value, "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := decoded.ValidateClaimSignature(channel, claimAddress, testChannelClaimID, "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Error("signature is not valid")
+	}
+
+	_, err = SignClaimWithChannel(claim, key, testChannelClaimID, wire.OutPoint{})
+	if err == nil {
+		t.Error("expected an error signing a legacy claim with the current scheme")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	channel, err := c.DecodeClaimHex("00125a0a583056301006072a8648ce3d020106052b8104000a034200045a0343c155302280da01ae0001b7295241eb03c42a837acf92ccb9680892f7db50fd1d3c14b28bb594e304f05fc4ae7c1f222a85d1d1a3461b3cfb9906f66cb5", "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channelKey, err := channel.GetPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := c.DecodeClaimHex("015cb78e424a34fbf79b67f9107430427aa62373e69b4998a29ecec8f14a9e0a213a043ced8064c069d7e464b5fd3ccb92b45bd59b15c0e1bb27e3c366d43f86a9a6b5ad42647a1aad69a73ac50b19ae3ec978c2c70aa2010a99010a301c662f19abc461e7eddecf165adfa7fca569e209773f3db31241c1e297f0a8d5b3e4768828b065fbeb1d6776f61073f6121b3031202d20556e6d6173746572656420496d70756c7365732e377a187a22146170706c69636174696f6e2f782d6578742d377a32302eb61ea475017e28c013616a56c1219ba90dc35fffff453d9675146f648f66634e0d1516528d37aba9f5801229d9f2181a044e6f6e6542087465737420707562520062020801", "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	txid, err := chainhash.NewHashFromStr(testFirstInputTxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheme, err := VerifySignature(claim, channelKey, *wire.NewOutPoint(txid, 0), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scheme != SchemeCurrent {
+		t.Errorf("expected %s scheme, got %s", SchemeCurrent, scheme)
+	}
+
+	_, err = VerifySignature(claim, channelKey, *wire.NewOutPoint(txid, 1), "")
+	if !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("expected ErrSignatureMismatch for the wrong outpoint, got %v", err)
+	}
+}
+
+func TestVerifySignature_Legacy(t *testing.T) {
+	channel, err := c.DecodeClaimHex("08011002225e0801100322583056301006072a8648ce3d020106052b8104000a03420004d015365a40f3e5c03c87227168e5851f44659837bcf6a3398ae633bc37d04ee19baeb26dc888003bd728146dbea39f5344bf8c52cedaf1a3a1623a0166f4a367", "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channelKey, err := channel.GetPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := c.DecodeClaimHex("080110011ad7010801128f01080410011a0c47616d65206f66206c696665221047616d65206f66206c696665206769662a0b4a6f686e20436f6e776179322e437265617469766520436f6d6d6f6e73204174747269627574696f6e20342e3020496e7465726e6174696f6e616c38004224080110011a195569c917f18bf5d2d67f1346aa467b218ba90cdbf2795676da250000803f4a0052005a001a41080110011a30b6adf6e2a62950407ea9fb045a96127b67d39088678d2f738c359894c88d95698075ee6203533d3c204330713aa7acaf2209696d6167652f6769662a5c080110031a40c73fe1be4f1743c2996102eec6ce0509e03744ab940c97d19ddb3b25596206367ab1a3d2583b16c04d2717eeb983ae8f84fee2a46621ffa5c4726b30174c6ff82214251305ca93d4dbedb50dceb282ebcb7b07b7ac65", "lbrycrd_main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheme, err := VerifySignature(claim, channelKey, wire.OutPoint{}, "bSkUov7HMWpYBiXackDwRnR5ishhGHvtJt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scheme != SchemeLegacy {
+		t.Errorf("expected %s scheme, got %s", SchemeLegacy, scheme)
+	}
+
+	_, err = VerifySignature(claim, channelKey, wire.OutPoint{}, "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha")
+	if !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("expected ErrSignatureMismatch for the wrong address, got %v", err)
+	}
+}
+
+func TestVerifyChannelSignature_NIST(t *testing.T) {
+	const claimAddress = "bSkUov7HMWpYBiXackDwRnR5ishhGHvtJt"
+	address, err := decodeAddressBytes(claimAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		curve   elliptic.Curve
+		newHash func() hash.Hash
+	}{
+		{elliptic.P256(), sha256.New},
+		{elliptic.P384(), sha512.New384},
+	} {
+		claim, err := c.DecodeClaimHex("080110011ad7010801128f01080410011a0c47616d65206f66206c696665221047616d65206f66206c696665206769662a0b4a6f686e20436f6e776179322e437265617469766520436f6d6d6f6e73204174747269627574696f6e20342e3020496e7465726e6174696f6e616c38004224080110011a195569c917f18bf5d2d67f1346aa467b218ba90cdbf2795676da250000803f4a0052005a001a41080110011a30b6adf6e2a62950407ea9fb045a96127b67d39088678d2f738c359894c88d95698075ee6203533d3c204330713aa7acaf2209696d6167652f6769662a5c080110031a40c73fe1be4f1743c2996102eec6ce0509e03744ab940c97d19ddb3b25596206367ab1a3d2583b16c04d2717eeb983ae8f84fee2a46621ffa5c4726b30174c6ff82214251305ca93d4dbedb50dceb282ebcb7b07b7ac65", "lbrycrd_main")
+		if err != nil {
+			t.Fatal(err)
+		}
+		private, err := ecdsa.GenerateKey(test.curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, payload, err := legacyUnsignedPayload(claim)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, private, legacyClaimSignatureDigest(test.newHash(), address, payload, claim.ClaimID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := (test.curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		copy(signature[size-len(r.Bytes()):size], r.Bytes())
+		copy(signature[2*size-len(s.Bytes()):], s.Bytes())
+		claim.Signature = signature
+
+		scheme, err := VerifyChannelSignature(claim, &private.PublicKey, wire.OutPoint{}, claimAddress)
+		if err != nil {
+			t.Fatalf("%s: %v", test.curve.Params().Name, err)
+		}
+		if scheme != SchemeLegacy {
+			t.Errorf("%s: expected %s scheme, got %s", test.curve.Params().Name, SchemeLegacy, scheme)
+		}
+
+		_, err = VerifyChannelSignature(claim, &private.PublicKey, wire.OutPoint{}, "bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha")
+		if !errors.Is(err, ErrSignatureMismatch) {
+			t.Errorf("%s: expected ErrSignatureMismatch for the wrong address, got %v", test.curve.Params().Name, err)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// PrevTx describes an output a transaction spends, so lbrycrd can sign for outputs it doesn't know about.
+// Amount is in LBC, and is only needed for segwit outputs.
+type PrevTx struct {
+	TxID          string  `json:"txid"`
+	Vout          uint32  `json:"vout"`
+	ScriptPubKey  string  `json:"scriptPubKey"`
+	RedeemScript  string  `json:"redeemScript,omitempty"`
+	WitnessScript string  `json:"witnessScript,omitempty"`
+	Amount        float64 `json:"amount,omitempty"`
+}
+
+// SignInputError is an input lbrycrd could not sign
+type SignInputError struct {
+	TxID      string   `json:"txid"`
+	Vout      uint32   `json:"vout"`
+	ScriptSig string   `json:"scriptSig"`
+	Witness   []string `json:"witness,omitempty"`
+	Sequence  uint32   `json:"sequence"`
+	Error     string   `json:"error"`
+}
+
+// SignedTx is a transaction lbrycrd signed. Complete is false if some inputs still need signatures, and Errors
+// says why.
+type SignedTx struct {
+	Tx       *wire.MsgTx
+	Complete bool
+	Errors   []SignInputError
+}
+
+type signRawTransactionResult struct {
+	Hex      string           `json:"hex"`
+	Complete bool             `json:"complete"`
+	Errors   []SignInputError `json:"errors"`
+}
+
+// SignRawTransactionWithKey signs a transaction with the given WIF private keys, without using the wallet.
+// prevTxs describes the outputs being spent that aren't in the utxo set yet. hashType 0 signs with SigHashAll.
+func (c *Client) SignRawTransactionWithKey(tx *wire.MsgTx, keys []string, prevTxs []PrevTx, hashType txscript.SigHashType) (*SignedTx, error) {
+	return c.signRawTransaction("signrawtransactionwithkey", tx, keys, prevTxs, hashType)
+}
+
+// SignRawTransactionWithWallet signs a transaction with the keys in lbrycrd's wallet. prevTxs describes the
+// outputs being spent that aren't in the utxo set yet. hashType 0 signs with SigHashAll.
+func (c *Client) SignRawTransactionWithWallet(tx *wire.MsgTx, prevTxs []PrevTx, hashType txscript.SigHashType) (*SignedTx, error) {
+	return c.signRawTransaction("signrawtransactionwithwallet", tx, nil, prevTxs, hashType)
+}
+
+func (c *Client) signRawTransaction(method string, tx *wire.MsgTx, keys []string, prevTxs []PrevTx, hashType txscript.SigHashType) (*SignedTx, error) {
+	txHex, err := encodeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	name, err := sigHashName(hashType)
+	if err != nil {
+		return nil, err
+	}
+	if prevTxs == nil {
+		prevTxs = []PrevTx{}
+	}
+
+	params := []interface{}{txHex}
+	if method == "signrawtransactionwithkey" {
+		if keys == nil {
+			keys = []string{}
+		}
+		params = append(params, keys)
+	}
+	params = append(params, prevTxs, name)
+
+	var result signRawTransactionResult
+	if err := c.rawCall(&result, method, params...); err != nil {
+		return nil, err
+	}
+	signed, err := decodeTx(result.Hex)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTx{Tx: signed, Complete: result.Complete, Errors: result.Errors}, nil
+}
+
+// sigHashName returns the name lbrycrd uses for a signature hash type
+func sigHashName(hashType txscript.SigHashType) (string, error) {
+	if hashType == 0 {
+		hashType = txscript.SigHashAll
+	}
+	var name string
+	switch hashType &^ txscript.SigHashAnyOneCanPay {
+	case txscript.SigHashAll:
+		name = "ALL"
+	case txscript.SigHashNone:
+		name = "NONE"
+	case txscript.SigHashSingle:
+		name = "SINGLE"
+	default:
+		return "", errors.Err("unknown signature hash type %d", hashType)
+	}
+	if hashType&txscript.SigHashAnyOneCanPay != 0 {
+		name += "|ANYONECANPAY"
+	}
+	return name, nil
+}
+
+func encodeTx(tx *wire.MsgTx) (string, error) {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", errors.Err(err)
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+func decodeTx(txHex string) (*wire.MsgTx, error) {
+	b, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(b)); err != nil {
+		return nil, errors.Prefix("decoding transaction", err)
+	}
+	return tx, nil
+}
+
+// pubKeyHash returns the hash a P2PKH script, or a claim script that pays to one, pays to
+func pubKeyHash(script []byte) ([]byte, error) {
+	d := DecodeScript(script, &MainNetParams)
+	if d.Class != txscript.PubKeyHashTy {
+		return nil, errors.Err("script does not pay to a public key hash")
+	}
+	return d.PkScript[3:23], nil
+}
+
+// SignatureHash returns the hash an input's signature signs. prevScript is the script of the output the input
+// spends, either P2PKH or a claim, update or support that pays to a P2PKH address. lbrycrd hashes the whole
+// script, claim and all.
+func SignatureHash(tx *wire.MsgTx, idx int, prevScript []byte, hashType txscript.SigHashType) ([]byte, error) {
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return nil, errors.Err("transaction has no input %d", idx)
+	}
+	if _, err := pubKeyHash(prevScript); err != nil {
+		return nil, err
+	}
+	hash, err := txscript.CalcSignatureHash(prevScript, hashType, tx, idx)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return hash, nil
+}
+
+// SignInput signs an input that spends a P2PKH or claim output with key, and sets its signature script.
+// hashType 0 signs with SigHashAll.
+func SignInput(tx *wire.MsgTx, idx int, prevScript []byte, key *btcec.PrivateKey, hashType txscript.SigHashType) error {
+	if key == nil {
+		return errors.Err("private key is missing")
+	}
+	if hashType == 0 {
+		hashType = txscript.SigHashAll
+	}
+	hash, err := pubKeyHash(prevScript)
+	if err != nil {
+		return err
+	}
+	pubKey := key.PubKey().SerializeCompressed()
+	if !bytes.Equal(btcutil.Hash160(pubKey), hash) {
+		pubKey = key.PubKey().SerializeUncompressed()
+		if !bytes.Equal(btcutil.Hash160(pubKey), hash) {
+			return errors.Err("key does not match the address input %d spends", idx)
+		}
+	}
+
+	digest, err := SignatureHash(tx, idx, prevScript, hashType)
+	if err != nil {
+		return err
+	}
+	sig, err := key.Sign(digest)
+	if err != nil {
+		return errors.Err(err)
+	}
+	sigScript, err := txscript.NewScriptBuilder().
+		AddData(append(sig.Serialize(), byte(hashType))).
+		AddData(pubKey).
+		Script()
+	if err != nil {
+		return errors.Err(err)
+	}
+	tx.TxIn[idx].SignatureScript = sigScript
+	return nil
+}
+
+// SignTransaction signs each input of a transaction with the key for the address it spends. prevScripts are the
+// scripts of the outputs the inputs spend, in the same order. It fails if there is no key for an input.
+func SignTransaction(tx *wire.MsgTx, prevScripts [][]byte, keys []*btcec.PrivateKey) error {
+	if len(prevScripts) != len(tx.TxIn) {
+		return errors.Err("transaction has %d inputs but %d previous scripts were given", len(tx.TxIn), len(prevScripts))
+	}
+	byHash := make(map[string]*btcec.PrivateKey)
+	for _, key := range keys {
+		byHash[string(btcutil.Hash160(key.PubKey().SerializeCompressed()))] = key
+		byHash[string(btcutil.Hash160(key.PubKey().SerializeUncompressed()))] = key
+	}
+	for i, prevScript := range prevScripts {
+		hash, err := pubKeyHash(prevScript)
+		if err != nil {
+			return errors.Prefix("input "+strconv.Itoa(i), err)
+		}
+		key, ok := byHash[string(hash)]
+		if !ok {
+			return errors.Err("no key for input %d", i)
+		}
+		if err := SignInput(tx, i, prevScript, key, txscript.SigHashAll); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyInput checks the signature of an input that spends a P2PKH or claim output. btcd's script engine can't
+// run claim scripts, since lbrycrd's claim opcodes push a value where bitcoin's NOPs do nothing.
+func VerifyInput(tx *wire.MsgTx, idx int, prevScript []byte) error {
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return errors.Err("transaction has no input %d", idx)
+	}
+	hash, err := pubKeyHash(prevScript)
+	if err != nil {
+		return err
+	}
+	sigScript := tx.TxIn[idx].SignatureScript
+	pushes, err := txscript.PushedData(sigScript)
+	if err != nil || !txscript.IsPushOnlyScript(sigScript) || len(pushes) != 2 || len(pushes[0]) == 0 {
+		return errors.Err("input %d does not have a signature and public key", idx)
+	}
+	sigBytes, pubKeyBytes := pushes[0], pushes[1]
+	if !bytes.Equal(btcutil.Hash160(pubKeyBytes), hash) {
+		return errors.Err("public key of input %d does not match the address it spends", idx)
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return errors.Prefix("input "+strconv.Itoa(idx), err)
+	}
+	sig, err := btcec.ParseDERSignature(sigBytes[:len(sigBytes)-1], btcec.S256())
+	if err != nil {
+		return errors.Prefix("input "+strconv.Itoa(idx), err)
+	}
+	digest, err := SignatureHash(tx, idx, prevScript, txscript.SigHashType(sigBytes[len(sigBytes)-1]))
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(digest, pubKey) {
+		return errors.Err("signature of input %d is invalid", idx)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func testSpend(t *testing.T, inputs int) *wire.MsgTx {
+	t.Helper()
+	tx := wire.NewMsgTx(wire.TxVersion)
+	for i := 0; i < inputs; i++ {
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i)), nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_TRUE}))
+	return tx
+}
+
+func TestSignTransaction(t *testing.T) {
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	address, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(key.PubKey().SerializeCompressed()), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2pkh, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := ClaimNameScript("test", []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	support, err := SupportClaimScript("test", testChannelClaimID, nil, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prevScripts := [][]byte{p2pkh, claim, support}
+	tx := testSpend(t, len(prevScripts))
+	if err := SignTransaction(tx, prevScripts, []*btcec.PrivateKey{key}); err != nil {
+		t.Fatal(err)
+	}
+	for i, prevScript := range prevScripts {
+		if err := VerifyInput(tx, i, prevScript); err != nil {
+			t.Errorf("input %d: %v", i, err)
+		}
+	}
+
+	// the claim is part of what is signed
+	claimHash, err := SignatureHash(tx, 1, claim, txscript.SigHashAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plainHash, err := SignatureHash(tx, 1, p2pkh, txscript.SigHashAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(claimHash, plainHash) {
+		t.Error("expected the claim script to change the signature hash")
+	}
+	if err := VerifyInput(tx, 1, p2pkh); err == nil {
+		t.Error("expected a signature for a claim output to be invalid for the bare address")
+	}
+
+	tx.TxOut[0].Value++
+	if err := VerifyInput(tx, 0, p2pkh); err == nil {
+		t.Error("expected the signature to be invalid after the transaction changed")
+	}
+
+	other, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SignTransaction(tx, prevScripts, []*btcec.PrivateKey{other}); err == nil {
+		t.Error("expected an error without the key for the inputs")
+	}
+	if err := SignInput(tx, 0, p2pkh, other, 0); err == nil {
+		t.Error("expected an error signing with the wrong key")
+	}
+	if err := SignInput(tx, 0, []byte{txscript.OP_TRUE}, key, 0); err == nil {
+		t.Error("expected an error for a script that doesn't pay to a key hash")
+	}
+	if _, err := SignatureHash(tx, 3, p2pkh, txscript.SigHashAll); err == nil {
+		t.Error("expected an error for a missing input")
+	}
+}
+
+func TestSignInput_Uncompressed(t *testing.T) {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(key.PubKey().SerializeUncompressed()), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := UpdateClaimScript("test", testChannelClaimID, []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testSpend(t, 1)
+	if err := SignInput(tx, 0, script, key, txscript.SigHashSingle|txscript.SigHashAnyOneCanPay); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyInput(tx, 0, script); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSigHashName(t *testing.T) {
+	tests := map[txscript.SigHashType]string{
+		0:                      "ALL",
+		txscript.SigHashAll:    "ALL",
+		txscript.SigHashNone:   "NONE",
+		txscript.SigHashSingle: "SINGLE",
+		txscript.SigHashAll | txscript.SigHashAnyOneCanPay: "ALL|ANYONECANPAY",
+	}
+	for hashType, expected := range tests {
+		name, err := sigHashName(hashType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != expected {
+			t.Errorf("expected %s for %d, got %s", expected, hashType, name)
+		}
+	}
+	if _, err := sigHashName(txscript.SigHashAnyOneCanPay); err == nil {
+		t.Error("expected an error for an unknown hash type")
+	}
+}
+
+func TestClient_SignRawTransactionWithKey(t *testing.T) {
+	tx := testSpend(t, 1)
+	txHex, err := encodeTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := tx.Copy()
+	signed.TxIn[0].SignatureScript = []byte{txscript.OP_TRUE}
+	signedHex, err := encodeTx(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		var hexTx, hashType string
+		var keys []string
+		var prevTxs []PrevTx
+		switch req.Method {
+		case "signrawtransactionwithkey":
+			if len(req.Params) != 4 {
+				t.Fatalf("expected 4 params, got %d", len(req.Params))
+			}
+			json.Unmarshal(req.Params[1], &keys)
+			json.Unmarshal(req.Params[2], &prevTxs)
+			json.Unmarshal(req.Params[3], &hashType)
+		case "signrawtransactionwithwallet":
+			if len(req.Params) != 3 {
+				t.Fatalf("expected 3 params, got %d", len(req.Params))
+			}
+			json.Unmarshal(req.Params[1], &prevTxs)
+			json.Unmarshal(req.Params[2], &hashType)
+			return map[string]interface{}{
+				"hex":      txHex,
+				"complete": false,
+				"errors":   []map[string]interface{}{{"txid": tx.TxIn[0].PreviousOutPoint.Hash.String(), "vout": 0, "error": "Input not found or already spent"}},
+			}, ""
+		default:
+			return nil, "Method not found"
+		}
+		json.Unmarshal(req.Params[0], &hexTx)
+		if hexTx != txHex {
+			t.Errorf("expected tx %s, got %s", txHex, hexTx)
+		}
+		if len(keys) != 1 || keys[0] != "wif" {
+			t.Errorf("expected the key to be sent, got %v", keys)
+		}
+		if len(prevTxs) != 1 || prevTxs[0].ScriptPubKey != "51" {
+			t.Errorf("expected the previous output to be sent, got %v", prevTxs)
+		}
+		if hashType != "ALL|ANYONECANPAY" {
+			t.Errorf("expected ALL|ANYONECANPAY, got %s", hashType)
+		}
+		return map[string]interface{}{"hex": signedHex, "complete": true}, ""
+	})
+	defer server.Close()
+
+	prevTxs := []PrevTx{{TxID: tx.TxIn[0].PreviousOutPoint.Hash.String(), Vout: 0, ScriptPubKey: "51"}}
+	result, err := c.SignRawTransactionWithKey(tx, []string{"wif"}, prevTxs, txscript.SigHashAll|txscript.SigHashAnyOneCanPay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Complete || len(result.Errors) != 0 {
+		t.Errorf("expected a complete signature, got %+v", result)
+	}
+	if !bytes.Equal(result.Tx.TxIn[0].SignatureScript, []byte{txscript.OP_TRUE}) {
+		t.Error("expected the signed transaction")
+	}
+
+	result, err = c.SignRawTransactionWithWallet(tx, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Complete || len(result.Errors) != 1 || result.Errors[0].Error != "Input not found or already spent" {
+		t.Errorf("expected an incomplete signature with an error, got %+v", result)
+	}
+}
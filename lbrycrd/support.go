@@ -0,0 +1,174 @@
+package lbrycrd
+
+import (
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/golang/protobuf/proto"
+)
+
+// support payload versions, the first byte of the payload
+const (
+	supportUnsigned byte = 0
+	supportSigned   byte = 1
+)
+
+// SupportPayload is the data attached to a support, such as a tip. A support signed by a channel shows who
+// it came from.
+type SupportPayload struct {
+	Emoji          string
+	ChannelClaimID string // empty if the support is not signed
+	Signature      []byte // 64-byte R||S signature
+
+	message []byte // the protobuf the signature covers, as it was decoded
+}
+
+// supportPB is the Support message from lbry-sdk's support.proto
+type supportPB struct {
+	Emoji string `protobuf:"bytes,1,opt,name=emoji,proto3"`
+}
+
+func (m *supportPB) Reset()         { *m = supportPB{} }
+func (m *supportPB) String() string { return proto.CompactTextString(m) }
+func (*supportPB) ProtoMessage()    {}
+
+// IsSigned returns true if the support was signed by a channel
+func (s *SupportPayload) IsSigned() bool {
+	return s.ChannelClaimID != ""
+}
+
+func (s *SupportPayload) protobuf() ([]byte, error) {
+	if s.message != nil {
+		return s.message, nil
+	}
+	message, err := proto.Marshal(&supportPB{Emoji: s.Emoji})
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return message, nil
+}
+
+// Bytes serializes the payload: the version byte, then for signed supports the channel claim hash and the
+// signature, then the protobuf
+func (s *SupportPayload) Bytes() ([]byte, error) {
+	message, err := s.protobuf()
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsSigned() {
+		return append([]byte{supportUnsigned}, message...), nil
+	}
+
+	channelHash, err := ClaimHashFromID(s.ChannelClaimID)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Signature) != CompactSignatureLength {
+		return nil, errors.Err("support signature must be %d bytes", CompactSignatureLength)
+	}
+	value := make([]byte, 0, 1+len(channelHash)+len(s.Signature)+len(message))
+	value = append(value, supportSigned)
+	value = append(value, channelHash...)
+	value = append(value, s.Signature...)
+	value = append(value, message...)
+	return value, nil
+}
+
+// DecodeSupportPayload parses the data attached to a support
+func DecodeSupportPayload(value []byte) (*SupportPayload, error) {
+	if len(value) == 0 {
+		return nil, errors.Err("support payload is empty")
+	}
+
+	s := &SupportPayload{}
+	switch value[0] {
+	case supportUnsigned:
+		s.message = value[1:]
+	case supportSigned:
+		headerLength := 1 + ClaimIDLength/2 + CompactSignatureLength
+		if len(value) < headerLength {
+			return nil, errors.Err("signed support payload is too short")
+		}
+		s.ChannelClaimID = ClaimIDFromHash(value[1 : 1+ClaimIDLength/2])
+		s.Signature = value[1+ClaimIDLength/2 : headerLength]
+		s.message = value[headerLength:]
+	default:
+		return nil, errors.Err("unknown support payload version %d", value[0])
+	}
+
+	message := &supportPB{}
+	err := proto.Unmarshal(s.message, message)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	s.Emoji = message.Emoji
+	return s, nil
+}
+
+// SignSupport signs the support with a channel's private key. firstInput must be the first input of the
+// transaction that will contain the support. The digest is the same as for claims.
+func SignSupport(s *SupportPayload, channelKey *btcec.PrivateKey, channelClaimID string, firstInput wire.OutPoint) error {
+	channelHash, err := ClaimHashFromID(channelClaimID)
+	if err != nil {
+		return err
+	}
+	s.message = nil
+	message, err := s.protobuf()
+	if err != nil {
+		return err
+	}
+
+	signature, err := SignDigest(channelKey, claimSignatureDigest(firstInput, channelHash, message))
+	if err != nil {
+		return err
+	}
+	s.ChannelClaimID = channelClaimID
+	s.Signature = signature
+	s.message = message
+	return nil
+}
+
+// VerifySupport checks the signature of a signed support against its channel's public key. firstInput is the
+// first input of the support's transaction.
+func VerifySupport(s *SupportPayload, channelPublicKey *btcec.PublicKey, firstInput wire.OutPoint) error {
+	if !s.IsSigned() {
+		return errors.Err("support is not signed")
+	}
+	channelHash, err := ClaimHashFromID(s.ChannelClaimID)
+	if err != nil {
+		return err
+	}
+	message, err := s.protobuf()
+	if err != nil {
+		return err
+	}
+	if !VerifyDigest(channelPublicKey, claimSignatureDigest(firstInput, channelHash, message), s.Signature) {
+		return errors.Err(ErrSignatureMismatch)
+	}
+	return nil
+}
+
+// AddSupportWithPayloadToTx adds a support output that carries a payload, such as a signed tip
+func AddSupportWithPayloadToTx(rawTx *wire.MsgTx, name, claimID string, payload *SupportPayload, amount btcutil.Amount, address btcutil.Address) error {
+	value, err := payload.Bytes()
+	if err != nil {
+		return err
+	}
+	script, err := SupportClaimScript(name, claimID, value, address)
+	if err != nil {
+		return err
+	}
+	rawTx.AddTxOut(wire.NewTxOut(int64(amount), script))
+	return nil
+}
+
+// SupportPayloadFromScript returns the payload of a support output, or nil if the support has none
+func SupportPayloadFromScript(script []byte) (*SupportPayload, error) {
+	s, err := ParseClaimScript(script)
+	if err != nil {
+		return nil, err
+	}
+	return s.SupportPayload()
+}
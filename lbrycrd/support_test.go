@@ -0,0 +1,85 @@
+package lbrycrd
+
+import (
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestSignSupport(t *testing.T) {
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txid, err := chainhash.NewHashFromStr(testFirstInputTxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstInput := *wire.NewOutPoint(txid, 1)
+
+	support := &SupportPayload{Emoji: "🚀"}
+	err = SignSupport(support, key, testChannelClaimID, firstInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	err = AddSupportWithPayloadToTx(tx, "name", "2bfbc5be8c5fe8ebd39e4b49c8a8f04a8b6d8b5c", support, btcutil.Amount(100000000), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := SupportPayloadFromScript(tx.TxOut[0].PkScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Emoji != support.Emoji || decoded.ChannelClaimID != testChannelClaimID {
+		t.Errorf("expected %v, got %v", support, decoded)
+	}
+	err = VerifySupport(decoded, key.PubKey(), firstInput)
+	if err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+
+	err = VerifySupport(decoded, key.PubKey(), *wire.NewOutPoint(txid, 2))
+	if !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("expected ErrSignatureMismatch for a different input, got %v", err)
+	}
+
+	decoded.Emoji = "💩"
+	decoded.message = nil
+	err = VerifySupport(decoded, key.PubKey(), firstInput)
+	if !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("expected ErrSignatureMismatch for a modified support, got %v", err)
+	}
+}
+
+func TestDecodeSupportPayload(t *testing.T) {
+	unsigned, err := (&SupportPayload{Emoji: "👍"}).Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeSupportPayload(unsigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.IsSigned() || decoded.Emoji != "👍" {
+		t.Errorf("unexpected support %v", decoded)
+	}
+
+	for _, value := range [][]byte{nil, {2}, {supportSigned, 1, 2, 3}} {
+		_, err := DecodeSupportPayload(value)
+		if err == nil {
+			t.Errorf("%x: expected an error", value)
+		}
+	}
+}
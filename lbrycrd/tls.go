@@ -0,0 +1,62 @@
+package lbrycrd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// ConnOptions configures how a Client reaches lbrycrd when it isn't plain http, like when lbrycrd is exposed over
+// stunnel or a reverse proxy that terminates TLS
+type ConnOptions struct {
+	// TLS sends calls over https. An https url sets it.
+	TLS bool
+	// CACert is the PEM encoded certificate of the CA, or the self-signed certificate, the server's certificate is
+	// checked against. If it's empty, the system's roots are used. A cacert url param names a file to read it from.
+	CACert []byte
+	// ClientCert is presented to servers that ask for one
+	ClientCert *tls.Certificate
+	// InsecureSkipVerify accepts any certificate the server presents. Only use it for testing.
+	InsecureSkipVerify bool
+	// HTTPClient sends the calls, in place of one configured from the options above
+	HTTPClient *http.Client
+}
+
+// NewWithOptions initializes a new Client from a url like the one New takes, configured by opts
+func NewWithOptions(lbrycrdURL string, opts ConnOptions) (*Client, error) {
+	return newClient(lbrycrdURL, opts)
+}
+
+// readCACert reads a PEM encoded certificate file for ConnOptions.CACert
+func readCACert(path string) ([]byte, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Prefix("reading ca certificate", err)
+	}
+	return pem, nil
+}
+
+// httpClient returns the client that sends calls to lbrycrd
+func (o ConnOptions) httpClient() (*http.Client, error) {
+	if o.HTTPClient != nil {
+		return o.HTTPClient, nil
+	}
+	if !o.TLS {
+		return http.DefaultClient, nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify}
+	if len(o.CACert) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(o.CACert) {
+			return nil, errors.Err("no certificates found in ca certificate")
+		}
+	}
+	if o.ClientCert != nil {
+		config.Certificates = []tls.Certificate{*o.ClientCert}
+	}
+	return &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}}, nil
+}
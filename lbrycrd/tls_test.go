@@ -0,0 +1,171 @@
+package lbrycrd
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNew_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(fakeLbrycrdHandler(t, func(req fakeRequest) (interface{}, string) {
+		if req.Method == "getblockcount" {
+			return 12, ""
+		}
+		return nil, "Method not found"
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	dir, err := ioutil.TempDir("", "lbrycrd-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caPath := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(caPath, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := New("https://user:pass@" + host); err == nil {
+		t.Error("expected an error for a certificate the system doesn't trust")
+	}
+	if _, err := New("rpc://user:pass@" + host); err == nil {
+		t.Error("expected an error for plain http to a tls server")
+	}
+
+	c, err := New("https://user:pass@" + host + "?cacert=" + url.QueryEscape(caPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Shutdown()
+	if count, err := c.blockCount(); err != nil || count != 12 {
+		t.Errorf("expected 12 blocks from a raw call, got %d, %v", count, err)
+	}
+	if count, err := c.GetBlockCount(); err != nil || count != 12 {
+		t.Errorf("expected 12 blocks from the btcd client, got %d, %v", count, err)
+	}
+
+	wallet, err := c.Wallet("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wallet.Shutdown()
+	if _, err := wallet.blockCount(); err != nil {
+		t.Errorf("expected a wallet client to keep the tls config: %v", err)
+	}
+
+	insecure, err := NewWithOptions("rpc://user:pass@"+host, ConnOptions{TLS: true, InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer insecure.Shutdown()
+	if _, err := insecure.blockCount(); err != nil {
+		t.Error(err)
+	}
+	if _, err := insecure.GetBlockCount(); err != nil {
+		t.Errorf("expected the btcd client to skip verification too: %v", err)
+	}
+
+	if _, err := New("https://user:pass@" + host + "?cacert=" + url.QueryEscape(filepath.Join(dir, "missing.pem"))); err == nil {
+		t.Error("expected an error for a missing ca certificate")
+	}
+	if _, err := NewWithOptions("https://user:pass@"+host, ConnOptions{CACert: []byte("not a certificate")}); err == nil {
+		t.Error("expected an error for a ca certificate that isn't PEM")
+	}
+}
+
+func TestNewWithOptions_ClientCert(t *testing.T) {
+	var mu sync.Mutex
+	var presented int
+	handler := fakeLbrycrdHandler(t, func(req fakeRequest) (interface{}, string) {
+		return 3, ""
+	})
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		presented = len(r.TLS.PeerCertificates)
+		mu.Unlock()
+		handler.ServeHTTP(w, r)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	// the server's own certificate will do as a client certificate
+	cert := server.TLS.Certificates[0]
+	if _, err := NewWithOptions("https://user:pass@"+host, ConnOptions{InsecureSkipVerify: true}); err == nil {
+		t.Error("expected an error without a client certificate")
+	}
+	c, err := NewWithOptions("https://user:pass@"+host, ConnOptions{InsecureSkipVerify: true, ClientCert: &cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Shutdown()
+	mu.Lock()
+	presented = 0
+	mu.Unlock()
+	if _, err := c.GetBlockCount(); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if presented != 1 {
+		t.Errorf("expected the client certificate to be presented, got %d certificates", presented)
+	}
+}
+
+// headerTransport adds a header to each request, like a client for a proxy that wants its own token
+type headerTransport struct {
+	token string
+}
+
+func (h headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("X-Proxy-Token", h.token)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestNewWithOptions_HTTPClient(t *testing.T) {
+	handler := fakeLbrycrdHandler(t, func(req fakeRequest) (interface{}, string) {
+		return 5, ""
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Proxy-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	lbrycrdURL := "rpc://user:pass@" + strings.TrimPrefix(server.URL, "http://")
+
+	if _, err := New(lbrycrdURL); err == nil {
+		t.Error("expected an error when the proxy's token isn't sent")
+	}
+	c, err := NewWithOptions(lbrycrdURL, ConnOptions{HTTPClient: &http.Client{Transport: headerTransport{"secret"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Shutdown()
+	if count, err := c.blockCount(); err != nil || count != 5 {
+		t.Errorf("expected 5 blocks, got %d, %v", count, err)
+	}
+	b := c.NewBatch()
+	var count int64
+	b.Add(&count, "getblockcount")
+	if err := b.Send(); err != nil || count != 5 {
+		t.Errorf("expected batches to use the http client, got %d, %v", count, err)
+	}
+	if count, err := c.GetBlockCount(); err != nil || count != 5 {
+		t.Errorf("expected the btcd client to use the http client, got %d, %v", count, err)
+	}
+}
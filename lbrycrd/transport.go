@@ -0,0 +1,102 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/btcjson"
+)
+
+// requestID numbers the calls this package sends itself, rather than through the btcd client
+var requestID uint64
+
+type rpcResponse struct {
+	Result json.RawMessage   `json:"result"`
+	Error  *btcjson.RPCError `json:"error"`
+}
+
+// call sends a single call to lbrycrd and returns its result. Errors lbrycrd returns are *btcjson.RPCErrors, as
+// they are from the btcd client.
+func (c *Client) call(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if params == nil {
+		params = []json.RawMessage{}
+	}
+	id := int(atomic.AddUint64(&requestID, 1))
+	body, err := json.Marshal(batchRequest{JSONRPC: "1.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	response, status, err := c.post(body)
+	if err != nil {
+		return nil, err
+	}
+	// lbrycrd answers failed calls with an error status and the error in the body
+	var r rpcResponse
+	if err := json.Unmarshal(response, &r); err != nil {
+		// the same error the btcd client gives, so callers can handle both alike
+		return nil, errors.Err("status code: %d, response: %q", status, response)
+	}
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return r.Result, nil
+}
+
+// post sends a request body to lbrycrd and returns the response body and status. The btcd client's calls are
+// relayed here too. If lbrycrd rejects the cookie, the cookie is read again in case lbrycrd restarted, and the
+// request is sent once more.
+func (c *Client) post(body []byte) ([]byte, int, error) {
+	if c.config == nil {
+		return nil, 0, errors.Err("client has no connection config")
+	}
+	response, status, err := c.send(body)
+	if err != nil || status != http.StatusUnauthorized || c.cookie == nil {
+		return response, status, err
+	}
+	changed, err := c.cookie.reload()
+	if err != nil || !changed {
+		return response, status, nil
+	}
+	return c.send(body)
+}
+
+func (c *Client) send(body []byte) ([]byte, int, error) {
+	user, pass := c.config.User, c.config.Pass
+	if c.cookie != nil {
+		var err error
+		user, pass, err = c.cookie.credentials()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	scheme := "https://"
+	if c.config.DisableTLS {
+		scheme = "http://"
+	}
+	req, err := http.NewRequest(http.MethodPost, scheme+c.config.Host, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, errors.Err(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(user, pass)
+
+	httpClient := c.http
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, errors.Err(err)
+	}
+	defer resp.Body.Close()
+	var response bytes.Buffer
+	if _, err := response.ReadFrom(resp.Body); err != nil {
+		return nil, 0, errors.Err(err)
+	}
+	return response.Bytes(), resp.StatusCode, nil
+}
@@ -0,0 +1,124 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// ScriptSig is the signature script of an input
+type ScriptSig struct {
+	Asm string `json:"asm"`
+	Hex string `json:"hex"`
+}
+
+// VerboseTxIn is an input of a transaction. Coinbase is set instead of TxID, Vout and ScriptSig for the input
+// of a coinbase transaction.
+type VerboseTxIn struct {
+	Coinbase  string     `json:"coinbase,omitempty"`
+	TxID      string     `json:"txid,omitempty"`
+	Vout      uint32     `json:"vout"`
+	ScriptSig *ScriptSig `json:"scriptSig,omitempty"`
+	Witness   []string   `json:"txinwitness,omitempty"`
+	Sequence  uint32     `json:"sequence"`
+}
+
+// ScriptPubKey is the script of an output. Claim is the output's claim, update or support, decoded from Hex, or
+// nil if the output is not in the claimtrie.
+type ScriptPubKey struct {
+	Asm       string       `json:"asm"`
+	Hex       string       `json:"hex"`
+	ReqSigs   int32        `json:"reqSigs,omitempty"`
+	Type      string       `json:"type"`
+	Addresses []string     `json:"addresses,omitempty"`
+	Claim     *ClaimScript `json:"-"`
+}
+
+func (s *ScriptPubKey) UnmarshalJSON(b []byte) error {
+	type scriptPubKey ScriptPubKey
+	if err := json.Unmarshal(b, (*scriptPubKey)(s)); err != nil {
+		return err
+	}
+	script, err := hex.DecodeString(s.Hex)
+	if err != nil {
+		return errors.Prefix("decoding scriptPubKey", err)
+	}
+	if claim, err := ParseClaimScript(script); err == nil {
+		s.Claim = claim
+	}
+	return nil
+}
+
+// VerboseTxOut is an output of a transaction. Value is in LBC.
+type VerboseTxOut struct {
+	Value        float64      `json:"value"`
+	N            uint32       `json:"n"`
+	ScriptPubKey ScriptPubKey `json:"scriptPubKey"`
+}
+
+// VerboseTx is a transaction as lbrycrd describes it. The block fields are only set for transactions in a
+// block, and only by GetVerboseTransaction.
+type VerboseTx struct {
+	Hex      string         `json:"hex"`
+	TxID     string         `json:"txid"`
+	Hash     string         `json:"hash"`
+	Size     int32          `json:"size"`
+	VSize    int32          `json:"vsize"`
+	Weight   int32          `json:"weight,omitempty"`
+	Version  int32          `json:"version"`
+	LockTime uint32         `json:"locktime"`
+	Vin      []VerboseTxIn  `json:"vin"`
+	Vout     []VerboseTxOut `json:"vout"`
+
+	BlockHash     string `json:"blockhash,omitempty"`
+	Confirmations int64  `json:"confirmations,omitempty"`
+	Time          int64  `json:"time,omitempty"`
+	BlockTime     int64  `json:"blocktime,omitempty"`
+}
+
+// VerboseBlock is a block as lbrycrd describes it, with its transactions. ClaimTrieRoot is the root hash of
+// the claimtrie after the block.
+type VerboseBlock struct {
+	Hash              string      `json:"hash"`
+	Confirmations     int64       `json:"confirmations"`
+	StrippedSize      int32       `json:"strippedsize"`
+	Size              int32       `json:"size"`
+	Weight            int32       `json:"weight"`
+	Height            int32       `json:"height"`
+	Version           int32       `json:"version"`
+	VersionHex        string      `json:"versionHex"`
+	MerkleRoot        string      `json:"merkleroot"`
+	ClaimTrieRoot     string      `json:"nameclaimroot"`
+	Tx                []VerboseTx `json:"tx"`
+	Time              int64       `json:"time"`
+	MedianTime        int64       `json:"mediantime"`
+	Nonce             uint32      `json:"nonce"`
+	Bits              string      `json:"bits"`
+	Difficulty        float64     `json:"difficulty"`
+	ChainWork         string      `json:"chainwork"`
+	NTx               int32       `json:"nTx"`
+	PreviousBlockHash string      `json:"previousblockhash,omitempty"`
+	NextBlockHash     string      `json:"nextblockhash,omitempty"`
+}
+
+// GetVerboseBlock returns a block with all its transactions described
+func (c *Client) GetVerboseBlock(hash *chainhash.Hash) (*VerboseBlock, error) {
+	result := new(VerboseBlock)
+	if err := c.rawCall(result, "getblock", hash.String(), 2); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetVerboseTransaction describes a transaction. Transactions that are not in the mempool or the wallet can
+// only be found if lbrycrd runs with -txindex.
+func (c *Client) GetVerboseTransaction(txid *chainhash.Hash) (*VerboseTx, error) {
+	result := new(VerboseTx)
+	if err := c.rawCall(result, "getrawtransaction", txid.String(), true); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
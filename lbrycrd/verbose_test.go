@@ -0,0 +1,138 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+const verboseTxTemplate = `{
+  "txid": "a3c4d6e1f2b3a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+  "hash": "a3c4d6e1f2b3a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+  "version": 1,
+  "size": 245,
+  "vsize": 245,
+  "locktime": 0,
+  "vin": [
+    {
+      "txid": "b4d5e6f708192a3b4c5d6e7f8091a2b3c4da3c4d6e1f2b3a4c5d6e7f8091a2b3",
+      "vout": 1,
+      "scriptSig": {"asm": "3044[ALL] 02aa", "hex": "473044"},
+      "sequence": 4294967295
+    }
+  ],
+  "vout": [
+    {
+      "value": 1.5,
+      "n": 0,
+      "scriptPubKey": {"asm": "OP_CLAIM_NAME", "hex": "CLAIM", "reqSigs": 1, "type": "pubkeyhash", "addresses": ["bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"]}
+    },
+    {
+      "value": 0.25,
+      "n": 1,
+      "scriptPubKey": {"asm": "OP_SUPPORT_CLAIM", "hex": "SUPPORT", "reqSigs": 1, "type": "pubkeyhash", "addresses": ["bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"]}
+    },
+    {
+      "value": 7.9,
+      "n": 2,
+      "scriptPubKey": {"asm": "OP_DUP", "hex": "PKSCRIPT", "reqSigs": 1, "type": "pubkeyhash", "addresses": ["bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha"]}
+    }
+  ],
+  "hex": "0100"
+}`
+
+func verboseTxJSON(t *testing.T) string {
+	t.Helper()
+	address, err := DecodeAddress("bMUxfQVUeDi7ActVeZJZHzHKBceai7kHha", &mainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := ClaimNameScript("video", []byte{0x0a, 0x0b}, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	support, err := SupportClaimScript("video", testChannelClaimID, nil, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.NewReplacer(
+		`"CLAIM"`, `"`+hex.EncodeToString(claim)+`"`,
+		`"SUPPORT"`, `"`+hex.EncodeToString(support)+`"`,
+		`"PKSCRIPT"`, `"76a914`+strings.Repeat("00", 20)+`88ac"`,
+	).Replace(verboseTxTemplate)
+}
+
+func checkVerboseTx(t *testing.T, tx VerboseTx) {
+	t.Helper()
+	if len(tx.Vin) != 1 || tx.Vin[0].Vout != 1 || tx.Vin[0].ScriptSig == nil || len(tx.Vout) != 3 {
+		t.Fatalf("unexpected transaction %+v", tx)
+	}
+	claim := tx.Vout[0].ScriptPubKey.Claim
+	if claim == nil || claim.Type != ClaimName || claim.Name != "video" || hex.EncodeToString(claim.Value) != "0a0b" {
+		t.Errorf("unexpected claim %+v", claim)
+	}
+	support := tx.Vout[1].ScriptPubKey.Claim
+	if support == nil || support.Type != ClaimSupport || support.ClaimID != testChannelClaimID {
+		t.Errorf("unexpected support %+v", support)
+	}
+	if tx.Vout[2].ScriptPubKey.Claim != nil || tx.Vout[2].Value != 7.9 {
+		t.Errorf("unexpected output %+v", tx.Vout[2])
+	}
+}
+
+func TestClient_GetVerbose(t *testing.T) {
+	txJSON := verboseTxJSON(t)
+	blockJSON := `{
+  "hash": "9c89283ba0f3227f6c03b70216b9f665f0118d5e0fa729cedf4fb34d6a34f463",
+  "confirmations": 10,
+  "height": 812000,
+  "version": 536870912,
+  "merkleroot": "a3c4d6e1f2b3a4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+  "nameclaimroot": "0000000000000000000000000000000000000000000000000000000000000001",
+  "tx": [` + txJSON + `],
+  "time": 1600000000,
+  "nTx": 1,
+  "previousblockhash": "b4d5e6f708192a3b4c5d6e7f8091a2b3c4da3c4d6e1f2b3a4c5d6e7f8091a2b3"
+}`
+
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		switch req.Method {
+		case "getblock":
+			if len(req.Params) != 2 || string(req.Params[1]) != "2" {
+				return nil, "unexpected params"
+			}
+			return json.RawMessage(blockJSON), ""
+		case "getrawtransaction":
+			if len(req.Params) != 2 || string(req.Params[1]) != "true" {
+				return nil, "unexpected params"
+			}
+			return json.RawMessage(txJSON), ""
+		}
+		return nil, "Method not found"
+	})
+	defer server.Close()
+
+	hash := chainhash.Hash{1}
+	block, err := c.GetVerboseBlock(&hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Height != 812000 || block.ClaimTrieRoot[63] != '1' || len(block.Tx) != 1 {
+		t.Fatalf("unexpected block %+v", block)
+	}
+	checkVerboseTx(t, block.Tx[0])
+
+	tx, err := c.GetVerboseTransaction(&hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkVerboseTx(t, *tx)
+
+	var bad VerboseTxOut
+	if err := json.Unmarshal([]byte(`{"scriptPubKey": {"hex": "zz"}}`), &bad); err == nil {
+		t.Error("expected an error for a script that isn't hex")
+	}
+}
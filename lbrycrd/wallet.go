@@ -0,0 +1,92 @@
+package lbrycrd
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// walletPathPrefix starts the path lbrycrd routes a wallet's calls by, when it has several wallets loaded
+const walletPathPrefix = "/wallet/"
+
+// Wallet returns a client whose wallet calls go to the named wallet, for when lbrycrd has several wallets
+// loaded. Other calls work as they do on c, with the same credentials. Shut the client down when done with it.
+func (c *Client) Wallet(name string) (*Client, error) {
+	if c.config == nil {
+		return nil, errors.Err("client has no connection config")
+	}
+	if name == "" {
+		return nil, errors.Err("wallet name is empty")
+	}
+	config := *c.config
+	config.Host = nodeHost(c.config.Host) + walletPathPrefix + url.PathEscape(name)
+	wallet := &Client{config: &config, cookie: c.cookie, http: c.http}
+	if err := wallet.startRPCClient(); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// WalletName returns the wallet the client's wallet calls go to, or "" if they go to lbrycrd's default wallet
+func (c *Client) WalletName() string {
+	if c.config == nil {
+		return ""
+	}
+	i := strings.Index(c.config.Host, walletPathPrefix)
+	if i < 0 {
+		return ""
+	}
+	name, err := url.PathUnescape(c.config.Host[i+len(walletPathPrefix):])
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// nodeHost strips the wallet path from a host
+func nodeHost(host string) string {
+	if i := strings.Index(host, "/"); i >= 0 {
+		return host[:i]
+	}
+	return host
+}
+
+// CreateWalletResult is a wallet lbrycrd created or loaded. Warning is set if something went wrong that didn't
+// stop it.
+type CreateWalletResult struct {
+	Name    string `json:"name"`
+	Warning string `json:"warning"`
+}
+
+// ListWallets returns the names of the wallets lbrycrd has loaded. The default wallet's name is "".
+func (c *Client) ListWallets() ([]string, error) {
+	var result []string
+	if err := c.rawCall(&result, "listwallets"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWallet creates a wallet and loads it. A wallet with disablePrivateKeys can only watch addresses.
+func (c *Client) CreateWallet(name string, disablePrivateKeys bool) (*CreateWalletResult, error) {
+	result := new(CreateWalletResult)
+	if err := c.rawCall(result, "createwallet", name, disablePrivateKeys); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LoadWallet loads a wallet from a wallet file or directory in lbrycrd's wallet dir
+func (c *Client) LoadWallet(filename string) (*CreateWalletResult, error) {
+	result := new(CreateWalletResult)
+	if err := c.rawCall(result, "loadwallet", filename); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnloadWallet unloads a wallet. Calls to it fail until it is loaded again.
+func (c *Client) UnloadWallet(name string) error {
+	return c.rawCall(nil, "unloadwallet", name)
+}
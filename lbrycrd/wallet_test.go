@@ -0,0 +1,142 @@
+package lbrycrd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClient_Wallet(t *testing.T) {
+	var mu sync.Mutex
+	var path string
+	paths := map[string]string{} // the path of the last call to each method
+
+	handler := fakeLbrycrdHandler(t, func(req fakeRequest) (interface{}, string) {
+		mu.Lock()
+		paths[req.Method] = path
+		mu.Unlock()
+		switch req.Method {
+		case "getbalance":
+			return 1.5, ""
+		case "getblockcount":
+			return 10, ""
+		case "listwallets":
+			return []string{"", "alice", "bob smith"}, ""
+		case "createwallet", "loadwallet":
+			var name string
+			json.Unmarshal(req.Params[0], &name)
+			return map[string]interface{}{"name": name, "warning": ""}, ""
+		case "unloadwallet":
+			return nil, ""
+		}
+		return nil, "Method not found"
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		path = r.URL.EscapedPath()
+		mu.Unlock()
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	pathOf := func(method string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		return paths[method]
+	}
+
+	c, err := New("rpc://user:pass@" + host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.WalletName() != "" {
+		t.Errorf("expected the default wallet, got %s", c.WalletName())
+	}
+	wallets, err := c.ListWallets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wallets) != 3 || wallets[2] != "bob smith" {
+		t.Errorf("unexpected wallets %v", wallets)
+	}
+
+	bob, err := c.Wallet("bob smith")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bob.Shutdown()
+	if bob.WalletName() != "bob smith" {
+		t.Errorf("expected wallet bob smith, got %s", bob.WalletName())
+	}
+	if _, err := bob.GetBalance("*"); err != nil {
+		t.Fatal(err)
+	}
+	if p := pathOf("getbalance"); p != "/wallet/bob%20smith" {
+		t.Errorf("expected btcd calls to go to the wallet, got path %s", p)
+	}
+	if _, err := bob.blockCount(); err != nil {
+		t.Fatal(err)
+	}
+	if p := pathOf("getblockcount"); p != "/wallet/bob%20smith" {
+		t.Errorf("expected raw calls to go to the wallet, got path %s", p)
+	}
+	b := bob.NewBatch()
+	b.Add(nil, "getbalance")
+	if err := b.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if p := pathOf("getbalance"); p != "/wallet/bob%20smith" {
+		t.Errorf("expected batches to go to the wallet, got path %s", p)
+	}
+
+	// a wallet of a wallet client replaces its wallet
+	alice, err := bob.Wallet("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer alice.Shutdown()
+	if _, err := alice.blockCount(); err != nil {
+		t.Fatal(err)
+	}
+	if p := pathOf("getblockcount"); p != "/wallet/alice" {
+		t.Errorf("expected calls to go to alice, got path %s", p)
+	}
+
+	if _, err := c.blockCount(); err != nil {
+		t.Fatal(err)
+	}
+	if p := pathOf("getblockcount"); p != "/" && p != "" {
+		t.Errorf("expected the original client to use the default wallet, got path %s", p)
+	}
+
+	fromURL, err := New("rpc://user:pass@" + host + "/wallet/alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromURL.WalletName() != "alice" {
+		t.Errorf("expected wallet alice from the url, got %s", fromURL.WalletName())
+	}
+	if _, err := New("rpc://user:pass@" + host + "/other"); err == nil {
+		t.Error("expected an error for a path that isn't a wallet")
+	}
+	if _, err := c.Wallet(""); err == nil {
+		t.Error("expected an error for an empty wallet name")
+	}
+
+	created, err := c.CreateWallet("carol", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Name != "carol" {
+		t.Errorf("expected wallet carol, got %s", created.Name)
+	}
+	if _, err := c.LoadWallet("dave"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UnloadWallet("dave"); err != nil {
+		t.Fatal(err)
+	}
+}
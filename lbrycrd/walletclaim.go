@@ -0,0 +1,113 @@
+package lbrycrd
+
+import (
+	"encoding/hex"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// WalletClaimResult is a claim, update or support made by lbrycrd's wallet. Nout is the output that holds it,
+// and Fee is what its transaction paid.
+type WalletClaimResult struct {
+	TxID    chainhash.Hash
+	Nout    uint32
+	ClaimID string
+	Fee     btcutil.Amount
+	Tx      *wire.MsgTx
+}
+
+// Outpoint returns the output that holds the claim, update or support
+func (r *WalletClaimResult) Outpoint() wire.OutPoint {
+	return wire.OutPoint{Hash: r.TxID, Index: r.Nout}
+}
+
+// WalletClaimName claims a name with a value, staking amount from lbrycrd's wallet. If address is nil, the
+// wallet pays the claim to a new address of its own.
+func (c *Client) WalletClaimName(name string, value []byte, amount btcutil.Amount, address btcutil.Address) (*WalletClaimResult, error) {
+	var txid string
+	if err := c.rawCall(&txid, "claimname", name, hex.EncodeToString(value), amount.ToBTC(), encodeOptionalAddress(address)); err != nil {
+		return nil, err
+	}
+	return c.walletClaimResult(txid, ClaimName, name, "")
+}
+
+// WalletUpdateClaim replaces the value of a claim the wallet owns and changes its stake to amount. txid is the
+// transaction that holds the claim, or its last update. If address is nil, the wallet pays the update to a new
+// address of its own.
+func (c *Client) WalletUpdateClaim(txid *chainhash.Hash, value []byte, amount btcutil.Amount, address btcutil.Address) (*WalletClaimResult, error) {
+	var updateTxID string
+	if err := c.rawCall(&updateTxID, "updateclaim", txid.String(), hex.EncodeToString(value), amount.ToBTC(), encodeOptionalAddress(address)); err != nil {
+		return nil, err
+	}
+	return c.walletClaimResult(updateTxID, ClaimUpdate, "", "")
+}
+
+// WalletSupportClaim supports a claim with amount from lbrycrd's wallet. payload is the serialized
+// SupportPayload, or nil for a support without one. If address is nil, the wallet pays the support to a new
+// address of its own.
+func (c *Client) WalletSupportClaim(name, claimID string, amount btcutil.Amount, payload []byte, address btcutil.Address) (*WalletClaimResult, error) {
+	if _, err := ClaimHashFromID(claimID); err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if payload != nil {
+		value = hex.EncodeToString(payload)
+	}
+	var txid string
+	if err := c.rawCall(&txid, "supportclaim", name, claimID, amount.ToBTC(), value, encodeOptionalAddress(address)); err != nil {
+		return nil, err
+	}
+	return c.walletClaimResult(txid, ClaimSupport, name, claimID)
+}
+
+func encodeOptionalAddress(address btcutil.Address) interface{} {
+	if address == nil {
+		return nil
+	}
+	return address.EncodeAddress()
+}
+
+type walletTransaction struct {
+	Fee float64 `json:"fee"`
+	Hex string  `json:"hex"`
+}
+
+// walletClaimResult looks up the transaction the wallet made and finds the output of the given type in it. name
+// and claimID narrow the search when they're set.
+func (c *Client) walletClaimResult(txid string, scriptType ScriptType, name, claimID string) (*WalletClaimResult, error) {
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	var wtx walletTransaction
+	if err := c.rawCall(&wtx, "gettransaction", txid); err != nil {
+		return nil, err
+	}
+	tx, err := decodeTx(wtx.Hex)
+	if err != nil {
+		return nil, err
+	}
+	// the wallet reports what a transaction it sent paid in fees as a negative amount
+	fee, err := btcutil.NewAmount(-wtx.Fee)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+
+	for i, out := range tx.TxOut {
+		script, err := ParseClaimScript(out.PkScript)
+		if err != nil || script.Type != scriptType || (name != "" && script.Name != name) ||
+			(claimID != "" && script.ClaimID != claimID) {
+			continue
+		}
+		result := &WalletClaimResult{TxID: *hash, Nout: uint32(i), ClaimID: script.ClaimID, Fee: fee, Tx: tx}
+		if scriptType == ClaimName {
+			result.ClaimID = ClaimIDFromHash(ClaimHashFromOutpoint(result.Outpoint()))
+		}
+		return result, nil
+	}
+	return nil, errors.Err("transaction %s has no matching claim output", txid)
+}
@@ -0,0 +1,120 @@
+package lbrycrd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+func TestClient_WalletClaims(t *testing.T) {
+	address, err := btcutil.NewAddressPubKeyHash(make([]byte, 20), &MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimScript, err := ClaimNameScript("test", []byte("value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateScript, err := UpdateClaimScript("test", testChannelClaimID, []byte("new value"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supportScript, err := SupportClaimScript("test", testChannelClaimID, []byte("payload"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txs := map[string]string{}
+	makeTx := func(script []byte) string {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(5000, []byte{txscript.OP_TRUE})) // change
+		tx.AddTxOut(wire.NewTxOut(100000000, script))
+		txHex, err := encodeTx(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txid := tx.TxHash().String()
+		txs[txid] = txHex
+		return txid
+	}
+	claimTxID := makeTx(claimScript)
+	updateTxID := makeTx(updateScript)
+	supportTxID := makeTx(supportScript)
+
+	c, server := newFakeLbrycrd(t, func(req fakeRequest) (interface{}, string) {
+		var params []interface{}
+		for _, p := range req.Params {
+			var v interface{}
+			json.Unmarshal(p, &v)
+			params = append(params, v)
+		}
+		switch req.Method {
+		case "claimname":
+			if len(params) != 3 || params[0] != "test" || params[1] != "76616c7565" || params[2] != 1.0 {
+				t.Errorf("unexpected claimname params %v", params)
+			}
+			return claimTxID, ""
+		case "updateclaim":
+			if len(params) != 4 || params[0] != claimTxID || params[3] != address.EncodeAddress() {
+				t.Errorf("unexpected updateclaim params %v", params)
+			}
+			return updateTxID, ""
+		case "supportclaim":
+			if len(params) != 4 || params[1] != testChannelClaimID || params[3] != "7061796c6f6164" {
+				t.Errorf("unexpected supportclaim params %v", params)
+			}
+			return supportTxID, ""
+		case "gettransaction":
+			txHex, ok := txs[params[0].(string)]
+			if !ok {
+				return nil, "Invalid or non-wallet transaction id"
+			}
+			return map[string]interface{}{"fee": -0.0001, "hex": txHex}, ""
+		}
+		return nil, "Method not found"
+	})
+	defer server.Close()
+
+	claim, err := c.WalletClaimName("test", []byte("value"), btcutil.SatoshiPerBitcoin, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim.TxID.String() != claimTxID || claim.Nout != 1 || claim.Fee != 10000 {
+		t.Errorf("unexpected claim %+v", claim)
+	}
+	expectedID, err := ClaimIDFromOutpoint(claimTxID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim.ClaimID != expectedID {
+		t.Errorf("expected claim id %s, got %s", expectedID, claim.ClaimID)
+	}
+
+	update, err := c.WalletUpdateClaim(&claim.TxID, []byte("new value"), btcutil.SatoshiPerBitcoin, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.TxID.String() != updateTxID || update.Nout != 1 || update.ClaimID != testChannelClaimID {
+		t.Errorf("unexpected update %+v", update)
+	}
+
+	support, err := c.WalletSupportClaim("test", testChannelClaimID, btcutil.SatoshiPerBitcoin, []byte("payload"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if support.Outpoint().String() != supportTxID+":1" || support.ClaimID != testChannelClaimID {
+		t.Errorf("unexpected support %+v", support)
+	}
+
+	if _, err := c.WalletSupportClaim("other", testChannelClaimID, btcutil.SatoshiPerBitcoin, []byte("payload"), nil); err == nil {
+		t.Error("expected an error when the transaction has no support for the name")
+	}
+	if _, err := c.WalletSupportClaim("test", "not a claim id", btcutil.SatoshiPerBitcoin, nil, nil); err == nil {
+		t.Error("expected an error for an invalid claim id")
+	}
+}
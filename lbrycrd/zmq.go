@@ -0,0 +1,392 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/extras/stop"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	log "github.com/sirupsen/logrus"
+)
+
+// ZMQTopic is a notification lbrycrd publishes when started with the matching -zmqpub<topic>=<address> option
+type ZMQTopic string
+
+const (
+	// ZMQHashBlock carries a *chainhash.Hash of each new block
+	ZMQHashBlock = ZMQTopic("hashblock")
+	// ZMQHashTx carries a *chainhash.Hash of each new transaction
+	ZMQHashTx = ZMQTopic("hashtx")
+	// ZMQRawBlock carries each new block as a *Block
+	ZMQRawBlock = ZMQTopic("rawblock")
+	// ZMQRawTx carries each new transaction as a *wire.MsgTx
+	ZMQRawTx = ZMQTopic("rawtx")
+)
+
+// ZMQEvent is a notification from lbrycrd. Seq counts the notifications of each topic, so a gap means some
+// were missed, e.g. while reconnecting. If the body could not be decoded, Err says why and Data holds the raw
+// bytes.
+type ZMQEvent struct {
+	Topic ZMQTopic
+	Seq   uint32
+	Data  interface{}
+	Err   error
+}
+
+const (
+	zmqMinBackoff = 500 * time.Millisecond
+	zmqMaxBackoff = 30 * time.Second
+)
+
+// ZMQSubscriber delivers the notifications lbrycrd publishes on a zmq endpoint. It reconnects when the
+// connection drops, e.g. while lbrycrd restarts.
+type ZMQSubscriber struct {
+	address string
+	topics  []ZMQTopic
+	events  chan ZMQEvent
+	grp     *stop.Group
+
+	mu   sync.Mutex
+	conn net.Conn
+	err  error
+}
+
+// SubscribeZMQ connects to a lbrycrd zmq endpoint, e.g. tcp://127.0.0.1:28332, and subscribes to the topics.
+// lbrycrd can publish each topic on a different endpoint, so subscribe to each endpoint separately. The
+// subscription ends when the context is done or Close is called.
+func SubscribeZMQ(ctx context.Context, address string, topics ...ZMQTopic) (*ZMQSubscriber, error) {
+	if len(topics) == 0 {
+		return nil, errors.Err("no topics to subscribe to")
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	if u.Scheme != "tcp" || u.Host == "" {
+		return nil, errors.Err("unsupported zmq address %s", address)
+	}
+
+	s := &ZMQSubscriber{
+		address: u.Host,
+		topics:  topics,
+		events:  make(chan ZMQEvent),
+		grp:     stop.New(),
+	}
+	conn, err := dialZMQ(ctx, s.address, s.topics)
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+
+	s.grp.Add(1)
+	go func() {
+		defer s.grp.Done()
+		s.run(conn)
+	}()
+
+	s.grp.Add(1)
+	go func() {
+		defer s.grp.Done()
+		select {
+		case <-ctx.Done():
+			s.setErr(errors.Err(ctx.Err()))
+			s.grp.Stop()
+		case <-s.grp.Ch():
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// closing the connection ends the read loop
+		s.conn.Close()
+	}()
+
+	return s, nil
+}
+
+// Events returns the channel that notifications are delivered on. It is closed when the subscription ends.
+func (s *ZMQSubscriber) Events() <-chan ZMQEvent {
+	return s.events
+}
+
+// Err returns the reason the subscription ended, or nil if it is still running or was closed
+func (s *ZMQSubscriber) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close ends the subscription and waits for it to shut down
+func (s *ZMQSubscriber) Close() {
+	s.grp.StopAndWait()
+}
+
+func (s *ZMQSubscriber) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *ZMQSubscriber) stopped() bool {
+	select {
+	case <-s.grp.Ch():
+		return true
+	default:
+		return false
+	}
+}
+
+// run reads from conn, and from new connections when it fails, until the subscription ends
+func (s *ZMQSubscriber) run(conn net.Conn) {
+	defer close(s.events)
+
+	// stops reconnecting when the subscription ends
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.grp.Ch():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	backoff := zmqMinBackoff
+	for {
+		err := s.read(conn)
+		if s.stopped() {
+			return
+		}
+		log.Debugf("lbrycrd zmq: connection to %s failed, reconnecting: %v", s.address, err)
+
+		for {
+			select {
+			case <-s.grp.Ch():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > zmqMaxBackoff {
+				backoff = zmqMaxBackoff
+			}
+
+			conn, err = dialZMQ(ctx, s.address, s.topics)
+			if err == nil {
+				break
+			}
+			log.Debugf("lbrycrd zmq: reconnecting to %s failed: %v", s.address, err)
+		}
+
+		s.mu.Lock()
+		if s.stopped() {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conn = conn
+		s.mu.Unlock()
+		backoff = zmqMinBackoff
+	}
+}
+
+// read delivers the notifications on conn until it fails or the subscription ends
+func (s *ZMQSubscriber) read(conn net.Conn) error {
+	for {
+		parts, err := readZMQMessage(conn)
+		if err != nil {
+			return err
+		}
+		// lbrycrd sends the topic, the body and the sequence number
+		if len(parts) != 3 || len(parts[2]) != 4 {
+			return errors.Err("unexpected zmq message with %d parts", len(parts))
+		}
+		e, err := decodeZMQEvent(ZMQTopic(parts[0]), parts[1])
+		if err != nil {
+			// the connection is still fine, so the body is passed on instead of reconnecting
+			e.Data = parts[1]
+			e.Err = err
+		}
+		e.Seq = binary.LittleEndian.Uint32(parts[2])
+
+		select {
+		case s.events <- e:
+		case <-s.grp.Ch():
+			return nil
+		}
+	}
+}
+
+func decodeZMQEvent(topic ZMQTopic, body []byte) (ZMQEvent, error) {
+	e := ZMQEvent{Topic: topic}
+	switch topic {
+	case ZMQHashBlock, ZMQHashTx:
+		// hashes are sent in the order they are displayed in
+		hash, err := chainhash.NewHash(rev(body))
+		if err != nil {
+			return e, errors.Err(err)
+		}
+		e.Data = hash
+	case ZMQRawTx:
+		tx := wire.NewMsgTx(wire.TxVersion)
+		if err := tx.Deserialize(bytes.NewReader(body)); err != nil {
+			return e, errors.Prefix("decoding zmq rawtx", err)
+		}
+		e.Data = tx
+	case ZMQRawBlock:
+		block, err := ParseBlock(body)
+		if err != nil {
+			return e, errors.Prefix("decoding zmq rawblock", err)
+		}
+		e.Data = block
+	default:
+		e.Data = body
+	}
+	return e, nil
+}
+
+// lbrycrd speaks ZMTP 3.0, see https://rfc.zeromq.org/spec/23/. Only what a SUB socket needs is implemented.
+
+const (
+	zmtpFlagMore    = 0x01
+	zmtpFlagLong    = 0x02
+	zmtpFlagCommand = 0x04
+
+	zmtpGreetingSize = 64
+	zmtpMaxFrameSize = 64 << 20 // a little more than the largest block
+)
+
+// zmtpGreeting is the greeting for version 3.0 with the NULL security mechanism
+func zmtpGreeting() []byte {
+	g := make([]byte, zmtpGreetingSize)
+	g[0], g[9] = 0xff, 0x7f
+	g[10], g[11] = 3, 0
+	copy(g[12:32], "NULL")
+	return g
+}
+
+// dialZMQ connects to a publisher and subscribes to the topics
+func dialZMQ(ctx context.Context, address string, topics []ZMQTopic) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	if err := zmtpHandshake(conn, "SUB"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for _, t := range topics {
+		// ZMTP 3.0 subscribes with a message that starts with 1, followed by the topic
+		if err := writeZMTPFrame(conn, 0, append([]byte{1}, t...)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// zmtpHandshake exchanges greetings and READY commands with the peer
+func zmtpHandshake(conn net.Conn, socketType string) error {
+	if err := conn.SetDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return errors.Err(err)
+	}
+	defer conn.SetDeadline(time.Time{})
+
+	if _, err := conn.Write(zmtpGreeting()); err != nil {
+		return errors.Err(err)
+	}
+	greeting := make([]byte, zmtpGreetingSize)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return errors.Err(err)
+	}
+	if greeting[0] != 0xff || greeting[9] != 0x7f || greeting[10] < 3 {
+		return errors.Err("peer does not speak zmtp 3")
+	}
+	if string(bytes.TrimRight(greeting[12:32], "\x00")) != "NULL" {
+		return errors.Err("unsupported zmtp security mechanism")
+	}
+
+	ready := []byte{5}
+	ready = append(ready, "READY"...)
+	ready = append(ready, byte(len("Socket-Type")))
+	ready = append(ready, "Socket-Type"...)
+	size := make([]byte, 4)
+	binary.BigEndian.PutUint32(size, uint32(len(socketType)))
+	ready = append(ready, size...)
+	ready = append(ready, socketType...)
+	if err := writeZMTPFrame(conn, zmtpFlagCommand, ready); err != nil {
+		return err
+	}
+
+	flags, body, err := readZMTPFrame(conn)
+	if err != nil {
+		return err
+	}
+	if flags&zmtpFlagCommand == 0 || len(body) < 6 || string(body[1:6]) != "READY" {
+		return errors.Err("expected a zmtp READY command")
+	}
+	return nil
+}
+
+func writeZMTPFrame(w io.Writer, flags byte, body []byte) error {
+	var header []byte
+	if len(body) > 255 {
+		header = make([]byte, 9)
+		header[0] = flags | zmtpFlagLong
+		binary.BigEndian.PutUint64(header[1:], uint64(len(body)))
+	} else {
+		header = []byte{flags, byte(len(body))}
+	}
+	if _, err := w.Write(append(header, body...)); err != nil {
+		return errors.Err(err)
+	}
+	return nil
+}
+
+func readZMTPFrame(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, 9)
+	if _, err := io.ReadFull(r, header[:2]); err != nil {
+		return 0, nil, errors.Err(err)
+	}
+	flags := header[0]
+	size := uint64(header[1])
+	if flags&zmtpFlagLong != 0 {
+		if _, err := io.ReadFull(r, header[2:]); err != nil {
+			return 0, nil, errors.Err(err)
+		}
+		size = binary.BigEndian.Uint64(header[1:])
+	}
+	if size > zmtpMaxFrameSize {
+		return 0, nil, errors.Err("zmtp frame of %d bytes is too large", size)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, errors.Err(err)
+	}
+	return flags, body, nil
+}
+
+// readZMQMessage reads the frames of the next message, skipping commands such as PING
+func readZMQMessage(r io.Reader) ([][]byte, error) {
+	var parts [][]byte
+	for {
+		flags, body, err := readZMTPFrame(r)
+		if err != nil {
+			return nil, err
+		}
+		if flags&zmtpFlagCommand != 0 {
+			continue
+		}
+		parts = append(parts, body)
+		if flags&zmtpFlagMore == 0 {
+			return parts, nil
+		}
+	}
+}
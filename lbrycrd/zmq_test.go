@@ -0,0 +1,275 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// fakePublisher is a zmq PUB socket that sends each connection the messages from its channel, and drops the
+// connection when it receives nil
+type fakePublisher struct {
+	t             *testing.T
+	listener      net.Listener
+	messages      chan [][]byte
+	subscriptions chan []string
+}
+
+func newFakePublisher(t *testing.T) *fakePublisher {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &fakePublisher{
+		t:             t,
+		listener:      listener,
+		messages:      make(chan [][]byte),
+		subscriptions: make(chan []string, 10),
+	}
+	go p.serve()
+	return p
+}
+
+func (p *fakePublisher) address() string {
+	return "tcp://" + p.listener.Addr().String()
+}
+
+func (p *fakePublisher) close() {
+	p.listener.Close()
+}
+
+func (p *fakePublisher) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		p.handle(conn)
+	}
+}
+
+func (p *fakePublisher) handle(conn net.Conn) {
+	defer conn.Close()
+	if err := zmtpHandshake(conn, "PUB"); err != nil {
+		p.t.Error(err)
+		return
+	}
+
+	// the subscriber sends its subscriptions right after the handshake
+	var topics []string
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	for {
+		_, body, err := readZMTPFrame(conn)
+		if err != nil {
+			break
+		}
+		if len(body) > 0 && body[0] == 1 {
+			topics = append(topics, string(body[1:]))
+		}
+	}
+	conn.SetReadDeadline(time.Time{})
+	p.subscriptions <- topics
+
+	for msg := range p.messages {
+		if msg == nil {
+			return
+		}
+		for i, part := range msg {
+			var flags byte
+			if i < len(msg)-1 {
+				flags = zmtpFlagMore
+			}
+			if err := writeZMTPFrame(conn, flags, part); err != nil {
+				p.t.Error(err)
+				return
+			}
+		}
+	}
+}
+
+func zmqMessage(topic ZMQTopic, body []byte, seq uint32) [][]byte {
+	s := make([]byte, 4)
+	binary.LittleEndian.PutUint32(s, seq)
+	return [][]byte{[]byte(topic), body, s}
+}
+
+func nextZMQEvent(t *testing.T, s *ZMQSubscriber) ZMQEvent {
+	t.Helper()
+	select {
+	case e, ok := <-s.Events():
+		if !ok {
+			t.Fatalf("subscription ended: %v", s.Err())
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return ZMQEvent{}
+}
+
+func TestSubscribeZMQ(t *testing.T) {
+	p := newFakePublisher(t)
+	defer p.close()
+
+	s, err := SubscribeZMQ(context.Background(), p.address(), ZMQHashBlock, ZMQRawTx, ZMQRawBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	topics := <-p.subscriptions
+	if len(topics) != 3 || topics[0] != "hashblock" || topics[1] != "rawtx" || topics[2] != "rawblock" {
+		t.Errorf("unexpected subscriptions %v", topics)
+	}
+
+	hash, _ := chainhash.NewHashFromStr("f3d9a1b7b2e0f5c4a3b1c2d3e4f5061728394a5b6c7d8e9fa0b1c2d3e4f50617")
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(hash, 1), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(100000000, []byte{0x76, 0xa9}))
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		t.Fatal(err)
+	}
+
+	p.messages <- zmqMessage(ZMQHashBlock, rev(hash[:]), 7)
+	e := nextZMQEvent(t, s)
+	if h, ok := e.Data.(*chainhash.Hash); !ok || !h.IsEqual(hash) || e.Seq != 7 || e.Topic != ZMQHashBlock {
+		t.Errorf("unexpected hashblock event %+v", e)
+	}
+
+	p.messages <- zmqMessage(ZMQRawTx, rawTx.Bytes(), 8)
+	e = nextZMQEvent(t, s)
+	if got, ok := e.Data.(*wire.MsgTx); !ok || got.TxHash() != tx.TxHash() {
+		t.Errorf("unexpected rawtx event %+v", e)
+	}
+
+	block := Block{Header: BlockHeader{Version: 536870912, PrevBlock: *hash, Timestamp: time.Unix(1600000000, 0)}, Transactions: []*wire.MsgTx{tx}}
+	var rawBlock bytes.Buffer
+	if err := block.Serialize(&rawBlock); err != nil {
+		t.Fatal(err)
+	}
+	p.messages <- zmqMessage(ZMQRawBlock, rawBlock.Bytes(), 9)
+	e = nextZMQEvent(t, s)
+	if got, ok := e.Data.(*Block); !ok || got.BlockHash() != block.BlockHash() || len(got.Transactions) != 1 || e.Err != nil {
+		t.Errorf("unexpected rawblock event %+v", e)
+	}
+
+	// a body that can't be decoded is delivered raw, on the same connection
+	truncated := rawBlock.Bytes()[:50]
+	p.messages <- zmqMessage(ZMQRawBlock, truncated, 10)
+	e = nextZMQEvent(t, s)
+	if got, ok := e.Data.([]byte); !ok || !bytes.Equal(got, truncated) || e.Err == nil || e.Seq != 10 {
+		t.Errorf("unexpected event for a bad rawblock %+v", e)
+	}
+	p.messages <- zmqMessage(ZMQRawBlock, rawBlock.Bytes(), 11)
+	if e = nextZMQEvent(t, s); e.Seq != 11 || e.Err != nil {
+		t.Errorf("unexpected rawblock event after a bad one %+v", e)
+	}
+	select {
+	case <-p.subscriptions:
+		t.Error("expected a bad rawblock not to drop the connection")
+	default:
+	}
+
+	s.Close()
+	if _, open := <-s.Events(); open {
+		t.Error("events channel should be closed")
+	}
+	if s.Err() != nil {
+		t.Errorf("closing should not set an error, got %v", s.Err())
+	}
+}
+
+func TestSubscribeZMQ_Reconnect(t *testing.T) {
+	p := newFakePublisher(t)
+	defer p.close()
+
+	s, err := SubscribeZMQ(context.Background(), p.address(), ZMQHashTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	<-p.subscriptions
+
+	hash, _ := chainhash.NewHashFromStr("0a")
+	p.messages <- zmqMessage(ZMQHashTx, rev(hash[:]), 1)
+	nextZMQEvent(t, s)
+
+	// drop the connection. the subscriber should reconnect and subscribe again
+	p.messages <- nil
+	select {
+	case topics := <-p.subscriptions:
+		if len(topics) != 1 || topics[0] != "hashtx" {
+			t.Errorf("unexpected subscriptions %v", topics)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscriber did not reconnect")
+	}
+
+	p.messages <- zmqMessage(ZMQHashTx, rev(hash[:]), 2)
+	if e := nextZMQEvent(t, s); e.Seq != 2 {
+		t.Errorf("unexpected event after reconnecting %+v", e)
+	}
+}
+
+func TestSubscribeZMQ_Context(t *testing.T) {
+	p := newFakePublisher(t)
+	defer p.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := SubscribeZMQ(ctx, p.address(), ZMQHashBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	<-p.subscriptions
+
+	cancel()
+	select {
+	case _, open := <-s.Events():
+		if open {
+			t.Error("expected no events")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription was not canceled")
+	}
+	if !errors.Is(s.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", s.Err())
+	}
+}
+
+func TestSubscribeZMQ_Errors(t *testing.T) {
+	if _, err := SubscribeZMQ(context.Background(), "tcp://127.0.0.1:1"); err == nil {
+		t.Error("expected an error without topics")
+	}
+	if _, err := SubscribeZMQ(context.Background(), "ipc:///tmp/lbrycrd", ZMQHashBlock); err == nil {
+		t.Error("expected an error for an unsupported address")
+	}
+
+	// a server that doesn't speak zmtp
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.WriteString(conn, "HTTP/1.1 400 Bad Request\r\n\r\n"+string(make([]byte, zmtpGreetingSize)))
+	}()
+	if _, err := SubscribeZMQ(context.Background(), "tcp://"+listener.Addr().String(), ZMQHashBlock); err == nil {
+		t.Error("expected an error from a server that doesn't speak zmtp")
+	}
+}
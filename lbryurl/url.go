@@ -0,0 +1,257 @@
+package lbryurl
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Scheme is the prefix of every LBRY URL. It is optional when parsing
+const Scheme = "lbry://"
+
+const (
+	claimIDMaxLength = 40
+	channelPrefix    = '@'
+)
+
+// InvalidNameChars are the URL delimiters, which can't be used in stream or channel names. A channel name
+// starts with '@', but has none after that.
+const InvalidNameChars = "=&#:$@%?;\"/\\<>{}|^~`[]*"
+
+// modifier separators. ':' and '#' both introduce a claim ID
+const (
+	claimIDSeparator     = '#'
+	claimIDSeparatorAlt  = ':'
+	sequenceSeparator    = '*'
+	amountOrderSeparator = '$'
+	modifierSeparators   = "#:*$"
+)
+
+// PathPart is a single claim in a URL: a stream or channel name followed by at most one modifier
+type PathPart struct {
+	Name        string // includes the leading @ for channels
+	ClaimID     string // full or partial claim ID
+	Sequence    int    // nth claim for the name, starting at 1. 0 if not set
+	AmountOrder int    // nth claim for the name by amount, starting at 1. 0 if not set
+
+	separator byte // the claim ID separator used in the parsed URL, so it can be round-tripped
+}
+
+// URL is a parsed LBRY URL. It points at a stream, a channel, or a stream in a channel
+type URL struct {
+	Channel  *PathPart
+	Stream   *PathPart
+	RawQuery string // query string without the leading ?
+}
+
+// Parse parses a LBRY URL. The lbry:// scheme is optional.
+func Parse(raw string) (*URL, error) {
+	u := &URL{}
+
+	rest := strings.TrimPrefix(raw, Scheme)
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		u.RawQuery = rest[i+1:]
+		rest = rest[:i]
+		if _, err := url.ParseQuery(u.RawQuery); err != nil {
+			return nil, errors.Prefix("invalid query string", err)
+		}
+	}
+
+	if rest == "" {
+		return nil, errors.Err("url %q is empty", raw)
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) > 2 {
+		return nil, errors.Err("url %q has too many path segments", raw)
+	}
+
+	for i, p := range parts {
+		part, err := parsePathPart(p)
+		if err != nil {
+			return nil, err
+		}
+
+		isChannel := part.Name[0] == channelPrefix
+		switch {
+		case isChannel && i == 0:
+			u.Channel = part
+		case !isChannel && i == len(parts)-1:
+			u.Stream = part
+		case isChannel:
+			return nil, errors.Err("%q must come before the stream name", part.Name)
+		default:
+			return nil, errors.Err("%q is not a channel name, so it cannot contain a stream", part.Name)
+		}
+	}
+
+	return u, nil
+}
+
+func parsePathPart(s string) (*PathPart, error) {
+	p := &PathPart{}
+
+	nameEnd := strings.IndexAny(s, modifierSeparators)
+	if nameEnd < 0 {
+		nameEnd = len(s)
+	}
+	p.Name = s[:nameEnd]
+
+	err := validateName(p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if nameEnd == len(s) {
+		return p, nil
+	}
+
+	sep, value := s[nameEnd], s[nameEnd+1:]
+	switch sep {
+	case claimIDSeparator, claimIDSeparatorAlt:
+		if !isClaimID(value) {
+			return nil, errors.Err("claim ID %q must be 1 to %d lowercase hex characters", value, claimIDMaxLength)
+		}
+		p.ClaimID = value
+		p.separator = sep
+	case sequenceSeparator:
+		p.Sequence, err = parsePositive(value)
+		if err != nil {
+			return nil, errors.Prefix("invalid sequence", err)
+		}
+	case amountOrderSeparator:
+		p.AmountOrder, err = parsePositive(value)
+		if err != nil {
+			return nil, errors.Prefix("invalid amount order", err)
+		}
+	}
+
+	return p, nil
+}
+
+// validateName checks that a stream or channel name is non-empty and has no forbidden characters
+func validateName(name string) error {
+	if strings.HasPrefix(name, string(channelPrefix)) {
+		name = name[1:]
+	}
+	if name == "" {
+		return errors.Err("name is empty")
+	}
+	if !utf8.ValidString(name) {
+		return errors.Err("name %q is not valid utf-8", name)
+	}
+	for _, r := range name {
+		if isForbidden(r) {
+			return errors.Err("name %q contains forbidden character %q", name, r)
+		}
+	}
+	return nil
+}
+
+// isForbidden returns true for characters that may not appear in a name. these are the URL delimiters,
+// whitespace and control characters, and unicode noncharacters
+func isForbidden(r rune) bool {
+	if r <= 0x20 || r == 0xFFFE || r == 0xFFFF || (r >= 0xD800 && r <= 0xDFFF) {
+		return true
+	}
+	return strings.ContainsRune(InvalidNameChars, r)
+}
+
+func isClaimID(s string) bool {
+	if len(s) == 0 || len(s) > claimIDMaxLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func parsePositive(s string) (int, error) {
+	if s == "" || s[0] == '0' {
+		return 0, errors.Err("%q must be a positive integer", s)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, errors.Err("%q must be a positive integer", s)
+	}
+	return n, nil
+}
+
+// String returns the URL with the lbry:// scheme. Parsing it gives back the same URL
+func (u URL) String() string {
+	var b strings.Builder
+	b.WriteString(Scheme)
+	if u.Channel != nil {
+		b.WriteString(u.Channel.String())
+	}
+	if u.Stream != nil {
+		if u.Channel != nil {
+			b.WriteByte('/')
+		}
+		b.WriteString(u.Stream.String())
+	}
+	if u.RawQuery != "" {
+		b.WriteByte('?')
+		b.WriteString(u.RawQuery)
+	}
+	return b.String()
+}
+
+// Query parses the query string
+func (u URL) Query() url.Values {
+	v, _ := url.ParseQuery(u.RawQuery)
+	return v
+}
+
+// IsChannel returns true if the URL points at a channel rather than a stream
+func (u URL) IsChannel() bool {
+	return u.Channel != nil && u.Stream == nil
+}
+
+// Normalized returns a copy of the URL with every name normalized, for comparing URLs
+func (u URL) Normalized() URL {
+	if u.Channel != nil {
+		c := *u.Channel
+		c.Name = Normalize(c.Name)
+		u.Channel = &c
+	}
+	if u.Stream != nil {
+		s := *u.Stream
+		s.Name = Normalize(s.Name)
+		u.Stream = &s
+	}
+	return u
+}
+
+// String returns the name and its modifier, if it has one
+func (p PathPart) String() string {
+	switch {
+	case p.ClaimID != "":
+		sep := p.separator
+		if sep == 0 {
+			sep = claimIDSeparator
+		}
+		return p.Name + string(sep) + p.ClaimID
+	case p.Sequence > 0:
+		return p.Name + string(sequenceSeparator) + strconv.Itoa(p.Sequence)
+	case p.AmountOrder > 0:
+		return p.Name + string(amountOrderSeparator) + strconv.Itoa(p.AmountOrder)
+	default:
+		return p.Name
+	}
+}
+
+// Normalize normalizes a name the same way the claimtrie does: unicode NFD followed by case folding
+func Normalize(name string) string {
+	return cases.Fold().String(norm.NFD.String(name))
+}
@@ -0,0 +1,102 @@
+package lbryurl
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		url     string
+		channel PathPart
+		stream  PathPart
+		query   string
+	}{
+		{url: "lbry://what", stream: PathPart{Name: "what"}},
+		{url: "lbry://what#1c8", stream: PathPart{Name: "what", ClaimID: "1c8"}},
+		{url: "lbry://what:1c8", stream: PathPart{Name: "what", ClaimID: "1c8"}},
+		{url: "lbry://what*2", stream: PathPart{Name: "what", Sequence: 2}},
+		{url: "lbry://what$3", stream: PathPart{Name: "what", AmountOrder: 3}},
+		{url: "lbry://@lbry", channel: PathPart{Name: "@lbry"}},
+		{url: "lbry://@lbry#3f/what", channel: PathPart{Name: "@lbry", ClaimID: "3f"}, stream: PathPart{Name: "what"}},
+		{url: "@lbry/what:6ac?t=30", channel: PathPart{Name: "@lbry"}, stream: PathPart{Name: "what", ClaimID: "6ac"}, query: "t=30"},
+		{url: "lbry://ünïcödé", stream: PathPart{Name: "ünïcödé"}},
+	}
+
+	for _, test := range tests {
+		u, err := Parse(test.url)
+		if err != nil {
+			t.Errorf("%s: %v", test.url, err)
+			continue
+		}
+		check := func(which string, actual *PathPart, expected PathPart) {
+			if expected.Name == "" {
+				if actual != nil {
+					t.Errorf("%s: expected no %s, got %+v", test.url, which, *actual)
+				}
+				return
+			}
+			if actual == nil {
+				t.Errorf("%s: expected %s %+v, got none", test.url, which, expected)
+				return
+			}
+			if actual.Name != expected.Name || actual.ClaimID != expected.ClaimID ||
+				actual.Sequence != expected.Sequence || actual.AmountOrder != expected.AmountOrder {
+				t.Errorf("%s: expected %s %+v, got %+v", test.url, which, expected, *actual)
+			}
+		}
+		check("channel", u.Channel, test.channel)
+		check("stream", u.Stream, test.stream)
+		if u.RawQuery != test.query {
+			t.Errorf("%s: expected query %q, got %q", test.url, test.query, u.RawQuery)
+		}
+
+		expected := test.url
+		if expected[0] == '@' {
+			expected = Scheme + expected
+		}
+		if u.String() != expected {
+			t.Errorf("%s: round trip gave %s", test.url, u.String())
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	urls := []string{
+		"",
+		"lbry://",
+		"lbry://@",
+		"lbry://what/@lbry",
+		"lbry://what/else",
+		"lbry://@a/@b",
+		"lbry://@a/b/c",
+		"lbry://what#xyz",
+		"lbry://what#ABC",
+		"lbry://what#12345678901234567890123456789012345678901",
+		"lbry://what*0",
+		"lbry://what$-1",
+		"lbry://wh at",
+		"lbry://wh@t",
+		"lbry://what?%zz",
+	}
+	for _, url := range urls {
+		u, err := Parse(url)
+		if err == nil {
+			t.Errorf("%q: expected an error, got %s", url, u)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	u, err := Parse("lbry://@Channel/Ünicode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := u.Normalized()
+	if n.Channel.Name != "@channel" {
+		t.Errorf("expected @channel, got %s", n.Channel.Name)
+	}
+	if n.Stream.Name != Normalize("ünicode") {
+		t.Errorf("expected %s, got %s", Normalize("ünicode"), n.Stream.Name)
+	}
+	if u.Stream.Name != "Ünicode" {
+		t.Error("Normalized modified the original url")
+	}
+}
@@ -1,10 +1,10 @@
 package stream
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/sha512"
+	"crypto/subtle"
 	"encoding/hex"
 	"strconv"
 
@@ -22,6 +22,12 @@ type Blob []byte
 var ErrBlobTooBig = errors.Base("blob must be at most " + strconv.Itoa(MaxBlobSize) + " bytes")
 var ErrBlobEmpty = errors.Base("blob is empty")
 
+// ErrTruncatedBlob means the encrypted data is not a whole number of cipher blocks, so part of it is missing
+var ErrTruncatedBlob = errors.Base("encrypted blob length is not a multiple of the block size, blob may be truncated")
+
+// ErrInvalidPadding means the blob decrypted to data with bad padding, which usually means the key or IV is wrong
+var ErrInvalidPadding = errors.Base("invalid padding after decryption, key or IV may be wrong")
+
 func (b Blob) Size() int {
 	return len(b)
 }
@@ -51,28 +57,17 @@ func (b Blob) ValidForSend() error {
 	return nil
 }
 
+// NewBlob pads and encrypts data into a blob
 func NewBlob(data, key, iv []byte) (Blob, error) {
 	if len(data) == 0 {
 		// this is here to match python behavior. in theory we could encrypt an empty blob
 		return nil, errors.Err("cannot encrypt empty slice")
 	}
-	blockCipher, err := aes.NewCipher(key)
+	blockCipher, err := newBlockCipher(key, iv)
 	if err != nil {
-		return nil, errors.Err(err)
+		return nil, err
 	}
-	if len(iv) != blockCipher.BlockSize() {
-		return nil, errors.Err("IV length must equal to block size")
-	}
-
-	cbc := cipher.NewCBCEncrypter(blockCipher, iv)
-	plaintext, err := pkcs7Pad(data, blockCipher.BlockSize())
-	if err != nil {
-		return nil, errors.Err(err)
-	}
-
-	ciphertext := make([]byte, len(plaintext))
-	cbc.CryptBlocks(ciphertext, plaintext)
-	return ciphertext, nil
+	return encryptBlob(blockCipher, data, iv), nil
 }
 
 // DecryptBlob decrypts a blob
@@ -80,7 +75,18 @@ func DecryptBlob(b Blob, key, iv []byte) ([]byte, error) {
 	return b.Plaintext(key, iv)
 }
 
+// Plaintext decrypts the blob and removes the padding
 func (b Blob) Plaintext(key, iv []byte) ([]byte, error) {
+	blockCipher, err := newBlockCipher(key, iv)
+	if err != nil {
+		return nil, err
+	}
+	return b.appendPlaintext(nil, blockCipher, iv)
+}
+
+// newBlockCipher creates the AES cipher for a stream key. crypto/aes uses the CPU's AES instructions
+// when they are available, and the cipher can be reused for every blob in the stream.
+func newBlockCipher(key, iv []byte) (cipher.Block, error) {
 	blockCipher, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, errors.Err(err)
@@ -88,51 +94,89 @@ func (b Blob) Plaintext(key, iv []byte) ([]byte, error) {
 	if len(iv) != blockCipher.BlockSize() {
 		return nil, errors.Err("IV length must equal to block size")
 	}
+	return blockCipher, nil
+}
+
+// encryptBlob pads and encrypts data using a single allocation. The padding is written right after
+// the data and the buffer is encrypted in place. data must not be empty.
+func encryptBlob(blockCipher cipher.Block, data, iv []byte) Blob {
+	blockLen := blockCipher.BlockSize()
+	padLen := blockLen - len(data)%blockLen
+
+	buf := make([]byte, len(data)+padLen)
+	copy(buf, data)
+	for i := len(data); i < len(buf); i++ {
+		buf[i] = byte(padLen)
+	}
+
+	cipher.NewCBCEncrypter(blockCipher, iv).CryptBlocks(buf, buf)
+	return buf
+}
 
-	cbc := cipher.NewCBCDecrypter(blockCipher, iv)
-	plaintext := make([]byte, len(b))
-	cbc.CryptBlocks(plaintext, b)
+// appendPlaintext decrypts the blob directly into the spare capacity of dst and returns dst extended
+// by the plaintext. dst is only reallocated if it does not have room for the whole encrypted blob.
+func (b Blob) appendPlaintext(dst []byte, blockCipher cipher.Block, iv []byte) ([]byte, error) {
+	blockLen := blockCipher.BlockSize()
+	if len(b) == 0 || len(b)%blockLen != 0 {
+		return dst, errors.Err(ErrTruncatedBlob)
+	}
 
-	plaintext, err = pkcs7Unpad(plaintext, blockCipher.BlockSize())
+	start := len(dst)
+	if cap(dst)-start < len(b) {
+		grown := make([]byte, start, start+len(b))
+		copy(grown, dst)
+		dst = grown
+	}
+	out := dst[start : start+len(b)]
+
+	cipher.NewCBCDecrypter(blockCipher, iv).CryptBlocks(out, b)
+
+	plaintext, err := PKCS7Unpad(out, blockLen)
 	if err != nil {
-		return nil, errors.Err(err)
+		return dst[:start], err
 	}
 
-	return plaintext, nil
+	return dst[:start+len(plaintext)], nil
 }
 
-// https://github.com/fullsailor/pkcs7/blob/master/pkcs7.go#L468
-func pkcs7Pad(data []byte, blockLen int) ([]byte, error) {
-	if blockLen < 1 {
+// PKCS7Pad returns a copy of data with PKCS#7 padding added, so its length is a multiple of blockLen.
+// At least one byte of padding is always added.
+func PKCS7Pad(data []byte, blockLen int) ([]byte, error) {
+	if blockLen < 1 || blockLen > 255 {
 		return nil, errors.Err("invalid block length %d", blockLen)
 	}
-	padLen := blockLen - (len(data) % blockLen)
-	if padLen == 0 {
-		padLen = blockLen
-	}
+	padLen := blockLen - len(data)%blockLen
 	padded := make([]byte, len(data)+padLen)
 	copy(padded, data)
-	copy(padded[len(padded)-padLen:], bytes.Repeat([]byte{byte(padLen)}, padLen))
+	for i := len(data); i < len(padded); i++ {
+		padded[i] = byte(padLen)
+	}
 	return padded, nil
 }
 
-func pkcs7Unpad(data []byte, blockLen int) ([]byte, error) {
-	if blockLen < 1 {
+// PKCS7Unpad checks and removes PKCS#7 padding, returning a subslice of data. The padding is checked in
+// constant time, so the time taken does not reveal how much of it was valid. It returns ErrTruncatedBlob
+// if data is not a whole number of blocks, and ErrInvalidPadding if it is but the padding is wrong.
+func PKCS7Unpad(data []byte, blockLen int) ([]byte, error) {
+	if blockLen < 1 || blockLen > 255 {
 		return nil, errors.Err("invalid block length %d", blockLen)
 	}
-	if len(data)%blockLen != 0 || len(data) == 0 {
-		return nil, errors.Err("invalid data length %d", len(data))
+	if len(data) == 0 || len(data)%blockLen != 0 {
+		return nil, errors.Err(ErrTruncatedBlob)
 	}
 
 	// the last byte is the length of padding
 	padLen := int(data[len(data)-1])
+	valid := subtle.ConstantTimeLessOrEq(1, padLen) & subtle.ConstantTimeLessOrEq(padLen, blockLen)
 
-	// check padding integrity, all bytes should be the same
-	pad := data[len(data)-padLen:]
-	for _, padbyte := range pad {
-		if padbyte != byte(padLen) {
-			return nil, errors.Err("invalid padding")
-		}
+	// check the whole last block, not just the padding, so the timing doesn't depend on padLen
+	lastBlock := data[len(data)-blockLen:]
+	for i, b := range lastBlock {
+		isPad := subtle.ConstantTimeLessOrEq(blockLen, i+padLen)
+		valid &= subtle.ConstantTimeByteEq(b, byte(padLen)) | (isPad ^ 1)
+	}
+	if valid != 1 {
+		return nil, errors.Err(ErrInvalidPadding)
 	}
 
 	return data[:len(data)-padLen], nil
@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
 )
 
-func Test_pkcs7Pad(t *testing.T) {
+func TestPKCS7Pad(t *testing.T) {
 	blockLen := 16
 	tests := map[string]struct {
 		data     []byte
@@ -43,7 +45,7 @@ func Test_pkcs7Pad(t *testing.T) {
 	}
 
 	for name, tt := range tests {
-		actual, err := pkcs7Pad(tt.data, blockLen)
+		actual, err := PKCS7Pad(tt.data, blockLen)
 		if err != nil {
 			t.Errorf("%s: %v", name, err)
 			continue
@@ -53,7 +55,7 @@ func Test_pkcs7Pad(t *testing.T) {
 			t.Errorf("%s: got %s, expected %s", name, hex.EncodeToString(actual), hex.EncodeToString(tt.expected))
 		}
 
-		unpadded, err := pkcs7Unpad(actual, blockLen)
+		unpadded, err := PKCS7Unpad(actual, blockLen)
 		if err != nil {
 			t.Errorf("%s: unpad: %v", name, err)
 			continue
@@ -65,6 +67,28 @@ func Test_pkcs7Pad(t *testing.T) {
 	}
 }
 
+func TestPKCS7Unpad_Invalid(t *testing.T) {
+	blockLen := 16
+	tests := map[string]struct {
+		data     []byte
+		expected error
+	}{
+		"empty":          {data: []byte{}, expected: ErrTruncatedBlob},
+		"partial block":  {data: bytes.Repeat([]byte{1}, 17), expected: ErrTruncatedBlob},
+		"zero pad":       {data: make([]byte, 16), expected: ErrInvalidPadding},
+		"pad too long":   {data: bytes.Repeat([]byte{17}, 32), expected: ErrInvalidPadding},
+		"pad too long 2": {data: bytes.Repeat([]byte{255}, 16), expected: ErrInvalidPadding},
+		"mismatched pad": {data: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 4, 4}, expected: ErrInvalidPadding},
+	}
+
+	for name, tt := range tests {
+		_, err := PKCS7Unpad(tt.data, blockLen)
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("%s: got error %v, expected %v", name, err, tt.expected)
+		}
+	}
+}
+
 func TestBlob_Encrypt(t *testing.T) {
 	tests := map[string]struct {
 		key, iv, data, ciphertext string
@@ -138,7 +162,59 @@ func TestBlob_Plaintext(t *testing.T) {
 	}
 }
 
-func testdata(t *testing.T, filename string) []byte {
+func TestBlob_PlaintextErrors(t *testing.T) {
+	key := unhex(t, "b450f70bd285726e470428df6c6ff8d2")
+	iv := unhex(t, "0553e3eb17916333d3468286a30738f1")
+	blob := Blob(testdata(t, "a2f1841bb9c5f3b583ac3b8c07ee1a5bf9cc48923721c30d5ca6318615776c284e8936d72fa4db7fdda2e4e9598b1e6c"))
+
+	_, err := blob[:blob.Size()-1].Plaintext(key, iv)
+	if !errors.Is(err, ErrTruncatedBlob) {
+		t.Errorf("truncated blob: got error %v, expected %v", err, ErrTruncatedBlob)
+	}
+
+	wrongKey := unhex(t, "00000000000000000000000000000000")
+	_, err = blob.Plaintext(wrongKey, iv)
+	if !errors.Is(err, ErrInvalidPadding) {
+		t.Errorf("wrong key: got error %v, expected %v", err, ErrInvalidPadding)
+	}
+}
+
+func BenchmarkNewBlob(b *testing.B) {
+	key := randIV()
+	iv := randIV()
+	data := bytes.Repeat([]byte{0x42}, maxBlobDataSize)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := NewBlob(data, key, iv)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlob_Plaintext(b *testing.B) {
+	key := randIV()
+	iv := randIV()
+	blob, err := NewBlob(bytes.Repeat([]byte{0x42}, maxBlobDataSize), key, iv)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(blob.Size()))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := blob.Plaintext(key, iv)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func testdata(t testing.TB, filename string) []byte {
 	data, err := ioutil.ReadFile(filepath.Join("testdata", filename))
 	if err != nil {
 		t.Fatal(err)
@@ -146,7 +222,7 @@ func testdata(t *testing.T, filename string) []byte {
 	return data
 }
 
-func unhex(t *testing.T, s string) []byte {
+func unhex(t testing.TB, s string) []byte {
 	r, err := hex.DecodeString(s)
 	if err != nil {
 		t.Fatal(err)
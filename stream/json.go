@@ -108,3 +108,36 @@ func (bi *BlobInfo) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+type ParityBlobInfoAlias ParityBlobInfo
+
+type JSONParityBlobInfo struct {
+	ParityBlobInfoAlias
+	BlobHash string `json:"blob_hash"`
+}
+
+func (pi ParityBlobInfo) MarshalJSON() ([]byte, error) {
+	var tmp JSONParityBlobInfo
+
+	tmp.BlobHash = hex.EncodeToString(pi.BlobHash)
+	tmp.ParityBlobInfoAlias = ParityBlobInfoAlias(pi)
+
+	return json.Marshal(tmp)
+}
+
+func (pi *ParityBlobInfo) UnmarshalJSON(b []byte) error {
+	var tmp JSONParityBlobInfo
+	err := json.Unmarshal(b, &tmp)
+	if err != nil {
+		return errors.Err(err)
+	}
+
+	*pi = ParityBlobInfo(tmp.ParityBlobInfoAlias)
+
+	pi.BlobHash, err = hex.DecodeString(tmp.BlobHash)
+	if err != nil {
+		return errors.Err(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package stream
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"github.com/klauspost/reedsolomon"
+)
+
+// ParityInfo describes the Reed-Solomon parity blobs that protect a stream's content blobs.
+// Content blobs are grouped into stripes of DataBlobs consecutive blobs (the last stripe may be shorter)
+// and each stripe gets ParityBlobs parity blobs, so up to ParityBlobs missing blobs per stripe can be rebuilt.
+// Parity is computed over the encrypted blobs, so no key is needed to repair a stream.
+type ParityInfo struct {
+	DataBlobs   int              `json:"data_blobs"`
+	ParityBlobs int              `json:"parity_blobs"`
+	Blobs       []ParityBlobInfo `json:"blobs"`
+}
+
+// ParityBlobInfo is the stream descriptor info for a single parity blob
+// Encoding to and from JSON is customized to match BlobInfo (see json.go in package)
+type ParityBlobInfo struct {
+	Length   int    `json:"length"`
+	BlobNum  int    `json:"blob_num"`
+	BlobHash []byte `json:"-"`
+}
+
+// WithParity returns a copy of the stream with parity blobs appended after the content blobs. Every
+// dataBlobs consecutive content blobs get parityBlobs parity blobs, and the layout is recorded in an
+// extended sd blob. The stream hash is unchanged, so clients that don't know about parity still decode it.
+func (s Stream) WithParity(dataBlobs, parityBlobs int) (Stream, error) {
+	if dataBlobs < 1 || parityBlobs < 1 {
+		return nil, errors.Err("data and parity blob counts must be positive")
+	}
+	if dataBlobs+parityBlobs > 256 {
+		return nil, errors.Err("at most 256 data and parity blobs are allowed per stripe")
+	}
+
+	sd, err := s.sdBlob()
+	if err != nil {
+		return nil, err
+	}
+	if sd.Parity != nil {
+		return nil, errors.Err("stream already has parity blobs")
+	}
+
+	numContent := len(sd.BlobInfos) - 1 // -1 for terminating 0-length blob
+	if len(s[1:]) != numContent {
+		return nil, errors.Err("number of blobs in stream does not match number of blobs in sd info")
+	}
+
+	info := &ParityInfo{DataBlobs: dataBlobs, ParityBlobs: parityBlobs}
+	var parity []Blob
+
+	for start := 0; start < numContent; start += dataBlobs {
+		end := start + dataBlobs
+		if end > numContent {
+			end = numContent
+		}
+
+		stripe := s[1+start : 1+end]
+		shardSize := 0
+		for _, b := range stripe {
+			if b.Size() > shardSize {
+				shardSize = b.Size()
+			}
+		}
+
+		shards := make([][]byte, len(stripe)+parityBlobs)
+		for i, b := range stripe {
+			shards[i] = make([]byte, shardSize)
+			copy(shards[i], b)
+		}
+		for i := len(stripe); i < len(shards); i++ {
+			shards[i] = make([]byte, shardSize)
+		}
+
+		enc, err := reedsolomon.New(len(stripe), parityBlobs)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		err = enc.Encode(shards)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+
+		for _, shard := range shards[len(stripe):] {
+			b := Blob(shard)
+			info.Blobs = append(info.Blobs, ParityBlobInfo{
+				BlobNum:  len(info.Blobs),
+				Length:   b.Size(),
+				BlobHash: b.Hash(),
+			})
+			parity = append(parity, b)
+		}
+	}
+
+	sd.Parity = info
+	sdBlob, err := sd.toStreamBlob()
+	if err != nil {
+		return nil, err
+	}
+
+	withParity := make(Stream, 0, len(s)+len(parity))
+	withParity = append(withParity, sdBlob)
+	withParity = append(withParity, s[1:]...)
+	withParity = append(withParity, parity...)
+	return withParity, nil
+}
+
+// Repair returns a copy of the stream with missing or corrupt content blobs rebuilt from the parity blobs.
+// The stream must have one entry per blob listed in the sd blob; unavailable blobs should be left nil.
+func (s Stream) Repair() (Stream, error) {
+	sd, err := s.sdBlob()
+	if err != nil {
+		return nil, err
+	}
+	if sd.Parity == nil {
+		return nil, errors.Err("stream has no parity blobs")
+	}
+	if len(s[1:]) != len(sd.BlobInfos)-1+len(sd.Parity.Blobs) {
+		return nil, errors.Err("number of blobs in stream does not match number of blobs in sd info")
+	}
+	return s.repair(*sd)
+}
+
+// contentIntact returns true if every content blob is present and matches its hash in the sd blob
+func (s Stream) contentIntact(sd SDBlob) bool {
+	for i, bi := range sd.BlobInfos[:len(sd.BlobInfos)-1] {
+		if i+1 >= len(s) || !bytes.Equal(s[i+1].Hash(), bi.BlobHash) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Stream) repair(sd SDBlob) (Stream, error) {
+	p := sd.Parity
+	if p.DataBlobs < 1 || p.ParityBlobs < 1 {
+		return nil, errors.Err("invalid parity info in sd blob")
+	}
+
+	numContent := len(sd.BlobInfos) - 1 // -1 for terminating 0-length blob
+	numStripes := (numContent + p.DataBlobs - 1) / p.DataBlobs
+	if len(p.Blobs) != numStripes*p.ParityBlobs {
+		return nil, errors.Err("sd blob lists %d parity blobs, expected %d", len(p.Blobs), numStripes*p.ParityBlobs)
+	}
+
+	repaired := make(Stream, len(s))
+	copy(repaired, s)
+
+	for stripe := 0; stripe < numStripes; stripe++ {
+		start := stripe * p.DataBlobs
+		end := start + p.DataBlobs
+		if end > numContent {
+			end = numContent
+		}
+		dataInfos := sd.BlobInfos[start:end]
+		parityInfos := p.Blobs[stripe*p.ParityBlobs : (stripe+1)*p.ParityBlobs]
+
+		shardSize := 0
+		for _, bi := range dataInfos {
+			if bi.Length > shardSize {
+				shardSize = bi.Length
+			}
+		}
+
+		shards := make([][]byte, len(dataInfos)+len(parityInfos))
+		var missing []int
+		for i, bi := range dataInfos {
+			b := s[1+start+i]
+			if !bytes.Equal(b.Hash(), bi.BlobHash) {
+				missing = append(missing, i)
+				continue
+			}
+			shards[i] = make([]byte, shardSize)
+			copy(shards[i], b)
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		for i, pi := range parityInfos {
+			b := s[1+numContent+stripe*p.ParityBlobs+i]
+			if pi.Length == shardSize && bytes.Equal(b.Hash(), pi.BlobHash) {
+				shards[len(dataInfos)+i] = b
+			}
+		}
+
+		enc, err := reedsolomon.New(len(dataInfos), p.ParityBlobs)
+		if err != nil {
+			return nil, errors.Err(err)
+		}
+		err = enc.ReconstructData(shards)
+		if err != nil {
+			return nil, errors.Prefix("cannot repair blobs "+blobNums(start, missing), err)
+		}
+
+		for _, i := range missing {
+			b := Blob(shards[i][:dataInfos[i].Length])
+			if !bytes.Equal(b.Hash(), dataInfos[i].BlobHash) {
+				return nil, errors.Err("repaired blob %d does not match hash in sd blob", start+i)
+			}
+			repaired[1+start+i] = b
+		}
+	}
+
+	return repaired, nil
+}
+
+// blobNums formats stripe-relative blob indexes as stream blob numbers for error messages
+func blobNums(offset int, indexes []int) string {
+	var buf bytes.Buffer
+	for i, idx := range indexes {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.Itoa(offset + idx))
+	}
+	return buf.String()
+}
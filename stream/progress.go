@@ -0,0 +1,43 @@
+package stream
+
+// Progress reports how far along a stream encode or decode is
+type Progress struct {
+	BytesProcessed int // plaintext bytes encrypted or decrypted so far
+	BlobsCompleted int // content blobs encrypted or decrypted so far
+	BlobsTotal     int // number of content blobs in the stream
+	EstimatedTotal int // plaintext size of the whole stream. exact when encoding, within one AES block when decoding
+}
+
+// Done returns true once every content blob has been processed
+func (p Progress) Done() bool {
+	return p.BlobsCompleted >= p.BlobsTotal
+}
+
+// ProgressFunc is called after each content blob is encrypted or decrypted. It is called from the
+// goroutine doing the work, so it should return quickly.
+type ProgressFunc func(Progress)
+
+// report calls fn if it is set
+func (fn ProgressFunc) report(p Progress) {
+	if fn != nil {
+		fn(p)
+	}
+}
+
+// estimatedPlaintextSize estimates the size of the stream's content from the encrypted blob lengths.
+// Every blob carries at least one byte of padding, so this is exact for all full-size blobs and
+// overestimates the last blob by at most one AES block. The lengths come from the sd blob, so no
+// blob is counted as bigger than MaxBlobSize.
+func (s SDBlob) estimatedPlaintextSize() int {
+	size := 0
+	for _, bi := range s.BlobInfos {
+		length := bi.Length
+		if length > MaxBlobSize {
+			length = MaxBlobSize
+		}
+		if length > 0 {
+			size += length - 1
+		}
+	}
+	return size
+}
@@ -36,12 +36,13 @@ func (bi BlobInfo) Hash() []byte {
 // SDBlob contains information about the rest of the blobs in the stream
 // Encoding to and from JSON is customized to match existing behavior (see json.go in package)
 type SDBlob struct {
-	StreamName        string     `json:"-"`
-	BlobInfos         []BlobInfo `json:"blobs"`
-	StreamType        string     `json:"stream_type"`
-	Key               []byte     `json:"-"`
-	SuggestedFileName string     `json:"-"`
-	StreamHash        []byte     `json:"-"`
+	StreamName        string      `json:"-"`
+	BlobInfos         []BlobInfo  `json:"blobs"`
+	StreamType        string      `json:"stream_type"`
+	Key               []byte      `json:"-"`
+	SuggestedFileName string      `json:"-"`
+	StreamHash        []byte      `json:"-"`
+	Parity            *ParityInfo `json:"parity,omitempty"`
 }
 
 // ToBlob converts the SDBlob to a normal data Blob
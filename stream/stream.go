@@ -2,6 +2,7 @@ package stream
 
 import (
 	"bytes"
+	"crypto/aes"
 	"math"
 	"strings"
 
@@ -21,7 +22,7 @@ func New(data []byte) (Stream, error) {
 		ivs[i] = randIV()
 	}
 
-	return makeStream(data, key, ivs, "", "")
+	return makeStream(data, key, ivs, "", "", nil)
 }
 
 // NewWithTitle creates a new Stream from a byte slice
@@ -32,7 +33,7 @@ func NewWithTitle(data []byte, title string) (Stream, error) {
 		ivs[i] = randIV()
 	}
 
-	return makeStream(data, key, ivs, title, "")
+	return makeStream(data, key, ivs, title, "", nil)
 }
 
 // NewWithStreamName creates a new Stream from a byte slice
@@ -43,22 +44,38 @@ func NewWithStreamName(data []byte, streamName, suggestedFilename string) (Strea
 		ivs[i] = randIV()
 	}
 
-	return makeStream(data, key, ivs, streamName, suggestedFilename)
+	return makeStream(data, key, ivs, streamName, suggestedFilename, nil)
+}
+
+// NewWithProgress creates a new Stream from a byte slice, calling progress after each content blob is encrypted
+func NewWithProgress(data []byte, streamName, suggestedFilename string, progress ProgressFunc) (Stream, error) {
+	key := randIV()
+	ivs := make([][]byte, numContentBlobs(data)+1) // +1 for terminating 0-length blob
+	for i := range ivs {
+		ivs[i] = randIV()
+	}
+
+	return makeStream(data, key, ivs, streamName, suggestedFilename, progress)
 }
 
 // Reconstruct creates a stream from the given data using predetermined IVs and key from the SD blob
 // NOTE: this will assume that all blobs except the last one are at max length. in theory this is not
 // required, but in practice this is always true. if this is false, streams may not match exactly
+// If the SD blob lists parity blobs, they are regenerated too, so the SD blob matches.
 func Reconstruct(data []byte, sdBlob SDBlob) (Stream, error) {
 	ivs := make([][]byte, len(sdBlob.BlobInfos))
 	for i := range ivs {
 		ivs[i] = sdBlob.BlobInfos[i].IV
 	}
 
-	return makeStream(data, sdBlob.Key, ivs, sdBlob.StreamName, sdBlob.SuggestedFileName)
+	s, err := makeStream(data, sdBlob.Key, ivs, sdBlob.StreamName, sdBlob.SuggestedFileName, nil)
+	if err != nil || sdBlob.Parity == nil {
+		return s, err
+	}
+	return s.WithParity(sdBlob.Parity.DataBlobs, sdBlob.Parity.ParityBlobs)
 }
 
-func makeStream(data, key []byte, ivs [][]byte, streamName, suggestedFilename string) (Stream, error) {
+func makeStream(data, key []byte, ivs [][]byte, streamName, suggestedFilename string, progress ProgressFunc) (Stream, error) {
 	var err error
 
 	numBlobs := numContentBlobs(data)
@@ -66,6 +83,12 @@ func makeStream(data, key []byte, ivs [][]byte, streamName, suggestedFilename st
 		return nil, errors.Err("incorrect number of IVs provided")
 	}
 
+	// one cipher is shared by every blob in the stream, since they all use the same key
+	blockCipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+
 	s := make(Stream, numBlobs+1) // +1 for sd blob
 	for i := 0; i < numBlobs; i++ {
 		start := i * maxBlobDataSize
@@ -73,14 +96,30 @@ func makeStream(data, key []byte, ivs [][]byte, streamName, suggestedFilename st
 		if end > len(data) {
 			end = len(data)
 		}
-		s[i+1], err = NewBlob(data[start:end], key, ivs[i])
-		if err != nil {
-			return nil, err
+		if len(ivs[i]) != blockCipher.BlockSize() {
+			return nil, errors.Err("IV length must equal to block size")
 		}
+		s[i+1] = encryptBlob(blockCipher, data[start:end], ivs[i])
+		progress.report(Progress{
+			BytesProcessed: end,
+			BlobsCompleted: i + 1,
+			BlobsTotal:     numBlobs,
+			EstimatedTotal: len(data),
+		})
 	}
 
 	sd := newSdBlob(s[1:], key, ivs, streamName, suggestedFilename)
-	jsonSD, err := sd.ToBlob()
+	s[0], err = sd.toStreamBlob()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// toStreamBlob converts the SDBlob to the blob that is published as part of the stream
+func (s SDBlob) toStreamBlob() (Blob, error) {
+	jsonSD, err := s.ToBlob()
 	if err != nil {
 		return nil, err
 	}
@@ -90,34 +129,48 @@ func makeStream(data, key []byte, ivs [][]byte, streamName, suggestedFilename st
 	jsonSD = []byte(strings.Replace(string(jsonSD), ",", ", ", -1))
 	jsonSD = []byte(strings.Replace(string(jsonSD), ":", ": ", -1))
 
-	s[0] = jsonSD
-	return s, nil
+	return jsonSD, nil
 }
 
+// Data decrypts the stream and returns its contents
 func (s Stream) Data() ([]byte, error) {
-	if len(s) < 2 {
-		return nil, errors.Err("stream must be at least 2 blobs long") // sd blob and content blob
-	}
+	return s.DataWithProgress(nil)
+}
 
-	sdBlob := &SDBlob{}
-	err := sdBlob.FromBlob(s[0])
+// DataWithProgress decrypts the stream like Data, calling progress after each content blob is decrypted
+func (s Stream) DataWithProgress(progress ProgressFunc) ([]byte, error) {
+	sdBlob, err := s.sdBlob()
 	if err != nil {
 		return nil, err
 	}
 
-	if !sdBlob.IsValid() {
-		return nil, errors.Err("sd blob is not valid")
+	expectedBlobs := len(sdBlob.BlobInfos) - 1 // -1 for terminating 0-length blob
+	if sdBlob.Parity != nil {
+		expectedBlobs += len(sdBlob.Parity.Blobs)
+	}
+	if len(s[1:]) != expectedBlobs {
+		return nil, errors.Err("number of blobs in stream does not match number of blobs in sd info")
 	}
 
-	if sdBlob.BlobInfos[len(sdBlob.BlobInfos)-1].Length != 0 {
-		return nil, errors.Err("sd blob is missing the terminating 0-length blob")
+	if sdBlob.Parity != nil && !s.contentIntact(*sdBlob) {
+		repaired, err := s.repair(*sdBlob)
+		if err != nil {
+			return nil, err
+		}
+		s = repaired
 	}
 
-	if len(s[1:]) != len(sdBlob.BlobInfos)-1 { // -1 for terminating 0-length blob
-		return nil, errors.Err("number of blobs in stream does not match number of blobs in sd info")
+	numBlobs := len(sdBlob.BlobInfos) - 1 // -1 for terminating 0-length blob
+	estimatedTotal := sdBlob.estimatedPlaintextSize()
+
+	blockCipher, err := aes.NewCipher(sdBlob.Key)
+	if err != nil {
+		return nil, errors.Err(err)
 	}
 
-	var file []byte
+	// estimatedTotal is never less than the plaintext size, and the +1 leaves room to decrypt the last
+	// blob before its padding is removed, so the plaintext is written in place without any reallocation
+	file := make([]byte, 0, estimatedTotal+1)
 	for i, blobInfo := range sdBlob.BlobInfos {
 		if blobInfo.Length == 0 {
 			if i != len(sdBlob.BlobInfos)-1 {
@@ -136,16 +189,47 @@ func (s Stream) Data() ([]byte, error) {
 			return nil, errors.Err("blob hash doesn't match hash in blobInfo")
 		}
 
-		data, err := blob.Plaintext(sdBlob.Key, blobInfo.IV)
+		if len(blobInfo.IV) != blockCipher.BlockSize() {
+			return nil, errors.Err("IV length must equal to block size")
+		}
+		file, err = blob.appendPlaintext(file, blockCipher, blobInfo.IV)
 		if err != nil {
 			return nil, err
 		}
-		file = append(file, data...)
+		progress.report(Progress{
+			BytesProcessed: len(file),
+			BlobsCompleted: i + 1,
+			BlobsTotal:     numBlobs,
+			EstimatedTotal: estimatedTotal,
+		})
 	}
 
 	return file, nil
 }
 
+// sdBlob parses the stream's sd blob
+func (s Stream) sdBlob() (*SDBlob, error) {
+	if len(s) < 2 {
+		return nil, errors.Err("stream must be at least 2 blobs long") // sd blob and content blob
+	}
+
+	sd := &SDBlob{}
+	err := sd.FromBlob(s[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !sd.IsValid() {
+		return nil, errors.Err("sd blob is not valid")
+	}
+
+	if len(sd.BlobInfos) == 0 || sd.BlobInfos[len(sd.BlobInfos)-1].Length != 0 {
+		return nil, errors.Err("sd blob is missing the terminating 0-length blob")
+	}
+
+	return sd, nil
+}
+
 //numContentBlobs returns the number of content blobs required to store the data
 func numContentBlobs(data []byte) int {
 	return int(math.Ceil(float64(len(data)) / float64(maxBlobDataSize)))
@@ -65,3 +65,177 @@ func TestStreamToFile(t *testing.T) {
 func TestNew(t *testing.T) {
 	t.Skip("TODO: test new stream creation and decryption")
 }
+
+func TestStreamProgress(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 2*maxBlobDataSize+1234)
+
+	var encodeUpdates []Progress
+	s, err := NewWithProgress(data, "", "", func(p Progress) {
+		encodeUpdates = append(encodeUpdates, p)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(encodeUpdates) != 3 {
+		t.Fatalf("expected 3 encode progress updates, got %d", len(encodeUpdates))
+	}
+	last := encodeUpdates[len(encodeUpdates)-1]
+	if !last.Done() || last.BytesProcessed != len(data) || last.EstimatedTotal != len(data) {
+		t.Errorf("unexpected final encode progress %+v", last)
+	}
+
+	var decodeUpdates []Progress
+	decoded, err := s.DataWithProgress(func(p Progress) {
+		decodeUpdates = append(decodeUpdates, p)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Fatal("decoded data does not match original")
+	}
+
+	if len(decodeUpdates) != 3 {
+		t.Fatalf("expected 3 decode progress updates, got %d", len(decodeUpdates))
+	}
+	for i, p := range decodeUpdates {
+		if p.BlobsCompleted != i+1 || p.BlobsTotal != 3 {
+			t.Errorf("update %d: unexpected blob counts %+v", i, p)
+		}
+	}
+	last = decodeUpdates[len(decodeUpdates)-1]
+	if !last.Done() || last.BytesProcessed != len(data) {
+		t.Errorf("unexpected final decode progress %+v", last)
+	}
+	if last.EstimatedTotal < len(data) || last.EstimatedTotal-len(data) >= 16 {
+		t.Errorf("estimated total %d is too far from actual size %d", last.EstimatedTotal, len(data))
+	}
+}
+
+func TestStreamHostileBlobLength(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 1234)
+	s, err := New(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sd := SDBlob{}
+	if err := sd.FromBlob(s[0]); err != nil {
+		t.Fatal(err)
+	}
+	sd.BlobInfos[0].Length = int(^uint(0) >> 1) // the biggest int
+	sd.StreamHash = sd.computeStreamHash()
+	s[0], err = sd.toStreamBlob()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := s.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Error("decoded data does not match original")
+	}
+}
+
+func TestStreamParity(t *testing.T) {
+	data := make([]byte, 4*maxBlobDataSize+5000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	s, err := New(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withParity, err := s.WithParity(2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 5 content blobs in stripes of 2, 2 and 1, with one parity blob each
+	if len(withParity) != len(s)+3 {
+		t.Fatalf("expected %d blobs, got %d", len(s)+3, len(withParity))
+	}
+
+	sd := &SDBlob{}
+	err = sd.FromBlob(withParity[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sd.IsValid() {
+		t.Fatal("sd blob with parity info is not valid")
+	}
+	if sd.Parity == nil || len(sd.Parity.Blobs) != 3 {
+		t.Fatalf("unexpected parity info %+v", sd.Parity)
+	}
+
+	reconstructed, err := Reconstruct(data, *sd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reconstructed) != len(withParity) {
+		t.Fatalf("expected %d reconstructed blobs, got %d", len(withParity), len(reconstructed))
+	}
+	for i := range withParity {
+		if reconstructed[i].HashHex() != withParity[i].HashHex() {
+			t.Errorf("reconstructed blob %d hash mismatch. got %s, expected %s", i, reconstructed[i].HashHex(), withParity[i].HashHex())
+		}
+	}
+
+	// lose one blob from each of two different stripes
+	damaged := make(Stream, len(withParity))
+	copy(damaged, withParity)
+	damaged[2] = nil
+	damaged[5] = nil
+
+	decoded, err := damaged.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Fatal("decoded data does not match original")
+	}
+
+	// two missing blobs in the same stripe is more than one parity blob can recover
+	damaged[1] = nil
+	_, err = damaged.Repair()
+	if err == nil {
+		t.Fatal("expected an error repairing a stripe with too many missing blobs")
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	data := bytes.Repeat([]byte{0x42}, 8*maxBlobDataSize)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := New(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStream_Data(b *testing.B) {
+	data := bytes.Repeat([]byte{0x42}, 8*maxBlobDataSize)
+	s, err := New(data)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := s.Data()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}